@@ -228,14 +228,15 @@ func runOvnKube(ctx *cli.Context) error {
 		watchFactory = masterWatchFactory
 		var ovnNBClient, ovnSBClient goovn.Client
 
-		if ovnNBClient, err = util.NewOVNNBClient(); err != nil {
-			return fmt.Errorf("error when trying to initialize go-ovn NB client: %v", err)
-		}
-
 		if ovnSBClient, err = util.NewOVNSBClient(); err != nil {
 			return fmt.Errorf("error when trying to initialize go-ovn SB client: %v", err)
 		}
 
+		// the NB client resolves the chassis binding of the ports from the SB client
+		if ovnNBClient, err = util.NewOVNNBClient(ovnSBClient); err != nil {
+			return fmt.Errorf("error when trying to initialize go-ovn NB client: %v", err)
+		}
+
 		// register prometheus metrics exported by the master
 		// this must be done prior to calling controller start
 		// since we capture some metrics in Start()
@@ -49,6 +49,8 @@ require (
 )
 
 replace (
+	github.com/ebay/go-ovn => ./third_party/github.com/ebay/go-ovn
+	github.com/ebay/libovsdb => ./third_party/github.com/ebay/libovsdb
 	github.com/gogo/protobuf => github.com/gogo/protobuf v1.3.2
 	k8s.io/api => k8s.io/api v0.20.0
 	k8s.io/apiextensions-apiserver => k8s.io/apiextensions-apiserver v0.20.0
@@ -66,4 +66,11 @@ for pkg in ${PKGS}; do
     i=$((i+1))
 done
 
+# The forked OVSDB client libraries are modules of their own and are not
+# vendored with their tests
+for mod in third_party/github.com/ebay/*; do
+    echo "go test -mod=mod -test.v ./... (${mod})"
+    (cd "${mod}" && go test -mod=mod -test.v ./... 2>&1)
+done
+
 rm -f /tmp/ovn-test.* || true
@@ -364,7 +364,8 @@ func getLocalNodeSubnet(nodeName string) (*net.IPNet, error) {
 
 	// First wait for the node logical switch to be created by the Master, timeout is 300s.
 	if err := wait.PollImmediate(500*time.Millisecond, 300*time.Second, func() (bool, error) {
-		if cidr, _, err = util.RunOVNNbctl("get", "logical_switch", nodeName, "other-config:subnet"); err != nil {
+		if cidr, _, err = util.RunOVNNbctl("get", types.OVNNBTableLogicalSwitch, nodeName,
+			util.OVNNbctlOtherConfig+":"+util.OVNOtherConfigSubnet); err != nil {
 			return false, nil
 		}
 		return true, nil
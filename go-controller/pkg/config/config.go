@@ -266,6 +266,9 @@ type KubernetesConfig struct {
 type OVNKubernetesFeatureConfig struct {
 	EnableEgressIP       bool `gcfg:"enable-egress-ip"`
 	EnableEgressFirewall bool `gcfg:"enable-egress-firewall"`
+	// EnablePodNetworkOverrides lets pod annotations override the port
+	// security and chassis binding of the pod's logical switch port
+	EnablePodNetworkOverrides bool `gcfg:"enable-pod-network-overrides"`
 }
 
 // GatewayMode holds the node gateway mode
@@ -747,6 +750,12 @@ var OVNK8sFeatureFlags = []cli.Flag{
 		Destination: &cliConfig.OVNKubernetesFeature.EnableEgressFirewall,
 		Value:       OVNKubernetesFeature.EnableEgressFirewall,
 	},
+	&cli.BoolFlag{
+		Name:        "enable-pod-network-overrides",
+		Usage:       "Allow pods to disable their port security or request additional chassis through annotations. Any pod can then spoof addresses, only enable it on clusters where all pod authors are trusted.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnablePodNetworkOverrides,
+		Value:       OVNKubernetesFeature.EnablePodNetworkOverrides,
+	},
 }
 
 // K8sFlags capture Kubernetes-related options
@@ -1779,7 +1788,7 @@ func (a *OvnAuthConfig) ensureCACert() error {
 		args = append(args, "--certificate="+a.Cert)
 		args = append(args, "--bootstrap-ca-cert="+a.CACert)
 	}
-	args = append(args, "list", "nb_global")
+	args = append(args, "list", types.OVNNBTableNBGlobal)
 	_, _ = rawExec(a.exec, "ovn-nbctl", args...)
 	if _, err := os.Stat(a.CACert); os.IsNotExist(err) {
 		klog.Warningf("Bootstrapping %s CA certificate failed", a.CACert)
@@ -46,6 +46,18 @@ var metricOvnCliLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 	[]string{"command"},
 )
 
+// metricPodPartialDefaultRoutes is the number of dual-stack pods annotated with
+// a default route in a single IP family, labeled with the family missing one
+var metricPodPartialDefaultRoutes = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemMaster,
+	Name:      "pod_partial_default_routes_total",
+	Help:      "The number of times a dual-stack pod was annotated with a default route in a single IP family"},
+	[]string{
+		"missing_family",
+	},
+)
+
 // MetricResourceUpdateCount is the number of times a particular resource's UpdateFunc has been called.
 var MetricResourceUpdateCount = prometheus.NewCounterVec(prometheus.CounterOpts{
 	Namespace: MetricOvnkubeNamespace,
@@ -169,6 +181,7 @@ func RegisterMasterMetrics(nbClient, sbClient goovn.Client) {
 		prometheus.MustRegister(metricE2ETimestamp)
 		prometheus.MustRegister(MetricMasterLeader)
 		prometheus.MustRegister(metricPodCreationLatency)
+		prometheus.MustRegister(metricPodPartialDefaultRoutes)
 
 		scrapeOvnTimestamp := func() float64 {
 			options, err := sbClient.SBGlobalGetOptions()
@@ -176,7 +189,7 @@ func RegisterMasterMetrics(nbClient, sbClient goovn.Client) {
 				klog.Errorf("Failed to get global options for the SB_Global table")
 				return 0
 			}
-			if val, ok := options["e2e_timestamp"]; ok {
+			if val, ok := options[goovn.GlobalOptionE2ETimestamp]; ok {
 				return parseMetricToFloat(MetricOvnkubeSubsystemMaster, "sb_e2e_timestamp", val)
 			}
 			return 0
@@ -226,6 +239,32 @@ func RegisterMasterMetrics(nbClient, sbClient goovn.Client) {
 			},
 			func() float64 { return 1 },
 		))
+		prometheus.MustRegister(prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{
+				Namespace: MetricOvnkubeNamespace,
+				Subsystem: MetricOvnkubeSubsystemMaster,
+				Name:      "nb_cache_staleness_seconds",
+				Help: "The number of seconds since the northbound database client cache last received an " +
+					"update; a steadily growing value on a busy cluster points to a wedged monitor",
+			}, func() float64 {
+				return cacheStaleness(nbClient)
+			}))
+		prometheus.MustRegister(prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{
+				Namespace: MetricOvnkubeNamespace,
+				Subsystem: MetricOvnkubeSubsystemMaster,
+				Name:      "sb_cache_staleness_seconds",
+				Help: "The number of seconds since the southbound database client cache last received an " +
+					"update; a steadily growing value on a busy cluster points to a wedged monitor",
+			}, func() float64 {
+				return cacheStaleness(sbClient)
+			}))
+		prometheus.MustRegister(newTxnCountsCollector(nbClient, "nb",
+			"The number of northbound database transactions, by table they write to and by result: "+
+				"success, failure, or retry when a transaction lost with the connection was resubmitted"))
+		prometheus.MustRegister(newTxnCountsCollector(sbClient, "sb",
+			"The number of southbound database transactions, by table they write to and by result: "+
+				"success, failure, or retry when a transaction lost with the connection was resubmitted"))
 		prometheus.MustRegister(metricV4HostSubnetCount)
 		prometheus.MustRegister(metricV6HostSubnetCount)
 		prometheus.MustRegister(metricV4AllocatedHostSubnetCount)
@@ -234,6 +273,43 @@ func RegisterMasterMetrics(nbClient, sbClient goovn.Client) {
 	})
 }
 
+// txnCountsCollector exports the transaction counts kept by a database client
+type txnCountsCollector struct {
+	client goovn.Client
+	desc   *prometheus.Desc
+}
+
+func newTxnCountsCollector(client goovn.Client, db, help string) *txnCountsCollector {
+	return &txnCountsCollector{
+		client: client,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(MetricOvnkubeNamespace, MetricOvnkubeSubsystemMaster, db+"_transactions_total"),
+			help, []string{"table", "result"}, nil),
+	}
+}
+
+func (c *txnCountsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *txnCountsCollector) Collect(ch chan<- prometheus.Metric) {
+	for table, counts := range c.client.TransactionCounts() {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.CounterValue, float64(counts.Succeeded), table, "success")
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.CounterValue, float64(counts.Failed), table, "failure")
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.CounterValue, float64(counts.Retried), table, "retry")
+	}
+}
+
+// cacheStaleness returns the number of seconds since the client cache was last
+// updated by its database monitor, 0 if it never was
+func cacheStaleness(client goovn.Client) float64 {
+	lastUpdate := client.LastUpdateTime()
+	if lastUpdate.IsZero() {
+		return 0
+	}
+	return time.Since(lastUpdate).Seconds()
+}
+
 // StartE2ETimeStampMetricUpdater adds a goroutine that updates a "timestamp" value in the
 // nbdb every 30 seconds. This is so we can determine freshness of the database
 func StartE2ETimeStampMetricUpdater(stopChan <-chan struct{}, ovnNBClient goovn.Client) {
@@ -249,7 +325,7 @@ func StartE2ETimeStampMetricUpdater(stopChan <-chan struct{}, ovnNBClient goovn.
 						continue
 					}
 					t := time.Now().Unix()
-					options["e2e_timestamp"] = fmt.Sprintf("%d", t)
+					options[goovn.GlobalOptionE2ETimestamp] = fmt.Sprintf("%d", t)
 					cmd, err := ovnNBClient.NBGlobalSetOptions(options)
 					if err != nil {
 						klog.Errorf("Failed to bump timestamp: %v", err)
@@ -288,6 +364,12 @@ func RecordPodCreated(pod *kapi.Pod) {
 	}
 }
 
+// RecordPodPartialDefaultRoutes records that a dual-stack pod was annotated
+// without a default route for missingFamily
+func RecordPodPartialDefaultRoutes(missingFamily string) {
+	metricPodPartialDefaultRoutes.WithLabelValues(missingFamily).Inc()
+}
+
 // RecordSubnetUsage records the number of subnets allocated for nodes
 func RecordSubnetUsage(v4SubnetsAllocated, v6SubnetsAllocated float64) {
 	metricV4AllocatedHostSubnetCount.Set(v4SubnetsAllocated)
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	goovn "github.com/ebay/go-ovn"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -267,10 +269,10 @@ func ovnE2eTimeStampUpdater(direction, database string) {
 
 	if direction == "sb" {
 		stdout, stderr, err = util.RunOVNSbctlUnix("--if-exists", "--no-leader-only",
-			"get", "SB_Global", ".", "options:e2e_timestamp")
+			"get", types.OVNSBTableSBGlobal, ".", "options:"+goovn.GlobalOptionE2ETimestamp)
 	} else {
 		stdout, stderr, err = util.RunOVNNbctlUnix("--if-exists", "--no-leader-only",
-			"get", "NB_Global", ".", "options:e2e_timestamp")
+			"get", types.OVNNBTableNBGlobal, ".", "options:"+goovn.GlobalOptionE2ETimestamp)
 	}
 	if err != nil {
 		klog.Errorf("Failed to scrape timestamp for database %s: "+
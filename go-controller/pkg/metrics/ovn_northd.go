@@ -4,6 +4,8 @@ import (
 	"strings"
 	"time"
 
+	goovn "github.com/ebay/go-ovn"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -112,8 +114,8 @@ func RegisterOvnNorthdMetrics(clientset kubernetes.Interface, k8sNodeName string
 			Help: "The maximum number of milliseconds of idle time on connection to the OVN SB " +
 				"and NB DB before sending an inactivity probe message",
 		}, func() float64 {
-			stdout, stderr, err := util.RunOVNNbctlWithTimeout(5, "get", "NB_Global", ".",
-				"options:northd_probe_interval")
+			stdout, stderr, err := util.RunOVNNbctlWithTimeout(5, "get", types.OVNNBTableNBGlobal, ".",
+				"options:"+goovn.NBGlobalOptionNorthdProbeInterval)
 			if err != nil {
 				klog.Errorf("Failed to get northd_probe_interval value "+
 					"stderr(%s) :(%v)", stderr, err)
@@ -23,7 +23,7 @@ func GetRejectACLs() (map[string]string, error) {
 	type ovnACLData struct {
 		Data [][]interface{}
 	}
-	data, stderr, err := util.RunOVNNbctl("--columns=name,_uuid", "--format=json", "find", "acl", "action=reject")
+	data, stderr, err := util.RunOVNNbctl("--columns=name,_uuid", "--format=json", "find", types.OVNNBTableACL, "action=reject")
 	if err != nil {
 		return result, errors.Wrapf(err, "Error while querying ACLs with reject action: %s", stderr)
 	}
@@ -73,7 +73,7 @@ func RemoveACLFromNodeSwitches(switches []string, aclUUID string) error {
 
 // RemoveACLFromPortGroup removes the ACL from the port-group
 func RemoveACLFromPortGroup(aclUUID, clusterPortGroupUUID string) error {
-	_, stderr, err := util.RunOVNNbctl("--", "--if-exists", "remove", "port_group", clusterPortGroupUUID, "acls", aclUUID)
+	_, stderr, err := util.RunOVNNbctl("--", "--if-exists", "remove", types.OVNNBTablePortGroup, clusterPortGroupUUID, util.OVNColumnACLs, aclUUID)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to remove reject ACL %s from port group %s: stderr: %q", aclUUID, clusterPortGroupUUID, stderr)
 	}
@@ -119,7 +119,7 @@ func AddRejectACLToLogicalSwitch(logicalSwitch, aclName, sourceIP string, source
 }
 
 func PurgeRejectRules(clusterPortGroupUUID string) error {
-	data, stderr, err := util.RunOVNNbctl("--columns=_uuid", "--format=csv", "--data=bare", "--no-headings", "find", "acl", "action=reject")
+	data, stderr, err := util.RunOVNNbctl("--columns=_uuid", "--format=csv", "--data=bare", "--no-headings", "find", types.OVNNBTableACL, "action=reject")
 	if err != nil {
 		return errors.Wrapf(err, "Error while querying ACLs with reject action: %s", stderr)
 	}
@@ -134,7 +134,7 @@ func PurgeRejectRules(clusterPortGroupUUID string) error {
 			klog.Errorf("Error trying to remove ACL for clusterPortGroupUUID %s/%s: %v", uuid, clusterPortGroupUUID, err)
 		}
 
-		data, stderr, err := util.RunOVNNbctl("--format=csv", "--data=bare", "--no-headings", "--columns=_uuid", "find", "logical_switch", fmt.Sprintf("acls{>=}%s", uuid))
+		data, stderr, err := util.RunOVNNbctl("--format=csv", "--data=bare", "--no-headings", "--columns=_uuid", "find", types.OVNNBTableLogicalSwitch, fmt.Sprintf("acls{>=}%s", uuid))
 		if err != nil {
 			return errors.Wrapf(err, "Error while querying ACLs uuid:%s with reject action: %s", uuid, stderr)
 		}
@@ -143,7 +143,7 @@ func PurgeRejectRules(clusterPortGroupUUID string) error {
 		if err != nil {
 			return errors.Wrapf(err, "Failed to remove reject acl from logical switches")
 		}
-		_, stderr, err = util.RunOVNNbctl("--if-exists", "destroy", "acl", uuid)
+		_, stderr, err = util.RunOVNNbctl("--if-exists", "destroy", types.OVNNBTableACL, uuid)
 		if err != nil {
 			klog.Errorf("Failed to destroy ACL %s, stderr: %q, (%v)",
 				uuid, stderr, err)
@@ -49,6 +49,8 @@ type AddressSet interface {
 	GetASHashNames() (string, string)
 	// GetName returns the descriptive name of the address set
 	GetName() string
+	// GetIPs returns the addresses currently stored in the address set
+	GetIPs() ([]string, error)
 	// AddIPs adds the array of IPs to the address set
 	AddIPs(ip []net.IP) error
 	// SetIPs sets the address set to the given array of addresses
@@ -256,11 +258,20 @@ func newOvnAddressSet(nb goovn.Client, name string, ips []net.IP) (*ovnAddressSe
 		}
 		// ovnAddressSet has not been created yet. Create it.
 		cmd, err := nb.ASAdd(as.hashName, ipsToStringArray(ips), map[string]string{"name": name})
+		if err == goovn.ErrorExist {
+			// created concurrently since we looked it up, e.g. by the
+			// namespace add handler racing a pod add
+			return as.adopt(ips)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to create address set cmd: %q: %v", name, err)
 		}
 		uuids, err := nb.ExecuteR(cmd)
 		if err != nil {
+			// the same race, but lost in the server rather than in our cache
+			if _, getErr := nb.ASGet(as.hashName); getErr == nil {
+				return as.adopt(ips)
+			}
 			return nil, fmt.Errorf("failed to create address set %q: %v", asDetail(as), err)
 		}
 		if len(uuids) != 1 {
@@ -280,6 +291,24 @@ func newOvnAddressSet(nb goovn.Client, name string, ips []net.IP) (*ovnAddressSe
 	return as, nil
 }
 
+// adopt takes over an address set that someone else created while we were
+// creating it. Its IPs are merged with a set-insert rather than replaced, as
+// the other creator may already have added IPs of its own.
+func (as *ovnAddressSet) adopt(ips []net.IP) (*ovnAddressSet, error) {
+	ovnAs, err := as.nb.ASGet(as.hashName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get concurrently created address set %q: %v", asDetail(as), err)
+	}
+	as.uuid = ovnAs.UUID
+	klog.V(5).Infof("New(%s) was created concurrently; adding IPs %v", asDetail(as), ips)
+	if len(ips) > 0 {
+		if err := as.addIPs(ips); err != nil {
+			return nil, err
+		}
+	}
+	return as, nil
+}
+
 func (as *ovnAddressSets) GetASHashNames() (string, string) {
 	var ipv4AS string
 	var ipv6AS string
@@ -296,6 +325,21 @@ func (as *ovnAddressSets) GetName() string {
 	return as.name
 }
 
+func (as *ovnAddressSets) GetIPs() ([]string, error) {
+	var ips []string
+	for _, set := range []*ovnAddressSet{as.ipv4, as.ipv6} {
+		if set == nil {
+			continue
+		}
+		setIPs, err := set.getIPs()
+		if err != nil {
+			return nil, err
+		}
+		ips = append(ips, setIPs...)
+	}
+	return ips, nil
+}
+
 func (as *ovnAddressSets) SetIPs(ips []net.IP) error {
 	var err error
 
@@ -417,6 +461,15 @@ func (as *ovnAddressSets) Destroy() error {
 	return nil
 }
 
+// getIPs returns the addresses of the address set as stored in OVN
+func (as *ovnAddressSet) getIPs() ([]string, error) {
+	ovnAs, err := as.nb.ASGet(as.hashName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get address set %q: %v", asDetail(as), err)
+	}
+	return ovnAs.Addresses, nil
+}
+
 // setIP updates the given address set in OVN to be only the given IPs, disregarding
 // existing state.
 func (as *ovnAddressSet) setIPs(ips []net.IP) error {
@@ -450,10 +503,7 @@ func (as *ovnAddressSet) addIPsCmd(ips []net.IP) (*goovn.OvnCommand, error) {
 	if len(ips) == 0 {
 		return nil, nil
 	}
-	uniqIPs := make([]string, 0, len(ips))
-	for _, ip := range ips {
-		uniqIPs = append(uniqIPs, ip.String())
-	}
+	uniqIPs := ipsToStringArray(ips)
 
 	cmd, err := as.nb.ASAddIPs(as.hashName, as.uuid, uniqIPs)
 	if err != nil {
@@ -481,10 +531,7 @@ func (as *ovnAddressSet) deleteIPsCmd(ips []net.IP) (*goovn.OvnCommand, error) {
 	if len(ips) == 0 {
 		return nil, nil
 	}
-	uniqIPs := make([]string, 0, len(ips))
-	for _, ip := range ips {
-		uniqIPs = append(uniqIPs, ip.String())
-	}
+	uniqIPs := ipsToStringArray(ips)
 	ipStr := joinIPs(ips)
 
 	cmd, err := as.nb.ASDelIPs(as.hashName, as.uuid, uniqIPs)
@@ -506,10 +553,17 @@ func (as *ovnAddressSet) destroy() error {
 	return nil
 }
 
+// ipsToStringArray returns the canonical form of the IPs, without duplicates,
+// e.g. the 4 and 16 byte forms of the same IPv4 address
 func ipsToStringArray(ips []net.IP) []string {
 	out := make([]string, 0, len(ips))
+	seen := sets.NewString()
 	for _, ip := range ips {
-		out = append(out, ip.String())
+		s := ip.String()
+		if !seen.Has(s) {
+			seen.Insert(s)
+			out = append(out, s)
+		}
 	}
 	return out
 }
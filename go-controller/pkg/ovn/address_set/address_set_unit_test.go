@@ -0,0 +1,98 @@
+package addressset
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	goovn "github.com/ebay/go-ovn"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	goovn_mock "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/ebay/go-ovn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewAddressSetConcurrentCreation(t *testing.T) {
+	const (
+		name     = "foobar_v4"
+		hashName = "a16990491322166530807"
+		asUUID   = "8a86f6d8-7972-4253-b0bd-ddbef66e9303"
+	)
+	ips := []net.IP{net.ParseIP("1.2.3.4"), net.ParseIP("5.6.7.8")}
+	ipStrs := []string{"1.2.3.4", "5.6.7.8"}
+	externalIDs := map[string]string{"name": name}
+	createErr := fmt.Errorf("constraint violation")
+
+	tests := []struct {
+		desc      string
+		addErr    error
+		execErr   error
+		existsNow bool
+		errMatch  string
+	}{
+		{
+			desc:      "adopts a set created before the insert was built",
+			addErr:    goovn.ErrorExist,
+			existsNow: true,
+		},
+		{
+			desc:      "adopts a set created before the insert was committed",
+			execErr:   createErr,
+			existsNow: true,
+		},
+		{
+			desc:     "fails when the insert fails and no set exists",
+			execErr:  createErr,
+			errMatch: "failed to create address set \"foobar_v4/" + hashName + "\": constraint violation",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			nb := new(goovn_mock.Client)
+			nb.On("ASGet", hashName).Return(nil, goovn.ErrorNotFound).Once()
+			insertCmd := &goovn.OvnCommand{}
+			nb.On("ASAdd", hashName, ipStrs, externalIDs).Return(insertCmd, tc.addErr)
+			if tc.addErr == nil {
+				nb.On("ExecuteR", insertCmd).Return(nil, tc.execErr)
+			}
+			if tc.existsNow {
+				nb.On("ASGet", hashName).Return(&goovn.AddressSet{Name: hashName, UUID: asUUID}, nil)
+				addCmd := &goovn.OvnCommand{}
+				nb.On("ASAddIPs", hashName, asUUID, ipStrs).Return(addCmd, nil)
+				nb.On("Execute", addCmd).Return(nil)
+			} else {
+				nb.On("ASGet", hashName).Return(nil, goovn.ErrorNotFound)
+			}
+
+			as, err := newOvnAddressSet(nb, name, ips)
+
+			if tc.errMatch != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMatch)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, asUUID, as.uuid)
+			}
+			nb.AssertNotCalled(t, "ASUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			nb.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNewAddressSetsConcurrentCreation(t *testing.T) {
+	config.IPv4Mode = true
+	config.IPv6Mode = false
+	defer func() { config.IPv4Mode = false }()
+
+	nb := new(goovn_mock.Client)
+	nb.On("ASGet", mock.Anything).Return(nil, goovn.ErrorNotFound).Once()
+	nb.On("ASAdd", mock.Anything, []string{}, mock.Anything).Return(nil, goovn.ErrorExist)
+	nb.On("ASGet", mock.Anything).Return(&goovn.AddressSet{UUID: "uuid"}, nil)
+
+	sets, err := newOvnAddressSets(nb, "foobar", nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "uuid", sets.ipv4.uuid)
+	nb.AssertNotCalled(t, "Execute", mock.Anything)
+	nb.AssertExpectations(t)
+}
@@ -233,6 +233,16 @@ func (as *fakeAddressSets) GetName() string {
 	return as.name
 }
 
+func (as *fakeAddressSets) GetIPs() ([]string, error) {
+	var ips []string
+	for _, set := range []*fakeAddressSet{as.ipv4, as.ipv6} {
+		if set != nil {
+			ips = append(ips, set.getIPs()...)
+		}
+	}
+	return ips, nil
+}
+
 func (as *fakeAddressSets) AddIPs(ips []net.IP) error {
 	var err error
 	as.Lock()
@@ -311,6 +321,17 @@ func (as *fakeAddressSet) getName() string {
 	return as.name
 }
 
+func (as *fakeAddressSet) getIPs() []string {
+	as.Lock()
+	defer as.Unlock()
+	gomega.Expect(atomic.LoadUint32(&as.destroyed)).To(gomega.Equal(uint32(0)))
+	ips := make([]string, 0, len(as.ips))
+	for ip := range as.ips {
+		ips = append(ips, ip)
+	}
+	return ips
+}
+
 func (as *fakeAddressSet) addIP(ip net.IP) error {
 	as.Lock()
 	defer as.Unlock()
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	goovn "github.com/ebay/go-ovn"
+
 	net "net"
 
 	mock "github.com/stretchr/testify/mock"
@@ -76,6 +78,29 @@ func (_m *AddressSet) GetASHashNames() (string, string) {
 	return r0, r1
 }
 
+// GetIPs provides a mock function with given fields:
+func (_m *AddressSet) GetIPs() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetName provides a mock function with given fields:
 func (_m *AddressSet) GetName() string {
 	ret := _m.Called()
@@ -90,6 +115,52 @@ func (_m *AddressSet) GetName() string {
 	return r0
 }
 
+// PrepareAddIPsCmds provides a mock function with given fields: ip
+func (_m *AddressSet) PrepareAddIPsCmds(ip []net.IP) ([]*goovn.OvnCommand, error) {
+	ret := _m.Called(ip)
+
+	var r0 []*goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func([]net.IP) []*goovn.OvnCommand); ok {
+		r0 = rf(ip)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]net.IP) error); ok {
+		r1 = rf(ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PrepareDeleteIPsCmds provides a mock function with given fields: ip
+func (_m *AddressSet) PrepareDeleteIPsCmds(ip []net.IP) ([]*goovn.OvnCommand, error) {
+	ret := _m.Called(ip)
+
+	var r0 []*goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func([]net.IP) []*goovn.OvnCommand); ok {
+		r0 = rf(ip)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]net.IP) error); ok {
+		r1 = rf(ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetIPs provides a mock function with given fields: ip
 func (_m *AddressSet) SetIPs(ip []net.IP) error {
 	ret := _m.Called(ip)
@@ -6,9 +6,11 @@ import (
 	"sync/atomic"
 	"time"
 
+	goovn "github.com/ebay/go-ovn"
 	globalconfig "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/acl"
 	ovnlb "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/loadbalancer"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -64,7 +66,7 @@ func (r *repair) runBeforeSync(clusterPortGroupUUID string) {
 
 	// Ensure unidling is enabled
 	if globalconfig.Kubernetes.OVNEmptyLbEvents {
-		_, _, err := util.RunOVNNbctl("set", "nb_global", ".", "options:controller_event=true")
+		_, _, err := util.RunOVNNbctl("set", types.OVNNBTableNBGlobal, ".", "options:"+goovn.NBGlobalOptionControllerEvent+"=true")
 		if err != nil {
 			klog.Error("Unable to enable controller events. Unidling not possible")
 		}
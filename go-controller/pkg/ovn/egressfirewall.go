@@ -102,7 +102,7 @@ func (oc *Controller) syncEgressFirewall(egressFirwalls []interface{}) {
 			"--columns=_uuid",
 			"--format=table",
 			"find",
-			"acl",
+			types.OVNNBTableACL,
 			fmt.Sprintf("priority<=%s", types.EgressFirewallStartPriority),
 			fmt.Sprintf("priority>=%s", types.MinimumReservedEgressFirewallPriority),
 		)
@@ -127,7 +127,7 @@ func (oc *Controller) syncEgressFirewall(egressFirwalls []interface{}) {
 					"--columns=acls",
 					"--format=table",
 					"list",
-					"logical_switch",
+					types.OVNNBTableLogicalSwitch,
 					logicalSwitch,
 				)
 				if err != nil {
@@ -137,9 +137,9 @@ func (oc *Controller) syncEgressFirewall(egressFirwalls []interface{}) {
 					if strings.Contains(switchACLs, egressFirewallACLID) {
 						_, stderr, err := util.RunOVNNbctl(
 							"remove",
-							"logical_switch",
+							types.OVNNBTableLogicalSwitch,
 							logicalSwitch,
-							"acls",
+							util.OVNColumnACLs,
 							egressFirewallACLID,
 						)
 						if err != nil {
@@ -156,7 +156,7 @@ func (oc *Controller) syncEgressFirewall(egressFirwalls []interface{}) {
 		"--columns=_uuid",
 		"--format=table",
 		"find",
-		"acl",
+		types.OVNNBTableACL,
 		fmt.Sprintf("priority<=%s", types.EgressFirewallStartPriority),
 		fmt.Sprintf("priority>=%s", types.MinimumReservedEgressFirewallPriority),
 		fmt.Sprintf("direction=%s", types.DirectionFromLPort),
@@ -169,7 +169,7 @@ func (oc *Controller) syncEgressFirewall(egressFirwalls []interface{}) {
 		for _, egressFirewallACLID := range strings.Fields(egressFirewallACLIDs) {
 			_, stderr, err := util.RunOVNNbctl(
 				"set",
-				"acl",
+				types.OVNNBTableACL,
 				egressFirewallACLID,
 				fmt.Sprintf("direction=%s", types.DirectionToLPort),
 			)
@@ -186,7 +186,7 @@ func (oc *Controller) syncEgressFirewall(egressFirwalls []interface{}) {
 		"--columns=_uuid",
 		"--format=table",
 		"find",
-		"logical_router_policy",
+		types.OVNNBTableLogicalRouterPolicy,
 		fmt.Sprintf("priority<=%s", types.EgressFirewallStartPriority),
 		fmt.Sprintf("priority>=%s", types.MinimumReservedEgressFirewallPriority),
 	)
@@ -198,9 +198,9 @@ func (oc *Controller) syncEgressFirewall(egressFirwalls []interface{}) {
 		for _, egressFirewallPolicyID := range strings.Fields(egressFirewallPolicyIDs) {
 			_, stderr, err := util.RunOVNNbctl(
 				"remove",
-				"logical_router",
+				types.OVNNBTableLogicalRouter,
 				types.OVNClusterRouter,
-				"policies",
+				util.OVNColumnPolicies,
 				egressFirewallPolicyID,
 			)
 			if err != nil {
@@ -216,7 +216,7 @@ func (oc *Controller) syncEgressFirewall(egressFirwalls []interface{}) {
 		"--columns=external_id",
 		"--format=table",
 		"find",
-		"acl",
+		types.OVNNBTableACL,
 		fmt.Sprintf("priority<=%s", types.EgressFirewallStartPriority),
 		fmt.Sprintf("priority>=%s", types.MinimumReservedEgressFirewallPriority),
 	)
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	goovn "github.com/ebay/go-ovn"
 	utilnet "k8s.io/utils/net"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
@@ -506,34 +507,84 @@ func (oc *Controller) deletePerPodGRSNAT(node string, podIPNets []*net.IPNet) {
 }
 
 func (oc *Controller) addPerPodGRSNAT(pod *kapi.Pod, podIfAddrs []*net.IPNet) error {
+	cmds, err := oc.getPerPodGRSNATCmds(pod, podIfAddrs)
+	if err != nil {
+		return err
+	}
+	if err = oc.ovnNBClient.Execute(cmds...); err != nil {
+		return fmt.Errorf("failed to update NAT for pod: %s, error: %v", pod.Name, err)
+	}
+	return nil
+}
+
+// getPerPodGRSNATCmds returns the commands SNATing the pod IPs to the gateway
+// IPs of the node the pod runs on, so that they can be committed together with
+// the pod's logical switch port
+func (oc *Controller) getPerPodGRSNATCmds(pod *kapi.Pod, podIfAddrs []*net.IPNet) ([]*goovn.OvnCommand, error) {
 	nodeName := pod.Spec.NodeName
 	node, err := oc.watchFactory.GetNode(nodeName)
 	if err != nil {
-		return fmt.Errorf("failed to get node %s: %v", nodeName, err)
+		return nil, fmt.Errorf("failed to get node %s: %v", nodeName, err)
 	}
 	l3GWConfig, err := util.ParseNodeL3GatewayAnnotation(node)
 	if err != nil {
-		return fmt.Errorf("unable to parse node L3 gw annotation: %v", err)
+		return nil, fmt.Errorf("unable to parse node L3 gw annotation: %v", err)
 	}
-	gr := types.GWRouterPrefix + nodeName
-	for _, gwIPNet := range l3GWConfig.IPAddresses {
+	cmds, err := perPodGRSNATCmds(oc.ovnNBClient, types.GWRouterPrefix+nodeName, l3GWConfig.IPAddresses, podIfAddrs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update NAT for pod: %s, error: %v", pod.Name, err)
+	}
+	return cmds, nil
+}
+
+// perPodGRSNATCmds returns the commands SNATing each pod IP to the gateway IP
+// of the same family on the gateway router gr. Pod IPs SNATed to the right
+// gateway IP already are skipped; an SNAT to another IP is replaced.
+func perPodGRSNATCmds(nbClient goovn.Client, gr string, gwIPNets, podIPNets []*net.IPNet) ([]*goovn.OvnCommand, error) {
+	nats, err := nbClient.LRNATList(gr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list NAT rules of router %s: %v", gr, err)
+	}
+
+	var cmds []*goovn.OvnCommand
+	for _, gwIPNet := range gwIPNets {
 		gwIP := gwIPNet.IP.String()
-		for _, podIPNet := range podIfAddrs {
+		for _, podIPNet := range podIPNets {
 			podIP := podIPNet.IP.String()
 			if utilnet.IsIPv6String(gwIP) != utilnet.IsIPv6String(podIP) {
 				continue
 			}
-			mask := GetIPFullMask(podIP)
-			_, fullMaskPodNet, err := net.ParseCIDR(podIP + mask)
-			if err != nil {
-				return fmt.Errorf("invalid IP: %s and mask: %s combination, error: %v", podIP, mask, err)
+			exists, stale := false, false
+			for _, nat := range nats {
+				if nat == nil || nat.Type != "snat" || nat.LogicalIP != podIP {
+					continue
+				}
+				if nat.ExternalIP == gwIP {
+					exists = true
+				} else {
+					stale = true
+				}
 			}
-			if err := util.UpdateRouterSNAT(gr, gwIPNet.IP, fullMaskPodNet); err != nil {
-				return fmt.Errorf("failed to update NAT for pod: %s, error: %v", pod.Name, err)
+			if exists {
+				continue
 			}
+			if stale {
+				cmd, err := nbClient.LRNATDel(gr, "snat", podIP)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create the command deleting the stale SNAT of %s on router %s: %v",
+						podIP, gr, err)
+				}
+				cmds = append(cmds, cmd)
+			}
+			cmd, err := nbClient.LRNATAdd(gr, "snat", gwIP, podIP, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create the command adding the SNAT of %s to %s on router %s: %v",
+					podIP, gwIP, gr, err)
+			}
+			cmds = append(cmds, cmd)
 		}
 	}
-	return nil
+	return cmds, nil
 }
 
 // addHybridRoutePolicyForPod handles adding a higher priority allow policy to allow traffic to be routed normally
@@ -756,8 +807,8 @@ func (oc *Controller) cleanExGwECMPRoutes() {
 			if !ovnRoute.shouldExist {
 				klog.Infof("Found stale exgw ecmp route, podIP: %s, nexthop: %s, router: %s",
 					podIP, ovnRoute.nextHop, ovnRoute.router)
-				_, stderr, err := util.RunOVNNbctl("--if-exists", "remove", "Logical_Router",
-					strings.TrimSuffix(ovnRoute.router, "\n"), "static_routes", ovnRoute.uuid)
+				_, stderr, err := util.RunOVNNbctl("--if-exists", "remove", types.OVNNBTableLogicalRouter,
+					strings.TrimSuffix(ovnRoute.router, "\n"), util.OVNColumnStaticRoutes, ovnRoute.uuid)
 				if err != nil {
 					klog.Errorf("Failed to destroy Logical_Router_Static_Route %s, stderr: %q, (%v)",
 						ovnRoute.uuid, stderr, err)
@@ -940,7 +991,7 @@ func buildOVNECMPCache() map[string][]*ovnRoute {
 		nexthop := values[2]
 		outport := values[3]
 		gr, stderr, err := util.RunOVNNbctl(
-			"--format=csv", "--data=bare", "--no-heading", "--columns=name", "find", "Logical_Router", fmt.Sprintf("static_routes{>=}[%s]", uuid))
+			"--format=csv", "--data=bare", "--no-heading", "--columns=name", "find", types.OVNNBTableLogicalRouter, fmt.Sprintf("%s{>=}[%s]", util.OVNColumnStaticRoutes, uuid))
 		if err != nil || gr == "" {
 			klog.Errorf("CleanECMPRoutes: failed to find logical router for %s", uuid, err, stderr)
 			continue
@@ -0,0 +1,197 @@
+package ovn
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	goovn "github.com/ebay/go-ovn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	egressfirewallfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/clientset/versioned/fake"
+	egressipfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned/fake"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	as_mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set/mocks"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	goovn_mock "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/ebay/go-ovn"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+func TestPerPodGRSNATCmds(t *testing.T) {
+	gr := "GR_node1"
+	gwIPs := []*net.IPNet{ovntest.MustParseIPNet("172.18.0.2/16"), ovntest.MustParseIPNet("fc00:f853:ccd:e793::2/64")}
+	podIPs := []*net.IPNet{ovntest.MustParseIPNet("10.128.1.3/24"), ovntest.MustParseIPNet("fd00:10:244:2::3/64")}
+
+	tests := []struct {
+		desc       string
+		nats       []*goovn.NAT
+		listErr    error
+		addErr     error
+		expAdds    [][2]string
+		expDeletes []string
+		expCmds    []string
+		errMatch   string
+	}{
+		{
+			desc:    "SNATs each pod IP to the gateway IP of its family",
+			expAdds: [][2]string{{"172.18.0.2", "10.128.1.3"}, {"fc00:f853:ccd:e793::2", "fd00:10:244:2::3"}},
+			expCmds: []string{"add", "add"},
+		},
+		{
+			desc: "skips the pod IPs SNATed already",
+			nats: []*goovn.NAT{
+				{Type: "snat", ExternalIP: "172.18.0.2", LogicalIP: "10.128.1.3"},
+				{Type: "dnat_and_snat", ExternalIP: "fc00:f853:ccd:e793::5", LogicalIP: "fd00:10:244:2::3"},
+			},
+			expAdds: [][2]string{{"fc00:f853:ccd:e793::2", "fd00:10:244:2::3"}},
+			expCmds: []string{"add"},
+		},
+		{
+			desc: "replaces an SNAT to another gateway IP",
+			nats: []*goovn.NAT{
+				{Type: "snat", ExternalIP: "172.18.0.9", LogicalIP: "10.128.1.3"},
+				{Type: "snat", ExternalIP: "fc00:f853:ccd:e793::2", LogicalIP: "fd00:10:244:2::3"},
+			},
+			expDeletes: []string{"10.128.1.3"},
+			expAdds:    [][2]string{{"172.18.0.2", "10.128.1.3"}},
+			expCmds:    []string{"del", "add"},
+		},
+		{
+			desc:     "fails when the NAT rules of the router cannot be listed",
+			listErr:  goovn.ErrorNotFound,
+			errMatch: "failed to list NAT rules of router GR_node1",
+		},
+		{
+			desc:     "returns no command when one cannot be created",
+			addErr:   fmt.Errorf("boom"),
+			expAdds:  [][2]string{{"172.18.0.2", "10.128.1.3"}},
+			errMatch: "failed to create the command adding the SNAT of 10.128.1.3 to 172.18.0.2",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			mockNbClient := new(goovn_mock.Client)
+			mockNbClient.On("LRNATList", gr).Return(tc.nats, tc.listErr)
+			for _, podIP := range tc.expDeletes {
+				mockNbClient.On("LRNATDel", gr, "snat", podIP).Return(ovntest.MockCommand("del"), nil)
+			}
+			for _, add := range tc.expAdds {
+				var addCmd *goovn.OvnCommand
+				if tc.addErr == nil {
+					addCmd = ovntest.MockCommand("add")
+				}
+				mockNbClient.On("LRNATAdd", gr, "snat", add[0], add[1], map[string]string(nil)).Return(addCmd, tc.addErr)
+			}
+
+			cmds, err := perPodGRSNATCmds(mockNbClient, gr, gwIPs, podIPs)
+			if tc.errMatch != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMatch)
+				assert.Nil(t, cmds)
+			} else {
+				assert.Nil(t, err)
+				cmdNames := []string{}
+				for _, cmd := range cmds {
+					cmdNames = append(cmdNames, cmd.Describe()[0].Table)
+				}
+				assert.Equal(t, tc.expCmds, cmdNames)
+			}
+			mockNbClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAddLogicalPortPerPodSNAT(t *testing.T) {
+
+	tests := []struct {
+		desc       string
+		executeErr error
+		errMatch   string
+	}{
+		{
+			desc: "commits the port and its SNAT together",
+		},
+		{
+			desc:       "leaves neither the port nor its SNAT when the transaction fails",
+			executeErr: fmt.Errorf("transaction failed"),
+			errMatch:   "error while creating logical port namespace1_pod1 error: transaction failed",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			config.PrepareTestConfig()
+			config.Gateway.DisableSNATMultipleGWs = true
+			defer config.PrepareTestConfig()
+
+			node := &v1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name: "node1",
+				Annotations: map[string]string{
+					"k8s.ovn.org/l3-gateway-config": `{"default":{"mode":"shared","mac-address":"7e:57:f8:f0:3c:49",` +
+						`"ip-address":"172.18.0.2/16","next-hop":"172.18.0.1"}}`,
+					"k8s.ovn.org/node-chassis-id": "cb9ec8fa-b409-4ef3-9f42-d9283c47aac6",
+				},
+			}}
+			pod := newPod("namespace1", "pod1", "node1", "")
+			fakeClient := &util.OVNClientset{
+				KubeClient:           fake.NewSimpleClientset(node, newNamespace("namespace1"), pod),
+				EgressIPClient:       egressipfake.NewSimpleClientset(),
+				EgressFirewallClient: egressfirewallfake.NewSimpleClientset(),
+			}
+			stopChan := make(chan struct{})
+			defer close(stopChan)
+			wf, err := factory.NewMasterWatchFactory(fakeClient)
+			assert.Nil(t, err)
+			defer wf.Shutdown()
+
+			portCmd, addrSetCmd, snatCmd := ovntest.MockCommand("LSPAddFull"), ovntest.MockCommand("PrepareAddIPsCmds"), ovntest.MockCommand("LRNATAdd")
+			mockAddressSet := new(as_mocks.AddressSet)
+			mockAddressSet.On("PrepareAddIPsCmds", mock.Anything).Return([]*goovn.OvnCommand{addrSetCmd}, nil)
+			mockAddressSetFactory := new(as_mocks.AddressSetFactory)
+			mockAddressSetFactory.On("NewAddressSet", "namespace1", mock.Anything).Return(mockAddressSet, nil)
+			mockNbClient := new(goovn_mock.Client)
+			mockNbClient.On("LSPGet", "namespace1_pod1").Return(nil, goovn.ErrorNotFound)
+			mockNbClient.On("LSPAddFull", "node1", "ls-uuid", "namespace1_pod1", mock.Anything).Return(portCmd, nil)
+			mockNbClient.On("LRNATList", "GR_node1").Return(nil, nil)
+			mockNbClient.On("LRNATAdd", "GR_node1", "snat", "172.18.0.2", mock.Anything, map[string]string(nil)).Return(snatCmd, nil)
+			// a single transaction holds the port, the address set update
+			// and the SNAT, the port first
+			var result []string
+			if tc.executeErr == nil {
+				result = []string{"lsp-uuid", "nat-uuid"}
+				mockNbClient.On("LSPGetUUID", "lsp-uuid").Return(
+					&goovn.LogicalSwitchPort{UUID: "lsp-uuid", Name: "namespace1_pod1"}, nil)
+			}
+			mockNbClient.On("ExecuteR", portCmd, addrSetCmd, snatCmd).Return(result, tc.executeErr)
+
+			oc := NewOvnController(fakeClient, wf, stopChan, mockAddressSetFactory,
+				mockNbClient, new(goovn_mock.Client), record.NewFakeRecorder(10))
+			oc.multicastSupport = false
+			assert.Nil(t, oc.lsManager.AddNode("node1", "ls-uuid", []*net.IPNet{ovntest.MustParseIPNet("10.128.1.0/24")}))
+
+			err = oc.addLogicalPort(pod)
+			// nothing but the failed transaction was sent to the nbdb, so
+			// no SNAT nor address set IP is left behind without its port
+			mockNbClient.AssertNotCalled(t, "Execute")
+			mockNbClient.AssertNumberOfCalls(t, "ExecuteR", 1)
+			mockNbClient.AssertExpectations(t)
+			if tc.errMatch != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMatch)
+				_, err = oc.logicalPortCache.get("namespace1_pod1")
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			portInfo, err := oc.logicalPortCache.get("namespace1_pod1")
+			if assert.Nil(t, err) {
+				assert.Equal(t, "lsp-uuid", portInfo.uuid)
+			}
+		})
+	}
+}
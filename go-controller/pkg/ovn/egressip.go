@@ -209,9 +209,9 @@ func (oc *Controller) syncEgressIPs(eIPs []interface{}) {
 	for _, policyID := range policyIDs {
 		_, stderr, err := util.RunOVNNbctl(
 			"remove",
-			"logical_router",
+			types.OVNNBTableLogicalRouter,
 			types.OVNClusterRouter,
-			"policies",
+			util.OVNColumnPolicies,
 			policyID,
 		)
 		if err != nil {
@@ -239,7 +239,7 @@ func (oc *Controller) syncStaleEgressReroutePolicy(egressIPToPodIPCache map[stri
 		"--no-heading",
 		"--columns=_uuid,external_ids,match",
 		"find",
-		"logical_router_policy",
+		types.OVNNBTableLogicalRouterPolicy,
 		fmt.Sprintf("priority=%v", types.EgressIPReroutePriority),
 	)
 	if err != nil {
@@ -271,9 +271,9 @@ func (oc *Controller) syncStaleEgressReroutePolicy(egressIPToPodIPCache map[stri
 		if !exists || !podIPCache.Has(parsedLogicalIP.String()) {
 			_, stderr, err := util.RunOVNNbctl(
 				"remove",
-				"logical_router",
+				types.OVNNBTableLogicalRouter,
 				types.OVNClusterRouter,
-				"policies",
+				util.OVNColumnPolicies,
 				UUID,
 			)
 			if err != nil {
@@ -290,7 +290,7 @@ func (oc *Controller) syncStaleNATRules(egressIPToPodIPCache map[string]sets.Str
 		"--no-heading",
 		"--columns=_uuid,external_ids,logical_ip",
 		"find",
-		"nat",
+		types.OVNNBTableNAT,
 	)
 	if err != nil {
 		klog.Errorf("Unable to sync egress IPs, unable to find NAT IDs, stderr: %s, err: %v", stderr, err)
@@ -320,7 +320,7 @@ func (oc *Controller) syncStaleNATRules(egressIPToPodIPCache map[string]sets.Str
 				"--no-heading",
 				"--columns=name",
 				"find",
-				"logical_router",
+				types.OVNNBTableLogicalRouter,
 				fmt.Sprintf("nat{>=}%s", UUID),
 			)
 			if err != nil {
@@ -330,9 +330,9 @@ func (oc *Controller) syncStaleNATRules(egressIPToPodIPCache map[string]sets.Str
 				if logicalRouter != "" {
 					_, stderr, err = util.RunOVNNbctl(
 						"remove",
-						"logical_router",
+						types.OVNNBTableLogicalRouter,
 						logicalRouter,
-						"nat",
+						util.OVNColumnNAT,
 						UUID,
 					)
 					if err != nil {
@@ -612,7 +612,7 @@ func (oc *Controller) addEgressNode(egressNode *kapi.Node) error {
 	klog.V(5).Infof("Egress node: %s about to be initialized", egressNode.Name)
 	if stdout, stderr, err := util.RunOVNNbctl(
 		"set",
-		"logical_switch_port",
+		types.OVNNBTableLogicalSwitchPort,
 		types.EXTSwitchToGWRouterPrefix+types.GWRouterPrefix+egressNode.Name,
 		"options:nat-addresses=router",
 	); err != nil {
@@ -649,9 +649,9 @@ func (oc *Controller) deleteEgressNode(egressNode *kapi.Node) error {
 	klog.V(5).Infof("Egress node: %s about to be removed", egressNode.Name)
 	if stdout, stderr, err := util.RunOVNNbctl(
 		"remove",
-		"logical_switch_port",
+		types.OVNNBTableLogicalSwitchPort,
 		types.EXTSwitchToGWRouterPrefix+types.GWRouterPrefix+egressNode.Name,
-		"options",
+		util.OVNColumnOptions,
 		"nat-addresses=router",
 	); err != nil {
 		klog.Errorf("Unable to remove GARP configuration on external logical switch port for egress node: %s, stdout: %s, stderr: %s, err: %v", egressNode.Name, stdout, stderr, err)
@@ -946,7 +946,7 @@ func (e *egressIPController) createEgressReroutePolicy(filterOption, egressIPNam
 		_, stderr, err := util.RunOVNNbctl(
 			"--id=@lr-policy",
 			"create",
-			"logical_router_policy",
+			types.OVNNBTableLogicalRouterPolicy,
 			"action=reroute",
 			fmt.Sprintf("match=\"%s\"", filterOption),
 			fmt.Sprintf("priority=%v", types.EgressIPReroutePriority),
@@ -954,9 +954,9 @@ func (e *egressIPController) createEgressReroutePolicy(filterOption, egressIPNam
 			fmt.Sprintf("external_ids:name=%s", egressIPName),
 			"--",
 			"add",
-			"logical_router",
+			types.OVNNBTableLogicalRouter,
 			types.OVNClusterRouter,
-			"policies",
+			util.OVNColumnPolicies,
 			"@lr-policy",
 		)
 		if err != nil {
@@ -974,9 +974,9 @@ func (e *egressIPController) deleteEgressReroutePolicy(filterOption, egressIPNam
 	for _, policyID := range policyIDs {
 		_, stderr, err := util.RunOVNNbctl(
 			"remove",
-			"logical_router",
+			types.OVNNBTableLogicalRouter,
 			types.OVNClusterRouter,
-			"policies",
+			util.OVNColumnPolicies,
 			policyID,
 		)
 		if err != nil {
@@ -993,7 +993,7 @@ func findReroutePolicyIDs(filterOption, egressIPName string, gatewayRouterIPs []
 		"--no-heading",
 		"--columns=_uuid",
 		"find",
-		"logical_router_policy",
+		types.OVNNBTableLogicalRouterPolicy,
 		fmt.Sprintf("match=\"%s\"", filterOption),
 		fmt.Sprintf("priority=%v", types.EgressIPReroutePriority),
 		fmt.Sprintf("external_ids:name=%s", egressIPName),
@@ -1015,7 +1015,7 @@ func findLegacyReroutePolicyIDs() ([]string, error) {
 		"--no-heading",
 		"--columns=_uuid",
 		"find",
-		"logical_router_policy",
+		types.OVNNBTableLogicalRouterPolicy,
 		fmt.Sprintf("priority=%v", types.EgressIPReroutePriority),
 		"nexthop!=[]",
 	)
@@ -1210,7 +1210,7 @@ func createNATRule(podIPs []net.IP, status egressipv1.EgressIPStatusItem, egress
 				_, stderr, err := util.RunOVNNbctl(
 					"--id=@nat",
 					"create",
-					"nat",
+					types.OVNNBTableNAT,
 					"type=snat",
 					fmt.Sprintf("logical_port=k8s-%s", status.Node),
 					fmt.Sprintf("external_ip=\"%s\"", status.EgressIP),
@@ -1218,9 +1218,9 @@ func createNATRule(podIPs []net.IP, status egressipv1.EgressIPStatusItem, egress
 					fmt.Sprintf("external_ids:name=%s", egressIPName),
 					"--",
 					"add",
-					"logical_router",
+					types.OVNNBTableLogicalRouter,
 					util.GetGatewayRouterFromNode(status.Node),
-					"nat",
+					util.OVNColumnNAT,
 					"@nat",
 				)
 				if err != nil {
@@ -1242,9 +1242,9 @@ func deleteNATRule(podIPs []net.IP, status egressipv1.EgressIPStatusItem, egress
 			for _, natID := range natIDs {
 				_, stderr, err := util.RunOVNNbctl(
 					"remove",
-					"logical_router",
+					types.OVNNBTableLogicalRouter,
 					util.GetGatewayRouterFromNode(status.Node),
-					"nat",
+					util.OVNColumnNAT,
 					natID,
 				)
 				if err != nil {
@@ -1263,7 +1263,7 @@ func findNatIDs(egressIPName, podIP, egressIP string) ([]string, error) {
 		"--no-heading",
 		"--columns=_uuid",
 		"find",
-		"nat",
+		types.OVNNBTableNAT,
 		fmt.Sprintf("external_ids:name=%s", egressIPName),
 		fmt.Sprintf("logical_ip=\"%s\"", podIP),
 		fmt.Sprintf("external_ip=\"%s\"", egressIP),
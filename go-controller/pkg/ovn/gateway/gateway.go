@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"github.com/pkg/errors"
 )
@@ -23,7 +24,7 @@ var (
 func GetOvnGateways() ([]string, string, error) {
 	out, stderr, err := util.RunOVNNbctl("--data=bare", "--no-heading",
 		"--columns=name", "find",
-		"logical_router",
+		types.OVNNBTableLogicalRouter,
 		"options:chassis!=null")
 	if err != nil {
 		return nil, stderr, err
@@ -33,7 +34,7 @@ func GetOvnGateways() ([]string, string, error) {
 
 // GetGatewayPhysicalIP return gateway physical IP
 func GetGatewayPhysicalIP(gatewayRouter string) (string, error) {
-	physicalIP, stderr, err := util.RunOVNNbctl("get", "logical_router",
+	physicalIP, stderr, err := util.RunOVNNbctl("get", types.OVNNBTableLogicalRouter,
 		gatewayRouter, "external_ids:physical_ip")
 	if err != nil {
 		return "", errors.Wrapf(err, "error to obtain physical IP on router %s, stderr: %v", gatewayRouter, stderr)
@@ -46,7 +47,7 @@ func GetGatewayPhysicalIP(gatewayRouter string) (string, error) {
 
 // GetGatewayPhysicalIPs return gateway physical IPs
 func GetGatewayPhysicalIPs(gatewayRouter string) ([]string, error) {
-	physicalIPs, _, err := util.RunOVNNbctl("get", "logical_router",
+	physicalIPs, _, err := util.RunOVNNbctl("get", types.OVNNBTableLogicalRouter,
 		gatewayRouter, "external_ids:physical_ips")
 	if err == nil && physicalIPs != "" {
 		return strings.Split(physicalIPs, ","), nil
@@ -81,7 +81,7 @@ func delPbrAndNatRules(nodeName string, lrpTypes []string) {
 	// because there will be none since this NAT is only for outbound traffic and not for inbound
 	mgmtPortName := types.K8sPrefix + nodeName
 	externalIP, stderr, err := util.RunOVNNbctl("--data=bare", "--no-heading",
-		"--columns=external_ip", "find", "nat", fmt.Sprintf("logical_port=%s", mgmtPortName))
+		"--columns=external_ip", "find", types.OVNNBTableNAT, fmt.Sprintf("logical_port=%s", mgmtPortName))
 	if err != nil {
 		klog.Errorf("Failed to fetch the dnat_and_snat entry for the management port %s "+
 			"stderr: %s, error: %v", mgmtPortName, stderr, err)
@@ -104,7 +104,7 @@ func staticRouteCleanup(nextHops []net.IP) {
 		// Get a list of all the routes in cluster router with the next hop IP.
 		var uuids string
 		uuids, stderr, err := util.RunOVNNbctl("--data=bare", "--no-heading",
-			"--columns=_uuid", "find", "logical_router_static_route",
+			"--columns=_uuid", "find", types.OVNNBTableLogicalRouterStaticRoute,
 			"nexthop=\""+nextHop.String()+"\"")
 		if err != nil {
 			klog.Errorf("Failed to fetch all routes with "+
@@ -117,7 +117,7 @@ func staticRouteCleanup(nextHops []net.IP) {
 		routes := strings.Fields(uuids)
 		for _, route := range routes {
 			_, stderr, err = util.RunOVNNbctl("--if-exists", "remove",
-				"logical_router", types.OVNClusterRouter, "static_routes", route)
+				types.OVNNBTableLogicalRouter, types.OVNClusterRouter, util.OVNColumnStaticRoutes, route)
 			if err != nil {
 				klog.Errorf("Failed to delete static route %s"+
 					", stderr: %q, err = %v", route, stderr, err)
@@ -152,7 +152,7 @@ func multiJoinSwitchGatewayCleanup(nodeName string, upgradeOnly bool) error {
 	for _, gwIPAddr := range gwIPAddrs {
 		// Delete logical router policy whose nexthop is the old rtoj- gateway port address
 		stdout, stderr, err := util.RunOVNNbctl("--data=bare", "--no-heading", "--columns=_uuid",
-			"find", "logical_router_policy", fmt.Sprintf("nexthop=%s", gwIPAddr.IP))
+			"find", types.OVNNBTableLogicalRouterPolicy, fmt.Sprintf("nexthop=%s", gwIPAddr.IP))
 		if err != nil {
 			klog.Errorf("Unable to find LR policy of nexthop: %s for node %s, stderr: %s, err: %v",
 				gwIPAddr.IP, nodeName, stderr, err)
@@ -161,7 +161,7 @@ func multiJoinSwitchGatewayCleanup(nodeName string, upgradeOnly bool) error {
 		if stdout != "" {
 			policyIDs := strings.Fields(stdout)
 			for _, policyID := range policyIDs {
-				_, stderr, err = util.RunOVNNbctl("remove", "logical_router", types.OVNClusterRouter, "policies", policyID)
+				_, stderr, err = util.RunOVNNbctl("remove", types.OVNNBTableLogicalRouter, types.OVNClusterRouter, util.OVNColumnPolicies, policyID)
 				if err != nil {
 					klog.Errorf("Unable to remove LR policy: %s, stderr: %s, err: %v", policyID, stderr, err)
 				}
@@ -235,7 +235,7 @@ func removeLRPolicies(nodeName string, priorities []string) {
 
 	// find the pbr rules associated with the node and delete them
 	matches, stderr, err := util.RunOVNNbctl("--data=bare", "--no-heading", "--columns=match",
-		"find", "logical_router_policy")
+		"find", types.OVNNBTableLogicalRouterPolicy)
 	if err != nil {
 		klog.Errorf("Failed to fetch the policy route for the node subnet "+
 			"stdout: %s, stderr: %s, error: %v", matches, stderr, err)
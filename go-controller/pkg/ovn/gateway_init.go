@@ -5,6 +5,7 @@ import (
 	"net"
 	"strings"
 
+	goovn "github.com/ebay/go-ovn"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -16,7 +17,7 @@ import (
 )
 
 // gatewayInit creates a gateway router for the local chassis.
-func gatewayInit(nodeName string, clusterIPSubnet []*net.IPNet, hostSubnets []*net.IPNet,
+func gatewayInit(nbClient goovn.Client, nodeName string, clusterIPSubnet []*net.IPNet, hostSubnets []*net.IPNet,
 	l3GatewayConfig *util.L3GatewayConfig, sctpSupport bool, gwLRPIfAddrs, drLRPIfAddrs []*net.IPNet) error {
 
 	gwLRPIPs := make([]net.IP, 0)
@@ -31,7 +32,7 @@ func gatewayInit(nodeName string, clusterIPSubnet []*net.IPNet, hostSubnets []*n
 		physicalIPs[i] = ip.IP.String()
 	}
 	stdout, stderr, err := util.RunOVNNbctl("--", "--may-exist", "lr-add",
-		gatewayRouter, "--", "set", "logical_router", gatewayRouter,
+		gatewayRouter, "--", "set", types.OVNNBTableLogicalRouter, gatewayRouter,
 		"options:chassis="+l3GatewayConfig.ChassisID,
 		"external_ids:physical_ip="+physicalIPs[0],
 		"external_ids:physical_ips="+strings.Join(physicalIPs, ","))
@@ -40,30 +41,19 @@ func gatewayInit(nodeName string, clusterIPSubnet []*net.IPNet, hostSubnets []*n
 			"stderr: %q, error: %v", gatewayRouter, stdout, stderr, err)
 	}
 
-	gwSwitchPort := types.JoinSwitchToGWRouterPrefix + gatewayRouter
-	gwRouterPort := types.GWRouterToJoinSwitchPrefix + gatewayRouter
-
-	stdout, stderr, err = util.RunOVNNbctl(
-		"--", "--may-exist", "lsp-add", types.OVNJoinSwitch, gwSwitchPort,
-		"--", "set", "logical_switch_port", gwSwitchPort, "type=router", "options:router-port="+gwRouterPort,
-		"addresses=router")
-	if err != nil {
-		return fmt.Errorf("failed to add port %q to logical switch %q, "+
-			"stdout: %q, stderr: %q, error: %v", gwSwitchPort, types.OVNJoinSwitch, stdout, stderr, err)
-	}
-
 	gwLRPMAC := util.IPAddrToHWAddr(gwLRPIPs[0])
-	args := []string{
-		"--", "--if-exists", "lrp-del", gwRouterPort,
-		"--", "lrp-add", gatewayRouter, gwRouterPort, gwLRPMAC.String(),
-	}
+	gwLRPNetworks := make([]string, 0, len(gwLRPIfAddrs))
 	for _, gwLRPIfAddr := range gwLRPIfAddrs {
-		args = append(args, gwLRPIfAddr.String())
+		gwLRPNetworks = append(gwLRPNetworks, gwLRPIfAddr.String())
 	}
-	_, stderr, err = util.RunOVNNbctl(args...)
+	cmds, err := AddNodeToJoinSwitch(nbClient, nodeName, gwLRPMAC.String(), gwLRPNetworks)
 	if err != nil {
-		return fmt.Errorf("failed to add logical router port %q for gateway router %s, "+
-			"stderr: %q, error: %v", gwRouterPort, gatewayRouter, stderr, err)
+		return err
+	}
+	if len(cmds) > 0 {
+		if err := nbClient.Execute(cmds...); err != nil {
+			return fmt.Errorf("failed to connect gateway router %s to the join switch: %v", gatewayRouter, err)
+		}
 	}
 
 	// Local gateway mode does not need SNAT or routes on GR because GR is only used for multiple external gws
@@ -73,7 +63,7 @@ func gatewayInit(nodeName string, clusterIPSubnet []*net.IPNet, hostSubnets []*n
 		// default for any sane deployment), we need to SNAT traffic
 		// heading to the logical space with the Gateway router's IP so that
 		// return traffic comes back to the same gateway router.
-		stdout, stderr, err = util.RunOVNNbctl("set", "logical_router",
+		stdout, stderr, err = util.RunOVNNbctl("set", types.OVNNBTableLogicalRouter,
 			gatewayRouter, "options:lb_force_snat_ip=router_ip")
 		if err != nil {
 			return fmt.Errorf("failed to set logical router %s's lb_force_snat_ip option, "+
@@ -82,7 +72,7 @@ func gatewayInit(nodeName string, clusterIPSubnet []*net.IPNet, hostSubnets []*n
 
 		// Set shared gw SNAT to use host CT Zone. This will avoid potential SNAT collisions between
 		// host networked pods and OVN networked pods northbound traffic
-		stdout, stderr, err = util.RunOVNNbctl("set", "logical_router", gatewayRouter, "options:snat-ct-zone=0")
+		stdout, stderr, err = util.RunOVNNbctl("set", types.OVNNBTableLogicalRouter, gatewayRouter, "options:snat-ct-zone=0")
 		if err != nil {
 			return fmt.Errorf("failed to set logical router %s's SNAT CT Zone to 0, "+
 				"stdout: %q, stderr: %q, error: %v", gatewayRouter, stdout, stderr, err)
@@ -92,7 +82,7 @@ func gatewayInit(nodeName string, clusterIPSubnet []*net.IPNet, hostSubnets []*n
 	// To decrease the numbers of MAC_Binding entries in a large scale cluster, change the default behavior of always
 	// learning the MAC/IP binding and adding a new MAC_Binding entry. Only do it when necessary.
 	// See details in ovn-northd(8).
-	stdout, stderr, err = util.RunOVNNbctl("set", "logical_router", gatewayRouter,
+	stdout, stderr, err = util.RunOVNNbctl("set", types.OVNNBTableLogicalRouter, gatewayRouter,
 		"options:always_learn_from_arp_request=false")
 	if err != nil {
 		klog.Warningf("Failed to set logical router %s's always_learn_from_arp_request "+
@@ -101,7 +91,7 @@ func gatewayInit(nodeName string, clusterIPSubnet []*net.IPNet, hostSubnets []*n
 
 	// To avoid flow exploding problem in large scale ovn-kubernetes cluster, not to prepopulate static mappings
 	// for all neighbor routers in the ARP/ND Resolution stage. See details in ovn-northd(8).
-	stdout, stderr, err = util.RunOVNNbctl("set", "logical_router",
+	stdout, stderr, err = util.RunOVNNbctl("set", types.OVNNBTableLogicalRouter,
 		gatewayRouter, "options:dynamic_neigh_routers=true")
 	if err != nil {
 		klog.Warningf("Failed to set logical router %s's dynamic_neigh_routers "+
@@ -239,6 +229,129 @@ func gatewayInit(nodeName string, clusterIPSubnet []*net.IPNet, hostSubnets []*n
 	return nil
 }
 
+// AddNodeToJoinSwitch returns the commands connecting the gateway router of
+// the node to the join switch, to be executed together in a single
+// transaction: a router type port on the join switch that points to the
+// gateway router port, and that gateway router port with the given MAC and
+// networks. An existing gateway router port is replaced, as lrp-del and
+// lrp-add would, unless it already has the MAC and networks, and an existing
+// join switch port is updated unless it is in sync. No commands are returned
+// when both ports are in sync.
+//
+// The peer of the gateway router port is left empty: the peer column may only
+// name another logical router port, a router port and a switch port are linked
+// by the router-port option of the switch port alone.
+func AddNodeToJoinSwitch(nbClient goovn.Client, node, grPortMAC string, grNetworks []string) ([]*goovn.OvnCommand, error) {
+	if _, err := net.ParseMAC(grPortMAC); err != nil {
+		return nil, fmt.Errorf("invalid MAC %q for the join port of node %s: %v", grPortMAC, node, err)
+	}
+	if len(grNetworks) == 0 {
+		return nil, fmt.Errorf("no networks for the join port of node %s", node)
+	}
+	for _, network := range grNetworks {
+		if _, _, err := net.ParseCIDR(network); err != nil {
+			return nil, fmt.Errorf("invalid network %q for the join port of node %s: %v", network, node, err)
+		}
+	}
+
+	gatewayRouter := types.GWRouterPrefix + node
+	gwSwitchPort := types.JoinSwitchToGWRouterPrefix + gatewayRouter
+	gwRouterPort := types.GWRouterToJoinSwitchPrefix + gatewayRouter
+
+	routerType := "router"
+	lspSpec := goovn.LSPSpec{
+		Type:      &routerType,
+		Addresses: []string{"router"},
+		Options:   map[string]string{util.OVNOptionRouterPort: gwRouterPort},
+	}
+	var cmds []*goovn.OvnCommand
+	lsp, err := nbClient.LSPGet(gwSwitchPort)
+	switch {
+	case err == goovn.ErrorNotFound:
+		cmd, err := nbClient.LSPAddFull(types.OVNJoinSwitch, "", gwSwitchPort, lspSpec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the command adding port %s to logical switch %s: %v",
+				gwSwitchPort, types.OVNJoinSwitch, err)
+		}
+		cmds = append(cmds, cmd)
+	case err != nil:
+		return nil, fmt.Errorf("failed to get port %s of logical switch %s: %v", gwSwitchPort, types.OVNJoinSwitch, err)
+	case joinSwitchPortDrift(lsp, gwRouterPort):
+		cmd, err := nbClient.LSPSet(gwSwitchPort, lspSpec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the command updating port %s of logical switch %s: %v",
+				gwSwitchPort, types.OVNJoinSwitch, err)
+		}
+		cmds = append(cmds, cmd)
+	}
+
+	lrpAddCmd, err := nbClient.LRPAdd(gatewayRouter, gwRouterPort, grPortMAC, grNetworks, "", nil)
+	if err != nil && err != goovn.ErrorExist {
+		return nil, fmt.Errorf("failed to create the command adding logical router port %s to gateway router %s: %v",
+			gwRouterPort, gatewayRouter, err)
+	}
+	if err == nil {
+		lrpDelCmd, err := nbClient.LRPDel(gatewayRouter, gwRouterPort)
+		if err == nil {
+			cmds = append(cmds, lrpDelCmd)
+		} else if err != goovn.ErrorNotFound {
+			return nil, fmt.Errorf("failed to create the command deleting logical router port %s: %v", gwRouterPort, err)
+		}
+		cmds = append(cmds, lrpAddCmd)
+	}
+	return cmds, nil
+}
+
+// joinSwitchPortDrift tells whether the join switch port lsp is not a router
+// type port with the router address pointing to gwRouterPort
+func joinSwitchPortDrift(lsp *goovn.LogicalSwitchPort, gwRouterPort string) bool {
+	if lsp.Type != "router" || len(lsp.Addresses) != 1 || lsp.Addresses[0] != "router" {
+		return true
+	}
+	routerPort, _ := lsp.Options[util.OVNOptionRouterPort].(string)
+	return routerPort != gwRouterPort
+}
+
+// GatewayRouterState is what the gateway router of a node holds, for the
+// gateway reconciliation to diff against the desired state
+type GatewayRouterState struct {
+	Router        string
+	LoadBalancers []*goovn.LoadBalancer
+	NATs          []*goovn.NAT
+	Routes        []*goovn.LogicalRouterStaticRoute
+	Policies      []*goovn.LogicalRouterPolicy
+	// Dangling lists the UUIDs the router refers to that are missing from
+	// the cache
+	Dangling []string
+}
+
+// GRStateGet returns the load balancers, NAT rules, static routes and
+// policies of the gateway router of the node, read together in a single pass
+// over the client cache
+func (oc *Controller) GRStateGet(node string) (*GatewayRouterState, error) {
+	return grStateGet(oc.ovnNBClient, node)
+}
+
+func grStateGet(nbClient goovn.Client, node string) (*GatewayRouterState, error) {
+	gatewayRouter := types.GWRouterPrefix + node
+	lr, err := nbClient.LRGetFull(gatewayRouter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gateway router %s: %v", gatewayRouter, err)
+	}
+	if len(lr.Dangling) > 0 {
+		klog.Warningf("Gateway router %s refers to rows missing from the cache: %s",
+			gatewayRouter, strings.Join(lr.Dangling, ", "))
+	}
+	return &GatewayRouterState{
+		Router:        gatewayRouter,
+		LoadBalancers: lr.LoadBalancers,
+		NATs:          lr.NATs,
+		Routes:        lr.Routes,
+		Policies:      lr.RouterPolicies,
+		Dangling:      lr.Dangling,
+	}, nil
+}
+
 // This DistributedGWPort guarantees to always have both IPv4 and IPv6 regardless of dual-stack
 func addDistributedGWPort() error {
 	masterChassisID, err := util.GetNodeChassisID()
@@ -254,7 +367,7 @@ func addDistributedGWPort() error {
 
 	// check if there is already a distributed gateway port
 	dgpUUID, stderr, err := util.RunOVNNbctl("--data=bare", "--no-heading",
-		"--columns=_uuid", "find", "logical_router_port", "name="+dgpName)
+		"--columns=_uuid", "find", types.OVNNBTableLogicalRouterPort, "name="+dgpName)
 	if err != nil {
 		return fmt.Errorf("error executing find logical_router_port for distributed GW port, stderr: %q, %+v", stderr, err)
 	}
@@ -266,13 +379,13 @@ func addDistributedGWPort() error {
 		klog.V(5).Infof("Distributed GW port already exists with uuid %s", dgpUUID)
 		// update the mac address if necessary
 		currentMac, _, err := util.RunOVNNbctl("--data=bare", "--no-heading",
-			"get", "logical_router_port", dgpUUID, "mac")
+			"get", types.OVNNBTableLogicalRouterPort, dgpUUID, util.OVNColumnMAC)
 		if err != nil {
 			return err
 		}
 		if currentMac != dgpMac {
 			_, _, err := util.RunOVNNbctl("--data=bare", "--no-heading",
-				"set", "logical_router_port", dgpUUID, "mac=\""+dgpMac+"\"")
+				"set", types.OVNNBTableLogicalRouterPort, dgpUUID, "mac=\""+dgpMac+"\"")
 			if err != nil {
 				return err
 			}
@@ -280,14 +393,14 @@ func addDistributedGWPort() error {
 		}
 		// update the port networks if necessary
 		currentNetworks, _, err := util.RunOVNNbctl("--data=bare", "--no-heading",
-			"get", "logical_router_port", dgpUUID, "networks")
+			"get", types.OVNNBTableLogicalRouterPort, dgpUUID, util.OVNColumnNetworks)
 		if err != nil {
 			return err
 		}
 		// only consider converting from single to dual-stack
 		if len(strings.Split(currentNetworks, ",")) != 2 {
 			_, _, err := util.RunOVNNbctl("--data=bare", "--no-heading",
-				"set", "logical_router_port", dgpUUID, "networks=\""+dgpNetworkV4+"\",\""+dgpNetworkV6+"\"")
+				"set", types.OVNNBTableLogicalRouterPort, dgpUUID, "networks=\""+dgpNetworkV4+"\",\""+dgpNetworkV6+"\"")
 			if err != nil {
 				return err
 			}
@@ -318,14 +431,14 @@ func addDistributedGWPort() error {
 	lclNetPortname := "lnet-" + types.NodeLocalSwitch
 	nbctlArgs = append(nbctlArgs,
 		"--", "--may-exist", "lsp-add", types.NodeLocalSwitch, lclNetPortname,
-		"--", "set", "logical_switch_port", lclNetPortname, "addresses=unknown", "type=localnet",
+		"--", "set", types.OVNNBTableLogicalSwitchPort, lclNetPortname, "addresses=unknown", "type=localnet",
 		"options:network_name="+types.LocalNetworkName)
 	// connect the switch to the distributed router
 	lspName := types.SwitchToRouterPrefix + types.NodeLocalSwitch
 	nbctlArgs = append(nbctlArgs,
 		"--", "--may-exist", "lsp-add", types.NodeLocalSwitch, lspName,
-		"--", "set", "logical_switch_port", lspName, "type=router", "addresses=router",
-		"options:nat-addresses=router", "options:router-port="+dgpName)
+		"--", "set", types.OVNNBTableLogicalSwitchPort, lspName, "type=router", "addresses=router",
+		"options:nat-addresses=router", util.OVNColumnOptions+":"+util.OVNOptionRouterPort+"="+dgpName)
 	stdout, stderr, err = util.RunOVNNbctl(nbctlArgs...)
 	if err != nil {
 		return fmt.Errorf("failed creating logical switch %s and its ports (%s, %s) "+
@@ -386,7 +499,7 @@ func addExternalSwitch(prefix, interfaceID, nodeName, gatewayRouter, macAddress,
 
 	if vlanID != nil {
 		lspArgs := []string{
-			"--", "set", "logical_switch_port", interfaceID,
+			"--", "set", types.OVNNBTableLogicalSwitchPort, interfaceID,
 			fmt.Sprintf("tag_request=%d", *vlanID),
 		}
 		cmdArgs = append(cmdArgs, lspArgs...)
@@ -426,8 +539,8 @@ func addExternalSwitch(prefix, interfaceID, nodeName, gatewayRouter, macAddress,
 	// Connect the external_switch to the router.
 	stdout, stderr, err = util.RunOVNNbctl("--", "--may-exist", "lsp-add",
 		externalSwitch, switchToRouterPort, "--", "set",
-		"logical_switch_port", switchToRouterPort, "type=router",
-		"options:router-port="+routerToSwitchPort,
+		types.OVNNBTableLogicalSwitchPort, switchToRouterPort, "type=router",
+		util.OVNColumnOptions+":"+util.OVNOptionRouterPort+"="+routerToSwitchPort,
 		"addresses="+"\""+macAddress+"\"")
 	if err != nil {
 		return fmt.Errorf("failed to add logical port to router %s, stdout: %q, "+
@@ -589,7 +702,7 @@ func findPolicyBasedRoutes(priority, compareTo string) ([]string, error) {
 		"--no-heading",
 		fmt.Sprintf("--columns=_uuid,%s", compareTo),
 		"find",
-		"logical_router_policy",
+		types.OVNNBTableLogicalRouterPolicy,
 		fmt.Sprintf("priority=%s", priority),
 	)
 	if err != nil {
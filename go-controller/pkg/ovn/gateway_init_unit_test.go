@@ -0,0 +1,211 @@
+package ovn
+
+import (
+	"fmt"
+	"testing"
+
+	goovn "github.com/ebay/go-ovn"
+	"github.com/stretchr/testify/assert"
+
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	goovn_mock "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/ebay/go-ovn"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+)
+
+func TestAddNodeToJoinSwitch(t *testing.T) {
+	gwSwitchPort := types.JoinSwitchToGWRouterPrefix + types.GWRouterPrefix + "node1"
+	gwRouterPort := types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + "node1"
+	routerType := "router"
+	lspSpec := goovn.LSPSpec{
+		Type:      &routerType,
+		Addresses: []string{"router"},
+		Options:   map[string]string{"router-port": gwRouterPort},
+	}
+	networks := []string{"100.64.0.2/16", "fd98::2/64"}
+	joinLSP := func(portType, routerPort string) *goovn.LogicalSwitchPort {
+		return &goovn.LogicalSwitchPort{
+			UUID:      "lsp1",
+			Name:      gwSwitchPort,
+			Type:      portType,
+			Addresses: []string{"router"},
+			Options:   map[interface{}]interface{}{"router-port": routerPort},
+		}
+	}
+
+	tests := []struct {
+		desc      string
+		mac       string
+		networks  []string
+		lsp       *goovn.LogicalSwitchPort
+		lspGetErr error
+		lrpAddErr error
+		lrpDelErr error
+		expCmds   []string
+		errMatch  string
+	}{
+		{
+			desc:      "adds both ports to a new node",
+			mac:       "0a:58:64:40:00:02",
+			networks:  networks,
+			lspGetErr: goovn.ErrorNotFound,
+			lrpDelErr: goovn.ErrorNotFound,
+			expCmds:   []string{"LSPAddFull", "LRPAdd"},
+		},
+		{
+			desc:     "updates the switch port and replaces the router port of an existing node",
+			mac:      "0a:58:64:40:00:02",
+			networks: networks,
+			lsp:      joinLSP("router", types.GWRouterToJoinSwitchPrefix+types.GWRouterPrefix+"node2"),
+			expCmds:  []string{"LSPSet", "LRPDel", "LRPAdd"},
+		},
+		{
+			desc:     "updates a switch port that is not a router port",
+			mac:      "0a:58:64:40:00:02",
+			networks: networks,
+			lsp:      joinLSP("", gwRouterPort),
+			expCmds:  []string{"LSPSet", "LRPDel", "LRPAdd"},
+		},
+		{
+			desc:     "only replaces the router port when the switch port is in sync",
+			mac:      "0a:58:64:40:00:02",
+			networks: networks,
+			lsp:      joinLSP("router", gwRouterPort),
+			expCmds:  []string{"LRPDel", "LRPAdd"},
+		},
+		{
+			desc:      "leaves the ports of a node in sync untouched",
+			mac:       "0a:58:64:40:00:02",
+			networks:  networks,
+			lsp:       joinLSP("router", gwRouterPort),
+			lrpAddErr: goovn.ErrorExist,
+			expCmds:   []string{},
+		},
+		{
+			desc:     "fails on an invalid MAC",
+			mac:      "0a:58:64:40:00",
+			networks: networks,
+			errMatch: "invalid MAC",
+		},
+		{
+			desc:     "fails on an invalid network",
+			mac:      "0a:58:64:40:00:02",
+			networks: []string{"100.64.0.2"},
+			errMatch: "invalid network",
+		},
+		{
+			desc:      "fails when the switch port cannot be read",
+			mac:       "0a:58:64:40:00:02",
+			networks:  networks,
+			lspGetErr: fmt.Errorf("boom"),
+			errMatch:  "failed to get port",
+		},
+		{
+			desc:      "fails when the router port cannot be added",
+			mac:       "0a:58:64:40:00:02",
+			networks:  networks,
+			lsp:       joinLSP("router", gwRouterPort),
+			lrpAddErr: fmt.Errorf("boom"),
+			errMatch:  "failed to create the command adding logical router port",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			mockNbClient := new(goovn_mock.Client)
+			if tc.lsp != nil || tc.lspGetErr != nil {
+				mockNbClient.On("LSPGet", gwSwitchPort).Return(tc.lsp, tc.lspGetErr)
+			}
+			if tc.lspGetErr == goovn.ErrorNotFound {
+				mockNbClient.On("LSPAddFull", types.OVNJoinSwitch, "", gwSwitchPort, lspSpec).Return(ovntest.MockCommand("LSPAddFull"), nil)
+			}
+			if len(tc.expCmds) > 0 && tc.expCmds[0] == "LSPSet" {
+				mockNbClient.On("LSPSet", gwSwitchPort, lspSpec).Return(ovntest.MockCommand("LSPSet"), nil)
+			}
+			if tc.lsp != nil || tc.lspGetErr == goovn.ErrorNotFound {
+				var lrpAddCmd, lrpDelCmd *goovn.OvnCommand
+				if tc.lrpAddErr == nil {
+					lrpAddCmd = ovntest.MockCommand("LRPAdd")
+				}
+				if tc.lrpDelErr == nil {
+					lrpDelCmd = ovntest.MockCommand("LRPDel")
+				}
+				mockNbClient.On("LRPAdd", types.GWRouterPrefix+"node1", gwRouterPort, tc.mac, tc.networks, "",
+					map[string]string(nil)).Return(lrpAddCmd, tc.lrpAddErr)
+				if tc.lrpAddErr == nil {
+					mockNbClient.On("LRPDel", types.GWRouterPrefix+"node1", gwRouterPort).Return(lrpDelCmd, tc.lrpDelErr)
+				}
+			}
+
+			cmds, err := AddNodeToJoinSwitch(mockNbClient, "node1", tc.mac, tc.networks)
+			if tc.errMatch != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMatch)
+				assert.Nil(t, cmds)
+			} else {
+				assert.Nil(t, err)
+				cmdNames := []string{}
+				for _, cmd := range cmds {
+					cmdNames = append(cmdNames, cmd.Operations[0].Table)
+				}
+				assert.Equal(t, tc.expCmds, cmdNames)
+			}
+			mockNbClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGRStateGet(t *testing.T) {
+	lb := &goovn.LoadBalancer{UUID: "lb1", Name: "Service_default/kubernetes_TCP_node_router_node1"}
+	nat := &goovn.NAT{UUID: "nat1", Type: "snat", ExternalIP: "172.18.0.2", LogicalIP: "10.128.0.0/14", Router: "GR_node1"}
+	route := &goovn.LogicalRouterStaticRoute{UUID: "route1", IPPrefix: "0.0.0.0/0", Nexthop: "172.18.0.1"}
+	policy := &goovn.LogicalRouterPolicy{UUID: "policy1", Priority: 1004, Action: "reroute"}
+
+	tests := []struct {
+		desc     string
+		lr       *goovn.LogicalRouterFull
+		getErr   error
+		expState *GatewayRouterState
+		errMatch string
+	}{
+		{
+			desc: "bundles the rows of the gateway router",
+			lr: &goovn.LogicalRouterFull{
+				LogicalRouter:  goovn.LogicalRouter{UUID: "lr1", Name: "GR_node1"},
+				LoadBalancers:  []*goovn.LoadBalancer{lb},
+				NATs:           []*goovn.NAT{nat},
+				Routes:         []*goovn.LogicalRouterStaticRoute{route},
+				RouterPolicies: []*goovn.LogicalRouterPolicy{policy},
+				Dangling:       []string{"gone"},
+			},
+			expState: &GatewayRouterState{
+				Router:        "GR_node1",
+				LoadBalancers: []*goovn.LoadBalancer{lb},
+				NATs:          []*goovn.NAT{nat},
+				Routes:        []*goovn.LogicalRouterStaticRoute{route},
+				Policies:      []*goovn.LogicalRouterPolicy{policy},
+				Dangling:      []string{"gone"},
+			},
+		},
+		{
+			desc:     "fails when the gateway router does not exist",
+			getErr:   goovn.ErrorNotFound,
+			errMatch: "failed to get gateway router GR_node1",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			mockNbClient := new(goovn_mock.Client)
+			mockNbClient.On("LRGetFull", types.GWRouterPrefix+"node1").Return(tc.lr, tc.getErr)
+
+			state, err := grStateGet(mockNbClient, "node1")
+			if tc.errMatch != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMatch)
+				assert.Nil(t, state)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expState, state)
+			}
+			mockNbClient.AssertExpectations(t)
+		})
+	}
+}
@@ -382,7 +382,7 @@ func (gp *gressPolicy) addOrModifyACLAllow(match, l4Match, portGroupUUID string,
 
 	if uuid != "" {
 		// We already have an ACL. We will update it.
-		_, stderr, err = util.RunOVNNbctl("set", "acl", uuid,
+		_, stderr, err = util.RunOVNNbctl("set", types.OVNNBTableACL, uuid,
 			match,
 			fmt.Sprintf("priority=%s", types.DefaultAllowPriority),
 			fmt.Sprintf("direction=%s", direction),
@@ -401,7 +401,7 @@ func (gp *gressPolicy) addOrModifyACLAllow(match, l4Match, portGroupUUID string,
 	}
 
 	_, stderr, err = util.RunOVNNbctl("--id=@acl", "create",
-		"acl", fmt.Sprintf("priority=%s", types.DefaultAllowPriority),
+		types.OVNNBTableACL, fmt.Sprintf("priority=%s", types.DefaultAllowPriority),
 		fmt.Sprintf("direction=%s", direction),
 		match,
 		fmt.Sprintf("action=%s", action),
@@ -415,7 +415,7 @@ func (gp *gressPolicy) addOrModifyACLAllow(match, l4Match, portGroupUUID string,
 		fmt.Sprintf("external-ids:policy=%s", gp.policyName),
 		fmt.Sprintf("external-ids:%s_num=%d", gp.policyType, gp.idx),
 		fmt.Sprintf("external-ids:policy_type=%s", gp.policyType),
-		"--", "add", "port_group", portGroupUUID, "acls", "@acl")
+		"--", "add", types.OVNNBTablePortGroup, portGroupUUID, util.OVNColumnACLs, "@acl")
 	if err != nil {
 		return fmt.Errorf("failed to create the acl allow rule for "+
 			"namespace=%s, policy=%s, stderr: %q (%v)", gp.policyNamespace,
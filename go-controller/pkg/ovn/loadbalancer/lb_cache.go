@@ -6,6 +6,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
@@ -185,7 +186,7 @@ func newCache() (*LBCache, error) {
 		c.existing[lbs[i].UUID] = &lbs[i]
 	}
 
-	switches, err := findTableLBs("logical_switch")
+	switches, err := findTableLBs(types.OVNNBTableLogicalSwitch)
 	if err != nil {
 		return nil, err
 	}
@@ -198,7 +199,7 @@ func newCache() (*LBCache, error) {
 		}
 	}
 
-	routers, err := findTableLBs("logical_router")
+	routers, err := findTableLBs(types.OVNNBTableLogicalRouter)
 	if err != nil {
 		return nil, err
 	}
@@ -217,7 +218,7 @@ func newCache() (*LBCache, error) {
 // listLBs lists all load balancers in nbdb
 func listLBs() ([]CachedLB, error) {
 	stdout, _, err := util.RunOVNNbctlRawOutput(15, "--format=json", "--data=json",
-		"--columns=name,_uuid,protocol,external_ids,vips", "find", "load_balancer")
+		"--columns=name,_uuid,protocol,external_ids,vips", "find", types.OVNNBTableLoadBalancer)
 
 	if err != nil {
 		return nil, fmt.Errorf("could not list load_balancer: %w", err)
@@ -5,8 +5,10 @@ import (
 	"sort"
 	"strings"
 
+	goovn "github.com/ebay/go-ovn"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	"k8s.io/klog/v2"
@@ -90,7 +92,7 @@ func ensureLB(txn *util.NBTxn, lbCache *LBCache, lb *LB, existing *CachedLB) (st
 	uuid := ""
 	if existing == nil {
 		cmds := []string{
-			"create", "load_balancer",
+			"create", types.OVNNBTableLoadBalancer,
 		}
 		cmds = append(cmds, lbToColumns(lb)...)
 		// note: load-balancer creation is not in the transaction
@@ -105,7 +107,7 @@ func ensureLB(txn *util.NBTxn, lbCache *LBCache, lb *LB, existing *CachedLB) (st
 		lbCache.addNewLB(lb)
 	} else {
 		cmds := []string{
-			"set", "load_balancer", existing.UUID,
+			"set", types.OVNNBTableLoadBalancer, existing.UUID,
 		}
 		cmds = append(cmds, lbToColumns(lb)...)
 		_, _, err := txn.AddOrCommit(cmds)
@@ -161,6 +163,38 @@ func ensureLB(txn *util.NBTxn, lbCache *LBCache, lb *LB, existing *CachedLB) (st
 	return uuid, nil
 }
 
+// ReconcileGRLoadBalancers returns a single command that makes the set of load
+// balancers attached to the node's gateway router equal to desired, a list of
+// load balancer UUIDs. The current set is read from the client cache and only
+// the load balancers to add or remove are part of the command.
+// goovn.ErrorNoChanges is returned if the router is already in sync.
+func ReconcileGRLoadBalancers(nbClient goovn.Client, node string, desired []string) (*goovn.OvnCommand, error) {
+	gatewayRouter := types.GWRouterPrefix + node
+	routers, err := nbClient.LRGet(gatewayRouter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gateway router %s: %w", gatewayRouter, err)
+	}
+	if len(routers) != 1 {
+		return nil, fmt.Errorf("expected one gateway router %s, found %d", gatewayRouter, len(routers))
+	}
+
+	existing := sets.NewString(routers[0].LoadBalancer...)
+	want := sets.NewString(desired...)
+	toAdd := want.Difference(existing).List()
+	toDel := existing.Difference(want).List()
+	if len(toAdd) == 0 && len(toDel) == 0 {
+		return nil, goovn.ErrorNoChanges
+	}
+
+	cmd, err := nbClient.LRLBUpdate(gatewayRouter, toAdd, toDel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create load balancer update command for gateway router %s: %w",
+			gatewayRouter, err)
+	}
+	klog.V(5).Infof("Reconciling load balancers on %s: adding %v, removing %v", gatewayRouter, toAdd, toDel)
+	return cmd, nil
+}
+
 // lbToColumns turns a load balancer in to a set of column arguments
 // that can be passed to nbctl create or set
 func lbToColumns(lb *LB) []string {
@@ -283,7 +317,7 @@ func DeleteLoadBalancerVIPs(toRemove []DeleteVIPEntry) error {
 		}
 
 		_, _, err := txn.AddOrCommit([]string{
-			"--if-exists", "remove", "load_balancer", entry.LBUUID, "vips", vipsStr.String(),
+			"--if-exists", "remove", types.OVNNBTableLoadBalancer, entry.LBUUID, util.OVNColumnVIPs, vipsStr.String(),
 		})
 		if err != nil {
 			return fmt.Errorf("failed to remove vips from load_balancer: %w", err)
@@ -0,0 +1,82 @@
+package loadbalancer
+
+import (
+	"fmt"
+	"testing"
+
+	goovn "github.com/ebay/go-ovn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	go_ovn_mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/ebay/go-ovn"
+)
+
+func TestReconcileGRLoadBalancers(t *testing.T) {
+	tests := []struct {
+		desc     string
+		current  []string
+		desired  []string
+		getErr   error
+		routers  int
+		expAdd   []string
+		expDel   []string
+		expErr   error
+		errMatch string
+	}{
+		{
+			desc:    "adds and removes only the differences",
+			current: []string{"lb-a", "lb-b"},
+			desired: []string{"lb-b", "lb-d", "lb-c"},
+			routers: 1,
+			expAdd:  []string{"lb-c", "lb-d"},
+			expDel:  []string{"lb-a"},
+		},
+		{
+			desc:    "returns ErrorNoChanges when already in sync",
+			current: []string{"lb-a", "lb-b"},
+			desired: []string{"lb-b", "lb-a"},
+			routers: 1,
+			expErr:  goovn.ErrorNoChanges,
+		},
+		{
+			desc:     "fails when the gateway router cannot be read",
+			getErr:   goovn.ErrorNotFound,
+			errMatch: "failed to get gateway router GR_node1",
+		},
+		{
+			desc:     "fails when the gateway router is not unique",
+			routers:  2,
+			errMatch: "expected one gateway router GR_node1, found 2",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			mockNbClient := new(go_ovn_mocks.Client)
+			routers := []*goovn.LogicalRouter{}
+			for j := 0; j < tc.routers; j++ {
+				routers = append(routers, &goovn.LogicalRouter{Name: "GR_node1", LoadBalancer: tc.current})
+			}
+			mockNbClient.On("LRGet", "GR_node1").Return(routers, tc.getErr)
+			expCmd := &goovn.OvnCommand{}
+			if tc.expAdd != nil || tc.expDel != nil {
+				mockNbClient.On("LRLBUpdate", "GR_node1", tc.expAdd, tc.expDel).Return(expCmd, nil)
+			}
+
+			cmd, err := ReconcileGRLoadBalancers(mockNbClient, "node1", tc.desired)
+			switch {
+			case tc.expErr != nil:
+				assert.Equal(t, tc.expErr, err)
+			case tc.errMatch != "":
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMatch)
+			default:
+				assert.Nil(t, err)
+				assert.Equal(t, expCmd, cmd)
+			}
+			if tc.expAdd == nil && tc.expDel == nil {
+				mockNbClient.AssertNotCalled(t, "LRLBUpdate", mock.Anything, mock.Anything, mock.Anything)
+			}
+			mockNbClient.AssertExpectations(t)
+		})
+	}
+}
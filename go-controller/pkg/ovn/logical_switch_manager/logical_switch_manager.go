@@ -1,17 +1,21 @@
 package logicalswitchmanager
 
 import (
+	"bytes"
 	"fmt"
 	"net"
 	"reflect"
+	"sort"
 	"sync"
 
+	goovn "github.com/ebay/go-ovn"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	ipam "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/ipallocator"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/ipallocator/allocator"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
 )
 
 // logicalSwitchInfo contains information corresponding to the node. It holds the
@@ -141,6 +145,31 @@ func (manager *LogicalSwitchManager) IsNonHostSubnetSwitch(nodeName string) bool
 	return ok && lsi.noHostSubnet
 }
 
+// ListNonHostSubnetSwitches returns the sorted names of the switches
+// that were not assigned a host subnet
+func (manager *LogicalSwitchManager) ListNonHostSubnetSwitches() []string {
+	return manager.listSwitches(true)
+}
+
+// ListHostSubnetSwitches returns the sorted names of the switches
+// that were assigned host subnets
+func (manager *LogicalSwitchManager) ListHostSubnetSwitches() []string {
+	return manager.listSwitches(false)
+}
+
+func (manager *LogicalSwitchManager) listSwitches(noHostSubnet bool) []string {
+	manager.RLock()
+	defer manager.RUnlock()
+	names := []string{}
+	for nodeName, lsi := range manager.cache {
+		if lsi.noHostSubnet == noHostSubnet {
+			names = append(names, nodeName)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Given a switch name and UUID, get all its host-subnets
 func (manager *LogicalSwitchManager) GetSwitchSubnetsAndUUID(nodeName string) ([]*net.IPNet, string) {
 	manager.RLock()
@@ -159,6 +188,100 @@ func (manager *LogicalSwitchManager) GetSwitchSubnetsAndUUID(nodeName string) ([
 	return nil, ""
 }
 
+// SwitchSubnetExpansion checks that newSubnet is an expansion of the host
+// subnets of a node, as ExpandSwitchSubnet requires, and returns the host
+// subnet of the node it replaces, nil when newSubnet is of a new IP family,
+// and whether newSubnet grows the node at all.
+func (manager *LogicalSwitchManager) SwitchSubnetExpansion(nodeName string, newSubnet *net.IPNet) (*net.IPNet, bool, error) {
+	manager.RLock()
+	defer manager.RUnlock()
+	idx, grow, err := manager.expansionIndex(nodeName, newSubnet)
+	if err != nil || !grow {
+		return nil, false, err
+	}
+	if hostSubnets := manager.cache[nodeName].hostSubnets; idx < len(hostSubnets) {
+		subnet := *hostSubnets[idx]
+		return &subnet, true, nil
+	}
+	return nil, true, nil
+}
+
+// ExpandSwitchSubnet grows the host subnet of a node of the IP family of
+// newSubnet to newSubnet, keeping the IPs allocated in it, or adds newSubnet
+// to the node when it has no subnet of that family, e.g. when the node becomes
+// dual-stack. A grown subnet must keep the network address of the current one,
+// which the gateway and management IPs of the node derive from, and an IPv6
+// subnet must be a /64 as OVN only knows the prefix of the switch. Only the
+// IPAM of the node is updated, the caller sets the subnet of the node switch.
+func (manager *LogicalSwitchManager) ExpandSwitchSubnet(nodeName string, newSubnet *net.IPNet) error {
+	manager.Lock()
+	defer manager.Unlock()
+	idx, grow, err := manager.expansionIndex(nodeName, newSubnet)
+	if err != nil || !grow {
+		return err
+	}
+	lsi := manager.cache[nodeName]
+	newIPAM, err := manager.ipamFunc(newSubnet)
+	if err != nil {
+		return fmt.Errorf("failed to create the IPAM of subnet %s for node %s: %v", newSubnet, nodeName, err)
+	}
+	if idx < len(lsi.ipams) {
+		// the reserved IPs of the subnet are allocated already
+		lsi.ipams[idx].ForEach(func(ip net.IP) {
+			if err == nil && !newIPAM.Has(ip) {
+				err = newIPAM.Allocate(ip)
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("failed to move the IPs allocated on node %s to subnet %s: %v", nodeName, newSubnet, err)
+		}
+	}
+
+	// the slices may be shared with the callers of AddNode
+	hostSubnets := append([]*net.IPNet{}, lsi.hostSubnets...)
+	ipams := append([]ipam.Interface{}, lsi.ipams...)
+	if idx < len(hostSubnets) {
+		hostSubnets[idx], ipams[idx] = newSubnet, newIPAM
+	} else {
+		hostSubnets, ipams = append(hostSubnets, newSubnet), append(ipams, newIPAM)
+	}
+	lsi.hostSubnets, lsi.ipams = hostSubnets, ipams
+	manager.cache[nodeName] = lsi
+	klog.Infof("Expanded node %s host subnets to %s", nodeName, util.JoinIPNets(hostSubnets, ","))
+	return nil
+}
+
+// expansionIndex returns the index of the host subnet of the node that
+// newSubnet replaces, or the number of host subnets when newSubnet is of a new
+// IP family, and whether newSubnet grows the node at all. The caller must hold
+// the manager lock.
+func (manager *LogicalSwitchManager) expansionIndex(nodeName string, newSubnet *net.IPNet) (int, bool, error) {
+	lsi, ok := manager.cache[nodeName]
+	if !ok {
+		return 0, false, fmt.Errorf("node %s not found in the logical switch manager cache", nodeName)
+	}
+	if lsi.noHostSubnet {
+		return 0, false, fmt.Errorf("node %s has no host subnet to expand", nodeName)
+	}
+	if ones, _ := newSubnet.Mask.Size(); utilnet.IsIPv6CIDR(newSubnet) && ones != 64 {
+		return 0, false, fmt.Errorf("IPv6 subnet %s of node %s is not a /64", newSubnet, nodeName)
+	}
+
+	for i, subnet := range lsi.hostSubnets {
+		if utilnet.IsIPv6CIDR(subnet) != utilnet.IsIPv6CIDR(newSubnet) {
+			continue
+		}
+		ones, _ := subnet.Mask.Size()
+		newOnes, _ := newSubnet.Mask.Size()
+		if !subnet.IP.Equal(newSubnet.IP) || newOnes > ones {
+			return 0, false, fmt.Errorf("subnet %s is not an expansion of node %s subnet %s with the same network address",
+				newSubnet, nodeName, subnet)
+		}
+		return i, newOnes != ones, nil
+	}
+	return len(lsi.hostSubnets), true, nil
+}
+
 // AllocateIPs will block off IPs in the ipnets slice as already allocated
 // for a given switch
 func (manager *LogicalSwitchManager) AllocateIPs(nodeName string, ipnets []*net.IPNet) error {
@@ -287,6 +410,131 @@ func (manager *LogicalSwitchManager) ReleaseIPs(nodeName string, ipnets []*net.I
 	return nil
 }
 
+// ReleaseUnexpectedIPs releases the IPs allocated on the node that are neither
+// the subnet IPs reserved by the manager, nor in podIPs, nor in reservedIPs,
+// e.g. the IPs of pods deleted while the master was down, and returns them
+// sorted. reservedIPs are the IPs held by the other users of the node switch,
+// such as the ports that don't belong to pods. The manager lock is held for
+// writing so that no IP is allocated between listing the stale IPs and
+// releasing them.
+func (manager *LogicalSwitchManager) ReleaseUnexpectedIPs(nodeName string, podIPs, reservedIPs []net.IP) ([]net.IP, error) {
+	manager.Lock()
+	defer manager.Unlock()
+	lsi, ok := manager.cache[nodeName]
+	if !ok {
+		return nil, fmt.Errorf("node %s not found in the logical switch manager cache", nodeName)
+	}
+	keep := make(map[string]bool, len(podIPs)+len(reservedIPs))
+	for _, ip := range podIPs {
+		keep[ip.String()] = true
+	}
+	for _, ip := range reservedIPs {
+		keep[ip.String()] = true
+	}
+
+	var released []net.IP
+	for _, ipam := range lsi.ipams {
+		cidr := ipam.CIDR()
+		var stale []net.IP
+		ipam.ForEach(func(ip net.IP) {
+			if !keep[ip.String()] && !isReservedIP(&cidr, ip) {
+				stale = append(stale, ip)
+			}
+		})
+		// released outside of ForEach, which holds the allocator lock
+		for _, ip := range stale {
+			if err := ipam.Release(ip); err != nil {
+				return released, fmt.Errorf("failed to release IP %s on node %s: %v", ip, nodeName, err)
+			}
+			released = append(released, ip)
+		}
+	}
+	sort.Slice(released, func(i, j int) bool {
+		return bytes.Compare(released[i].To16(), released[j].To16()) < 0
+	})
+	return released, nil
+}
+
+// DuplicateAlloc describes a pod IP on a node's switch whose allocation is
+// inconsistent: it is claimed by more than one logical switch port, it is
+// one of the subnet's reserved addresses, or the node's IPAM doesn't have it
+// marked as allocated and may hand it out again.
+type DuplicateAlloc struct {
+	IP net.IP
+	// Ports claiming the IP, sorted by name
+	Ports []string
+	// Reserved is set when the IP is the subnet's gateway, management or
+	// hybrid overlay address
+	Reserved bool
+	// Unallocated is set when the IP is not allocated in the node's IPAM
+	Unallocated bool
+}
+
+// FindDuplicateAllocations checks the addresses of the given logical switch
+// ports of a node against each other and against the node's IPAM, and returns
+// every IP whose allocation is inconsistent sorted by IP. Only addresses in the
+// node's host subnets are considered.
+func (manager *LogicalSwitchManager) FindDuplicateAllocations(nodeName string, ports []*goovn.LogicalSwitchPort) ([]DuplicateAlloc, error) {
+	manager.RLock()
+	defer manager.RUnlock()
+	lsi, ok := manager.cache[nodeName]
+	if !ok {
+		return nil, fmt.Errorf("node %s not found in the logical switch manager cache", nodeName)
+	}
+	if len(lsi.ipams) == 0 {
+		return nil, fmt.Errorf("failed to check IPs for node %s because there is no IPAM instance", nodeName)
+	}
+
+	owners := make(map[string][]string)
+	ips := make(map[string]net.IP)
+	for _, port := range ports {
+		_, portIPs, err := util.ParsePortAddresses(port)
+		if err != nil {
+			klog.Warningf("Skipping logical switch port %s while checking for duplicate IPs on node %s: %v",
+				port.Name, nodeName, err)
+			continue
+		}
+		for _, ip := range portIPs {
+			key := ip.String()
+			owners[key] = append(owners[key], port.Name)
+			ips[key] = ip
+		}
+	}
+
+	var dups []DuplicateAlloc
+	for key, ip := range ips {
+		for _, ipam := range lsi.ipams {
+			cidr := ipam.CIDR()
+			if !cidr.Contains(ip) {
+				continue
+			}
+			dup := DuplicateAlloc{
+				IP:          ip,
+				Ports:       owners[key],
+				Reserved:    isReservedIP(&cidr, ip),
+				Unallocated: !ipam.Has(ip),
+			}
+			if len(dup.Ports) > 1 || dup.Reserved || dup.Unallocated {
+				sort.Strings(dup.Ports)
+				dups = append(dups, dup)
+			}
+			break
+		}
+	}
+	sort.Slice(dups, func(i, j int) bool {
+		return bytes.Compare(dups[i].IP.To16(), dups[j].IP.To16()) < 0
+	})
+	return dups, nil
+}
+
+// isReservedIP tells whether ip is one of the subnet IPs reserved by reserveIPs
+func isReservedIP(subnet *net.IPNet, ip net.IP) bool {
+	if ip.Equal(util.GetNodeGatewayIfAddr(subnet).IP) || ip.Equal(util.GetNodeManagementIfAddr(subnet).IP) {
+		return true
+	}
+	return config.HybridOverlay.Enabled && ip.Equal(util.GetNodeHybridOverlayIfAddr(subnet).IP)
+}
+
 // IP allocator manager for join switch's IPv4 and IPv6 subnets.
 type JoinSwitchIPManager struct {
 	lsm            *LogicalSwitchManager
@@ -0,0 +1,13 @@
+package logicalswitchmanager
+
+import (
+	"testing"
+
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+)
+
+func TestLogicalSwitchManager(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "Logical Switch Manager Operations Suite")
+}
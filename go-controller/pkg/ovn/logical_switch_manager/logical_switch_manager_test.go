@@ -1,11 +1,15 @@
 package logicalswitchmanager
 
 import (
+	"net"
+
+	goovn "github.com/ebay/go-ovn"
 	"github.com/urfave/cli/v2"
 	"k8s.io/klog/v2"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	"github.com/onsi/ginkgo"
 	"github.com/onsi/gomega"
@@ -49,7 +53,7 @@ var _ = ginkgo.Describe("OVN Logical Switch Manager operations", func() {
 
 				expectedIPs := []string{"10.1.1.3", "2000::3"}
 
-				err = lsManager.AddNode(testNode.nodeName, ovntest.MustParseIPNets(testNode.subnets...))
+				err = lsManager.AddNode(testNode.nodeName, "", ovntest.MustParseIPNets(testNode.subnets...))
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 
 				ips, err := lsManager.AllocateNextIPs(testNode.nodeName)
@@ -68,7 +72,7 @@ var _ = ginkgo.Describe("OVN Logical Switch Manager operations", func() {
 				}
 				config.HybridOverlay.Enabled = true
 				expectedIPs = []string{"10.1.1.4", "2000::4"}
-				err = lsManager.AddNode(testHONode.nodeName, ovntest.MustParseIPNets(testNode.subnets...))
+				err = lsManager.AddNode(testHONode.nodeName, "", ovntest.MustParseIPNets(testNode.subnets...))
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 
 				ips, err = lsManager.AllocateNextIPs(testHONode.nodeName)
@@ -92,7 +96,7 @@ var _ = ginkgo.Describe("OVN Logical Switch Manager operations", func() {
 					subnets:  []string{},
 				}
 
-				err = lsManager.AddNode(testNode.nodeName, ovntest.MustParseIPNets(testNode.subnets...))
+				err = lsManager.AddNode(testNode.nodeName, "", ovntest.MustParseIPNets(testNode.subnets...))
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 				noHostSubnet := lsManager.IsNonHostSubnetSwitch(testNode.nodeName)
 				gomega.Expect(noHostSubnet).To(gomega.BeTrue())
@@ -102,6 +106,46 @@ var _ = ginkgo.Describe("OVN Logical Switch Manager operations", func() {
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
 
+		ginkgo.It("lists host subnet and no host subnet switches separately", func() {
+			app.Action = func(ctx *cli.Context) error {
+				_, err := config.InitConfig(ctx, fexec, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(lsManager.ListHostSubnetSwitches()).To(gomega.BeEmpty())
+				gomega.Expect(lsManager.ListNonHostSubnetSwitches()).To(gomega.BeEmpty())
+
+				tests := []testNodeSubnetData{
+					{
+						nodeName: "testNode3",
+						subnets:  []string{"10.1.3.0/24"},
+					},
+					{
+						nodeName: "testNode2",
+						subnets:  []string{},
+					},
+					{
+						nodeName: "testNode1",
+						subnets:  []string{"10.1.1.0/24", "2000::/64"},
+					},
+					{
+						nodeName: "testNode4",
+						subnets:  []string{},
+					},
+				}
+				for _, node := range tests {
+					err := lsManager.AddNode(node.nodeName, "", ovntest.MustParseIPNets(node.subnets...))
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				}
+				gomega.Expect(lsManager.ListHostSubnetSwitches()).To(gomega.Equal([]string{"testNode1", "testNode3"}))
+				gomega.Expect(lsManager.ListNonHostSubnetSwitches()).To(gomega.Equal([]string{"testNode2", "testNode4"}))
+
+				lsManager.DeleteNode("testNode2")
+				gomega.Expect(lsManager.ListNonHostSubnetSwitches()).To(gomega.Equal([]string{"testNode4"}))
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
 		ginkgo.It("handles updates to the host subnets correctly", func() {
 			app.Action = func(ctx *cli.Context) error {
 				_, err := config.InitConfig(ctx, fexec, nil)
@@ -116,7 +160,7 @@ var _ = ginkgo.Describe("OVN Logical Switch Manager operations", func() {
 
 				expectedIPs := []string{"10.1.1.3", "2000::3"}
 
-				err = lsManager.AddNode(testNode.nodeName, ovntest.MustParseIPNets(testNode.subnets...))
+				err = lsManager.AddNode(testNode.nodeName, "", ovntest.MustParseIPNets(testNode.subnets...))
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 
 				ips, err := lsManager.AllocateNextIPs(testNode.nodeName)
@@ -125,7 +169,7 @@ var _ = ginkgo.Describe("OVN Logical Switch Manager operations", func() {
 				}
 				testNode.subnets = []string{"10.1.2.0/24"}
 				expectedIPs = []string{"10.1.2.3"}
-				err = lsManager.AddNode(testNode.nodeName, ovntest.MustParseIPNets(testNode.subnets...))
+				err = lsManager.AddNode(testNode.nodeName, "", ovntest.MustParseIPNets(testNode.subnets...))
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 
 				ips, err = lsManager.AllocateNextIPs(testNode.nodeName)
@@ -159,7 +203,7 @@ var _ = ginkgo.Describe("OVN Logical Switch Manager operations", func() {
 					{"10.1.1.4", "2000::4"},
 				}
 
-				err = lsManager.AddNode(testNode.nodeName, ovntest.MustParseIPNets(testNode.subnets...))
+				err = lsManager.AddNode(testNode.nodeName, "", ovntest.MustParseIPNets(testNode.subnets...))
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 				for _, expectedIPs := range expectedIPAllocations {
 					ips, err := lsManager.AllocateNextIPs(testNode.nodeName)
@@ -190,7 +234,7 @@ var _ = ginkgo.Describe("OVN Logical Switch Manager operations", func() {
 					{"10.1.1.4"},
 				}
 
-				err = lsManager.AddNode(testNode.nodeName, ovntest.MustParseIPNets(testNode.subnets...))
+				err = lsManager.AddNode(testNode.nodeName, "", ovntest.MustParseIPNets(testNode.subnets...))
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 				for _, expectedIPs := range expectedIPAllocations {
 					ips, err := lsManager.AllocateNextIPs(testNode.nodeName)
@@ -209,6 +253,94 @@ var _ = ginkgo.Describe("OVN Logical Switch Manager operations", func() {
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
 
+		ginkgo.It("finds duplicate and inconsistent IP allocations", func() {
+			app.Action = func(ctx *cli.Context) error {
+				_, err := config.InitConfig(ctx, fexec, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				testNode := testNodeSubnetData{
+					nodeName: "testNode1",
+					subnets: []string{
+						"10.1.1.0/24",
+					},
+				}
+
+				err = lsManager.AddNode(testNode.nodeName, "", ovntest.MustParseIPNets(testNode.subnets...))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				for _, ip := range []string{"10.1.1.3/24", "10.1.1.4/24"} {
+					err = lsManager.AllocateIPs(testNode.nodeName, ovntest.MustParseIPNets(ip))
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				}
+
+				ports := []*goovn.LogicalSwitchPort{
+					{Name: "ns_pod1", Addresses: []string{"0a:58:0a:01:01:03 10.1.1.3"}},
+					{Name: "ns_pod2", Addresses: []string{"0a:58:0a:01:01:04 10.1.1.4"}},
+					{Name: "ns_pod0", Addresses: []string{"0a:58:0a:01:01:05 10.1.1.3"}},
+					{Name: "ns_pod3", Addresses: []string{"0a:58:0a:01:01:02 10.1.1.2"}},
+					{Name: "ns_pod4", Addresses: []string{"0a:58:0a:01:01:06 10.1.1.6"}},
+					{Name: "ns_pod5", Addresses: []string{"0a:58:0a:01:02:03 10.1.2.3"}},
+				}
+				dups, err := lsManager.FindDuplicateAllocations(testNode.nodeName, ports)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(dups).To(gomega.HaveLen(3))
+				gomega.Expect(dups[0].IP.String()).To(gomega.Equal("10.1.1.2"))
+				gomega.Expect(dups[0].Ports).To(gomega.Equal([]string{"ns_pod3"}))
+				gomega.Expect(dups[0].Reserved).To(gomega.BeTrue())
+				gomega.Expect(dups[1].IP.String()).To(gomega.Equal("10.1.1.3"))
+				gomega.Expect(dups[1].Ports).To(gomega.Equal([]string{"ns_pod0", "ns_pod1"}))
+				gomega.Expect(dups[1].Unallocated).To(gomega.BeFalse())
+				gomega.Expect(dups[2].IP.String()).To(gomega.Equal("10.1.1.6"))
+				gomega.Expect(dups[2].Ports).To(gomega.Equal([]string{"ns_pod4"}))
+				gomega.Expect(dups[2].Unallocated).To(gomega.BeTrue())
+
+				_, err = lsManager.FindDuplicateAllocations("unknownNode", ports)
+				gomega.Expect(err).To(gomega.HaveOccurred())
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("releases the IP allocations not backed by an expected port", func() {
+			app.Action = func(ctx *cli.Context) error {
+				_, err := config.InitConfig(ctx, fexec, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				testNode := testNodeSubnetData{
+					nodeName: "testNode1",
+					subnets: []string{
+						"10.1.1.0/24",
+						"2000::/64",
+					},
+				}
+
+				err = lsManager.AddNode(testNode.nodeName, "", ovntest.MustParseIPNets(testNode.subnets...))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				for _, ip := range []string{"10.1.1.3/24", "10.1.1.4/24", "10.1.1.5/24", "2000::3/64", "2000::4/64"} {
+					err = lsManager.AllocateIPs(testNode.nodeName, ovntest.MustParseIPNets(ip))
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				}
+
+				released, err := lsManager.ReleaseUnexpectedIPs(testNode.nodeName,
+					[]net.IP{ovntest.MustParseIP("10.1.1.3"), ovntest.MustParseIP("2000::3")},
+					[]net.IP{ovntest.MustParseIP("10.1.1.5")})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(released).To(gomega.Equal([]net.IP{ovntest.MustParseIP("10.1.1.4"), ovntest.MustParseIP("2000::4")}))
+				// the orphaned IPs are free again, the pod, reserved and
+				// subnet IPs are not
+				err = lsManager.AllocateIPs(testNode.nodeName, ovntest.MustParseIPNets("10.1.1.4/24", "2000::4/64"))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				for _, ip := range []string{"10.1.1.1/24", "10.1.1.2/24", "10.1.1.3/24", "10.1.1.5/24", "2000::3/64"} {
+					err = lsManager.AllocateIPs(testNode.nodeName, ovntest.MustParseIPNets(ip))
+					gomega.Expect(err).To(gomega.HaveOccurred())
+				}
+
+				_, err = lsManager.ReleaseUnexpectedIPs("unknownNode", nil, nil)
+				gomega.Expect(err).To(gomega.HaveOccurred())
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
 		ginkgo.It("releases IPs for other host subnet nodes when any host subnets allocation fails", func() {
 			app.Action = func(ctx *cli.Context) error {
 				_, err := config.InitConfig(ctx, fexec, nil)
@@ -227,7 +359,7 @@ var _ = ginkgo.Describe("OVN Logical Switch Manager operations", func() {
 					{"10.1.1.6", "10.1.2.6"},
 				}
 
-				err = lsManager.AddNode(testNode.nodeName, ovntest.MustParseIPNets(testNode.subnets...))
+				err = lsManager.AddNode(testNode.nodeName, "", ovntest.MustParseIPNets(testNode.subnets...))
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 				// exhaust valid ips in second subnet
 				for _, expectedIPs := range expectedIPAllocations {
@@ -264,7 +396,7 @@ var _ = ginkgo.Describe("OVN Logical Switch Manager operations", func() {
 					"2000::2/64",
 				}
 				allocatedIPNets := ovntest.MustParseIPNets(allocatedIPs...)
-				err = lsManager.AddNode(testNode.nodeName, ovntest.MustParseIPNets(testNode.subnets...))
+				err = lsManager.AddNode(testNode.nodeName, "", ovntest.MustParseIPNets(testNode.subnets...))
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 				err = lsManager.AllocateIPs(testNode.nodeName, allocatedIPNets)
 				klog.Errorf("error: %v", err)
@@ -277,4 +409,77 @@ var _ = ginkgo.Describe("OVN Logical Switch Manager operations", func() {
 
 	})
 
+	ginkgo.Context("when expanding a node subnet", func() {
+		ginkgo.It("grows an IPv4 subnet keeping its allocations", func() {
+			app.Action = func(ctx *cli.Context) error {
+				_, err := config.InitConfig(ctx, fexec, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = lsManager.AddNode("testNode1", "", ovntest.MustParseIPNets("10.1.0.0/24"))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				ips, err := lsManager.AllocateNextIPs("testNode1")
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(ips[0].String()).To(gomega.Equal("10.1.0.3/24"))
+
+				// neither a subnet with another network address nor a smaller one is an expansion
+				err = lsManager.ExpandSwitchSubnet("testNode1", ovntest.MustParseIPNet("10.0.0.0/15"))
+				gomega.Expect(err).To(gomega.HaveOccurred())
+				err = lsManager.ExpandSwitchSubnet("testNode1", ovntest.MustParseIPNet("10.1.0.0/25"))
+				gomega.Expect(err).To(gomega.HaveOccurred())
+
+				oldSubnet, grow, err := lsManager.SwitchSubnetExpansion("testNode1", ovntest.MustParseIPNet("10.1.0.0/23"))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(grow).To(gomega.BeTrue())
+				gomega.Expect(oldSubnet).To(gomega.Equal(ovntest.MustParseIPNet("10.1.0.0/24")))
+				err = lsManager.ExpandSwitchSubnet("testNode1", ovntest.MustParseIPNet("10.1.0.0/23"))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				subnets, _ := lsManager.GetSwitchSubnetsAndUUID("testNode1")
+				gomega.Expect(subnets).To(gomega.Equal(ovntest.MustParseIPNets("10.1.0.0/23")))
+
+				// the IPs allocated before are still allocated, the new ones can be allocated
+				err = lsManager.AllocateIPs("testNode1", ovntest.MustParseIPNets("10.1.0.3/23"))
+				gomega.Expect(err).To(gomega.HaveOccurred())
+				err = lsManager.AllocateIPs("testNode1", ovntest.MustParseIPNets("10.1.1.200/23"))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				ips, err = lsManager.AllocateNextIPs("testNode1")
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(ips[0].String()).To(gomega.Equal("10.1.0.4/23"))
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("adds an IPv6 subnet to an IPv4 node", func() {
+			app.Action = func(ctx *cli.Context) error {
+				_, err := config.InitConfig(ctx, fexec, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = lsManager.AddNode("testNode1", "", ovntest.MustParseIPNets("10.1.1.0/24"))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				ips, err := lsManager.AllocateNextIPs("testNode1")
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(ips).To(gomega.HaveLen(1))
+
+				// OVN only knows the /64 prefix of an IPv6 switch
+				err = lsManager.ExpandSwitchSubnet("testNode1", ovntest.MustParseIPNet("2000::/63"))
+				gomega.Expect(err).To(gomega.HaveOccurred())
+
+				oldSubnet, grow, err := lsManager.SwitchSubnetExpansion("testNode1", ovntest.MustParseIPNet("2000::/64"))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(grow).To(gomega.BeTrue())
+				gomega.Expect(oldSubnet).To(gomega.BeNil())
+				err = lsManager.ExpandSwitchSubnet("testNode1", ovntest.MustParseIPNet("2000::/64"))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				subnets, _ := lsManager.GetSwitchSubnetsAndUUID("testNode1")
+				gomega.Expect(subnets).To(gomega.Equal(ovntest.MustParseIPNets("10.1.1.0/24", "2000::/64")))
+
+				ips, err = lsManager.AllocateNextIPs("testNode1")
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(util.JoinIPNets(ips, " ")).To(gomega.Equal("10.1.1.4/24 2000::3/64"))
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+	})
+
 })
@@ -40,6 +40,9 @@ const (
 	IdledServiceAnnotationSuffix   = "idled-at"
 	OvnNodeAnnotationRetryInterval = 100 * time.Millisecond
 	OvnNodeAnnotationRetryTimeout  = 1 * time.Second
+	// sbSyncPollInterval is the interval at which SyncToSB polls the
+	// southbound cache
+	sbSyncPollInterval = 50 * time.Millisecond
 )
 
 type ovnkubeMasterLeaderMetrics struct{}
@@ -159,7 +162,7 @@ func (oc *Controller) upgradeToSingleSwitchOVNTopology(existingNodeList *kapi.No
 	}
 
 	nodeSwitches, stderr, err := util.RunOVNNbctl("--data=bare", "--no-heading", "--format=csv",
-		"--columns=name", "find", "logical_switch")
+		"--columns=name", "find", types.OVNNBTableLogicalSwitch)
 	if err != nil {
 		return fmt.Errorf("failed to get all logical switches for upgrade: stderr: %q, error: %v",
 			stderr, err)
@@ -226,7 +229,7 @@ func (oc *Controller) enableOVNLogicalDatapathGroups() error {
 		klog.Errorf("Failed to get NB global options: %v", err)
 		return err
 	}
-	options["use_logical_dp_groups"] = "true"
+	options[goovn.NBGlobalOptionUseLogicalDPGroups] = "true"
 	cmd, err := oc.ovnNBClient.NBGlobalSetOptions(options)
 	if err != nil {
 		klog.Errorf("Failed to set NB global option to enable logical datapath groups: %v", err)
@@ -331,7 +334,7 @@ func (oc *Controller) StartClusterMaster(masterNodeName string) error {
 	metrics.RecordSubnetCount(v4HostSubnetCount, v6HostSubnetCount)
 	metrics.RecordSubnetUsage(oc.v4HostSubnetsUsed, oc.v6HostSubnetsUsed)
 
-	if _, _, err := util.RunOVNNbctl("--columns=_uuid", "list", "port_group"); err != nil {
+	if _, _, err := util.RunOVNNbctl("--columns=_uuid", "list", types.OVNNBTablePortGroup); err != nil {
 		klog.Fatal("OVN version too old; does not support port groups")
 	}
 
@@ -343,7 +346,7 @@ func (oc *Controller) StartClusterMaster(masterNodeName string) error {
 		}
 	}
 
-	if uuid, _, err := util.RunOVNNbctl("--data=bare", "--columns=_uuid", "find", "meter", "name="+types.OvnACLLoggingMeter); err == nil && uuid == "" {
+	if uuid, _, err := util.RunOVNNbctl("--data=bare", "--columns=_uuid", "find", types.OVNNBTableMeter, "name="+types.OvnACLLoggingMeter); err == nil && uuid == "" {
 		dropRate := strconv.Itoa(config.Logging.ACLLoggingRateLimit)
 		if _, _, err := util.RunOVNNbctl("meter-add", types.OvnACLLoggingMeter, "drop", dropRate, "pktps"); err != nil {
 			klog.Warningf("ACL logging support enabled, however acl-logging meter could not be created. Disabling ACL logging support")
@@ -378,7 +381,7 @@ func (oc *Controller) StartClusterMaster(masterNodeName string) error {
 func (oc *Controller) SetupMaster(masterNodeName string, existingNodeNames []string) error {
 	// Create a single common distributed router for the cluster.
 	stdout, stderr, err := util.RunOVNNbctl("--", "--may-exist", "lr-add", types.OVNClusterRouter,
-		"--", "set", "logical_router", types.OVNClusterRouter, "external_ids:k8s-cluster-router=yes")
+		"--", "set", types.OVNNBTableLogicalRouter, types.OVNClusterRouter, "external_ids:k8s-cluster-router=yes")
 	if err != nil {
 		klog.Errorf("Failed to create a single common distributed router for the cluster, "+
 			"stdout: %q, stderr: %q, error: %v", stdout, stderr, err)
@@ -421,8 +424,8 @@ func (oc *Controller) SetupMaster(masterNodeName string, existingNodeNames []str
 	// If supported, enable IGMP relay on the router to forward multicast
 	// traffic between nodes.
 	if oc.multicastSupport {
-		stdout, stderr, err = util.RunOVNNbctl("--", "set", "logical_router",
-			types.OVNClusterRouter, "options:mcast_relay=\"true\"")
+		stdout, stderr, err = util.RunOVNNbctl("--", "set", types.OVNNBTableLogicalRouter,
+			types.OVNClusterRouter, util.OVNColumnOptions+":"+util.OVNOptionMcastRelay+"=\"true\"")
 		if err != nil {
 			klog.Errorf("Failed to enable IGMP relay on the cluster router, "+
 				"stdout: %q, stderr: %q, error: %v", stdout, stderr, err)
@@ -484,8 +487,8 @@ func (oc *Controller) SetupMaster(masterNodeName string, existingNodeNames []str
 
 	// Connect the switch OVNJoinSwitch to the router.
 	_, stderr, err = util.RunOVNNbctl("--may-exist", "lsp-add", types.OVNJoinSwitch,
-		drSwitchPort, "--", "set", "logical_switch_port", drSwitchPort, "type=router",
-		"options:router-port="+drRouterPort, "addresses=router")
+		drSwitchPort, "--", "set", types.OVNNBTableLogicalSwitchPort, drSwitchPort, "type=router",
+		util.OVNColumnOptions+":"+util.OVNOptionRouterPort+"="+drRouterPort, "addresses=router")
 	if err != nil {
 		klog.Errorf("Failed to add router-type logical switch port %s to %s, stderr: %q, error: %v",
 			drSwitchPort, types.OVNJoinSwitch, stderr, err)
@@ -508,7 +511,7 @@ func addNodeLogicalSwitchPort(logicalSwitch, portName, portType, addresses, opti
 	// UUID must be retrieved separately from the lsp-add transaction since
 	// (as of OVN 2.12) a bogus UUID is returned if they are part of the same
 	// transaction.
-	uuid, stderr, err := util.RunOVNNbctl("get", "logical_switch_port", portName, "_uuid")
+	uuid, stderr, err := util.RunOVNNbctl("get", types.OVNNBTableLogicalSwitchPort, portName, util.OVNColumnUUID)
 	if err != nil {
 		klog.Errorf("Error getting UUID for logical port %s "+
 			"stdout: %q, stderr: %q (%v)", portName, uuid, stderr, err)
@@ -520,12 +523,63 @@ func addNodeLogicalSwitchPort(logicalSwitch, portName, portType, addresses, opti
 	return uuid, nil
 }
 
-func (oc *Controller) syncNodeManagementPort(node *kapi.Node, hostSubnets []*net.IPNet) error {
-	macAddress, err := util.ParseNodeManagementPortMACAddress(node)
+// expandNodeSubnets grows the node switch to the host subnets of the node
+// annotation, e.g. after an admin grew the pod CIDR of the node. A grown subnet
+// is reserved in the cluster subnet allocator first, so that it can neither
+// overlap the subnet of another node nor be handed out to a new node. The
+// subnet and the exclude_ips of the node switch are then updated before its
+// IPAM, so that the node doesn't allocate IPs from a subnet that OVN doesn't
+// know about.
+func (oc *Controller) expandNodeSubnets(node *kapi.Node) error {
+	hostSubnets, err := util.ParseNodeHostSubnetAnnotation(node)
 	if err != nil {
 		return err
 	}
+	for _, hostSubnet := range hostSubnets {
+		oldSubnet, grow, err := oc.lsManager.SwitchSubnetExpansion(node.Name, hostSubnet)
+		if err != nil {
+			return fmt.Errorf("failed to expand the subnet of node %s to %s: %v", node.Name, hostSubnet, err)
+		}
+		if !grow {
+			continue
+		}
+		if err := oc.masterSubnetAllocator.ExpandNetwork(oldSubnet, hostSubnet); err != nil {
+			return fmt.Errorf("failed to expand the subnet of node %s to %s: %v", node.Name, hostSubnet, err)
+		}
+		if err := updateNodeSwitchSubnet(node.Name, hostSubnet); err != nil {
+			// give back the part of the subnet that the node didn't have
+			if errR := oc.masterSubnetAllocator.ReleaseNetwork(hostSubnet); errR != nil {
+				klog.Warningf("Failed to release subnet %s of node %s: %v", hostSubnet, node.Name, errR)
+			} else if oldSubnet != nil {
+				if errR := oc.masterSubnetAllocator.MarkAllocatedNetwork(oldSubnet); errR != nil {
+					klog.Warningf("Failed to reserve subnet %s of node %s: %v", oldSubnet, node.Name, errR)
+				}
+			}
+			return fmt.Errorf("failed to expand the subnet of node %s to %s: %v", node.Name, hostSubnet, err)
+		}
+		if err := oc.lsManager.ExpandSwitchSubnet(node.Name, hostSubnet); err != nil {
+			return fmt.Errorf("failed to expand the subnet of node %s to %s: %v", node.Name, hostSubnet, err)
+		}
+	}
+	return nil
+}
+
+// updateNodeSwitchSubnet sets the subnet of the node switch of the IP family
+// of subnet, and its exclude_ips for an IPv4 subnet
+func updateNodeSwitchSubnet(nodeName string, subnet *net.IPNet) error {
+	otherConfig := util.OVNNbctlOtherConfig + ":" + util.OVNOtherConfigSubnet + "=" + subnet.String()
+	if utilnet.IsIPv6CIDR(subnet) {
+		otherConfig = util.OVNNbctlOtherConfig + ":" + util.OVNOtherConfigIPv6Prefix + "=" + subnet.IP.String()
+	}
+	_, stderr, err := util.RunOVNNbctl("set", types.OVNNBTableLogicalSwitch, nodeName, otherConfig)
+	if err != nil {
+		return fmt.Errorf("failed to set the subnet, stderr: %q, error: %v", stderr, err)
+	}
+	return util.UpdateNodeSwitchExcludeIPs(nodeName, subnet)
+}
 
+func (oc *Controller) syncNodeManagementPort(node *kapi.Node, hostSubnets []*net.IPNet) error {
+	var err error
 	if hostSubnets == nil {
 		hostSubnets, err = util.ParseNodeHostSubnetAnnotation(node)
 		if err != nil {
@@ -533,20 +587,12 @@ func (oc *Controller) syncNodeManagementPort(node *kapi.Node, hostSubnets []*net
 		}
 	}
 
-	var v4Subnet *net.IPNet
-	addresses := macAddress.String()
 	for _, hostSubnet := range hostSubnets {
 		mgmtIfAddr := util.GetNodeManagementIfAddr(hostSubnet)
-		addresses += " " + mgmtIfAddr.IP.String()
-
 		if err := addAllowACLFromNode(node.Name, mgmtIfAddr.IP, oc.ovnNBClient); err != nil {
 			return err
 		}
 
-		if !utilnet.IsIPv6CIDR(hostSubnet) {
-			v4Subnet = hostSubnet
-		}
-
 		if config.Gateway.Mode == config.GatewayModeLocal {
 			stdout, stderr, err := util.RunOVNNbctl("--may-exist",
 				"--policy=src-ip", "lr-route-add", types.OVNClusterRouter,
@@ -559,27 +605,179 @@ func (oc *Controller) syncNodeManagementPort(node *kapi.Node, hostSubnets []*net
 		}
 	}
 
-	// Create this node's management logical port on the node switch
-	portName := types.K8sPrefix + node.Name
-	uuid, err := addNodeLogicalSwitchPort(node.Name, portName, "", addresses, "")
+	// Create this node's management logical port on the node switch. The
+	// switch is created with ovn-nbctl, so the client may not have received
+	// it yet; the error then makes the node update retry the port.
+	mac, err := util.ParseNodeManagementPortMACAddress(node)
 	if err != nil {
 		return err
 	}
+	for _, hostSubnet := range hostSubnets {
+		if err := reconcileManagementPort(oc.ovnNBClient, node.Name, mac, hostSubnet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	if err := addToPortGroup(oc.ovnNBClient, clusterPortGroupName, &lpInfo{
-		uuid: uuid,
-		name: portName,
-	}); err != nil {
-		klog.Errorf(err.Error())
+// ReconcileManagementPort ensures the management port of the node is on the
+// node switch with the management address of subnet, in the cluster port
+// group, and that the exclude_ips of the switch no longer leave out the
+// management address. The address of the other IP family, if the port has
+// one, is kept, so that a dual-stack node reconciles each of its subnets in
+// turn. Only the addresses of the port are managed: its type, options and
+// external IDs are deliberately left alone, as the port is created without
+// any and other components may set them.
+func (oc *Controller) ReconcileManagementPort(node string, subnet *net.IPNet) error {
+	kNode, err := oc.watchFactory.GetNode(node)
+	if err != nil {
+		return fmt.Errorf("failed to get node %s: %v", node, err)
+	}
+	mac, err := util.ParseNodeManagementPortMACAddress(kNode)
+	if err != nil {
 		return err
 	}
+	return reconcileManagementPort(oc.ovnNBClient, node, mac, subnet)
+}
 
-	if v4Subnet != nil {
-		if err := util.UpdateNodeSwitchExcludeIPs(node.Name, v4Subnet); err != nil {
-			return err
+func reconcileManagementPort(nbClient goovn.Client, node string, mac net.HardwareAddr, subnet *net.IPNet) error {
+	portName := types.K8sPrefix + node
+	switches, err := nbClient.LSGet(node)
+	if err != nil {
+		return fmt.Errorf("failed to get logical switch %s: %v", node, err)
+	}
+	ls := switches[0]
+	lsp, err := nbClient.LSPGet(portName)
+	if err != nil && err != goovn.ErrorNotFound {
+		return fmt.Errorf("failed to get management port %s: %v", portName, err)
+	}
+
+	mgmtIfAddr := util.GetNodeManagementIfAddr(subnet)
+	isIPv6 := utilnet.IsIPv6CIDR(subnet)
+	var otherFamilyIPs []string
+	if lsp != nil {
+		_, ips, err := util.ParsePortAddresses(lsp)
+		if err != nil {
+			klog.Warningf("Overwriting the addresses of management port %s: %v", portName, err)
+		}
+		for _, ip := range ips {
+			if utilnet.IsIPv6(ip) != isIPv6 {
+				otherFamilyIPs = append(otherFamilyIPs, ip.String())
+			}
 		}
 	}
+	// the IPv4 address comes first
+	addresses := []string{mac.String()}
+	if isIPv6 {
+		addresses = append(append(addresses, otherFamilyIPs...), mgmtIfAddr.IP.String())
+	} else {
+		addresses = append(append(addresses, mgmtIfAddr.IP.String()), otherFamilyIPs...)
+	}
+	// only the addresses are owned here, see ReconcileManagementPort
+	spec := goovn.LSPSpec{
+		Addresses: []string{strings.Join(addresses, " ")},
+	}
 
+	var cmds []*goovn.OvnCommand
+	if lsp == nil {
+		cmd, err := nbClient.LSPAddFull(node, ls.UUID, portName, spec)
+		if err != nil {
+			return fmt.Errorf("failed to build the command adding management port %s: %v", portName, err)
+		}
+		cmds = append(cmds, cmd)
+	} else if update, _ := lspDrift(map[string]goovn.LSPSpec{portName: spec}, []*goovn.LogicalSwitchPort{lsp}); len(update) > 0 {
+		klog.Infof("Management port %s drifted, updating its addresses to %q", portName, spec.Addresses[0])
+		cmd, err := nbClient.LSPSet(portName, spec)
+		if err != nil {
+			return fmt.Errorf("failed to build the command updating management port %s: %v", portName, err)
+		}
+		cmds = append(cmds, cmd)
+	}
+
+	// with the management port holding its address, only the hybrid overlay
+	// address, never reserved on a port, is left for IPv4 exclude_ips
+	if !isIPv6 {
+		var excluded []net.IP
+		if config.HybridOverlay.Enabled {
+			excluded = append(excluded, util.GetNodeHybridOverlayIfAddr(subnet).IP)
+		}
+		excludeIPs := util.FormatExcludeIPs(excluded)
+		current, isSet := ls.OtherConfig[util.OVNOtherConfigExcludeIPs].(string)
+		var cmd *goovn.OvnCommand
+		if len(excludeIPs) > 0 && current != excludeIPs {
+			cmd, err = nbClient.AuxKeyValSet(goovn.TableLogicalSwitch, node, util.OVNColumnOtherConfig,
+				map[string]string{util.OVNOtherConfigExcludeIPs: excludeIPs})
+		} else if len(excludeIPs) == 0 && isSet {
+			cmd, err = nbClient.AuxKeyValDel(goovn.TableLogicalSwitch, node, util.OVNColumnOtherConfig,
+				map[string]*string{util.OVNOtherConfigExcludeIPs: nil})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to build the command setting switch %s exclude_ips: %v", node, err)
+		}
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	var lspUUID string
+	if lsp != nil {
+		lspUUID = lsp.UUID
+	}
+	if len(cmds) > 0 {
+		r, err := nbClient.ExecuteR(cmds...)
+		if err != nil {
+			return fmt.Errorf("failed to reconcile management port %s: %v", portName, err)
+		}
+		if lsp == nil {
+			// Grab the port's UUID from the creation response, where it is
+			// followed by the UUIDs of the rows the other commands inserted
+			if len(r) == 0 {
+				return fmt.Errorf("unexpected management port %q create response %v", portName, r)
+			}
+			lspUUID = r[0]
+		}
+	}
+
+	pg, err := nbClient.PortGroupGet(clusterPortGroupName)
+	if err != nil {
+		return fmt.Errorf("failed to get port group %s: %v", clusterPortGroupName, err)
+	}
+	for _, port := range pg.Ports {
+		if port == lspUUID {
+			return nil
+		}
+	}
+	return addToPortGroup(nbClient, clusterPortGroupName, &lpInfo{uuid: lspUUID, name: portName})
+}
+
+// SyncToSB waits for ovn-northd to translate the northbound changes committed
+// so far to the southbound database, like ovn-nbctl --wait=sb. It increments
+// nb_cfg of NB_Global and polls the southbound cache until ovn-northd copies
+// the new value to SB_Global, or until ctx is done.
+func (oc *Controller) SyncToSB(ctx context.Context) error {
+	return syncToSB(ctx, oc.ovnNBClient, oc.ovnSBClient, sbSyncPollInterval)
+}
+
+func syncToSB(ctx context.Context, nbClient, sbClient goovn.Client, interval time.Duration) error {
+	nbCfg, err := nbClient.NBGlobalIncrementNbCfg()
+	if err != nil {
+		return fmt.Errorf("failed to increment nb_cfg of %s: %v", goovn.TableNBGlobal, err)
+	}
+	sbCfg := 0
+	err = utilwait.PollImmediateUntil(interval, func() (bool, error) {
+		current, err := sbClient.SBGlobalGetNbCfg()
+		if err == goovn.ErrorNotFound {
+			// ovn-northd has yet to create the SB_Global row
+			return false, nil
+		} else if err != nil {
+			return false, fmt.Errorf("failed to get nb_cfg of %s: %v", goovn.TableSBGlobal, err)
+		}
+		sbCfg = current
+		return sbCfg >= nbCfg, nil
+	}, ctx.Done())
+	if err != nil {
+		return fmt.Errorf("southbound database not synced with nb_cfg %d, last synced with %d: %v", nbCfg, sbCfg, err)
+	}
 	return nil
 }
 
@@ -597,7 +795,7 @@ func (oc *Controller) syncGatewayLogicalNetwork(node *kapi.Node, l3GatewayConfig
 	}
 
 	drLRPIPs, _ := oc.joinSwIPManager.EnsureJoinLRPIPs(types.OVNClusterRouter)
-	err = gatewayInit(node.Name, clusterSubnets, hostSubnets, l3GatewayConfig, oc.SCTPSupport, gwLRPIPs, drLRPIPs)
+	err = gatewayInit(oc.ovnNBClient, node.Name, clusterSubnets, hostSubnets, l3GatewayConfig, oc.SCTPSupport, gwLRPIPs, drLRPIPs)
 	if err != nil {
 		return fmt.Errorf("failed to init shared interface gateway: %v", err)
 	}
@@ -721,18 +919,18 @@ func (oc *Controller) ensureNodeLogicalNetwork(node *kapi.Node, hostSubnets []*n
 			v6Gateway = gwIfAddr.IP
 
 			lsArgs = append(lsArgs,
-				"other-config:ipv6_prefix="+hostSubnet.IP.String(),
+				util.OVNNbctlOtherConfig+":"+util.OVNOtherConfigIPv6Prefix+"="+hostSubnet.IP.String(),
 			)
 		} else {
 			v4Gateway = gwIfAddr.IP
-			excludeIPs := mgmtIfAddr.IP.String()
+			excluded := []net.IP{mgmtIfAddr.IP}
 			if config.HybridOverlay.Enabled {
 				hybridOverlayIfAddr := util.GetNodeHybridOverlayIfAddr(hostSubnet)
-				excludeIPs += ".." + hybridOverlayIfAddr.IP.String()
+				excluded = append(excluded, hybridOverlayIfAddr.IP)
 			}
 			lsArgs = append(lsArgs,
-				"other-config:subnet="+hostSubnet.String(),
-				"other-config:exclude_ips="+excludeIPs,
+				util.OVNNbctlOtherConfig+":"+util.OVNOtherConfigSubnet+"="+hostSubnet.String(),
+				util.OVNNbctlOtherConfig+":"+util.OVNOtherConfigExcludeIPs+"="+util.FormatExcludeIPs(excluded),
 			)
 		}
 	}
@@ -784,8 +982,8 @@ func (oc *Controller) ensureNodeLogicalNetwork(node *kapi.Node, hostSubnets []*n
 
 	// If supported, enable IGMP/MLD snooping and querier on the node.
 	if oc.multicastSupport {
-		stdout, stderr, err = util.RunOVNNbctl("set", "logical_switch",
-			nodeName, "other-config:mcast_snoop=\"true\"")
+		stdout, stderr, err = util.RunOVNNbctl("set", types.OVNNBTableLogicalSwitch,
+			nodeName, util.OVNNbctlOtherConfig+":"+util.OVNOtherConfigMcastSnoop+"=\"true\"")
 		if err != nil {
 			klog.Errorf("Failed to enable IGMP on logical switch %v, stdout: %q, stderr: %q, error: %v",
 				nodeName, stdout, stderr, err)
@@ -796,10 +994,10 @@ func (oc *Controller) ensureNodeLogicalNetwork(node *kapi.Node, hostSubnets []*n
 		// Otherwise disable it.
 		if v4Gateway != nil || v6Gateway != nil {
 			if v4Gateway != nil {
-				stdout, stderr, err = util.RunOVNNbctl("set", "logical_switch",
-					nodeName, "other-config:mcast_querier=\"true\"",
-					"other-config:mcast_eth_src=\""+nodeLRPMAC.String()+"\"",
-					"other-config:mcast_ip4_src=\""+v4Gateway.String()+"\"")
+				stdout, stderr, err = util.RunOVNNbctl("set", types.OVNNBTableLogicalSwitch,
+					nodeName, util.OVNNbctlOtherConfig+":"+util.OVNOtherConfigMcastQuerier+"=\"true\"",
+					util.OVNNbctlOtherConfig+":"+util.OVNOtherConfigMcastEthSrc+"=\""+nodeLRPMAC.String()+"\"",
+					util.OVNNbctlOtherConfig+":"+util.OVNOtherConfigMcastIP4Src+"=\""+v4Gateway.String()+"\"")
 				if err != nil {
 					klog.Errorf("Failed to enable IGMP Querier on logical switch %v, stdout: %q, stderr: %q, error: %v",
 						nodeName, stdout, stderr, err)
@@ -807,10 +1005,10 @@ func (oc *Controller) ensureNodeLogicalNetwork(node *kapi.Node, hostSubnets []*n
 				}
 			}
 			if v6Gateway != nil {
-				stdout, stderr, err = util.RunOVNNbctl("set", "logical_switch",
-					nodeName, "other-config:mcast_querier=\"true\"",
-					"other-config:mcast_eth_src=\""+nodeLRPMAC.String()+"\"",
-					"other-config:mcast_ip6_src=\""+util.HWAddrToIPv6LLA(nodeLRPMAC).String()+"\"")
+				stdout, stderr, err = util.RunOVNNbctl("set", types.OVNNBTableLogicalSwitch,
+					nodeName, util.OVNNbctlOtherConfig+":"+util.OVNOtherConfigMcastQuerier+"=\"true\"",
+					util.OVNNbctlOtherConfig+":"+util.OVNOtherConfigMcastEthSrc+"=\""+nodeLRPMAC.String()+"\"",
+					util.OVNNbctlOtherConfig+":"+util.OVNOtherConfigMcastIP6Src+"=\""+util.HWAddrToIPv6LLA(nodeLRPMAC).String()+"\"")
 				if err != nil {
 					klog.Errorf("Failed to enable MLD Querier on logical switch %v, stdout: %q, stderr: %q, error: %v",
 						nodeName, stdout, stderr, err)
@@ -818,8 +1016,8 @@ func (oc *Controller) ensureNodeLogicalNetwork(node *kapi.Node, hostSubnets []*n
 				}
 			}
 		} else {
-			stdout, stderr, err = util.RunOVNNbctl("set", "logical_switch",
-				nodeName, "other-config:mcast_querier=\"false\"")
+			stdout, stderr, err = util.RunOVNNbctl("set", types.OVNNBTableLogicalSwitch,
+				nodeName, util.OVNNbctlOtherConfig+":"+util.OVNOtherConfigMcastQuerier+"=\"false\"")
 			if err != nil {
 				klog.Errorf("Failed to disable IGMP/MLD Querier on logical switch %v, stdout: %q, stderr: %q, error: %v",
 					nodeName, stdout, stderr, err)
@@ -1302,7 +1500,7 @@ func (oc *Controller) syncNodes(nodes []interface{}) {
 	}
 
 	nodeSwitches, stderr, err := util.RunOVNNbctl("--data=bare", "--no-heading",
-		"--format=csv", "--columns=name,other-config", "find", "logical_switch")
+		"--format=csv", "--columns=name,other-config", "find", types.OVNNBTableLogicalSwitch)
 	if err != nil {
 		klog.Errorf("Failed to get node logical switches: stderr: %q, error: %v",
 			stderr, err)
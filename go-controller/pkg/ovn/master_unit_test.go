@@ -0,0 +1,330 @@
+package ovn
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	goovn "github.com/ebay/go-ovn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	lsm "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/logical_switch_manager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/subnetallocator"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	goovn_mock "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/ebay/go-ovn"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReconcileManagementPort(t *testing.T) {
+	mac := ovntest.MustParseMAC("0a:58:0a:80:01:02")
+	v4Subnet := ovntest.MustParseIPNet("10.128.1.0/24")
+	v6Subnet := ovntest.MustParseIPNet("fd00:10:244:2::/64")
+	mgmtPort := func(addresses string) *goovn.LogicalSwitchPort {
+		return &goovn.LogicalSwitchPort{UUID: "mgmt-uuid", Name: "k8s-node1", Addresses: []string{addresses}}
+	}
+
+	tests := []struct {
+		desc          string
+		subnet        *net.IPNet
+		hybridOverlay bool
+		// existing is the management port, nil if it is missing
+		existing    *goovn.LogicalSwitchPort
+		otherConfig map[interface{}]interface{}
+		pgPorts     []string
+		lsGetErr    error
+		expSpec     *goovn.LSPSpec
+		expCmds     []string
+		errMatch    string
+	}{
+		{
+			desc:        "creates a missing port, clears exclude_ips and adds the port to the cluster port group",
+			subnet:      v4Subnet,
+			otherConfig: map[interface{}]interface{}{"subnet": "10.128.1.0/24", "exclude_ips": "10.128.1.2"},
+			expSpec:     &goovn.LSPSpec{Addresses: []string{"0a:58:0a:80:01:02 10.128.1.2"}},
+			expCmds:     []string{"LSPAddFull", "AuxKeyValDel", "PortGroupAddPort"},
+		},
+		{
+			desc:        "leaves a port in sync untouched",
+			subnet:      v4Subnet,
+			existing:    mgmtPort("0a:58:0a:80:01:02 10.128.1.2"),
+			otherConfig: map[interface{}]interface{}{"subnet": "10.128.1.0/24"},
+			pgPorts:     []string{"mgmt-uuid"},
+		},
+		{
+			desc:        "updates the drifted address of its family and keeps the other",
+			subnet:      v6Subnet,
+			existing:    mgmtPort("0a:58:0a:80:01:02 10.128.1.2 fd00:10:244:2::9"),
+			otherConfig: map[interface{}]interface{}{"subnet": "10.128.1.0/24"},
+			pgPorts:     []string{"mgmt-uuid"},
+			expSpec:     &goovn.LSPSpec{Addresses: []string{"0a:58:0a:80:01:02 10.128.1.2 fd00:10:244:2::2"}},
+			expCmds:     []string{"LSPSet"},
+		},
+		{
+			desc:          "excludes the hybrid overlay address",
+			subnet:        v4Subnet,
+			hybridOverlay: true,
+			existing:      mgmtPort("0a:58:0a:80:01:02 10.128.1.2"),
+			otherConfig:   map[interface{}]interface{}{"subnet": "10.128.1.0/24", "exclude_ips": "10.128.1.2..10.128.1.3"},
+			pgPorts:       []string{"mgmt-uuid"},
+			expCmds:       []string{"AuxKeyValSet"},
+		},
+		{
+			desc:     "fails when the node switch is missing",
+			subnet:   v4Subnet,
+			lsGetErr: goovn.ErrorNotFound,
+			errMatch: "failed to get logical switch node1",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			config.HybridOverlay.Enabled = tc.hybridOverlay
+			defer func() { config.HybridOverlay.Enabled = false }()
+
+			mockNbClient := new(goovn_mock.Client)
+			var switches []*goovn.LogicalSwitch
+			if tc.lsGetErr == nil {
+				switches = []*goovn.LogicalSwitch{{UUID: "ls-uuid", Name: "node1", OtherConfig: tc.otherConfig}}
+			}
+			mockNbClient.On("LSGet", "node1").Return(switches, tc.lsGetErr)
+			if tc.existing != nil {
+				mockNbClient.On("LSPGet", "k8s-node1").Return(tc.existing, nil)
+			} else {
+				mockNbClient.On("LSPGet", "k8s-node1").Return(nil, goovn.ErrorNotFound)
+			}
+			mockNbClient.On("LSPAddFull", "node1", "ls-uuid", "k8s-node1", mock.Anything).Return(ovntest.MockCommand("LSPAddFull"), nil)
+			mockNbClient.On("LSPSet", "k8s-node1", mock.Anything).Return(ovntest.MockCommand("LSPSet"), nil)
+			mockNbClient.On("AuxKeyValSet", goovn.TableLogicalSwitch, "node1", util.OVNColumnOtherConfig,
+				map[string]string{util.OVNOtherConfigExcludeIPs: "10.128.1.3"}).Return(ovntest.MockCommand("AuxKeyValSet"), nil)
+			mockNbClient.On("AuxKeyValDel", goovn.TableLogicalSwitch, "node1", util.OVNColumnOtherConfig,
+				map[string]*string{util.OVNOtherConfigExcludeIPs: nil}).Return(ovntest.MockCommand("AuxKeyValDel"), nil)
+			mockNbClient.On("PortGroupGet", clusterPortGroupName).Return(&goovn.PortGroup{Name: clusterPortGroupName, Ports: tc.pgPorts}, nil)
+			mockNbClient.On("PortGroupAddPort", clusterPortGroupName, "mgmt-uuid").Return(ovntest.MockCommand("PortGroupAddPort"), nil)
+			// the UUID of a new port comes first in the response
+			mockNbClient.On("ExecuteR", mock.Anything).Return([]string{"mgmt-uuid"}, nil)
+			mockNbClient.On("ExecuteR", mock.Anything, mock.Anything).Return([]string{"mgmt-uuid"}, nil)
+			mockNbClient.On("Execute", mock.Anything).Return(nil)
+
+			err := reconcileManagementPort(mockNbClient, "node1", mac, tc.subnet)
+			if tc.errMatch != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMatch)
+				return
+			}
+			assert.Nil(t, err)
+
+			cmds := []string{}
+			for _, call := range mockNbClient.Calls {
+				switch call.Method {
+				case "Execute", "ExecuteR":
+					for _, arg := range call.Arguments {
+						cmds = append(cmds, arg.(*goovn.OvnCommand).Describe()[0].Table)
+					}
+				case "LSPAddFull", "LSPSet":
+					spec := call.Arguments.Get(len(call.Arguments) - 1).(goovn.LSPSpec)
+					assert.Equal(t, *tc.expSpec, spec)
+				}
+			}
+			if tc.expCmds == nil {
+				tc.expCmds = []string{}
+			}
+			assert.Equal(t, tc.expCmds, cmds)
+		})
+	}
+}
+
+func TestExpandNodeSubnets(t *testing.T) {
+	tests := []struct {
+		desc string
+		// annotated are the host subnets of the node annotation, none if nil
+		annotated []*net.IPNet
+		// allocated are the host subnets of the other nodes
+		allocated  []*net.IPNet
+		expCmds    []string
+		failCmd    string
+		expSubnets []*net.IPNet
+		// expReserved and expFree are the subnets that the cluster subnet
+		// allocator must have handed out or not afterwards
+		expReserved []*net.IPNet
+		expFree     []*net.IPNet
+		errMatch    string
+	}{
+		{
+			desc:      "grows the IPv4 subnet of the node switch",
+			annotated: ovntest.MustParseIPNets("10.128.0.0/23"),
+			expCmds: []string{
+				"ovn-nbctl --timeout=15 set logical_switch node1 other-config:subnet=10.128.0.0/23",
+				"ovn-nbctl --timeout=15 lsp-list node1",
+				"ovn-nbctl --timeout=15 -- --if-exists set logical_switch node1 other-config:exclude_ips=10.128.0.2",
+			},
+			expSubnets:  ovntest.MustParseIPNets("10.128.0.0/23"),
+			expReserved: ovntest.MustParseIPNets("10.128.0.0/24", "10.128.1.0/24"),
+		},
+		{
+			desc:      "adds an IPv6 subnet to the node switch",
+			annotated: ovntest.MustParseIPNets("10.128.0.0/24", "fd00:10:244:1::/64"),
+			expCmds: []string{
+				"ovn-nbctl --timeout=15 set logical_switch node1 other-config:ipv6_prefix=fd00:10:244:1::",
+			},
+			expSubnets:  ovntest.MustParseIPNets("10.128.0.0/24", "fd00:10:244:1::/64"),
+			expReserved: ovntest.MustParseIPNets("fd00:10:244:1::/64"),
+		},
+		{
+			desc:        "refuses a subnet overlapping the subnet of another node",
+			annotated:   ovntest.MustParseIPNets("10.128.0.0/23"),
+			allocated:   ovntest.MustParseIPNets("10.128.1.0/24"),
+			expSubnets:  ovntest.MustParseIPNets("10.128.0.0/24"),
+			expReserved: ovntest.MustParseIPNets("10.128.0.0/24", "10.128.1.0/24"),
+			errMatch:    "overlaps the allocated network 10.128.1.0/24",
+		},
+		{
+			desc:      "keeps the subnet when the node switch can't be updated",
+			annotated: ovntest.MustParseIPNets("10.128.0.0/23"),
+			expCmds: []string{
+				"ovn-nbctl --timeout=15 set logical_switch node1 other-config:subnet=10.128.0.0/23",
+			},
+			failCmd:     "ovn-nbctl --timeout=15 lsp-list node1",
+			expSubnets:  ovntest.MustParseIPNets("10.128.0.0/24"),
+			expReserved: ovntest.MustParseIPNets("10.128.0.0/24"),
+			expFree:     ovntest.MustParseIPNets("10.128.1.0/24"),
+			errMatch:    "failed to expand the subnet of node node1 to 10.128.0.0/23",
+		},
+		{
+			desc:        "keeps the subnet of the node switch that the annotation shrinks",
+			annotated:   ovntest.MustParseIPNets("10.128.0.0/25"),
+			expSubnets:  ovntest.MustParseIPNets("10.128.0.0/24"),
+			expReserved: ovntest.MustParseIPNets("10.128.0.0/24"),
+			errMatch:    "failed to expand the subnet of node node1 to 10.128.0.0/25",
+		},
+		{
+			desc:       "fails without the host subnet annotation",
+			expSubnets: ovntest.MustParseIPNets("10.128.0.0/24"),
+			errMatch:   "node \"node1\" has no \"k8s.ovn.org/node-subnets\" annotation",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			fexec := ovntest.NewFakeExec()
+			fexec.AddFakeCmdsNoOutputNoError(tc.expCmds)
+			if tc.failCmd != "" {
+				fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: tc.failCmd, Err: fmt.Errorf("timed out")})
+			}
+			assert.Nil(t, util.SetExec(fexec))
+			oc := &Controller{
+				lsManager:             lsm.NewLogicalSwitchManager(),
+				masterSubnetAllocator: subnetallocator.NewSubnetAllocator(),
+			}
+			assert.Nil(t, oc.masterSubnetAllocator.AddNetworkRange(ovntest.MustParseIPNet("10.128.0.0/14"), 24))
+			assert.Nil(t, oc.masterSubnetAllocator.AddNetworkRange(ovntest.MustParseIPNet("fd00:10:244::/48"), 64))
+			for _, subnet := range append(ovntest.MustParseIPNets("10.128.0.0/24"), tc.allocated...) {
+				assert.Nil(t, oc.masterSubnetAllocator.MarkAllocatedNetwork(subnet))
+			}
+			assert.Nil(t, oc.lsManager.AddNode("node1", "ls-uuid", ovntest.MustParseIPNets("10.128.0.0/24")))
+			node := &kapi.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+			if tc.annotated != nil {
+				annotation, err := util.CreateNodeHostSubnetAnnotation(tc.annotated)
+				assert.Nil(t, err)
+				node.Annotations = map[string]string{}
+				for k, v := range annotation {
+					node.Annotations[k] = v.(string)
+				}
+			}
+
+			err := oc.expandNodeSubnets(node)
+			if tc.errMatch != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMatch)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.True(t, fexec.CalledMatchesExpected(), fexec.ErrorDesc)
+			subnets, _ := oc.lsManager.GetSwitchSubnetsAndUUID("node1")
+			assert.Equal(t, tc.expSubnets, subnets)
+			for _, subnet := range tc.expReserved {
+				assert.Error(t, oc.masterSubnetAllocator.ExpandNetwork(nil, subnet), "subnet %s is free", subnet)
+			}
+			for _, subnet := range tc.expFree {
+				assert.Nil(t, oc.masterSubnetAllocator.ExpandNetwork(nil, subnet), "subnet %s is reserved", subnet)
+			}
+		})
+	}
+}
+
+func TestSyncToSB(t *testing.T) {
+	tests := []struct {
+		desc         string
+		incrementErr error
+		// sbCfgs are the successive nb_cfg values of SB_Global, -1 standing
+		// for a missing SB_Global row
+		sbCfgs   []int
+		sbErr    error
+		errMatch string
+	}{
+		{
+			desc:   "waits for ovn-northd to catch up",
+			sbCfgs: []int{3, 4, 5},
+		},
+		{
+			desc:   "waits for ovn-northd to create SB_Global",
+			sbCfgs: []int{-1, 5},
+		},
+		{
+			desc:   "is done when the southbound database is ahead",
+			sbCfgs: []int{6},
+		},
+		{
+			desc:     "times out when ovn-northd doesn't catch up",
+			sbCfgs:   []int{4},
+			errMatch: "not synced with nb_cfg 5, last synced with 4",
+		},
+		{
+			desc:         "fails when nb_cfg can't be incremented",
+			incrementErr: goovn.ErrorNotFound,
+			errMatch:     "failed to increment nb_cfg",
+		},
+		{
+			desc:     "fails when nb_cfg of SB_Global can't be read",
+			sbErr:    goovn.ErrorSchema,
+			errMatch: "failed to get nb_cfg of SB_Global",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			mockNbClient := new(goovn_mock.Client)
+			mockSbClient := new(goovn_mock.Client)
+			mockNbClient.On("NBGlobalIncrementNbCfg").Return(5, tc.incrementErr)
+			for j, sbCfg := range tc.sbCfgs {
+				call := mockSbClient.On("SBGlobalGetNbCfg")
+				if sbCfg < 0 {
+					call.Return(0, goovn.ErrorNotFound)
+				} else {
+					call.Return(sbCfg, nil)
+				}
+				// the last value is returned from then on
+				if j < len(tc.sbCfgs)-1 {
+					call.Once()
+				}
+			}
+			if tc.sbErr != nil {
+				mockSbClient.On("SBGlobalGetNbCfg").Return(0, tc.sbErr)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			err := syncToSB(ctx, mockNbClient, mockSbClient, time.Millisecond)
+			if tc.errMatch != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMatch)
+				return
+			}
+			assert.Nil(t, err)
+			mockSbClient.AssertNumberOfCalls(t, "SBGlobalGetNbCfg", len(tc.sbCfgs))
+		})
+	}
+}
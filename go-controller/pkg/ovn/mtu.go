@@ -0,0 +1,127 @@
+package ovn
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	goovn "github.com/ebay/go-ovn"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// minimum MTU of an IPv4 link, see RFC 791
+	minIPv4MTU = 576
+	// minimum MTU of an IPv6 link, see RFC 8200
+	minIPv6MTU = 1280
+	// maximum MTU that fits the IP total length field
+	maxMTU = 65535
+)
+
+// validateMTU checks that mtu can be carried by every IP family enabled in the
+// cluster and is not below the cluster MTU, as OVN would then reply
+// "fragmentation needed" to packets the overlay itself carries
+func validateMTU(mtu int) error {
+	minMTU := minIPv4MTU
+	if config.IPv6Mode {
+		minMTU = minIPv6MTU
+	}
+	if config.Default.MTU > minMTU {
+		minMTU = config.Default.MTU
+	}
+	if mtu < minMTU || mtu > maxMTU {
+		return fmt.Errorf("invalid MTU %d, must be between %d and %d", mtu, minMTU, maxMTU)
+	}
+	return nil
+}
+
+// SetRouterPortMTU sets the gateway_mtu option of the given logical router
+// port so that OVN generates ICMP "fragmentation needed"/"packet too big"
+// replies for larger packets leaving through it.
+func (oc *Controller) SetRouterPortMTU(lrp string, mtu int) error {
+	if err := validateMTU(mtu); err != nil {
+		return err
+	}
+	cmd, err := oc.ovnNBClient.LRPSetOptions(lrp, map[string]string{util.OVNOptionGatewayMTU: strconv.Itoa(mtu)})
+	if err != nil {
+		return fmt.Errorf("unable to create LRPSetOptions command for port %s: %v", lrp, err)
+	}
+	if err = oc.ovnNBClient.Execute(cmd); err != nil {
+		return fmt.Errorf("failed to set gateway_mtu %d on logical router port %s: %v", mtu, lrp, err)
+	}
+	return nil
+}
+
+// SetClusterRouterMTU sets gateway_mtu on the join switch port of every
+// gateway router found in the northbound database, in a single transaction.
+// All routers are checked before anything is written, so a router whose join
+// switch wiring is inconsistent leaves the MTU of every router unchanged.
+func (oc *Controller) SetClusterRouterMTU(mtu int) error {
+	if err := validateMTU(mtu); err != nil {
+		return err
+	}
+	routers, err := oc.ovnNBClient.LRList()
+	if err != nil {
+		return fmt.Errorf("failed to list logical routers: %v", err)
+	}
+	options := map[string]string{util.OVNOptionGatewayMTU: strconv.Itoa(mtu)}
+	var cmds []*goovn.OvnCommand
+	for _, router := range routers {
+		if !strings.HasPrefix(router.Name, types.GWRouterPrefix) {
+			continue
+		}
+		lrp, err := oc.gatewayRouterJoinPort(router.Name)
+		if err != nil {
+			return err
+		}
+		cmd, err := oc.ovnNBClient.LRPSetOptions(lrp, options)
+		if err != nil {
+			return fmt.Errorf("unable to create LRPSetOptions command for port %s: %v", lrp, err)
+		}
+		cmds = append(cmds, cmd)
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+	if err = oc.ovnNBClient.Execute(cmds...); err != nil {
+		return fmt.Errorf("failed to set gateway_mtu %d on gateway router ports: %v", mtu, err)
+	}
+	klog.Infof("Set gateway_mtu %d on %d gateway router ports", mtu, len(cmds))
+	return nil
+}
+
+// gatewayRouterJoinPort returns the port of gateway router gr that connects it
+// to the join switch, after checking that the router and the join switch
+// agree on it: the router port must exist and the join switch port must be a
+// router port pointing at it.
+func (oc *Controller) gatewayRouterJoinPort(gr string) (string, error) {
+	node := strings.TrimPrefix(gr, types.GWRouterPrefix)
+	lrp := types.GWRouterToJoinSwitchPrefix + gr
+	lrps, err := oc.ovnNBClient.LRPList(gr)
+	if err != nil {
+		return "", fmt.Errorf("failed to list ports of gateway router %s: %v", gr, err)
+	}
+	found := false
+	for _, port := range lrps {
+		if port.Name == lrp {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return "", fmt.Errorf("gateway router %s has no port %s", gr, lrp)
+	}
+	lsp := types.JoinSwitchToGWRouterPrefix + types.GWRouterPrefix + node
+	port, err := oc.ovnNBClient.LSPGet(lsp)
+	if err != nil {
+		return "", fmt.Errorf("failed to get join switch port %s: %v", lsp, err)
+	}
+	if peer, _ := port.Options[util.OVNOptionRouterPort].(string); port.Type != "router" || peer != lrp {
+		return "", fmt.Errorf("join switch port %s is not connected to %s (type %q, router-port %q)",
+			lsp, lrp, port.Type, peer)
+	}
+	return lrp, nil
+}
@@ -0,0 +1,209 @@
+package ovn
+
+import (
+	"fmt"
+	"testing"
+
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	goovn_mock "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/ebay/go-ovn"
+
+	goovn "github.com/ebay/go-ovn"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetRouterPortMTU(t *testing.T) {
+	mockGoOvnNBClient := new(goovn_mock.Client)
+	oc := &Controller{ovnNBClient: mockGoOvnNBClient}
+	lrp := "rtoj-GR_node1"
+
+	tests := []struct {
+		desc                      string
+		mtu                       int
+		ipv6                      bool
+		clusterMTU                int
+		errMatch                  error
+		onRetArgMockGoOvnNBClient []ovntest.TestifyMockHelper
+	}{
+		{
+			desc: "positive test case",
+			mtu:  9000,
+			onRetArgMockGoOvnNBClient: []ovntest.TestifyMockHelper{
+				{
+					OnCallMethodName: "LRPSetOptions", OnCallMethodArgType: []string{"string", "map[string]string"}, RetArgList: []interface{}{&goovn.OvnCommand{}, nil},
+				},
+				{
+					OnCallMethodName: "Execute", OnCallMethodArgType: []string{"*goovn.OvnCommand"}, RetArgList: []interface{}{nil},
+				},
+			},
+		},
+		{
+			desc:     "MTU too small for IPv4",
+			mtu:      500,
+			errMatch: fmt.Errorf("invalid MTU 500, must be between 576 and 65535"),
+		},
+		{
+			desc:     "MTU too small for IPv6",
+			mtu:      1000,
+			ipv6:     true,
+			errMatch: fmt.Errorf("invalid MTU 1000, must be between 1280 and 65535"),
+		},
+		{
+			desc:       "MTU below the cluster MTU",
+			mtu:        1300,
+			clusterMTU: 1400,
+			errMatch:   fmt.Errorf("invalid MTU 1300, must be between 1400 and 65535"),
+		},
+		{
+			desc:     "MTU too large",
+			mtu:      70000,
+			errMatch: fmt.Errorf("invalid MTU 70000, must be between 576 and 65535"),
+		},
+		{
+			desc:     "LRPSetOptions error",
+			mtu:      1400,
+			errMatch: fmt.Errorf("unable to create LRPSetOptions command for port %s: %v", lrp, goovn.ErrorNotFound),
+			onRetArgMockGoOvnNBClient: []ovntest.TestifyMockHelper{
+				{
+					OnCallMethodName: "LRPSetOptions", OnCallMethodArgType: []string{"string", "map[string]string"}, RetArgList: []interface{}{nil, goovn.ErrorNotFound},
+				},
+			},
+		},
+		{
+			desc:     "execute error",
+			mtu:      1400,
+			errMatch: fmt.Errorf("failed to set gateway_mtu 1400 on logical router port %s: %v", lrp, execError),
+			onRetArgMockGoOvnNBClient: []ovntest.TestifyMockHelper{
+				{
+					OnCallMethodName: "LRPSetOptions", OnCallMethodArgType: []string{"string", "map[string]string"}, RetArgList: []interface{}{&goovn.OvnCommand{}, nil},
+				},
+				{
+					OnCallMethodName: "Execute", OnCallMethodArgType: []string{"*goovn.OvnCommand"}, RetArgList: []interface{}{execError},
+				},
+			},
+		},
+	}
+
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			config.PrepareTestConfig()
+			config.IPv6Mode = tc.ipv6
+			config.Default.MTU = tc.clusterMTU
+			defer func() {
+				config.PrepareTestConfig()
+				config.IPv6Mode = false
+			}()
+			ovntest.ProcessMockFnList(&mockGoOvnNBClient.Mock, tc.onRetArgMockGoOvnNBClient)
+
+			err := oc.SetRouterPortMTU(lrp, tc.mtu)
+
+			if tc.errMatch != nil {
+				assert.Contains(t, err.Error(), tc.errMatch.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			mockGoOvnNBClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetClusterRouterMTU(t *testing.T) {
+	joinPort := func(name, peer string) *goovn.LogicalSwitchPort {
+		return &goovn.LogicalSwitchPort{
+			Name:    name,
+			Type:    "router",
+			Options: map[interface{}]interface{}{"router-port": peer},
+		}
+	}
+	routers := []*goovn.LogicalRouter{{Name: "ovn_cluster_router"}, {Name: "GR_node1"}, {Name: "GR_node2"}}
+
+	tests := []struct {
+		desc     string
+		mtu      int
+		node2LSP *goovn.LogicalSwitchPort
+		node2LRP string
+		listErr  error
+		execErr  error
+		errMatch string
+	}{
+		{
+			desc:     "sets gateway_mtu on every gateway router",
+			mtu:      1400,
+			node2LSP: joinPort("jtor-GR_node2", "rtoj-GR_node2"),
+			node2LRP: "rtoj-GR_node2",
+		},
+		{
+			desc:     "MTU below the cluster MTU",
+			mtu:      1300,
+			errMatch: "invalid MTU 1300, must be between 1400 and 65535",
+		},
+		{
+			desc:     "routers cannot be listed",
+			mtu:      1400,
+			listErr:  goovn.ErrorSchema,
+			errMatch: fmt.Sprintf("failed to list logical routers: %v", goovn.ErrorSchema),
+		},
+		{
+			desc:     "gateway router without a join port",
+			mtu:      1400,
+			node2LRP: "rtoe-GR_node2",
+			errMatch: "gateway router GR_node2 has no port rtoj-GR_node2",
+		},
+		{
+			desc:     "join switch port connected elsewhere",
+			mtu:      1400,
+			node2LSP: joinPort("jtor-GR_node2", "rtoj-GR_node1"),
+			node2LRP: "rtoj-GR_node2",
+			errMatch: "join switch port jtor-GR_node2 is not connected to rtoj-GR_node2",
+		},
+		{
+			desc:     "execute error",
+			mtu:      1400,
+			node2LSP: joinPort("jtor-GR_node2", "rtoj-GR_node2"),
+			node2LRP: "rtoj-GR_node2",
+			execErr:  execError,
+			errMatch: fmt.Sprintf("failed to set gateway_mtu 1400 on gateway router ports: %v", execError),
+		},
+	}
+
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			config.PrepareTestConfig()
+			config.Default.MTU = 1400
+			defer config.PrepareTestConfig()
+			mockGoOvnNBClient := new(goovn_mock.Client)
+			oc := &Controller{ovnNBClient: mockGoOvnNBClient}
+			options := map[string]string{util.OVNOptionGatewayMTU: fmt.Sprint(tc.mtu)}
+			if tc.node2LRP != "" || tc.listErr != nil {
+				mockGoOvnNBClient.On("LRList").Return(routers, tc.listErr)
+			}
+			if tc.node2LRP != "" {
+				mockGoOvnNBClient.On("LRPList", "GR_node1").Return([]*goovn.LogicalRouterPort{{Name: "rtoj-GR_node1"}}, nil)
+				mockGoOvnNBClient.On("LSPGet", "jtor-GR_node1").Return(joinPort("jtor-GR_node1", "rtoj-GR_node1"), nil)
+				mockGoOvnNBClient.On("LRPSetOptions", "rtoj-GR_node1", options).Return(&goovn.OvnCommand{}, nil)
+				mockGoOvnNBClient.On("LRPList", "GR_node2").Return([]*goovn.LogicalRouterPort{{Name: tc.node2LRP}}, nil)
+			}
+			if tc.node2LSP != nil {
+				mockGoOvnNBClient.On("LSPGet", "jtor-GR_node2").Return(tc.node2LSP, nil)
+			}
+			if tc.errMatch == "" || tc.execErr != nil {
+				mockGoOvnNBClient.On("LRPSetOptions", "rtoj-GR_node2", options).Return(&goovn.OvnCommand{}, nil)
+				mockGoOvnNBClient.On("Execute", &goovn.OvnCommand{}, &goovn.OvnCommand{}).Return(tc.execErr)
+			}
+
+			err := oc.SetClusterRouterMTU(tc.mtu)
+
+			if tc.errMatch != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMatch)
+			} else {
+				assert.Nil(t, err)
+			}
+			if tc.errMatch != "" && tc.execErr == nil {
+				mockGoOvnNBClient.AssertNotCalled(t, "Execute", &goovn.OvnCommand{}, &goovn.OvnCommand{})
+			}
+			mockGoOvnNBClient.AssertExpectations(t)
+		})
+	}
+}
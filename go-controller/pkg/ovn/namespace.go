@@ -13,6 +13,7 @@ import (
 
 	goovn "github.com/ebay/go-ovn"
 	kapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	utilwait "k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 )
@@ -121,6 +122,92 @@ func (oc *Controller) deletePodFromNamespace(ns, name, uuid string, ips []*net.I
 	return cmds, nil
 }
 
+// addressSetDrift describes how a namespace address set differs from the IPs
+// of the pods in the namespace
+type addressSetDrift struct {
+	// pod IPs that are missing from the address set
+	missing []string
+	// address set entries that no pod owns anymore
+	stale []string
+}
+
+// checkNamespaceAddressSetDrift compares the IPs in the pod annotations of the
+// namespace's pods with the contents of the namespace address set and returns
+// the IPs that would have to be added or removed to bring them back in sync.
+// Nothing is changed in OVN.
+func (oc *Controller) checkNamespaceAddressSetDrift(ns string) (*addressSetDrift, error) {
+	nsInfo, nsUnlock := oc.getNamespaceLocked(ns, true)
+	if nsInfo == nil {
+		return nil, fmt.Errorf("namespace %s not found", ns)
+	}
+	defer nsUnlock()
+	if nsInfo.addressSet == nil {
+		return &addressSetDrift{}, nil
+	}
+
+	pods, err := oc.watchFactory.GetPods(ns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods of namespace %s: %v", ns, err)
+	}
+	expected := sets.NewString()
+	for _, pod := range pods {
+		if !util.PodScheduled(pod) || !util.PodWantsNetwork(pod) {
+			continue
+		}
+		annotation, err := util.UnmarshalPodAnnotation(pod.Annotations)
+		if err != nil {
+			continue
+		}
+		for _, ip := range annotation.IPs {
+			expected.Insert(ip.IP.String())
+		}
+	}
+
+	ips, err := nsInfo.addressSet.GetIPs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get address set of namespace %s: %v", ns, err)
+	}
+	actual := sets.NewString(ips...)
+
+	// an entry not in its canonical form is stale in any namespace: OVN
+	// matches entries as strings, so it never stands for the pod IP
+	stale := sets.NewString()
+	for _, ip := range ips {
+		if canonical, err := util.CanonicalizeIP(ip); err == nil && canonical != ip {
+			stale.Insert(ip)
+		}
+	}
+	// the host network namespace address set also holds node addresses that
+	// are not owned by any pod
+	if ns != config.Kubernetes.HostNetworkNamespace {
+		stale = stale.Union(actual.Difference(expected))
+	}
+	return &addressSetDrift{missing: expected.Difference(actual).List(), stale: stale.List()}, nil
+}
+
+// checkAddressSetDrift reports the namespaces whose address set does not match
+// the IPs of their pods
+func (oc *Controller) checkAddressSetDrift() {
+	oc.namespacesMutex.Lock()
+	namespaces := make([]string, 0, len(oc.namespaces))
+	for ns := range oc.namespaces {
+		namespaces = append(namespaces, ns)
+	}
+	oc.namespacesMutex.Unlock()
+
+	for _, ns := range namespaces {
+		drift, err := oc.checkNamespaceAddressSetDrift(ns)
+		if err != nil {
+			klog.Warningf("Unable to check address set of namespace %s: %v", ns, err)
+			continue
+		}
+		if len(drift.missing) > 0 || len(drift.stale) > 0 {
+			klog.Errorf("Address set of namespace %s is out of sync: missing pod IPs %v, stale IPs %v",
+				ns, drift.missing, drift.stale)
+		}
+	}
+}
+
 func createIPAddressSlice(ips []*net.IPNet) []net.IP {
 	ipAddrs := make([]net.IP, 0)
 	for _, ip := range ips {
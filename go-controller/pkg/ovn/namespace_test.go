@@ -11,6 +11,7 @@ import (
 	egressfirewallfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/clientset/versioned/fake"
 	egressipfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned/fake"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	as_mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set/mocks"
 	lsm "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/logical_switch_manager"
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
@@ -128,6 +129,124 @@ var _ = ginkgo.Describe("OVN Namespace Operations", func() {
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
 
+		ginkgo.It("reports address set entries that are out of sync with the namespace pods", func() {
+			app.Action = func(ctx *cli.Context) error {
+				namespaceT := *newNamespace(namespaceName)
+				tP := newTPod(
+					"node1",
+					"10.128.1.0/24",
+					"10.128.1.2",
+					"10.128.1.1",
+					"myPod",
+					"10.128.1.3",
+					"11:22:33:44:55:66",
+					namespaceT.Name,
+				)
+				pod := newPod(namespaceT.Name, tP.podName, tP.nodeName, tP.podIP)
+				var err error
+				pod.Annotations, err = util.MarshalPodAnnotation(&util.PodAnnotation{
+					IPs: []*net.IPNet{ovntest.MustParseIPNet(tP.podIP + "/24")},
+					MAC: ovntest.MustParseMAC(tP.podMAC),
+				})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				fakeOvn.start(ctx,
+					&v1.NamespaceList{
+						Items: []v1.Namespace{
+							namespaceT,
+						},
+					},
+					&v1.PodList{
+						Items: []v1.Pod{
+							*pod,
+						},
+					},
+				)
+				fakeOvn.controller.WatchNamespaces()
+				fakeOvn.asf.ExpectAddressSetWithIPs(namespaceName, []string{tP.podIP})
+
+				drift, err := fakeOvn.controller.checkNamespaceAddressSetDrift(namespaceName)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(drift.missing).To(gomega.BeEmpty())
+				gomega.Expect(drift.stale).To(gomega.BeEmpty())
+
+				nsInfo, nsUnlock := fakeOvn.controller.getNamespaceLocked(namespaceName, true)
+				gomega.Expect(nsInfo).NotTo(gomega.BeNil())
+				gomega.Expect(nsInfo.addressSet.DeleteIPs([]net.IP{net.ParseIP(tP.podIP)})).To(gomega.Succeed())
+				gomega.Expect(nsInfo.addressSet.AddIPs([]net.IP{net.ParseIP("10.128.1.4")})).To(gomega.Succeed())
+				nsUnlock()
+
+				drift, err = fakeOvn.controller.checkNamespaceAddressSetDrift(namespaceName)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(drift.missing).To(gomega.Equal([]string{tP.podIP}))
+				gomega.Expect(drift.stale).To(gomega.Equal([]string{"10.128.1.4"}))
+
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("reports address set entries that are not in their canonical form as stale", func() {
+			app.Action = func(ctx *cli.Context) error {
+				namespaceT := *newNamespace(namespaceName)
+				podIP := "10.128.1.3"
+				pod := newPod(namespaceT.Name, "myPod", "node1", podIP)
+				var err error
+				pod.Annotations, err = util.MarshalPodAnnotation(&util.PodAnnotation{
+					IPs: []*net.IPNet{ovntest.MustParseIPNet(podIP + "/24")},
+					MAC: ovntest.MustParseMAC("11:22:33:44:55:66"),
+				})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				fakeOvn.start(ctx,
+					&v1.NamespaceList{
+						Items: []v1.Namespace{
+							namespaceT,
+						},
+					},
+					&v1.PodList{
+						Items: []v1.Pod{
+							*pod,
+						},
+					},
+				)
+				fakeOvn.controller.WatchNamespaces()
+
+				// OVN holds the pod IP both with a leading zero and in its
+				// canonical form, along with a node address
+				mockAddressSet := new(as_mocks.AddressSet)
+				mockAddressSet.On("GetIPs").Return([]string{"10.128.1.003", podIP, "172.18.0.2"}, nil)
+				nsInfo, nsUnlock := fakeOvn.controller.getNamespaceLocked(namespaceName, false)
+				gomega.Expect(nsInfo).NotTo(gomega.BeNil())
+				addressSet := nsInfo.addressSet
+				nsInfo.addressSet = mockAddressSet
+				nsUnlock()
+
+				drift, err := fakeOvn.controller.checkNamespaceAddressSetDrift(namespaceName)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(drift.missing).To(gomega.BeEmpty())
+				gomega.Expect(drift.stale).To(gomega.Equal([]string{"10.128.1.003", "172.18.0.2"}))
+
+				// the node addresses of the host network namespace are not
+				// stale, the leading zero entry still is
+				config.Kubernetes.HostNetworkNamespace = namespaceName
+				drift, err = fakeOvn.controller.checkNamespaceAddressSetDrift(namespaceName)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(drift.missing).To(gomega.BeEmpty())
+				gomega.Expect(drift.stale).To(gomega.Equal([]string{"10.128.1.003"}))
+
+				nsInfo, nsUnlock = fakeOvn.controller.getNamespaceLocked(namespaceName, false)
+				nsInfo.addressSet = addressSet
+				nsUnlock()
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
 		ginkgo.It("creates an empty address set for the namespace without pods", func() {
 			app.Action = func(ctx *cli.Context) error {
 				fakeOvn.start(ctx, &v1.NamespaceList{
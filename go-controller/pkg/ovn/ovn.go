@@ -366,7 +366,7 @@ func (oc *Controller) Run(wg *sync.WaitGroup, nodeName string) error {
 	}
 
 	// Master is fully running and resource handlers have synced, update Topology version in OVN
-	stdout, stderr, err := util.RunOVNNbctl("set", "logical_router", ovntypes.OVNClusterRouter,
+	stdout, stderr, err := util.RunOVNNbctl("set", ovntypes.OVNNBTableLogicalRouter, ovntypes.OVNClusterRouter,
 		fmt.Sprintf("external_ids:k8s-ovn-topo-version=%d", ovntypes.OvnCurrentTopologyVersion))
 	if err != nil {
 		klog.Errorf("Failed to set topology version in OVN, "+
@@ -403,7 +403,8 @@ func (oc *Controller) ovnTopologyCleanup() error {
 // syncPeriodic adds a goroutine that periodically does some work
 // right now there is only one ticker registered
 // for syncNodesPeriodic which deletes chassis records from the sbdb
-// every 5 minutes
+// and checkAddressSetDrift which reports namespace address sets that
+// are out of sync with their pods every 5 minutes
 func (oc *Controller) syncPeriodic() {
 	go func() {
 		nodeSyncTicker := time.NewTicker(5 * time.Minute)
@@ -411,6 +412,7 @@ func (oc *Controller) syncPeriodic() {
 			select {
 			case <-nodeSyncTicker.C:
 				oc.syncNodesPeriodic()
+				oc.checkAddressSetDrift()
 			case <-oc.stopChan:
 				return
 			}
@@ -1021,6 +1023,10 @@ func (oc *Controller) WatchNodes() {
 					return
 				}
 				addNodeFailed.Delete(node.Name)
+			} else if nodeSubnetChanged(oldNode, node) {
+				if err = oc.expandNodeSubnets(node); err != nil {
+					klog.Errorf("NodeUpdate: %v", err)
+				}
 			}
 
 			_, failed = nodeClusterRouterPortFailed.Load(node.Name)
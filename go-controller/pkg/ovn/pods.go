@@ -3,6 +3,8 @@ package ovn
 import (
 	"fmt"
 	"net"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/ipallocator"
+	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	util "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	kapi "k8s.io/api/core/v1"
 	ktypes "k8s.io/apimachinery/pkg/types"
@@ -19,14 +22,77 @@ import (
 	utilnet "k8s.io/utils/net"
 )
 
+// podRequestedChassisAnnotation lists, comma-separated, the chassis a pod may
+// additionally be bound to while it is live-migrated away from its node.
+const podRequestedChassisAnnotation = "k8s.ovn.org/requested-chassis"
+
+// podDisablePortSecurityAnnotation set to "true" removes the port security of
+// the pod's port, for pods that send or receive traffic of addresses other
+// than their own, e.g. virtual routers or VRRP instances.
+const podDisablePortSecurityAnnotation = "k8s.ovn.org/disable-port-security"
+
+// lspAddressUnknown is the logical switch port address that has the port also
+// receive the traffic to addresses no port of the switch owns
+const lspAddressUnknown = "unknown"
+
 // Builds the logical switch port name for a given pod.
 func podLogicalPortName(pod *kapi.Pod) string {
 	return pod.Namespace + "_" + pod.Name
 }
 
+// podRequestedChassis returns the ordered list of chassis the pod's port may be
+// bound to. The pod's node always comes first, followed by any chassis given in
+// the podRequestedChassisAnnotation, if the cluster admin enabled pod network
+// overrides.
+func podRequestedChassis(pod *kapi.Pod) []string {
+	chassis := []string{pod.Spec.NodeName}
+	value, ok := pod.Annotations[podRequestedChassisAnnotation]
+	if !ok {
+		return chassis
+	}
+	if !config.OVNKubernetesFeature.EnablePodNetworkOverrides {
+		klog.Warningf("Ignoring %s annotation of pod %s/%s, pod network overrides are disabled",
+			podRequestedChassisAnnotation, pod.Namespace, pod.Name)
+		return chassis
+	}
+	for _, c := range goovn.ParseRequestedChassis(value) {
+		if c != pod.Spec.NodeName {
+			chassis = append(chassis, c)
+		}
+	}
+	return chassis
+}
+
+// podPortSecurityDisabled returns true if the pod is annotated to have the port
+// security of its port disabled. Only the value "true" disables it; any other
+// value is ignored so that a typo never silently opens up a pod. Pods can set
+// their own annotations, so the annotation is ignored unless the cluster admin
+// enabled pod network overrides.
+func podPortSecurityDisabled(pod *kapi.Pod) bool {
+	value, ok := pod.Annotations[podDisablePortSecurityAnnotation]
+	if !ok {
+		return false
+	}
+	if !config.OVNKubernetesFeature.EnablePodNetworkOverrides {
+		klog.Warningf("Ignoring %s annotation of pod %s/%s, pod network overrides are disabled",
+			podDisablePortSecurityAnnotation, pod.Namespace, pod.Name)
+		return false
+	}
+	if value != "true" {
+		klog.Warningf("Ignoring invalid %s annotation %q of pod %s/%s, port security stays enabled",
+			podDisablePortSecurityAnnotation, value, pod.Namespace, pod.Name)
+		return false
+	}
+	return true
+}
+
 func (oc *Controller) syncPods(pods []interface{}) {
 	// get the list of logical switch ports (equivalent to pods)
 	expectedLogicalPorts := make(map[string]bool)
+	// the part of the port configuration that follows from the pod annotation
+	desiredPorts := make(map[string]goovn.LSPSpec)
+	// the IPs of the annotated pods, keyed by node
+	expectedIPs := make(map[string][]net.IP)
 	for _, podInterface := range pods {
 		pod, ok := podInterface.(*kapi.Pod)
 		if !ok {
@@ -37,6 +103,10 @@ func (oc *Controller) syncPods(pods []interface{}) {
 		if util.PodScheduled(pod) && util.PodWantsNetwork(pod) && err == nil {
 			logicalPort := podLogicalPortName(pod)
 			expectedLogicalPorts[logicalPort] = true
+			desiredPorts[logicalPort] = podAnnotationLSPSpec(pod, annotations)
+			for _, ip := range annotations.IPs {
+				expectedIPs[pod.Spec.NodeName] = append(expectedIPs[pod.Spec.NodeName], ip.IP)
+			}
 			if err = oc.lsManager.AllocateIPs(pod.Spec.NodeName, annotations.IPs); err != nil {
 				klog.Errorf("Couldn't allocate IPs: %s for pod: %s on node: %s"+
 					" error: %v", util.JoinIPNetIPs(annotations.IPs, " "), logicalPort,
@@ -46,6 +116,10 @@ func (oc *Controller) syncPods(pods []interface{}) {
 	}
 
 	existingLogicalPorts := make([]string, 0)
+	var expectedPodPorts []*goovn.LogicalSwitchPort
+	// the IPs of the ports that don't belong to pods, e.g. the management
+	// port, keyed by the node whose switch was listed
+	reservedIPs := make(map[string][]net.IP)
 	// get the list of logical ports from OVN
 	nodes, err := oc.watchFactory.GetNodes()
 	if err != nil {
@@ -58,11 +132,23 @@ func (oc *Controller) syncPods(pods []interface{}) {
 			klog.Errorf("Failed to list lsp for switch %s: error %v", n.Name, err)
 			continue
 		}
+		var podPorts []*goovn.LogicalSwitchPort
+		reservedIPs[n.Name] = []net.IP{}
 		for _, port := range nodeSwitchPorts {
 			if port.ExternalID["pod"] == "true" {
 				existingLogicalPorts = append(existingLogicalPorts, port.Name)
+				if expectedLogicalPorts[port.Name] {
+					podPorts = append(podPorts, port)
+				}
+				continue
+			}
+			// router ports have no IPs of the switch subnet
+			if _, ips, err := util.ParsePortAddresses(port); err == nil {
+				reservedIPs[n.Name] = append(reservedIPs[n.Name], ips...)
 			}
 		}
+		oc.checkDuplicateAllocations(n.Name, podPorts)
+		expectedPodPorts = append(expectedPodPorts, podPorts...)
 	}
 
 	for _, existingPort := range existingLogicalPorts {
@@ -81,6 +167,245 @@ func (oc *Controller) syncPods(pods []interface{}) {
 			}
 		}
 	}
+
+	// repair the ports whose configuration drifted from their pod, e.g.
+	// after a partial write; the ports that must be recreated are deleted
+	// and added back when the pod is added
+	update, recreate := lspDrift(desiredPorts, expectedPodPorts)
+	podPortsByName := make(map[string]*goovn.LogicalSwitchPort, len(expectedPodPorts))
+	for _, port := range expectedPodPorts {
+		podPortsByName[port.Name] = port
+	}
+	for _, port := range update {
+		klog.Infof("Logical port %s drifted from its pod configuration, updating it", port)
+		spec := desiredPorts[port]
+		spec.ExternalIDs = mergeExternalIDs(podPortsByName[port].ExternalID, spec.ExternalIDs)
+		cmd, err := oc.ovnNBClient.LSPSet(port, spec)
+		if err == nil {
+			err = oc.ovnNBClient.Execute(cmd)
+		}
+		if err != nil {
+			klog.Errorf("Error updating drifted logical port %s: %v", port, err)
+		}
+	}
+	for _, port := range recreate {
+		klog.Infof("Logical port %s drifted from its pod configuration, recreating it", port)
+		cmd, err := oc.ovnNBClient.LSPDel(port)
+		if err == nil {
+			err = oc.ovnNBClient.Execute(cmd)
+		}
+		if err != nil {
+			klog.Errorf("Error deleting drifted logical port %s: %v", port, err)
+		}
+	}
+
+	// release the IPs still allocated to pods deleted while the master was
+	// down, as no pod delete event will come to release them. The nodes whose
+	// switch could not be listed are skipped, as the IPs of their other ports
+	// are unknown.
+	for _, nodeName := range oc.lsManager.ListHostSubnetSwitches() {
+		nodeReservedIPs, ok := reservedIPs[nodeName]
+		if !ok {
+			continue
+		}
+		released, err := oc.lsManager.ReleaseUnexpectedIPs(nodeName, expectedIPs[nodeName], nodeReservedIPs)
+		if err != nil {
+			klog.Errorf("Error releasing stale IP allocations on node %s: %v", nodeName, err)
+		}
+		if len(released) > 0 {
+			klog.Infof("Released stale IP allocations on node %s: %s", nodeName, util.JoinIPs(released, " "))
+		}
+	}
+}
+
+// podAnnotationLSPSpec returns the type, addresses, port security and external
+// IDs addLogicalPort writes to the logical switch port of the annotated pod.
+// Pod ports are VIF ports, whose type is empty.
+func podAnnotationLSPSpec(pod *kapi.Pod, annotation *util.PodAnnotation) goovn.LSPSpec {
+	addresses := []string{annotation.MAC.String()}
+	for _, ip := range annotation.IPs {
+		addresses = append(addresses, ip.IP.String())
+	}
+	vifType := ""
+	spec := goovn.LSPSpec{
+		Type:         &vifType,
+		Addresses:    []string{strings.Join(addresses, " ")},
+		PortSecurity: util.ComputePortSecurity(annotation.MAC, annotation.IPs),
+		ExternalIDs:  map[string]string{"namespace": pod.Namespace, "pod": "true"},
+	}
+	if podPortSecurityDisabled(pod) {
+		spec.Addresses = append(spec.Addresses, lspAddressUnknown)
+		spec.PortSecurity = []string{}
+	}
+	return spec
+}
+
+// lspDrift compares the existing logical switch ports with their desired
+// configuration, keyed by port name. Only the fields set in a desired spec are
+// compared, and of the external IDs only the keys of the spec, as other
+// components may add their own. It returns the sorted names of the ports
+// whose configuration can be updated in place, and of the ports whose type
+// changed and that must be recreated. Desired ports that don't exist are left
+// to be created.
+func lspDrift(desired map[string]goovn.LSPSpec, existing []*goovn.LogicalSwitchPort) (update, recreate []string) {
+	for _, lsp := range existing {
+		spec, ok := desired[lsp.Name]
+		if !ok {
+			continue
+		}
+		switch {
+		case spec.Type != nil && *spec.Type != lsp.Type:
+			recreate = append(recreate, lsp.Name)
+		case spec.Addresses != nil && !reflect.DeepEqual(spec.Addresses, lsp.Addresses),
+			spec.PortSecurity != nil && (len(spec.PortSecurity) > 0 || len(lsp.PortSecurity) > 0) &&
+				!reflect.DeepEqual(spec.PortSecurity, lsp.PortSecurity),
+			spec.Options != nil && !stringMapEqual(spec.Options, lsp.Options),
+			!stringMapHas(lsp.ExternalID, spec.ExternalIDs):
+			update = append(update, lsp.Name)
+		}
+	}
+	sort.Strings(update)
+	sort.Strings(recreate)
+	return update, recreate
+}
+
+// stringMapEqual returns true if the OVSDB map m holds exactly the keys and
+// values of want
+func stringMapEqual(want map[string]string, m map[interface{}]interface{}) bool {
+	if len(want) != len(m) {
+		return false
+	}
+	for k, v := range want {
+		if m[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// stringMapHas returns true if the OVSDB map m holds all the keys and values
+// of want
+func stringMapHas(m map[interface{}]interface{}, want map[string]string) bool {
+	for k, v := range want {
+		if m[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeExternalIDs returns the external IDs of an existing port with the given
+// keys set, so that updating a port keeps the keys of other components
+func mergeExternalIDs(existing map[interface{}]interface{}, set map[string]string) map[string]string {
+	merged := make(map[string]string, len(existing)+len(set))
+	for k, v := range existing {
+		if ks, ok := k.(string); ok {
+			if vs, ok := v.(string); ok {
+				merged[ks] = vs
+			}
+		}
+	}
+	for k, v := range set {
+		merged[k] = v
+	}
+	return merged
+}
+
+// OrphanLogicalPorts returns the logical switch ports that belong neither to a
+// pod nor to a node's management or hybrid overlay port. Unlike syncPods it
+// does not rely on the external_ids of the ports, so ports left behind by a
+// partial write are found as well. It is meant for an explicit,
+// admin-triggered cleanup and doesn't delete anything itself.
+func (oc *Controller) OrphanLogicalPorts() ([]*goovn.LogicalSwitchPort, error) {
+	validNames := make(map[string]bool)
+	pods, err := oc.watchFactory.GetAllPods()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods: %v", err)
+	}
+	for _, pod := range pods {
+		if util.PodScheduled(pod) && util.PodWantsNetwork(pod) {
+			validNames[podLogicalPortName(pod)] = true
+		}
+	}
+	nodes, err := oc.watchFactory.GetNodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nodes: %v", err)
+	}
+	for _, node := range nodes {
+		validNames[ovntypes.K8sPrefix+node.Name] = true
+		validNames[util.GetHybridOverlayPortName(node.Name)] = true
+	}
+	return oc.ovnNBClient.OrphanLSPList(validNames)
+}
+
+// ListPodPortsWithIPs returns the IPs of the pod logical switch ports of all
+// node switches, by port name, as recorded in OVN. Together with the IPAM state
+// and the pod annotations it lets a reconciler find where they diverged.
+func (oc *Controller) ListPodPortsWithIPs() (map[string][]net.IP, error) {
+	nodes, err := oc.watchFactory.GetNodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nodes: %v", err)
+	}
+	nodeNames := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		nodeNames = append(nodeNames, node.Name)
+	}
+	return podPortsWithIPs(oc.ovnNBClient, nodeNames)
+}
+
+// podPortsWithIPs returns the IPs of the pod ports of the switches of the
+// given nodes. Switches not created yet and ports whose addresses cannot be
+// parsed are skipped.
+func podPortsWithIPs(nbClient goovn.Client, nodeNames []string) (map[string][]net.IP, error) {
+	portIPs := make(map[string][]net.IP)
+	for _, nodeName := range nodeNames {
+		nodeSwitchPorts, err := nbClient.LSPList(nodeName)
+		if err == goovn.ErrorNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list logical switch ports of node %s: %v", nodeName, err)
+		}
+		for _, port := range nodeSwitchPorts {
+			if port.ExternalID["pod"] != "true" {
+				continue
+			}
+			_, ips, err := util.ParsePortAddresses(port)
+			if err != nil {
+				klog.Warningf("Unable to parse the addresses of logical port %s: %v", port.Name, err)
+				continue
+			}
+			portIPs[port.Name] = ips
+		}
+	}
+	return portIPs, nil
+}
+
+// checkDuplicateAllocations flags pod ports on a node whose IPs collide with
+// each other or with the node's IPAM state, e.g. after a master crash in the
+// middle of an allocation. It only reports; repairs are left to the operator.
+func (oc *Controller) checkDuplicateAllocations(nodeName string, podPorts []*goovn.LogicalSwitchPort) {
+	if oc.lsManager.IsNonHostSubnetSwitch(nodeName) {
+		return
+	}
+	dups, err := oc.lsManager.FindDuplicateAllocations(nodeName, podPorts)
+	if err != nil {
+		klog.Warningf("Unable to check for duplicate IP allocations on node %s: %v", nodeName, err)
+		return
+	}
+	for _, dup := range dups {
+		switch {
+		case len(dup.Ports) > 1:
+			klog.Errorf("IP %s on node %s is assigned to multiple logical ports: %s",
+				dup.IP, nodeName, strings.Join(dup.Ports, ", "))
+		case dup.Reserved:
+			klog.Errorf("IP %s on node %s is reserved for the node but assigned to logical port %s",
+				dup.IP, nodeName, dup.Ports[0])
+		case dup.Unallocated:
+			klog.Errorf("IP %s of logical port %s on node %s is not allocated in IPAM and may be handed out again",
+				dup.IP, dup.Ports[0], nodeName)
+		}
+	}
 }
 
 func (oc *Controller) deleteLogicalPort(pod *kapi.Pod) {
@@ -174,14 +499,54 @@ func (oc *Controller) waitForNodeLogicalSwitch(nodeName string) (string, error)
 	return uuid, nil
 }
 
+// podDefaultRoutes records the IP families of a pod, and which of them
+// addRoutesGatewayIP gave a default route, through the OVN gateway or through
+// another network attachment requesting it
+type podDefaultRoutes struct {
+	hasIPv4        bool
+	hasIPv6        bool
+	defaultRouteV4 bool
+	defaultRouteV6 bool
+}
+
+// missingFamilies returns the names of the families of the pod IPs without a
+// default route
+func (r podDefaultRoutes) missingFamilies() []string {
+	missing := []string{}
+	if r.hasIPv4 && !r.defaultRouteV4 {
+		missing = append(missing, util.IPFamilyName(false))
+	}
+	if r.hasIPv6 && !r.defaultRouteV6 {
+		missing = append(missing, util.IPFamilyName(true))
+	}
+	return missing
+}
+
+// partial tells whether a dual-stack pod has a default route in a single family
+func (r podDefaultRoutes) partial() bool {
+	return r.hasIPv4 && r.hasIPv6 && r.defaultRouteV4 != r.defaultRouteV6
+}
+
+// reportPodDefaultRoutes records a dual-stack pod left with a default route in
+// a single family, which otherwise looks healthy
+func reportPodDefaultRoutes(pod *kapi.Pod, routes podDefaultRoutes) {
+	if !routes.partial() {
+		return
+	}
+	missingFamily := routes.missingFamilies()[0]
+	klog.Warningf("Dual-stack pod %s/%s has no %s default route", pod.Namespace, pod.Name, missingFamily)
+	metrics.RecordPodPartialDefaultRoutes(missingFamily)
+}
+
 func (oc *Controller) addRoutesGatewayIP(pod *kapi.Pod, podAnnotation *util.PodAnnotation, nodeSubnets []*net.IPNet,
-	routingExternalGWs *gatewayInfo, routingPodGWs map[string]*gatewayInfo, hybridOverlayExternalGW net.IP) error {
+	routingExternalGWs *gatewayInfo, routingPodGWs map[string]*gatewayInfo, hybridOverlayExternalGW net.IP) (podDefaultRoutes, error) {
+	routes := podDefaultRoutes{}
 	// if there are other network attachments for the pod, then check if those network-attachment's
 	// annotation has default-route key. If present, then we need to skip adding default route for
 	// OVN interface
 	networks, err := util.GetPodNetSelAnnotation(pod, util.NetworkAttachmentAnnotation)
 	if err != nil {
-		return fmt.Errorf("error while getting network attachment definition for [%s/%s]: %v",
+		return routes, fmt.Errorf("error while getting network attachment definition for [%s/%s]: %v",
 			pod.Namespace, pod.Name, err)
 	}
 	otherDefaultRouteV4 := false
@@ -198,9 +563,14 @@ func (oc *Controller) addRoutesGatewayIP(pod *kapi.Pod, podAnnotation *util.PodA
 
 	for _, podIfAddr := range podAnnotation.IPs {
 		isIPv6 := utilnet.IsIPv6CIDR(podIfAddr)
+		if isIPv6 {
+			routes.hasIPv6 = true
+		} else {
+			routes.hasIPv4 = true
+		}
 		nodeSubnet, err := util.MatchIPNetFamily(isIPv6, nodeSubnets)
 		if err != nil {
-			return err
+			return routes, err
 		}
 		// DUALSTACK FIXME: hybridOverlayExternalGW is not Dualstack
 		// When oc.getHybridOverlayExternalGwAnnotation() supports dualstack, return error if no match.
@@ -260,6 +630,68 @@ func (oc *Controller) addRoutesGatewayIP(pod *kapi.Pod, podAnnotation *util.PodA
 		if gatewayIP != nil {
 			podAnnotation.Gateways = append(podAnnotation.Gateways, gatewayIP)
 		}
+		if gatewayIP != nil || otherDefaultRoute {
+			if isIPv6 {
+				routes.defaultRouteV6 = true
+			} else {
+				routes.defaultRouteV4 = true
+			}
+		}
+	}
+	return routes, nil
+}
+
+// RefreshPodRoutes recomputes the gateways and routes of the network annotation
+// of an already annotated pod from the current configuration and namespace
+// gateways, e.g. after a service CIDR was added, so that the pod doesn't have
+// to be recreated. The annotation is only updated when they changed.
+func (oc *Controller) RefreshPodRoutes(pod *kapi.Pod) error {
+	annotation, err := util.UnmarshalPodAnnotation(pod.Annotations)
+	if err != nil {
+		return fmt.Errorf("failed to get the network annotation of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	}
+	nodeSubnets, _ := oc.lsManager.GetSwitchSubnetsAndUUID(pod.Spec.NodeName)
+	if nodeSubnets == nil {
+		return fmt.Errorf("cannot retrieve subnet for assigning gateway routes for pod %s/%s, node: %s",
+			pod.Namespace, pod.Name, pod.Spec.NodeName)
+	}
+
+	nsInfo, nsUnlock := oc.getNamespaceLocked(pod.Namespace, true)
+	if nsInfo == nil {
+		return fmt.Errorf("namespace %s of pod %s not found", pod.Namespace, pod.Name)
+	}
+	routingExternalGWs := oc.getRoutingExternalGWs(nsInfo)
+	routingPodGWs := oc.getRoutingPodGWs(nsInfo)
+	hybridOverlayExternalGW := nsInfo.hybridOverlayExternalGW
+	nsUnlock()
+
+	refreshed := util.PodAnnotation{
+		IPs: annotation.IPs,
+		MAC: annotation.MAC,
+	}
+	routes, err := oc.addRoutesGatewayIP(pod, &refreshed, nodeSubnets, routingExternalGWs, routingPodGWs, hybridOverlayExternalGW)
+	if err != nil {
+		return err
+	}
+	reportPodDefaultRoutes(pod, routes)
+	// compare what would be written, the annotation on the pod may have been
+	// formatted differently
+	current, err := util.MarshalPodAnnotation(annotation)
+	if err != nil {
+		return fmt.Errorf("error creating pod network annotation: %v", err)
+	}
+	marshalledAnnotation, err := util.MarshalPodAnnotation(&refreshed)
+	if err != nil {
+		return fmt.Errorf("error creating pod network annotation: %v", err)
+	}
+	if reflect.DeepEqual(current, marshalledAnnotation) {
+		return nil
+	}
+
+	klog.Infof("Updating the gateways and routes of pod %s/%s to %v, %v",
+		pod.Namespace, pod.Name, refreshed.Gateways, refreshed.Routes)
+	if err := oc.kube.SetAnnotationsOnPod(pod.Namespace, pod.Name, marshalledAnnotation); err != nil {
+		return fmt.Errorf("failed to set annotation on pod %s/%s: %v", pod.Namespace, pod.Name, err)
 	}
 	return nil
 }
@@ -291,10 +723,9 @@ func (oc *Controller) addLogicalPort(pod *kapi.Pod) (err error) {
 	var podMac net.HardwareAddr
 	var podIfAddrs []*net.IPNet
 	var cmds []*goovn.OvnCommand
-	var addresses []string
+	var snatCmds []*goovn.OvnCommand
 	var cmd *goovn.OvnCommand
-	var podCmd *goovn.OvnCommand
-	var releaseIPs bool
+	var lspSpec goovn.LSPSpec
 
 	opts := make(map[string]string)
 
@@ -321,14 +752,16 @@ func (oc *Controller) addLogicalPort(pod *kapi.Pod) (err error) {
 	// Bind the port to the node's chassis; prevents ping-ponging between
 	// chassis if ovnkube-node isn't running correctly and hasn't cleared
 	// out iface-id for an old instance of this pod, and the pod got
-	// rescheduled.
-	opts["requested-chassis"] = pod.Spec.NodeName
+	// rescheduled. Pods that may move between nodes get the whole ordered
+	// list written after the rest of the port configuration.
+	requestedChassis := podRequestedChassis(pod)
+	if len(requestedChassis) == 1 {
+		opts[util.OVNOptionRequestedChassis] = pod.Spec.NodeName
+	} else {
+		delete(opts, util.OVNOptionRequestedChassis)
+	}
 
 	if lsp == nil {
-		podCmd, err = oc.ovnNBClient.LSPAdd(logicalSwitch, lsUUID, portName)
-		if err != nil {
-			return fmt.Errorf("unable to create the LSPAdd command for port: %s from the nbdb: %v", portName, err)
-		}
 		// Unique identifier to distinguish interfaces for recreated pods, also set by ovnkube-node
 		// ovn-controller will claim the OVS interface only if external_ids:iface-id
 		// matches with the Port_Binding.logical_port and external_ids:iface-id-ver matches
@@ -341,34 +774,21 @@ func (oc *Controller) addLogicalPort(pod *kapi.Pod) (err error) {
 	} else {
 		klog.Infof("LSP already exists for port: %s", portName)
 	}
-
-	cmd, err = oc.ovnNBClient.LSPSetOptions(portName, opts)
-	if err != nil {
-		return fmt.Errorf("unable to create the LSPSetOptions command for port: %s from the nbdb: %v", portName, err)
-	}
-	if podCmd != nil {
-		podCmd.Operations[0].Row["options"] = cmd.Operations[0].Row["options"]
-	} else {
-		podCmd = cmd
-	}
-	cmds = append(cmds, podCmd)
+	lspSpec.Options = opts
 
 	// the IPs we allocate in this function need to be released back to the
 	// IPAM pool if there is some error in any step of addLogicalPort past
 	// the point the IPs were assigned via the IPAM manager.
-	// this needs to be done only when releaseIPs is set to true (the case where
+	// this needs to be done only when releaseIPs is set (the case where
 	// we truly have assigned podIPs in this call) AND when there is no error in
 	// the rest of the functionality of addLogicalPort. It is important to use a
 	// named return variable for defer to work correctly.
-
+	// The per-pod SNAT is committed in the same transaction as the port, so
+	// there is never a SNAT to remove for a port that failed to be created.
+	var releaseIPs func()
 	defer func() {
-		if releaseIPs && err != nil {
-			if relErr := oc.lsManager.ReleaseIPs(logicalSwitch, podIfAddrs); relErr != nil {
-				klog.Errorf("Error when releasing IPs for node: %s, err: %q",
-					logicalSwitch, relErr)
-			} else {
-				klog.Infof("Released IPs: %s for node: %s", util.JoinIPNetIPs(podIfAddrs, " "), logicalSwitch)
-			}
+		if releaseIPs != nil && err != nil {
+			releaseIPs()
 			if addrSetCmds, nsErr := oc.deletePodFromNamespace(pod.Namespace, portName, "", podIfAddrs); nsErr != nil {
 				klog.Errorf("Error when deleting pod: %s from namespace: %v", pod.Name, err)
 			} else {
@@ -379,54 +799,99 @@ func (oc *Controller) addLogicalPort(pod *kapi.Pod) (err error) {
 		}
 	}()
 
+	// add external ids
+	lspSpec.ExternalIDs = map[string]string{"namespace": pod.Namespace, "pod": "true"}
+	port := &podPort{
+		oc:                  oc,
+		name:                portName,
+		lsUUID:              lsUUID,
+		existing:            lsp,
+		spec:                lspSpec,
+		disablePortSecurity: podPortSecurityDisabled(pod),
+	}
+	var portCmd *goovn.OvnCommand
+
 	needsIP := true
 	annotation, err := util.UnmarshalPodAnnotation(pod.Annotations)
 	if err == nil {
-		podMac = annotation.MAC
-		podIfAddrs = annotation.IPs
-
 		// If the pod already has annotations use the existing static
 		// IP/MAC from the annotation.
-		podCmd.Operations[0].Row["dynamic_addresses"] = ""
+		noDynamicAddresses := ""
+		port.spec.DynamicAddresses = &noDynamicAddresses
+		port.mac = annotation.MAC
 
-		// ensure we have reserved the IPs in the annotation
-		if err = oc.lsManager.AllocateIPs(logicalSwitch, podIfAddrs); err != nil && err != ipallocator.ErrAllocated {
+		// ensure we have reserved the IPs in the annotation, they stay
+		// reserved on error as the annotation keeps them
+		podMac, podIfAddrs, portCmd, _, err = port.AllocateAndBuildPort(logicalSwitch, annotation.IPs)
+		if err != nil {
 			return fmt.Errorf("unable to ensure IPs allocated for already annotated pod: %s, IPs: %s, error: %v",
-				pod.Name, util.JoinIPNetIPs(podIfAddrs, " "), err)
-		} else {
-			needsIP = false
+				pod.Name, util.JoinIPNetIPs(annotation.IPs, " "), err)
 		}
+		needsIP = false
 	}
 
 	if needsIP {
+		var networks []*types.NetworkSelectionElement
+
+		networks, err = util.GetPodNetSelAnnotation(pod, util.DefNetworkAnnotation)
+		// handle error cases separately first to ensure binding to err, otherwise the
+		// defer will fail
+		if err != nil {
+			return fmt.Errorf("error while getting custom MAC config for port %q from "+
+				"default-network's network-attachment: %v", portName, err)
+		} else if networks != nil && len(networks) != 1 {
+			err = fmt.Errorf("invalid network annotation size while getting custom MAC config"+
+				" for port %q", portName)
+			return err
+		}
+
+		var macRequest net.HardwareAddr
+		if networks != nil && networks[0].MacRequest != "" {
+			klog.V(5).Infof("Pod %s/%s requested custom MAC: %s", pod.Namespace, pod.Name, networks[0].MacRequest)
+			macRequest, err = net.ParseMAC(networks[0].MacRequest)
+			if err != nil {
+				return fmt.Errorf("failed to parse mac %s requested in annotation for pod %s: Error %v",
+					networks[0].MacRequest, pod.Name, err)
+			}
+		}
+
 		// try to get the IP from existing port in OVN first
+		var portMac net.HardwareAddr
+		var portIPs []*net.IPNet
 		if lsp != nil {
-			podMac, podIfAddrs, err = oc.getPortAddresses(logicalSwitch, lsp)
+			portMac, portIPs, err = oc.getPortAddresses(logicalSwitch, lsp)
 			if err != nil {
 				return fmt.Errorf("failed to get pod addresses for pod %s on node: %s, err: %v",
 					portName, logicalSwitch, err)
 			}
 		}
-		needsNewAllocation := false
-		// ensure we have reserved the IPs found in OVN
-		if len(podIfAddrs) == 0 {
-			needsNewAllocation = true
-		} else if err = oc.lsManager.AllocateIPs(logicalSwitch, podIfAddrs); err != nil && err != ipallocator.ErrAllocated {
-			klog.Warningf("Unable to allocate IPs found on existing OVN port: %s, for pod %s on node: %s"+
-				" error: %v", util.JoinIPNetIPs(podIfAddrs, " "), portName, logicalSwitch, err)
-
-			needsNewAllocation = true
+		if len(portIPs) > 0 {
+			port.mac = portMac
+			if macRequest != nil {
+				port.mac = macRequest
+			}
+			podMac, podIfAddrs, portCmd, releaseIPs, err = port.AllocateAndBuildPort(logicalSwitch, portIPs)
+			if err != nil {
+				if releaseIPs != nil {
+					releaseIPs()
+				}
+				klog.Warningf("Unable to reuse the IPs found on existing OVN port: %s, for pod %s on node: %s"+
+					" error: %v", util.JoinIPNetIPs(portIPs, " "), portName, logicalSwitch, err)
+			}
 		}
-		if needsNewAllocation {
+		if len(portIPs) == 0 || err != nil {
 			// Previous attempts to use already configured IPs failed, need to assign new
-			podMac, podIfAddrs, err = oc.assignPodAddresses(logicalSwitch)
+			port.mac = macRequest
+			podMac, podIfAddrs, portCmd, releaseIPs, err = port.AllocateAndBuildPort(logicalSwitch, nil)
 			if err != nil {
+				if releaseIPs != nil {
+					releaseIPs()
+					releaseIPs = nil
+				}
 				return fmt.Errorf("failed to assign pod addresses for pod %s on node: %s, err: %v",
 					portName, logicalSwitch, err)
 			}
 		}
-
-		releaseIPs = true
 	}
 
 	// Ensure the namespace/nsInfo exists
@@ -434,31 +899,8 @@ func (oc *Controller) addLogicalPort(pod *kapi.Pod) (err error) {
 	if err != nil {
 		return err
 	}
-	cmds = append(cmds, addrSetCmds...)
 
 	if needsIP {
-		var networks []*types.NetworkSelectionElement
-
-		networks, err = util.GetPodNetSelAnnotation(pod, util.DefNetworkAnnotation)
-		// handle error cases separately first to ensure binding to err, otherwise the
-		// defer will fail
-		if err != nil {
-			return fmt.Errorf("error while getting custom MAC config for port %q from "+
-				"default-network's network-attachment: %v", portName, err)
-		} else if networks != nil && len(networks) != 1 {
-			err = fmt.Errorf("invalid network annotation size while getting custom MAC config"+
-				" for port %q", portName)
-			return err
-		}
-
-		if networks != nil && networks[0].MacRequest != "" {
-			klog.V(5).Infof("Pod %s/%s requested custom MAC: %s", pod.Namespace, pod.Name, networks[0].MacRequest)
-			podMac, err = net.ParseMAC(networks[0].MacRequest)
-			if err != nil {
-				return fmt.Errorf("failed to parse mac %s requested in annotation for pod %s: Error %v",
-					networks[0].MacRequest, pod.Name, err)
-			}
-		}
 		podAnnotation := util.PodAnnotation{
 			IPs: podIfAddrs,
 			MAC: podMac,
@@ -468,10 +910,12 @@ func (oc *Controller) addLogicalPort(pod *kapi.Pod) (err error) {
 			return fmt.Errorf("cannot retrieve subnet for assigning gateway routes for pod %s, node: %s",
 				pod.Name, logicalSwitch)
 		}
-		err = oc.addRoutesGatewayIP(pod, &podAnnotation, nodeSubnets, routingExternalGWs, routingPodGWs, hybridOverlayExternalGW)
+		var routes podDefaultRoutes
+		routes, err = oc.addRoutesGatewayIP(pod, &podAnnotation, nodeSubnets, routingExternalGWs, routingPodGWs, hybridOverlayExternalGW)
 		if err != nil {
 			return err
 		}
+		reportPodDefaultRoutes(pod, routes)
 		var marshalledAnnotation map[string]string
 		marshalledAnnotation, err = util.MarshalPodAnnotation(&podAnnotation)
 		if err != nil {
@@ -486,7 +930,7 @@ func (oc *Controller) addLogicalPort(pod *kapi.Pod) (err error) {
 		if err != nil {
 			return fmt.Errorf("failed to set annotation on pod %s: %v", pod.Name, err)
 		}
-		releaseIPs = false
+		releaseIPs = nil
 	}
 
 	// if we have any external or pod Gateways, add routes
@@ -512,7 +956,7 @@ func (oc *Controller) addLogicalPort(pod *kapi.Pod) (err error) {
 	} else if config.Gateway.DisableSNATMultipleGWs {
 		// Add NAT rules to pods if disable SNAT is set and does not have
 		// namespace annotations to go through external egress router
-		if err = oc.addPerPodGRSNAT(pod, podIfAddrs); err != nil {
+		if snatCmds, err = oc.getPerPodGRSNATCmds(pod, podIfAddrs); err != nil {
 			return err
 		}
 	}
@@ -523,36 +967,18 @@ func (oc *Controller) addLogicalPort(pod *kapi.Pod) (err error) {
 		return fmt.Errorf("failed to handle external GW check: %v", err)
 	}
 
-	// set addresses on the port
-	addresses = make([]string, len(podIfAddrs)+1)
-	addresses[0] = podMac.String()
-	for idx, podIfAddr := range podIfAddrs {
-		addresses[idx+1] = podIfAddr.IP.String()
-	}
-
-	// LSP addresses in OVN are a single space-separated value
-	lspAddrs := strings.Join(addresses, " ")
-	cmd, err = oc.ovnNBClient.LSPSetAddress(portName, lspAddrs)
-	if err != nil {
-		return fmt.Errorf("unable to create LSPSetAddress command for port: %s", portName)
-	}
-	podCmd.Operations[0].Row["addresses"] = cmd.Operations[0].Row["addresses"]
-
-	// add external ids
-	extIds := map[string]string{"namespace": pod.Namespace, "pod": "true"}
-	cmd, err = oc.ovnNBClient.LSPSetExternalIds(portName, extIds)
-	if err != nil {
-		return fmt.Errorf("unable to create LSPSetExternalIds command for port: %s", portName)
-	}
-	podCmd.Operations[0].Row["external_ids"] = cmd.Operations[0].Row["external_ids"]
-
-	// CNI depends on the flows from port security, delay setting it until end
-	psAddrs := strings.Join(addresses, " ")
-	cmd, err = oc.ovnNBClient.LSPSetPortSecurity(portName, psAddrs)
-	if err != nil {
-		return fmt.Errorf("unable to create LSPSetPortSecurity command for port: %s", portName)
+	// the port command goes first, so that the insert of a new port is the
+	// first operation of the transaction returning a UUID
+	cmds = append(cmds, portCmd)
+	if len(requestedChassis) > 1 {
+		cmd, err = oc.ovnNBClient.LSPSetRequestedChassis(portName, requestedChassis)
+		if err != nil {
+			return fmt.Errorf("unable to create LSPSetRequestedChassis command for port: %s: %v", portName, err)
+		}
+		cmds = append(cmds, cmd)
 	}
-	podCmd.Operations[0].Row["port_security"] = cmd.Operations[0].Row["port_security"]
+	cmds = append(cmds, addrSetCmds...)
+	cmds = append(cmds, snatCmds...)
 
 	start1 := time.Now()
 	// execute all the commands together. If a single operation fails, all commands will roll back =>
@@ -566,8 +992,10 @@ func (oc *Controller) addLogicalPort(pod *kapi.Pod) (err error) {
 	}
 
 	if lsp == nil {
-		// Grab the LSP's UUID from the creation response
-		if len(r) != 1 {
+		// Grab the LSP's UUID from the creation response, where it may be
+		// followed by the UUIDs of the rows inserted by the address set and
+		// SNAT commands
+		if len(r) == 0 {
 			return fmt.Errorf("unexpected logical switch port %q create response length %v", portName, r)
 		}
 		lsp, err = oc.ovnNBClient.LSPGetUUID(r[0])
@@ -600,22 +1028,107 @@ func (oc *Controller) addLogicalPort(pod *kapi.Pod) (err error) {
 	return nil
 }
 
-// Given a node, gets the next set of addresses (from the IPAM) for each of the node's
-// subnets to assign to the new pod
-func (oc *Controller) assignPodAddresses(nodeName string) (net.HardwareAddr, []*net.IPNet, error) {
-	var (
-		podMAC   net.HardwareAddr
-		podCIDRs []*net.IPNet
-		err      error
-	)
-	podCIDRs, err = oc.lsManager.AllocateNextIPs(nodeName)
+// podPort is the logical switch port of a pod, the IPs of which
+// AllocateAndBuildPort reserves and the command of which it builds
+type podPort struct {
+	oc     *Controller
+	name   string
+	lsUUID string
+	// existing is the port read from the nbdb, nil for a new port
+	existing *goovn.LogicalSwitchPort
+	// mac is the MAC of the port, derived from its first IP when nil
+	mac net.HardwareAddr
+	// spec is the rest of the port configuration, its addresses and port
+	// security are set from the MAC and IPs
+	spec goovn.LSPSpec
+	// disablePortSecurity leaves the port without port security, see
+	// podDisablePortSecurityAnnotation
+	disablePortSecurity bool
+}
+
+// AllocateAndBuildPort reserves the requested IPs on the node's switch, or the
+// next free IPs of each of its subnets when none are requested, and builds the
+// command writing them with the rest of the configuration to the port.
+// Requested IPs found reserved already are used as they are. The returned
+// function releases the IPs, for the caller to undo the allocation when the
+// command isn't executed. It is returned with the error of building the
+// command as well, as only the caller knows whether the IPs must stay reserved.
+func (p *podPort) AllocateAndBuildPort(node string, requested []*net.IPNet) (net.HardwareAddr,
+	[]*net.IPNet, *goovn.OvnCommand, func(), error) {
+	oc := p.oc
+	ips := requested
+	if len(requested) > 0 {
+		if err := oc.lsManager.AllocateIPs(node, requested); err != nil && err != ipallocator.ErrAllocated {
+			return nil, nil, nil, nil, err
+		}
+	} else {
+		var err error
+		if ips, err = oc.lsManager.AllocateNextIPs(node); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+	release := func() {
+		if err := oc.lsManager.ReleaseIPs(node, ips); err != nil {
+			klog.Errorf("Error when releasing IPs for node: %s, err: %q", node, err)
+		} else {
+			klog.Infof("Released IPs: %s for node: %s", util.JoinIPNetIPs(ips, " "), node)
+		}
+	}
+
+	mac := p.mac
+	if mac == nil && len(ips) > 0 {
+		mac = util.IPAddrToHWAddr(ips[0].IP)
+	}
+	cmd, err := p.buildCmd(node, mac, ips)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, release, err
 	}
-	if len(podCIDRs) > 0 {
-		podMAC = util.IPAddrToHWAddr(podCIDRs[0].IP)
+	return mac, ips, cmd, release, nil
+}
+
+// buildCmd returns the command writing the whole configuration of the pod's
+// port with the insert of a new port, or with a single update of an existing
+// one
+func (p *podPort) buildCmd(node string, mac net.HardwareAddr, ips []*net.IPNet) (*goovn.OvnCommand, error) {
+	// set addresses on the port
+	addresses := make([]string, len(ips)+1)
+	addresses[0] = mac.String()
+	for idx, ip := range ips {
+		addresses[idx+1] = ip.IP.String()
+	}
+
+	// LSP addresses in OVN are a single space-separated value
+	lspAddrs := strings.Join(addresses, " ")
+	spec := p.spec
+	if p.disablePortSecurity {
+		// the port also gets the traffic to addresses unknown to OVN
+		spec.Addresses = []string{lspAddrs, lspAddressUnknown}
+		spec.PortSecurity = []string{}
+	} else {
+		spec.Addresses = []string{lspAddrs}
+		// CNI depends on the flows from port security, delay setting it until end
+		spec.PortSecurity = util.ComputePortSecurity(mac, ips)
+	}
+
+	lsp := p.existing
+	if lsp == nil {
+		cmd, err := p.oc.ovnNBClient.LSPAddFull(node, p.lsUUID, p.name, spec)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create the LSPAddFull command for port: %s from the nbdb: %v", p.name, err)
+		}
+		return cmd, nil
+	}
+	// an existing port whose pod IPs changed gets its addresses swapped in
+	// place by the update; the port must not be recreated as that changes
+	// its UUID
+	if len(lsp.Addresses) > 0 && (len(lsp.Addresses) != 1 || lsp.Addresses[0] != lspAddrs) {
+		klog.Infof("Updating addresses of existing port %s from %v to %q", p.name, lsp.Addresses, lspAddrs)
+	}
+	cmd, err := p.oc.ovnNBClient.LSPSet(p.name, spec)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create LSPSet command for port: %s: %v", p.name, err)
 	}
-	return podMAC, podCIDRs, nil
+	return cmd, nil
 }
 
 // Given a pod and the node on which it is scheduled, get all addresses currently assigned
@@ -8,11 +8,14 @@ import (
 	"strings"
 	"time"
 
+	goovn "github.com/ebay/go-ovn"
+
 	hotypes "github.com/ovn-org/ovn-kubernetes/go-controller/hybrid-overlay/pkg/types"
 
 	"github.com/urfave/cli/v2"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/ipallocator"
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
@@ -126,7 +129,7 @@ func (p pod) baseCmds(fexec *ovntest.FakeExec) {
 
 func (p pod) populateLogicalSwitchCache(fakeOvn *FakeOVN) {
 	gomega.Expect(p.nodeName).NotTo(gomega.Equal(""))
-	fakeOvn.controller.lsManager.AddNode(p.nodeName, []*net.IPNet{ovntest.MustParseIPNet(p.nodeSubnet)})
+	fakeOvn.controller.lsManager.AddNode(p.nodeName, "", []*net.IPNet{ovntest.MustParseIPNet(p.nodeSubnet)})
 }
 
 func (p pod) addCmds(fexec *ovntest.FakeExec, fail bool) {
@@ -444,6 +447,248 @@ var _ = ginkgo.Describe("OVN Pod Operations", func() {
 				return nil
 			}
 
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+		ginkgo.It("lists logical ports that belong to no pod or node as orphans", func() {
+			app.Action = func(ctx *cli.Context) error {
+				namespaceT := newNamespace("namespace1")
+				t := newTPod(
+					"node1",
+					"10.128.1.0/24",
+					"10.128.1.2",
+					"10.128.1.1",
+					"myPod",
+					"10.128.1.3",
+					"0a:58:0a:80:01:03",
+					namespaceT.Name,
+				)
+				fakeOvn.start(ctx,
+					&v1.NodeList{
+						Items: []v1.Node{
+							{ObjectMeta: newObjectMeta(t.nodeName, "")},
+						},
+					},
+					&v1.PodList{
+						Items: []v1.Pod{
+							*newPod(t.namespace, t.podName, t.nodeName, t.podIP),
+						},
+					},
+				)
+
+				routerType := "router"
+				for _, name := range []string{t.portName, "k8s-" + t.nodeName, "stor-" + t.nodeName, "namespace1_gone"} {
+					cmd, err := fakeOvn.ovnNBClient.LSPAdd(t.nodeName, "", name)
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					gomega.Expect(fakeOvn.ovnNBClient.Execute(cmd)).To(gomega.Succeed())
+				}
+				cmd, err := fakeOvn.ovnNBClient.LSPSet("stor-"+t.nodeName, goovn.LSPSpec{Type: &routerType})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(fakeOvn.ovnNBClient.Execute(cmd)).To(gomega.Succeed())
+
+				orphans, err := fakeOvn.controller.OrphanLogicalPorts()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(orphans).To(gomega.HaveLen(1))
+				gomega.Expect(orphans[0].Name).To(gomega.Equal("namespace1_gone"))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("refreshes the routes of an annotated pod when the configuration changes", func() {
+			app.Action = func(ctx *cli.Context) error {
+				namespaceT := *newNamespace("namespace1")
+				t := newTPod(
+					"node1",
+					"10.128.1.0/24",
+					"10.128.1.2",
+					"10.128.1.1",
+					"myPod",
+					"10.128.1.3",
+					"0a:58:0a:80:01:03",
+					namespaceT.Name,
+				)
+				pod := newPod(t.namespace, t.podName, t.nodeName, t.podIP)
+				podJSON := `{"default": {"ip_addresses":["` + t.podIP + `/24"], "mac_address":"` + t.podMAC + `", "gateway_ips": ["` + t.nodeGWIP + `"], "ip_address":"` + t.podIP + `/24", "gateway_ip": "` + t.nodeGWIP + `"}}`
+				pod.Annotations = map[string]string{util.OvnPodAnnotationName: podJSON}
+
+				fakeOvn.start(ctx,
+					&v1.NamespaceList{
+						Items: []v1.Namespace{
+							namespaceT,
+						},
+					},
+					&v1.PodList{
+						Items: []v1.Pod{*pod},
+					},
+				)
+				t.populateLogicalSwitchCache(fakeOvn)
+				fakeOvn.controller.WatchNamespaces()
+
+				// nothing changed
+				gomega.Expect(fakeOvn.controller.RefreshPodRoutes(pod)).To(gomega.Succeed())
+				gomega.Expect(getPodAnnotations(fakeOvn.fakeClient.KubeClient, t.namespace, t.podName)).Should(gomega.MatchJSON(podJSON))
+
+				config.HybridOverlay.ClusterSubnets = []config.CIDRNetworkEntry{
+					{CIDR: ovntest.MustParseIPNet("10.132.0.0/14"), HostSubnetLength: 24},
+				}
+				gomega.Expect(fakeOvn.controller.RefreshPodRoutes(pod)).To(gomega.Succeed())
+				gomega.Expect(getPodAnnotations(fakeOvn.fakeClient.KubeClient, t.namespace, t.podName)).Should(gomega.MatchJSON(
+					`{"default": {"ip_addresses":["` + t.podIP + `/24"], "mac_address":"` + t.podMAC + `", "gateway_ips": ["` + t.nodeGWIP + `"], "routes": [{"dest": "10.132.0.0/14", "nextHop": "10.128.1.3"}], "ip_address":"` + t.podIP + `/24", "gateway_ip": "` + t.nodeGWIP + `"}}`))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("releases the IPs of pods deleted while the master was down on sync", func() {
+			app.Action = func(ctx *cli.Context) error {
+
+				namespaceT := newNamespace("namespace1")
+				t := newTPod(
+					"node1",
+					"10.128.1.0/24",
+					"10.128.1.2",
+					"10.128.1.1",
+					"myPod",
+					"10.128.1.3",
+					"0a:58:0a:80:01:03",
+					namespaceT.Name,
+				)
+
+				fakeOvn.start(ctx,
+					&v1.NodeList{
+						Items: []v1.Node{
+							{ObjectMeta: newObjectMeta(t.nodeName, "")},
+						},
+					},
+				)
+				t.populateLogicalSwitchCache(fakeOvn)
+				// the IP of a pod that no longer exists
+				orphaned := ovntest.MustParseIPNets("10.128.1.9/24")
+				gomega.Expect(fakeOvn.controller.lsManager.AllocateIPs(t.nodeName, orphaned)).To(gomega.Succeed())
+				// the IP of a port of the node switch that doesn't belong to a pod
+				portIP := ovntest.MustParseIPNets("10.128.1.10/24")
+				gomega.Expect(fakeOvn.controller.lsManager.AllocateIPs(t.nodeName, portIP)).To(gomega.Succeed())
+				cmd, err := fakeOvn.ovnNBClient.LSAdd(t.nodeName)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(fakeOvn.ovnNBClient.Execute(cmd)).To(gomega.Succeed())
+				cmd, err = fakeOvn.ovnNBClient.LSPAdd(t.nodeName, "", "int-"+t.nodeName)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(fakeOvn.ovnNBClient.Execute(cmd)).To(gomega.Succeed())
+				cmd, err = fakeOvn.ovnNBClient.LSPSetAddress("int-"+t.nodeName, "0a:58:0a:80:01:0a 10.128.1.10")
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(fakeOvn.ovnNBClient.Execute(cmd)).To(gomega.Succeed())
+
+				fakeOvn.controller.WatchNamespaces()
+				fakeOvn.controller.WatchPods()
+
+				gomega.Expect(fakeOvn.controller.lsManager.AllocateIPs(t.nodeName, orphaned)).To(gomega.Succeed())
+				// the reserved IPs of the node and the IPs of its other ports are kept
+				gomega.Expect(fakeOvn.controller.lsManager.AllocateIPs(t.nodeName,
+					ovntest.MustParseIPNets(t.nodeMgtIP+"/24"))).To(gomega.Equal(ipallocator.ErrAllocated))
+				gomega.Expect(fakeOvn.controller.lsManager.AllocateIPs(t.nodeName, portIP)).To(gomega.Equal(ipallocator.ErrAllocated))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("binds a pod with a requested-chassis annotation to all of the requested chassis", func() {
+			app.Action = func(ctx *cli.Context) error {
+
+				namespaceT := newNamespace("namespace1")
+				t := newTPod(
+					"node1",
+					"10.128.1.0/24",
+					"10.128.1.2",
+					"10.128.1.1",
+					"myPod",
+					"10.128.1.3",
+					"0a:58:0a:80:01:03",
+					namespaceT.Name,
+				)
+				t.baseCmds(fExec)
+
+				fakeOvn.start(ctx)
+				t.populateLogicalSwitchCache(fakeOvn)
+				fakeOvn.controller.WatchNamespaces()
+				fakeOvn.controller.WatchPods()
+
+				pod := newPod(t.namespace, t.podName, t.nodeName, t.podIP)
+				pod.Annotations = map[string]string{podRequestedChassisAnnotation: "node2, node1"}
+				_, err := fakeOvn.fakeClient.KubeClient.CoreV1().Pods(t.namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				gomega.Eventually(func() (map[string]string, error) {
+					return fakeOvn.ovnNBClient.LSPGetOptions(t.namespace + "_" + t.podName)
+				}, 2).Should(gomega.HaveKeyWithValue(goovn.LSPOptionRequestedChassis, "node1,node2"))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name, "--enable-pod-network-overrides"})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("updates the addresses of an existing logical port in place when the pod IP changed", func() {
+			app.Action = func(ctx *cli.Context) error {
+
+				namespaceT := newNamespace("namespace1")
+				t := newTPod(
+					"node1",
+					"10.128.1.0/24",
+					"10.128.1.2",
+					"10.128.1.1",
+					"myPod",
+					"10.128.1.3",
+					"0a:58:0a:80:01:03",
+					namespaceT.Name,
+				)
+				t.baseCmds(fExec)
+
+				fakeOvn.start(ctx)
+				t.populateLogicalSwitchCache(fakeOvn)
+
+				oldAddrs := []string{t.podMAC + " 10.128.1.4"}
+				cmd, err := fakeOvn.ovnNBClient.LSPAdd(t.nodeName, "", t.portName)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(fakeOvn.ovnNBClient.Execute(cmd)).To(gomega.Succeed())
+				cmd, err = fakeOvn.ovnNBClient.LSPSet(t.portName, goovn.LSPSpec{Addresses: oldAddrs, PortSecurity: oldAddrs})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(fakeOvn.ovnNBClient.Execute(cmd)).To(gomega.Succeed())
+				lsp, err := fakeOvn.ovnNBClient.LSPGet(t.portName)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				uuid := lsp.UUID
+
+				fakeOvn.controller.WatchNamespaces()
+				fakeOvn.controller.WatchPods()
+
+				pod := newPod(t.namespace, t.podName, t.nodeName, t.podIP)
+				pod.Annotations = map[string]string{
+					util.OvnPodAnnotationName: `{"default": {"ip_addresses":["` + t.podIP + `/24"], "mac_address":"` + t.podMAC + `", "gateway_ips": ["` + t.nodeGWIP + `"], "ip_address":"` + t.podIP + `/24", "gateway_ip": "` + t.nodeGWIP + `"}}`,
+				}
+				_, err = fakeOvn.fakeClient.KubeClient.CoreV1().Pods(t.namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				newAddrs := []string{t.podMAC + " " + t.podIP}
+				gomega.Eventually(func() ([]string, error) {
+					lsp, err := fakeOvn.ovnNBClient.LSPGet(t.portName)
+					if err != nil {
+						return nil, err
+					}
+					return lsp.Addresses, nil
+				}, 2).Should(gomega.Equal(newAddrs))
+				lsp, err = fakeOvn.ovnNBClient.LSPGet(t.portName)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(lsp.UUID).To(gomega.Equal(uuid))
+				gomega.Expect(lsp.PortSecurity).To(gomega.Equal(newAddrs))
+				return nil
+			}
+
 			err := app.Run([]string{app.Name})
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
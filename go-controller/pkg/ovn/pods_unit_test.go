@@ -0,0 +1,497 @@
+package ovn
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	goovn "github.com/ebay/go-ovn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/ipallocator"
+	lsm "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/logical_switch_manager"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	goovn_mock "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/ebay/go-ovn"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLSPDrift(t *testing.T) {
+	routerType := "router"
+	podAddrs := []string{"0a:58:0a:80:00:05 10.128.0.5"}
+	podExtIDs := map[string]string{"namespace": "ns", "pod": "true"}
+	podPort := func(name string) *goovn.LogicalSwitchPort {
+		return &goovn.LogicalSwitchPort{
+			Name:         name,
+			Addresses:    podAddrs,
+			PortSecurity: podAddrs,
+			Options:      map[interface{}]interface{}{"requested-chassis": "node1"},
+			ExternalID:   map[interface{}]interface{}{"namespace": "ns", "pod": "true"},
+		}
+	}
+	vifType := ""
+	podSpec := goovn.LSPSpec{Type: &vifType, Addresses: podAddrs, PortSecurity: podAddrs, ExternalIDs: podExtIDs}
+
+	tests := []struct {
+		desc        string
+		desired     map[string]goovn.LSPSpec
+		existing    []*goovn.LogicalSwitchPort
+		expUpdate   []string
+		expRecreate []string
+	}{
+		{
+			desc:     "ports in sync",
+			desired:  map[string]goovn.LSPSpec{"ns_pod1": podSpec, "ns_pod2": podSpec},
+			existing: []*goovn.LogicalSwitchPort{podPort("ns_pod1"), podPort("ns_pod2")},
+		},
+		{
+			desc:    "drifted addresses, port security and external IDs are updated",
+			desired: map[string]goovn.LSPSpec{"ns_pod1": podSpec, "ns_pod2": podSpec, "ns_pod3": podSpec},
+			existing: func() []*goovn.LogicalSwitchPort {
+				pod1, pod2, pod3 := podPort("ns_pod1"), podPort("ns_pod2"), podPort("ns_pod3")
+				pod3.Addresses = []string{"0a:58:0a:80:00:06 10.128.0.6"}
+				pod1.PortSecurity = nil
+				pod2.ExternalID = map[interface{}]interface{}{"namespace": "ns"}
+				return []*goovn.LogicalSwitchPort{pod3, pod2, pod1}
+			}(),
+			expUpdate: []string{"ns_pod1", "ns_pod2", "ns_pod3"},
+		},
+		{
+			desc:    "drifted options are updated",
+			desired: map[string]goovn.LSPSpec{"ns_pod1": {Options: map[string]string{"requested-chassis": "node2"}}},
+			existing: []*goovn.LogicalSwitchPort{
+				podPort("ns_pod1"),
+			},
+			expUpdate: []string{"ns_pod1"},
+		},
+		{
+			desc:        "ports whose type changed are recreated",
+			desired:     map[string]goovn.LSPSpec{"ns_pod1": {Type: &routerType, Addresses: []string{"router"}}},
+			existing:    []*goovn.LogicalSwitchPort{podPort("ns_pod1")},
+			expRecreate: []string{"ns_pod1"},
+		},
+		{
+			desc:    "pod ports that are no longer VIF ports are recreated",
+			desired: map[string]goovn.LSPSpec{"ns_pod1": podSpec, "ns_pod2": podSpec},
+			existing: func() []*goovn.LogicalSwitchPort {
+				pod1 := podPort("ns_pod1")
+				pod1.Type = "localport"
+				return []*goovn.LogicalSwitchPort{pod1, podPort("ns_pod2")}
+			}(),
+			expRecreate: []string{"ns_pod1"},
+		},
+		{
+			desc:    "external IDs added by other components are ignored",
+			desired: map[string]goovn.LSPSpec{"ns_pod1": podSpec},
+			existing: func() []*goovn.LogicalSwitchPort {
+				pod1 := podPort("ns_pod1")
+				pod1.ExternalID["iface-id-ver"] = "1234"
+				return []*goovn.LogicalSwitchPort{pod1}
+			}(),
+		},
+		{
+			desc: "ports without port security are in sync",
+			desired: map[string]goovn.LSPSpec{"ns_pod1": {
+				Addresses:    append(podAddrs, "unknown"),
+				PortSecurity: []string{},
+			}},
+			existing: func() []*goovn.LogicalSwitchPort {
+				pod1 := podPort("ns_pod1")
+				pod1.Addresses = append(podAddrs, "unknown")
+				pod1.PortSecurity = nil
+				return []*goovn.LogicalSwitchPort{pod1}
+			}(),
+		},
+		{
+			desc:      "port security left on a port that must have none is removed",
+			desired:   map[string]goovn.LSPSpec{"ns_pod1": {PortSecurity: []string{}}},
+			existing:  []*goovn.LogicalSwitchPort{podPort("ns_pod1")},
+			expUpdate: []string{"ns_pod1"},
+		},
+		{
+			desc:     "unset spec fields, missing and undesired ports are ignored",
+			desired:  map[string]goovn.LSPSpec{"ns_pod1": {ExternalIDs: podExtIDs}, "ns_pod2": podSpec},
+			existing: []*goovn.LogicalSwitchPort{podPort("ns_pod1"), {Name: "ns_pod3"}},
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			update, recreate := lspDrift(tc.desired, tc.existing)
+			assert.Equal(t, tc.expUpdate, update)
+			assert.Equal(t, tc.expRecreate, recreate)
+		})
+	}
+}
+
+func TestPodAnnotationLSPSpec(t *testing.T) {
+	annotation := &util.PodAnnotation{
+		MAC: ovntest.MustParseMAC("0a:58:0a:80:00:05"),
+		IPs: []*net.IPNet{ovntest.MustParseIPNet("10.128.0.5/24")},
+	}
+	podAddrs := []string{"0a:58:0a:80:00:05 10.128.0.5"}
+
+	tests := []struct {
+		desc            string
+		overrides       bool
+		annotations     map[string]string
+		expAddresses    []string
+		expPortSecurity []string
+	}{
+		{
+			desc:            "port security follows the pod addresses",
+			overrides:       true,
+			expAddresses:    podAddrs,
+			expPortSecurity: podAddrs,
+		},
+		{
+			desc:            "annotated pods have no port security",
+			overrides:       true,
+			annotations:     map[string]string{podDisablePortSecurityAnnotation: "true"},
+			expAddresses:    append(podAddrs, "unknown"),
+			expPortSecurity: []string{},
+		},
+		{
+			desc:            "invalid annotation values are ignored",
+			overrides:       true,
+			annotations:     map[string]string{podDisablePortSecurityAnnotation: "yes"},
+			expAddresses:    podAddrs,
+			expPortSecurity: podAddrs,
+		},
+		{
+			desc:            "the annotation is ignored when pod network overrides are disabled",
+			annotations:     map[string]string{podDisablePortSecurityAnnotation: "true"},
+			expAddresses:    podAddrs,
+			expPortSecurity: podAddrs,
+		},
+	}
+	defer config.PrepareTestConfig()
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			config.OVNKubernetesFeature.EnablePodNetworkOverrides = tc.overrides
+			pod := &kapi.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ns", Annotations: tc.annotations}}
+			spec := podAnnotationLSPSpec(pod, annotation)
+			assert.Equal(t, "", *spec.Type)
+			assert.Equal(t, tc.expAddresses, spec.Addresses)
+			assert.Equal(t, tc.expPortSecurity, spec.PortSecurity)
+			assert.Equal(t, map[string]string{"namespace": "ns", "pod": "true"}, spec.ExternalIDs)
+		})
+	}
+}
+
+func TestPodRequestedChassis(t *testing.T) {
+	tests := []struct {
+		desc        string
+		overrides   bool
+		annotations map[string]string
+		expChassis  []string
+	}{
+		{
+			desc:       "pods are bound to their node",
+			overrides:  true,
+			expChassis: []string{"node1"},
+		},
+		{
+			desc:        "the requested chassis follow the node",
+			overrides:   true,
+			annotations: map[string]string{podRequestedChassisAnnotation: "node2, node1,node3"},
+			expChassis:  []string{"node1", "node2", "node3"},
+		},
+		{
+			desc:        "the annotation is ignored when pod network overrides are disabled",
+			annotations: map[string]string{podRequestedChassisAnnotation: "node2"},
+			expChassis:  []string{"node1"},
+		},
+	}
+	defer config.PrepareTestConfig()
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			config.OVNKubernetesFeature.EnablePodNetworkOverrides = tc.overrides
+			pod := &kapi.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ns", Annotations: tc.annotations},
+				Spec:       kapi.PodSpec{NodeName: "node1"},
+			}
+			assert.Equal(t, tc.expChassis, podRequestedChassis(pod))
+		})
+	}
+}
+
+func TestPodPortsWithIPs(t *testing.T) {
+	podPort := func(name, addresses string) *goovn.LogicalSwitchPort {
+		return &goovn.LogicalSwitchPort{
+			Name:       name,
+			Addresses:  []string{addresses},
+			ExternalID: map[interface{}]interface{}{"namespace": "ns", "pod": "true"},
+		}
+	}
+
+	tests := []struct {
+		desc     string
+		ports    map[string][]*goovn.LogicalSwitchPort
+		listErrs map[string]error
+		expIPs   map[string][]net.IP
+		errMatch string
+	}{
+		{
+			desc: "collects the IPs of the pod ports of all nodes",
+			ports: map[string][]*goovn.LogicalSwitchPort{
+				"node1": {
+					podPort("ns_pod1", "0a:58:0a:80:01:03 10.128.1.3 fd00:10:244:2::3"),
+					{Name: "k8s-node1", Addresses: []string{"0a:58:0a:80:01:02 10.128.1.2"}},
+				},
+				"node2": {podPort("ns_pod2", "0a:58:0a:80:02:03 10.128.2.3")},
+			},
+			expIPs: map[string][]net.IP{
+				"ns_pod1": {ovntest.MustParseIP("10.128.1.3"), ovntest.MustParseIP("fd00:10:244:2::3")},
+				"ns_pod2": {ovntest.MustParseIP("10.128.2.3")},
+			},
+		},
+		{
+			desc: "skips the switches not created yet and the malformed addresses",
+			ports: map[string][]*goovn.LogicalSwitchPort{
+				"node1": {podPort("ns_pod1", "0a:58:0a:80:01 10.128.1.3"), podPort("ns_pod2", "dynamic")},
+			},
+			listErrs: map[string]error{"node2": goovn.ErrorNotFound},
+			expIPs:   map[string][]net.IP{"ns_pod2": nil},
+		},
+		{
+			desc:     "fails when the ports of a switch cannot be listed",
+			ports:    map[string][]*goovn.LogicalSwitchPort{"node1": {}},
+			listErrs: map[string]error{"node2": fmt.Errorf("boom")},
+			errMatch: "failed to list logical switch ports of node node2",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			mockNbClient := new(goovn_mock.Client)
+			nodeNames := []string{}
+			for nodeName, ports := range tc.ports {
+				mockNbClient.On("LSPList", nodeName).Return(ports, nil)
+				nodeNames = append(nodeNames, nodeName)
+			}
+			for nodeName, err := range tc.listErrs {
+				mockNbClient.On("LSPList", nodeName).Return(nil, err)
+				nodeNames = append(nodeNames, nodeName)
+			}
+
+			portIPs, err := podPortsWithIPs(mockNbClient, nodeNames)
+			if tc.errMatch != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMatch)
+				assert.Nil(t, portIPs)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expIPs, portIPs)
+			}
+		})
+	}
+}
+
+func TestAllocateAndBuildPort(t *testing.T) {
+	podIP := ovntest.MustParseIPNet("10.128.1.3/24")
+	command := &goovn.OvnCommand{}
+
+	tests := []struct {
+		desc string
+		// preallocated IPs are reserved before the call
+		preallocated []*net.IPNet
+		requested    []*net.IPNet
+		existing     *goovn.LogicalSwitchPort
+		// disablePortSecurity is set on the port
+		disablePortSecurity bool
+		buildErr            error
+		expCall             string
+		errMatch            string
+	}{
+		{
+			desc:    "allocates the next IP and builds a new port",
+			expCall: "LSPAddFull",
+		},
+		{
+			desc:      "reserves the requested IP and updates an existing port",
+			requested: []*net.IPNet{podIP},
+			existing:  &goovn.LogicalSwitchPort{Name: "ns_pod", Addresses: []string{"0a:58:0a:80:01:03 10.128.1.3"}},
+			expCall:   "LSPSet",
+		},
+		{
+			desc:      "swaps the addresses of an existing port in place",
+			requested: []*net.IPNet{podIP},
+			existing:  &goovn.LogicalSwitchPort{Name: "ns_pod", Addresses: []string{"0a:58:0a:80:01:09 10.128.1.9"}},
+			expCall:   "LSPSet",
+		},
+		{
+			desc:                "builds a new port without port security",
+			disablePortSecurity: true,
+			expCall:             "LSPAddFull",
+		},
+		{
+			desc:                "disables the port security of an existing port with its addresses",
+			requested:           []*net.IPNet{podIP},
+			existing:            &goovn.LogicalSwitchPort{Name: "ns_pod", Addresses: []string{"0a:58:0a:80:01:09 10.128.1.9"}},
+			disablePortSecurity: true,
+			expCall:             "LSPSet",
+		},
+		{
+			desc:         "uses and releases the requested IPs reserved already",
+			preallocated: []*net.IPNet{podIP},
+			requested:    []*net.IPNet{podIP},
+			expCall:      "LSPAddFull",
+		},
+		{
+			desc:      "returns the release function when the command cannot be built",
+			requested: []*net.IPNet{podIP},
+			buildErr:  fmt.Errorf("boom"),
+			expCall:   "LSPAddFull",
+			errMatch:  "unable to create the LSPAddFull command for port: ns_pod from the nbdb: boom",
+		},
+		{
+			desc:      "wraps the error of building the update of an existing port",
+			requested: []*net.IPNet{podIP},
+			existing:  &goovn.LogicalSwitchPort{Name: "ns_pod", Addresses: []string{"0a:58:0a:80:01:03 10.128.1.3"}},
+			buildErr:  fmt.Errorf("boom"),
+			expCall:   "LSPSet",
+			errMatch:  "unable to create LSPSet command for port: ns_pod: boom",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			mockNbClient := new(goovn_mock.Client)
+			var buildCmd *goovn.OvnCommand
+			if tc.buildErr == nil {
+				buildCmd = command
+			}
+			mockNbClient.On("LSPAddFull", "node1", "ls-uuid", "ns_pod", mock.Anything).Return(buildCmd, tc.buildErr)
+			mockNbClient.On("LSPSet", "ns_pod", mock.Anything).Return(buildCmd, tc.buildErr)
+			oc := &Controller{
+				lsManager:   lsm.NewLogicalSwitchManager(),
+				ovnNBClient: mockNbClient,
+			}
+			assert.Nil(t, oc.lsManager.AddNode("node1", "ls-uuid", []*net.IPNet{ovntest.MustParseIPNet("10.128.1.0/24")}))
+			if tc.preallocated != nil {
+				assert.Nil(t, oc.lsManager.AllocateIPs("node1", tc.preallocated))
+			}
+
+			port := &podPort{oc: oc, name: "ns_pod", lsUUID: "ls-uuid", existing: tc.existing,
+				disablePortSecurity: tc.disablePortSecurity}
+			mac, ips, cmd, release, err := port.AllocateAndBuildPort("node1", tc.requested)
+			mockNbClient.AssertNumberOfCalls(t, tc.expCall, 1)
+			assert.Len(t, mockNbClient.Calls, 1)
+			if tc.errMatch != "" {
+				assert.EqualError(t, err, tc.errMatch)
+				assert.Nil(t, cmd)
+				// the IP stays reserved until the caller releases it
+				assert.Equal(t, ipallocator.ErrAllocated, oc.lsManager.AllocateIPs("node1", tc.requested))
+				release()
+				assert.Nil(t, oc.lsManager.AllocateIPs("node1", tc.requested))
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, command, cmd)
+			assert.Len(t, ips, 1)
+			if tc.requested != nil {
+				assert.Equal(t, tc.requested, ips)
+			}
+			assert.Equal(t, ips[0].IP.To4(), net.IP(mac[2:]).To4())
+
+			// the spec is the last argument of both LSPAddFull and LSPSet
+			args := mockNbClient.Calls[0].Arguments
+			spec := args.Get(len(args) - 1).(goovn.LSPSpec)
+			if tc.disablePortSecurity {
+				assert.NotNil(t, spec.PortSecurity)
+				assert.Empty(t, spec.PortSecurity)
+				assert.Equal(t, []string{mac.String() + " " + ips[0].IP.String(), "unknown"}, spec.Addresses)
+			} else {
+				assert.Equal(t, util.ComputePortSecurity(mac, ips), spec.PortSecurity)
+				assert.Equal(t, []string{mac.String() + " " + ips[0].IP.String()}, spec.Addresses)
+			}
+
+			assert.Equal(t, ipallocator.ErrAllocated, oc.lsManager.AllocateIPs("node1", ips))
+			release()
+			assert.Nil(t, oc.lsManager.AllocateIPs("node1", ips))
+		})
+	}
+}
+
+func TestAddRoutesGatewayIPDefaultRoutes(t *testing.T) {
+	nodeSubnets := []*net.IPNet{
+		ovntest.MustParseIPNet("10.128.1.0/24"),
+		ovntest.MustParseIPNet("fd00:10:244:2::/64"),
+	}
+	dualStackIPs := []*net.IPNet{
+		ovntest.MustParseIPNet("10.128.1.5/24"),
+		ovntest.MustParseIPNet("fd00:10:244:2::5/64"),
+	}
+
+	tests := []struct {
+		desc                    string
+		ips                     []*net.IPNet
+		nodeSubnets             []*net.IPNet
+		networks                string
+		hybridOverlayExternalGW net.IP
+		expRoutes               podDefaultRoutes
+		expMissing              []string
+		expPartial              bool
+		errMatch                string
+	}{
+		{
+			desc:        "gives a dual-stack pod a default route in both families",
+			ips:         dualStackIPs,
+			nodeSubnets: nodeSubnets,
+			expRoutes:   podDefaultRoutes{hasIPv4: true, hasIPv6: true, defaultRouteV4: true, defaultRouteV6: true},
+			expMissing:  []string{},
+		},
+		{
+			desc:        "counts the default route requested by another network attachment",
+			ips:         dualStackIPs,
+			nodeSubnets: nodeSubnets,
+			networks:    `[{"name": "net1", "default-route": ["fd00:20::1"]}]`,
+			expRoutes:   podDefaultRoutes{hasIPv4: true, hasIPv6: true, defaultRouteV4: true, defaultRouteV6: true},
+			expMissing:  []string{},
+		},
+		{
+			desc:                    "reports the family without a hybrid overlay external gateway",
+			ips:                     dualStackIPs,
+			nodeSubnets:             nodeSubnets,
+			hybridOverlayExternalGW: ovntest.MustParseIP("10.0.0.1"),
+			expRoutes:               podDefaultRoutes{hasIPv4: true, hasIPv6: true, defaultRouteV4: true},
+			expMissing:              []string{"IPv6"},
+			expPartial:              true,
+		},
+		{
+			desc:        "leaves a single-stack pod alone",
+			ips:         dualStackIPs[:1],
+			nodeSubnets: nodeSubnets,
+			expRoutes:   podDefaultRoutes{hasIPv4: true, defaultRouteV4: true},
+			expMissing:  []string{},
+		},
+		{
+			desc:        "fails when no node subnet matches a family",
+			ips:         dualStackIPs,
+			nodeSubnets: nodeSubnets[:1],
+			errMatch:    "no IPv6 value available",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			pod := &kapi.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns"}}
+			if tc.networks != "" {
+				pod.Annotations = map[string]string{util.NetworkAttachmentAnnotation: tc.networks}
+			}
+			oc := &Controller{}
+			podAnnotation := &util.PodAnnotation{IPs: tc.ips}
+			routes, err := oc.addRoutesGatewayIP(pod, podAnnotation, tc.nodeSubnets, &gatewayInfo{}, nil, tc.hybridOverlayExternalGW)
+			if tc.errMatch != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMatch)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expRoutes, routes)
+			assert.Equal(t, tc.expMissing, routes.missingFamilies())
+			assert.Equal(t, tc.expPartial, routes.partial())
+		})
+	}
+}
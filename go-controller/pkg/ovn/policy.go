@@ -69,6 +69,8 @@ const (
 	ipv6DynamicMulticastMatch = "(ip6.dst[120..127] == 0xff && ip6.dst[116] == 1)"
 	// Legacy multicastDefaultDeny port group removed by commit 40a90f0
 	legacyMulticastDefaultDenyPortGroup = "mcastPortGroupDeny"
+	// External ID tagging the ACLs reconciled for a network policy with its UID
+	policyUIDExternalID = "policy_uid"
 )
 
 func getACLLoggingSeverity(aclLogging string) string {
@@ -186,15 +188,15 @@ func addACLPortGroup(policyNamespace, portGroupUUID, direction, priority, match,
 	} else {
 		policyName = policyNamespace
 	}
-	_, stderr, err := util.RunOVNNbctl("--id=@acl", "create", "acl",
+	_, stderr, err := util.RunOVNNbctl("--id=@acl", "create", types.OVNNBTableACL,
 		fmt.Sprintf("priority=%s", priority),
 		fmt.Sprintf("direction=%s", direction), match, "action="+action,
 		fmt.Sprintf("log=%t", aclLogging != ""), fmt.Sprintf("severity=%s", getACLLoggingSeverity(aclLogging)),
 		fmt.Sprintf("meter=%s", types.OvnACLLoggingMeter),
 		fmt.Sprintf("name=%.63s", policyName),
 		fmt.Sprintf("external-ids:default-deny-policy-type=%s", policyType),
-		"--", "add", "port_group", portGroupUUID,
-		"acls", "@acl")
+		"--", "add", types.OVNNBTablePortGroup, portGroupUUID,
+		util.OVNColumnACLs, "@acl")
 	if err != nil {
 		return fmt.Errorf("error executing create ACL command for "+
 			"policy type %s stderr: %q (%v)", policyType, stderr, err)
@@ -213,8 +215,8 @@ func deleteACLPortGroup(portGroupName, direction, priority, match, action string
 		return nil
 	}
 
-	_, stderr, err := util.RunOVNNbctl("remove", "port_group",
-		portGroupName, "acls", uuid)
+	_, stderr, err := util.RunOVNNbctl("remove", types.OVNNBTablePortGroup,
+		portGroupName, util.OVNColumnACLs, uuid)
 	if err != nil {
 		return fmt.Errorf("remove failed to delete the rule for "+
 			"port_group=%s, stderr: %q (%v)", portGroupName, stderr, err)
@@ -267,14 +269,145 @@ func setPortGroup(ovnNBClient goovn.Client, portGroupName string, ports ...*lpIn
 		uuids = append(uuids, port.uuid)
 	}
 
-	cmd, err := ovnNBClient.PortGroupUpdate(portGroupName, uuids, nil)
-	if err != nil {
+	cmd, err := ovnNBClient.PortGroupReconcilePorts(portGroupName, uuids)
+	if err == goovn.ErrorNoChanges {
+		return nil
+	} else if err != nil {
 		return err
 	}
 
 	return ovnNBClient.Execute(cmd)
 }
 
+// policyACLKey identifies an ACL of a port group, OVN not allowing two ACLs
+// with the same match, direction and priority on it
+type policyACLKey struct {
+	match     string
+	direction string
+	priority  int
+}
+
+// ReconcilePolicyACLs returns the command turning the ACLs of the network
+// policy with the given UID on portGroup into desired. Only the ACLs that
+// differ are added, updated in place or deleted, so that unlike deleting the
+// ACLs of the policy and adding them back, the traffic matching an unchanged
+// ACL is never dropped while the policy is updated. goovn.ErrorNoChanges is
+// returned if the ACLs are already in sync.
+func (oc *Controller) ReconcilePolicyACLs(portGroup, policyUID string, desired []goovn.ACLSpec) (*goovn.OvnCommand, error) {
+	return reconcilePolicyACLs(oc.ovnNBClient, portGroup, policyUID, desired)
+}
+
+func reconcilePolicyACLs(ovnNBClient goovn.Client, portGroup, policyUID string, desired []goovn.ACLSpec) (*goovn.OvnCommand, error) {
+	acls, err := ovnNBClient.ACLListEntity(goovn.PORT_GROUP, portGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the ACLs of port group %s: %v", portGroup, err)
+	}
+	// the ACLs of the policy are the ones tagged with its UID, a duplicate of
+	// one of them is deleted
+	owned := []*goovn.ACL{}
+	existing := make(map[policyACLKey]*goovn.ACL)
+	for _, acl := range acls {
+		if acl == nil || acl.ExternalID[policyUIDExternalID] != policyUID {
+			continue
+		}
+		owned = append(owned, acl)
+		key := policyACLKey{acl.Match, acl.Direction, acl.Priority}
+		if _, ok := existing[key]; !ok {
+			existing[key] = acl
+		}
+	}
+
+	cmds := []*goovn.OvnCommand{}
+	wanted := make(map[policyACLKey]bool, len(desired))
+	for _, spec := range desired {
+		key := policyACLKey{spec.Match, string(spec.Direction), spec.Priority}
+		if wanted[key] {
+			return nil, fmt.Errorf("duplicate %s ACL with priority %d and match %q for policy %s",
+				spec.Direction, spec.Priority, spec.Match, policyUID)
+		}
+		wanted[key] = true
+
+		externalIDs := make(map[string]string, len(spec.ExternalIDs)+1)
+		for k, v := range spec.ExternalIDs {
+			externalIDs[k] = v
+		}
+		externalIDs[policyUIDExternalID] = policyUID
+		spec.ExternalIDs = externalIDs
+
+		acl, ok := existing[key]
+		if !ok {
+			cmd, err := ovnNBClient.ACLAddSpec(goovn.PORT_GROUP, portGroup, spec)
+			if err == goovn.ErrorExist {
+				return nil, fmt.Errorf("%s ACL with priority %d and match %q on port group %s is not owned by policy %s",
+					spec.Direction, spec.Priority, spec.Match, portGroup, policyUID)
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to create the command adding an ACL to port group %s: %v", portGroup, err)
+			}
+			cmds = append(cmds, cmd)
+		} else if policyACLDrift(acl, spec) {
+			cmd, err := ovnNBClient.ACLSet(acl.UUID, spec)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create the command updating ACL %s of port group %s: %v", acl.UUID, portGroup, err)
+			}
+			cmds = append(cmds, cmd)
+		}
+	}
+	for _, acl := range owned {
+		key := policyACLKey{acl.Match, acl.Direction, acl.Priority}
+		if wanted[key] && existing[key] == acl {
+			continue
+		}
+		cmd, err := ovnNBClient.ACLDelEntity(goovn.PORT_GROUP, portGroup, acl.UUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the command deleting ACL %s of port group %s: %v", acl.UUID, portGroup, err)
+		}
+		cmds = append(cmds, cmd)
+	}
+	if len(cmds) == 0 {
+		return nil, goovn.ErrorNoChanges
+	}
+
+	// the operations of all the commands are run together so that the ACLs of
+	// the policy are updated in a single transaction
+	reconcileCmd := &goovn.OvnCommand{Exe: cmds[0].Exe}
+	for _, cmd := range cmds {
+		reconcileCmd.Operations = append(reconcileCmd.Operations, cmd.Operations...)
+	}
+	reconcileCmd.Results = make([][]map[string]interface{}, len(reconcileCmd.Operations))
+	return reconcileCmd, nil
+}
+
+// policyACLDrift tells whether acl differs from spec in a column other than the
+// match, direction and priority identifying it
+func policyACLDrift(acl *goovn.ACL, spec goovn.ACLSpec) bool {
+	if acl.Name != spec.Name || acl.Action != spec.Action || acl.Log != spec.Log || acl.ApplyAfterLB != spec.ApplyAfterLB {
+		return true
+	}
+	// the meter and severity only matter when logging
+	if spec.Log {
+		meter := ""
+		if len(acl.Meter) > 0 {
+			meter = acl.Meter[0]
+		}
+		severity := spec.Severity
+		if severity == "" {
+			severity = defaultACLLoggingSeverity
+		}
+		if meter != spec.Meter || acl.Severity != severity {
+			return true
+		}
+	}
+	if len(acl.ExternalID) != len(spec.ExternalIDs) {
+		return true
+	}
+	for k, v := range spec.ExternalIDs {
+		if acl.ExternalID[k] != v {
+			return true
+		}
+	}
+	return false
+}
+
 func defaultDenyPortGroup(namespace, gressSuffix string) string {
 	return hashedPortGroup(namespace) + "_" + gressSuffix
 }
@@ -329,7 +462,7 @@ func (oc *Controller) setACLDenyLogging(ns string, nsInfo *namespaceInfo, aclLog
 		// not suppose to happen
 		return fmt.Errorf("failed to find the ACL for pg=%s: %v", nsInfo.portGroupIngressDenyUUID, err)
 	}
-	if _, stderr, err := util.RunOVNNbctl("set", "acl", uuid,
+	if _, stderr, err := util.RunOVNNbctl("set", types.OVNNBTableACL, uuid,
 		fmt.Sprintf("log=%t", aclLogging != ""), fmt.Sprintf("severity=%s", aclLoggingSev)); err != nil {
 		return fmt.Errorf("failed to modify the pg=%s, stderr: %q (%v)", nsInfo.portGroupIngressDenyUUID, stderr, err)
 	}
@@ -343,7 +476,7 @@ func (oc *Controller) setACLDenyLogging(ns string, nsInfo *namespaceInfo, aclLog
 		// not suppose to happen
 		return fmt.Errorf("failed to find the ACL for pg=%s: %v", nsInfo.portGroupEgressDenyUUID, err)
 	}
-	if _, stderr, err := util.RunOVNNbctl("set", "acl", uuid,
+	if _, stderr, err := util.RunOVNNbctl("set", types.OVNNBTableACL, uuid,
 		fmt.Sprintf("log=%t", aclLogging != ""), fmt.Sprintf("severity=%s", aclLoggingSev)); err != nil {
 		return fmt.Errorf("failed to modify the pg=%s, stderr: %q (%v)", nsInfo.portGroupEgressDenyUUID, stderr, err)
 	}
@@ -9,6 +9,7 @@ import (
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 	goovn_mock "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/ebay/go-ovn"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestAddAllowACLFromNode(t *testing.T) {
@@ -101,3 +102,119 @@ func TestAddAllowACLFromNode(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcilePolicyACLs(t *testing.T) {
+	allow := func(match string) goovn.ACLSpec {
+		return goovn.ACLSpec{
+			Direction:   goovn.ACLDirectionToLport,
+			Match:       match,
+			Action:      "allow-related",
+			Priority:    1001,
+			ExternalIDs: map[string]string{"policy": "allow-web"},
+		}
+	}
+	acl := func(uuid, match, action, policyUID string) *goovn.ACL {
+		return &goovn.ACL{
+			UUID:       uuid,
+			Direction:  string(goovn.ACLDirectionToLport),
+			Match:      match,
+			Action:     action,
+			Priority:   1001,
+			ExternalID: map[interface{}]interface{}{"policy": "allow-web", policyUIDExternalID: policyUID},
+		}
+	}
+	webMatch := "ip4.src == {$a1} && outport == @pg"
+	dbMatch := "ip4.src == {$a2} && outport == @pg"
+
+	tests := []struct {
+		desc     string
+		existing []*goovn.ACL
+		desired  []goovn.ACLSpec
+		addErr   error
+		// expCmds names the commands in the order they are run, by their
+		// method and the UUID or match of their ACL
+		expCmds  []string
+		expNoop  bool
+		errMatch string
+	}{
+		{
+			desc:    "adds the missing ACLs of the policy",
+			desired: []goovn.ACLSpec{allow(webMatch)},
+			expCmds: []string{"ACLAddSpec " + webMatch},
+		},
+		{
+			desc:     "leaves ACLs in sync untouched",
+			existing: []*goovn.ACL{acl("acl1", webMatch, "allow-related", "uid1")},
+			desired:  []goovn.ACLSpec{allow(webMatch)},
+			expNoop:  true,
+		},
+		{
+			desc:     "updates a drifted ACL in place",
+			existing: []*goovn.ACL{acl("acl1", webMatch, "drop", "uid1")},
+			desired:  []goovn.ACLSpec{allow(webMatch)},
+			expCmds:  []string{"ACLSet acl1"},
+		},
+		{
+			desc: "deletes the stale ACLs of the policy and its duplicates, keeping the ACLs of other policies",
+			existing: []*goovn.ACL{
+				acl("acl1", webMatch, "allow-related", "uid1"),
+				acl("acl2", webMatch, "allow-related", "uid1"),
+				acl("acl3", dbMatch, "allow-related", "uid1"),
+				acl("acl4", dbMatch, "allow-related", "uid2"),
+			},
+			desired: []goovn.ACLSpec{allow(webMatch)},
+			expCmds: []string{"ACLDelEntity acl2", "ACLDelEntity acl3"},
+		},
+		{
+			desc:     "fails on an ACL owned by another policy",
+			desired:  []goovn.ACLSpec{allow(webMatch)},
+			addErr:   goovn.ErrorExist,
+			errMatch: "is not owned by policy uid1",
+		},
+		{
+			desc:     "fails on duplicate desired ACLs",
+			desired:  []goovn.ACLSpec{allow(webMatch), allow(webMatch)},
+			errMatch: "duplicate to-lport ACL",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			mockNbClient := new(goovn_mock.Client)
+			mockNbClient.On("ACLListEntity", goovn.PORT_GROUP, "pg").Return(tc.existing, nil)
+			mockNbClient.On("ACLAddSpec", goovn.PORT_GROUP, "pg", mock.Anything).Return(ovntest.MockCommand("ACLAddSpec"), tc.addErr)
+			mockNbClient.On("ACLSet", mock.Anything, mock.Anything).Return(ovntest.MockCommand("ACLSet"), nil)
+			mockNbClient.On("ACLDelEntity", goovn.PORT_GROUP, "pg", mock.Anything).Return(ovntest.MockCommand("ACLDelEntity"), nil)
+
+			cmd, err := reconcilePolicyACLs(mockNbClient, "pg", "uid1", tc.desired)
+			if tc.errMatch != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMatch)
+				return
+			}
+			if tc.expNoop {
+				assert.Equal(t, goovn.ErrorNoChanges, err)
+				return
+			}
+			assert.Nil(t, err)
+
+			cmds := []string{}
+			for _, call := range mockNbClient.Calls {
+				switch call.Method {
+				case "ACLAddSpec":
+					spec := call.Arguments.Get(2).(goovn.ACLSpec)
+					assert.Equal(t, "uid1", spec.ExternalIDs[policyUIDExternalID])
+					cmds = append(cmds, call.Method+" "+spec.Match)
+				case "ACLSet":
+					spec := call.Arguments.Get(1).(goovn.ACLSpec)
+					assert.Equal(t, "allow-related", spec.Action)
+					cmds = append(cmds, call.Method+" "+call.Arguments.String(0))
+				case "ACLDelEntity":
+					cmds = append(cmds, call.Method+" "+call.Arguments.String(2))
+				}
+			}
+			assert.Equal(t, tc.expCmds, cmds)
+			// the commands are joined in a single transaction
+			assert.Equal(t, len(tc.expCmds), len(cmd.Operations))
+		})
+	}
+}
@@ -369,3 +369,73 @@ func TestDeleteFromPortGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestSetPortGroup(t *testing.T) {
+	tests := []struct {
+		desc                      string
+		ports                     []*lpInfo
+		errMatch                  error
+		onRetArgMockGoOvnNBClient []ovntest.TestifyMockHelper
+	}{
+		{
+			desc:  "ports out of sync",
+			ports: []*lpInfo{{name: lspName, uuid: lspUUID}},
+			onRetArgMockGoOvnNBClient: []ovntest.TestifyMockHelper{
+				{
+					OnCallMethodName: "PortGroupReconcilePorts", OnCallMethodArgType: []string{"string", "[]string"}, RetArgList: []interface{}{&goovn.OvnCommand{}, nil},
+				},
+				{
+					OnCallMethodName: "Execute", OnCallMethodArgType: []string{"*goovn.OvnCommand"}, RetArgList: []interface{}{nil},
+				},
+			},
+		},
+		{
+			desc:  "ports in sync",
+			ports: []*lpInfo{{name: lspName, uuid: lspUUID}},
+			onRetArgMockGoOvnNBClient: []ovntest.TestifyMockHelper{
+				{
+					OnCallMethodName: "PortGroupReconcilePorts", OnCallMethodArgType: []string{"string", "[]string"}, RetArgList: []interface{}{nil, goovn.ErrorNoChanges},
+				},
+			},
+		},
+		{
+			desc:     "port group not found",
+			ports:    []*lpInfo{{name: lspName, uuid: lspUUID}},
+			errMatch: goovn.ErrorNotFound,
+			onRetArgMockGoOvnNBClient: []ovntest.TestifyMockHelper{
+				{
+					OnCallMethodName: "PortGroupReconcilePorts", OnCallMethodArgType: []string{"string", "[]string"}, RetArgList: []interface{}{nil, goovn.ErrorNotFound},
+				},
+			},
+		},
+		{
+			desc:     "execute error",
+			ports:    []*lpInfo{{name: lspName, uuid: lspUUID}},
+			errMatch: execError,
+			onRetArgMockGoOvnNBClient: []ovntest.TestifyMockHelper{
+				{
+					OnCallMethodName: "PortGroupReconcilePorts", OnCallMethodArgType: []string{"string", "[]string"}, RetArgList: []interface{}{&goovn.OvnCommand{}, nil},
+				},
+				{
+					OnCallMethodName: "Execute", OnCallMethodArgType: []string{"*goovn.OvnCommand"}, RetArgList: []interface{}{execError},
+				},
+			},
+		},
+	}
+
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			mockGoOvnNBClient := new(goovn_mock.Client)
+			ovntest.ProcessMockFnList(&mockGoOvnNBClient.Mock, tc.onRetArgMockGoOvnNBClient)
+
+			err := setPortGroup(mockGoOvnNBClient, pgName, tc.ports...)
+
+			if tc.errMatch != nil {
+				assert.Contains(t, err.Error(), tc.errMatch.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			mockGoOvnNBClient.AssertExpectations(t)
+		})
+	}
+}
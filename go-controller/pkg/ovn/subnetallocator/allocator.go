@@ -2,6 +2,7 @@ package subnetallocator
 
 import (
 	"fmt"
+	"math/big"
 	"net"
 	"sync"
 
@@ -108,6 +109,38 @@ func (sna *SubnetAllocator) AllocateIPv6Network() (*net.IPNet, error) {
 	return nil, ErrSubnetAllocatorFull
 }
 
+// ExpandNetwork marks network, a host subnet grown from oldNetwork, as allocated.
+// It fails without marking anything when network overlaps a subnet allocated
+// to another node, that is one oldNetwork doesn't cover. oldNetwork is nil
+// when network is of a new IP family for the node.
+func (sna *SubnetAllocator) ExpandNetwork(oldNetwork, network *net.IPNet) error {
+	sna.Lock()
+	defer sna.Unlock()
+
+	for _, snr := range append(append([]*subnetAllocatorRange{}, sna.v4ranges...), sna.v6ranges...) {
+		subnets := snr.hostSubnets(network)
+		if len(subnets) == 0 {
+			continue
+		}
+		owned := make(map[string]bool)
+		if oldNetwork != nil {
+			for _, subnet := range snr.hostSubnets(oldNetwork) {
+				owned[subnet] = true
+			}
+		}
+		for _, subnet := range subnets {
+			if snr.allocMap[subnet] && !owned[subnet] {
+				return fmt.Errorf("network %s overlaps the allocated network %s", network.String(), subnet)
+			}
+		}
+		for _, subnet := range subnets {
+			snr.allocMap[subnet] = true
+		}
+		return nil
+	}
+	return fmt.Errorf("network %s does not belong to any known range", network.String())
+}
+
 func (sna *SubnetAllocator) ReleaseNetwork(subnet *net.IPNet) error {
 	sna.Lock()
 	defer sna.Unlock()
@@ -189,14 +222,40 @@ func newSubnetAllocatorRange(network *net.IPNet, hostSubnetLen int) (*subnetAllo
 	return snr, nil
 }
 
+// hostSubnets returns the subnets of snr's range that network covers, or none
+// if network is not part of snr's range. A host subnet that was grown to a
+// shorter prefix covers several subnets of the range.
+func (snr *subnetAllocatorRange) hostSubnets(network *net.IPNet) []string {
+	ones, addrLen := network.Mask.Size()
+	rangeOnes, rangeAddrLen := snr.network.Mask.Size()
+	if addrLen != rangeAddrLen || ones < rangeOnes || !snr.network.Contains(network.IP) {
+		return nil
+	}
+	hostSubnetLen := addrLen - int(snr.hostBits)
+	if ones >= hostSubnetLen {
+		return []string{network.String()}
+	}
+
+	numSubnets := 1 << uint(hostSubnetLen-ones)
+	subnets := make([]string, 0, numSubnets)
+	next := utilnet.BigForIP(network.IP.Mask(network.Mask))
+	step := big.NewInt(0).Lsh(big.NewInt(1), uint(snr.hostBits))
+	for i := 0; i < numSubnets; i++ {
+		subnet := &net.IPNet{IP: utilnet.AddIPOffset(next, 0), Mask: net.CIDRMask(hostSubnetLen, addrLen)}
+		subnets = append(subnets, subnet.String())
+		next.Add(next, step)
+	}
+	return subnets
+}
+
 // markAllocatedNetwork marks network as being in use, if it is part of snr's range.
 // It returns whether the network was in snr's range.
 func (snr *subnetAllocatorRange) markAllocatedNetwork(network *net.IPNet) bool {
-	str := network.String()
-	if snr.network.Contains(network.IP) {
-		snr.allocMap[str] = true
+	subnets := snr.hostSubnets(network)
+	for _, subnet := range subnets {
+		snr.allocMap[subnet] = true
 	}
-	return snr.allocMap[str]
+	return len(subnets) > 0
 }
 
 // allocateNetwork returns a new subnet, or nil if the range is full
@@ -251,10 +310,9 @@ func (snr *subnetAllocatorRange) allocateNetwork() *net.IPNet {
 // releaseNetwork marks network as being not in use, if it is part of snr's range.
 // It returns whether the network was in snr's range.
 func (snr *subnetAllocatorRange) releaseNetwork(network *net.IPNet) bool {
-	if !snr.network.Contains(network.IP) {
-		return false
+	subnets := snr.hostSubnets(network)
+	for _, subnet := range subnets {
+		snr.allocMap[subnet] = false
 	}
-
-	snr.allocMap[network.String()] = false
-	return true
+	return len(subnets) > 0
 }
@@ -325,6 +325,42 @@ func TestMarkAllocatedNetwork(t *testing.T) {
 	}
 }
 
+func TestExpandNetwork(t *testing.T) {
+	sna, err := newSubnetAllocator("10.1.0.0/16", 24)
+	if err != nil {
+		t.Fatal("Failed to initialize subnet allocator: ", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := allocateExpected(sna, i, fmt.Sprintf("10.1.%d.0/24", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 10.1.0.0/23 covers 10.1.1.0/24 of another node
+	if err := sna.ExpandNetwork(ovntest.MustParseIPNet("10.1.0.0/24"), ovntest.MustParseIPNet("10.1.0.0/23")); err == nil {
+		t.Fatal("Unexpectedly succeeded in expanding a network over an allocated one")
+	}
+	if err := sna.ExpandNetwork(nil, ovntest.MustParseIPNet("10.2.0.0/24")); err == nil {
+		t.Fatal("Unexpectedly succeeded in expanding a network that doesn't belong to any range")
+	}
+
+	// the node of 10.1.2.0/24 grows to 10.1.2.0/23, covering 10.1.3.0/24 too
+	if err := sna.ExpandNetwork(ovntest.MustParseIPNet("10.1.2.0/24"), ovntest.MustParseIPNet("10.1.2.0/23")); err != nil {
+		t.Fatal("Failed to expand network: ", err)
+	}
+	if err := allocateExpected(sna, 3, "10.1.4.0/24"); err != nil {
+		t.Fatal(err)
+	}
+
+	// releasing the grown network releases every subnet it covers
+	if err := sna.ReleaseNetwork(ovntest.MustParseIPNet("10.1.2.0/23")); err != nil {
+		t.Fatal("Failed to release network: ", err)
+	}
+	if err := sna.ExpandNetwork(nil, ovntest.MustParseIPNet("10.1.2.0/23")); err != nil {
+		t.Fatal("Failed to expand network: ", err)
+	}
+}
+
 func TestAllocateReleaseSubnet(t *testing.T) {
 	sna, err := newSubnetAllocator("10.1.0.0/16", 18)
 	if err != nil {
@@ -33,6 +33,24 @@ func (mock *MockOVNClient) ChassisList() ([]*goovn.Chassis, error) {
 	return chArray, nil
 }
 
+// List chassis in the given transport zone
+func (mock *MockOVNClient) ChassisListByTransportZone(zone string) ([]*goovn.Chassis, error) {
+	chassisList, err := mock.ChassisList()
+	if err != nil {
+		return nil, err
+	}
+	chArray := []*goovn.Chassis{}
+	for _, chassis := range chassisList {
+		for _, tz := range chassis.TransportZones {
+			if tz == zone {
+				chArray = append(chArray, chassis)
+				break
+			}
+		}
+	}
+	return chArray, nil
+}
+
 // Delete chassis with given name
 func (mock *MockOVNClient) ChassisDel(chName string) (*goovn.OvnCommand, error) {
 	klog.V(5).Infof("Deleting chassis %s", chName)
@@ -2,6 +2,7 @@ package testing
 
 import (
 	"fmt"
+	"reflect"
 
 	goovn "github.com/ebay/go-ovn"
 	"github.com/mitchellh/copystructure"
@@ -35,6 +36,11 @@ func (mock *MockOVNClient) LRGet(lr string) ([]*goovn.LogicalRouter, error) {
 
 }
 
+// Get logical router by name along with the rows it references
+func (mock *MockOVNClient) LRGetFull(lr string) (*goovn.LogicalRouterFull, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 // Create logical router named lr
 func (mock *MockOVNClient) LRAdd(lr string, external_ids map[string]string) (*goovn.OvnCommand, error) {
 	klog.V(5).Infof("Adding  logical router %s", lr)
@@ -92,6 +98,21 @@ func (mock *MockOVNClient) LRList() ([]*goovn.LogicalRouter, error) {
 	return lrArray, nil
 }
 
+// Get the logical routers whose external_ids map key to value
+func (mock *MockOVNClient) LRListByExternalID(key, value string) ([]*goovn.LogicalRouter, error) {
+	lrs, err := mock.LRList()
+	if err != nil {
+		return nil, err
+	}
+	lrArray := []*goovn.LogicalRouter{}
+	for _, lr := range lrs {
+		if v, ok := lr.ExternalID[key].(string); ok && v == value {
+			lrArray = append(lrArray, lr)
+		}
+	}
+	return lrArray, nil
+}
+
 // Add LB to LR
 func (mock *MockOVNClient) LRLBAdd(ls string, lb string) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
@@ -107,21 +128,100 @@ func (mock *MockOVNClient) LRLBList(ls string) ([]*goovn.LoadBalancer, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
+// Add and remove LBs on a LR
+func (mock *MockOVNClient) LRLBUpdate(lr string, addUUIDs []string, delUUIDs []string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 func (mock *MockOVNClient) LBList() ([]*goovn.LoadBalancer, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
 // Add LRP with given name on given lr
 func (mock *MockOVNClient) LRPAdd(lr string, lrp string, mac string, network []string, peer string, external_ids map[string]string) (*goovn.OvnCommand, error) {
-	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	// like the client, refuse to add a port that exists as given
+	if port, ok := mock.cache[LogicalRouterPortType][lrp].(*goovn.LogicalRouterPort); ok &&
+		port.MAC == mac && reflect.DeepEqual(port.Networks, network) {
+		return nil, goovn.ErrorExist
+	}
+	klog.V(5).Infof("Adding lrp %s to router %s", lrp, lr)
+	extIdsMap := make(map[interface{}]interface{})
+	for k, v := range external_ids {
+		extIdsMap[k] = v
+	}
+	return &goovn.OvnCommand{
+		Exe: &MockExecution{
+			handler: mock,
+			op:      OpAdd,
+			table:   LogicalRouterPortType,
+			objName: lrp,
+			parent:  lr,
+			obj: &goovn.LogicalRouterPort{Name: lrp, UUID: FakeUUID, MAC: mac, Networks: network,
+				Peer: peer, ExternalID: extIdsMap},
+		},
+	}, nil
 }
 
 // Delete LRP with given name on given lr
 func (mock *MockOVNClient) LRPDel(lr string, lrp string) (*goovn.OvnCommand, error) {
-	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	if _, ok := mock.cache[LogicalRouterPortType][lrp]; !ok {
+		return nil, goovn.ErrorNotFound
+	}
+	klog.V(5).Infof("Deleting lrp %s from router %s", lrp, lr)
+	return &goovn.OvnCommand{
+		Exe: &MockExecution{
+			handler: mock,
+			op:      OpDelete,
+			table:   LogicalRouterPortType,
+			objName: lrp,
+		},
+	}, nil
+}
+
+// addRouterPort records the port on the router it is added to, the ports
+// added to routers the mock doesn't have are not recorded
+func (mock *MockOVNClient) addRouterPort(lr, lrp string) {
+	if lrouter, ok := mock.cache[LogicalRouterType][lr].(*goovn.LogicalRouter); ok {
+		lrouter.Ports = append(lrouter.Ports, lrp)
+	}
+}
+
+// delRouterPort removes the port from the router it was added to
+func (mock *MockOVNClient) delRouterPort(lrp string) {
+	for _, entry := range mock.cache[LogicalRouterType] {
+		lrouter, ok := entry.(*goovn.LogicalRouter)
+		if !ok {
+			continue
+		}
+		for i, name := range lrouter.Ports {
+			if name == lrp {
+				lrouter.Ports = append(lrouter.Ports[:i], lrouter.Ports[i+1:]...)
+				break
+			}
+		}
+	}
 }
 
 // Get all lrp by lr
 func (mock *MockOVNClient) LRPList(lr string) ([]*goovn.LogicalRouterPort, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
+
+// Set options on LRP
+func (mock *MockOVNClient) LRPSetOptions(lrp string, options map[string]string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Add CIDRs to the networks of LRP
+func (mock *MockOVNClient) LRPAddNetworks(lrp string, networks []string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Remove CIDRs from the networks of LRP
+func (mock *MockOVNClient) LRPDelNetworks(lrp string, networks []string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
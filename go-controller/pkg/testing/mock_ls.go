@@ -10,6 +10,9 @@ import (
 
 const (
 	LoadBalancer = "LoadBalancer"
+	// the updates of the other_config keys of a logical switch
+	LogicalSwitchOtherConfigSet string = "LSOtherConfigSet"
+	LogicalSwitchOtherConfigDel string = "LSOtherConfigDel"
 )
 
 // TODO: implement mock methods as we keep adding unit-tests
@@ -123,3 +126,83 @@ func (mock *MockOVNClient) LSLBList(ls string) ([]*goovn.LoadBalancer, error) {
 
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
+
+// Set keys of a map column of a row; only the other_config of a logical switch is supported
+func (mock *MockOVNClient) AuxKeyValSet(table string, rowName string, auxCol string, kv map[string]string) (*goovn.OvnCommand, error) {
+	if table != LogicalSwitchType || auxCol != "other_config" {
+		return nil, fmt.Errorf("method %s is not implemented yet for %s %s", functionName(), table, auxCol)
+	}
+	return &goovn.OvnCommand{
+		Exe: &MockExecution{
+			handler: mock,
+			op:      OpUpdate,
+			table:   LogicalSwitchType,
+			objName: rowName,
+			objUpdate: UpdateCache{
+				FieldType:  LogicalSwitchOtherConfigSet,
+				FieldValue: kv,
+			},
+		},
+	}, nil
+}
+
+// Delete keys of a map column of a row, a nil value matching any value; only
+// the other_config of a logical switch is supported
+func (mock *MockOVNClient) AuxKeyValDel(table string, rowName string, auxCol string, kv map[string]*string) (*goovn.OvnCommand, error) {
+	if table != LogicalSwitchType || auxCol != "other_config" {
+		return nil, fmt.Errorf("method %s is not implemented yet for %s %s", functionName(), table, auxCol)
+	}
+	return &goovn.OvnCommand{
+		Exe: &MockExecution{
+			handler: mock,
+			op:      OpUpdate,
+			table:   LogicalSwitchType,
+			objName: rowName,
+			objUpdate: UpdateCache{
+				FieldType:  LogicalSwitchOtherConfigDel,
+				FieldValue: kv,
+			},
+		},
+	}, nil
+}
+
+// helper function that applies field updates for a given ls to the mock object cache
+func (mock *MockOVNClient) updateLSCache(lsName string, update UpdateCache, mockCache MockObjectCacheByName) error {
+	entry, ok := mockCache[lsName]
+	if !ok {
+		return fmt.Errorf("error updating LS with name %s, LS doesn't exist", lsName)
+	}
+	ls, ok := entry.(*goovn.LogicalSwitch)
+	if !ok {
+		panic("type assertion failed for LS cache entry")
+	}
+	if ls.OtherConfig == nil {
+		ls.OtherConfig = make(map[interface{}]interface{})
+	}
+
+	switch update.FieldType {
+	case LogicalSwitchOtherConfigSet:
+		klog.V(5).Infof("Setting other_config for LS %s", lsName)
+		kv, ok := update.FieldValue.(map[string]string)
+		if !ok {
+			return fmt.Errorf("type assertion failed for LS field: %s", update.FieldType)
+		}
+		for k, v := range kv {
+			ls.OtherConfig[k] = v
+		}
+	case LogicalSwitchOtherConfigDel:
+		klog.V(5).Infof("Deleting other_config for LS %s", lsName)
+		kv, ok := update.FieldValue.(map[string]*string)
+		if !ok {
+			return fmt.Errorf("type assertion failed for LS field: %s", update.FieldType)
+		}
+		for k, v := range kv {
+			if v == nil || ls.OtherConfig[k] == *v {
+				delete(ls.OtherConfig, k)
+			}
+		}
+	default:
+		return fmt.Errorf("unrecognized field type: %s", update.FieldType)
+	}
+	return nil
+}
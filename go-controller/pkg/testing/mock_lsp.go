@@ -2,6 +2,8 @@ package testing
 
 import (
 	"fmt"
+	"net"
+	"strings"
 
 	goovn "github.com/ebay/go-ovn"
 	"github.com/mitchellh/copystructure"
@@ -14,6 +16,10 @@ const (
 	LogicalSwitchPortDynamicAddresses string = "LSPDynamicAddressesField"
 	LogicalSwitchPortExternalId       string = "LSPExternalIdsField"
 	LogicalSwitchPortPortSecurity     string = "LSPPortSecurityField"
+	LogicalSwitchPortSpec             string = "LSPSpecField"
+	LogicalSwitchPortRequestedChassis string = "LSPRequestedChassisField"
+	LogicalSwitchPortARPProxy         string = "LSPARPProxyField"
+	LogicalSwitchPortNATAddresses     string = "LSPNATAddressesField"
 	FakeUUID                                 = "8a86f6d8-7972-4253-b0bd-ddbef66e9303"
 )
 
@@ -41,8 +47,35 @@ func (mock *MockOVNClient) LSPGet(lsp string) (*goovn.LogicalSwitchPort, error)
 	return nil, fmt.Errorf("invalid object type assertion for %s", LogicalSwitchPortType)
 }
 
+// Get logical switch port by uuid
+func (mock *MockOVNClient) LSPGetUUID(uuid string) (*goovn.LogicalSwitchPort, error) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	var lspCache MockObjectCacheByName
+	var ok bool
+	if lspCache, ok = mock.cache[LogicalSwitchPortType]; !ok {
+		klog.V(5).Infof("Cache doesn't have any object of type %s", LogicalSwitchPortType)
+		return nil, goovn.ErrorSchema
+	}
+	for _, port := range lspCache {
+		lsp, ok := port.(*goovn.LogicalSwitchPort)
+		if !ok {
+			return nil, fmt.Errorf("invalid object type assertion for %s", LogicalSwitchPortType)
+		}
+		if lsp.UUID != uuid {
+			continue
+		}
+		port, err := copystructure.Copy(port)
+		if err != nil {
+			panic(err) // should never happen
+		}
+		return port.(*goovn.LogicalSwitchPort), nil
+	}
+	return nil, goovn.ErrorNotFound
+}
+
 // Add logical port PORT on SWITCH
-func (mock *MockOVNClient) LSPAdd(ls string, lsp string) (*goovn.OvnCommand, error) {
+func (mock *MockOVNClient) LSPAdd(ls string, lsUUID string, lsp string) (*goovn.OvnCommand, error) {
 	klog.V(5).Infof("Adding lsp %s to switch %s", lsp, ls)
 	return &goovn.OvnCommand{
 		Exe: &MockExecution{
@@ -50,11 +83,55 @@ func (mock *MockOVNClient) LSPAdd(ls string, lsp string) (*goovn.OvnCommand, err
 			op:      OpAdd,
 			table:   LogicalSwitchPortType,
 			objName: lsp,
+			parent:  ls,
 			obj:     &goovn.LogicalSwitchPort{Name: lsp, UUID: FakeUUID},
 		},
 	}, nil
 }
 
+// Add logical port PORT on SWITCH with the configuration in spec
+func (mock *MockOVNClient) LSPAddFull(ls string, lsUUID string, lsp string, spec goovn.LSPSpec) (*goovn.OvnCommand, error) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	// like the client, refuse to add a port that exists
+	if _, ok := mock.cache[LogicalSwitchPortType][lsp]; ok {
+		return nil, goovn.ErrorExist
+	}
+	klog.V(5).Infof("Adding lsp %s to switch %s with its configuration", lsp, ls)
+	port := &goovn.LogicalSwitchPort{Name: lsp, UUID: FakeUUID}
+	applyLSPSpec(port, spec)
+	return &goovn.OvnCommand{
+		Exe: &MockExecution{
+			handler: mock,
+			op:      OpAdd,
+			table:   LogicalSwitchPortType,
+			objName: lsp,
+			parent:  ls,
+			obj:     port,
+		},
+	}, nil
+}
+
+// Add external logical port PORT on SWITCH, bound to the named HA chassis group
+func (mock *MockOVNClient) LSPAddExternal(ls string, lsp string, haChassisGroup string) (*goovn.OvnCommand, error) {
+	klog.V(5).Infof("Adding external lsp %s to switch %s with HA chassis group %s", lsp, ls, haChassisGroup)
+	return &goovn.OvnCommand{
+		Exe: &MockExecution{
+			handler: mock,
+			op:      OpAdd,
+			table:   LogicalSwitchPortType,
+			objName: lsp,
+			parent:  ls,
+			obj: &goovn.LogicalSwitchPort{
+				Name:           lsp,
+				UUID:           FakeUUID,
+				Type:           goovn.LSPTypeExternal,
+				HAChassisGroup: haChassisGroup,
+			},
+		},
+	}, nil
+}
+
 // Delete PORT from its attached switch
 func (mock *MockOVNClient) LSPDel(lsp string) (*goovn.OvnCommand, error) {
 	klog.V(5).Infof("Deleting lsp %s", lsp)
@@ -102,9 +179,88 @@ func (mock *MockOVNClient) LSPSetPortSecurity(lsp string, security ...string) (*
 
 // Get all lport by lswitch
 func (mock *MockOVNClient) LSPList(ls string) ([]*goovn.LogicalSwitchPort, error) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	entry, ok := mock.cache[LogicalSwitchType][ls]
+	if !ok {
+		return nil, goovn.ErrorNotFound
+	}
+	lswitch, ok := entry.(*goovn.LogicalSwitch)
+	if !ok {
+		return nil, fmt.Errorf("invalid object type assertion for %s", LogicalSwitchType)
+	}
+	ports := []*goovn.LogicalSwitchPort{}
+	for _, name := range lswitch.Ports {
+		port, err := copystructure.Copy(mock.cache[LogicalSwitchPortType][name])
+		if err != nil {
+			panic(err) // should never happen
+		}
+		lsp, ok := port.(*goovn.LogicalSwitchPort)
+		if !ok {
+			return nil, fmt.Errorf("invalid object type assertion for %s", LogicalSwitchPortType)
+		}
+		ports = append(ports, lsp)
+	}
+	return ports, nil
+}
+
+// addSwitchPort records the port on the switch it is added to, the ports
+// added to switches the mock doesn't have are not recorded
+func (mock *MockOVNClient) addSwitchPort(ls, lsp string) {
+	if lswitch, ok := mock.cache[LogicalSwitchType][ls].(*goovn.LogicalSwitch); ok {
+		lswitch.Ports = append(lswitch.Ports, lsp)
+	}
+}
+
+// delSwitchPort removes the port from the switch it was added to
+func (mock *MockOVNClient) delSwitchPort(lsp string) {
+	for _, entry := range mock.cache[LogicalSwitchType] {
+		lswitch, ok := entry.(*goovn.LogicalSwitch)
+		if !ok {
+			continue
+		}
+		for i, name := range lswitch.Ports {
+			if name == lsp {
+				lswitch.Ports = append(lswitch.Ports[:i], lswitch.Ports[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// Get the lports of lswitch with the given type
+func (mock *MockOVNClient) LSPListByType(ls, portType string) ([]*goovn.LogicalSwitchPort, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
+// Get all untyped lports whose name is not in validNames
+func (mock *MockOVNClient) OrphanLSPList(validNames map[string]bool) ([]*goovn.LogicalSwitchPort, error) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	var lspCache MockObjectCacheByName
+	var ok bool
+	if lspCache, ok = mock.cache[LogicalSwitchPortType]; !ok {
+		klog.V(5).Infof("Cache doesn't have any object of type %s", LogicalSwitchPortType)
+		return nil, goovn.ErrorSchema
+	}
+	ports := []*goovn.LogicalSwitchPort{}
+	for name, port := range lspCache {
+		lsp, ok := port.(*goovn.LogicalSwitchPort)
+		if !ok {
+			return nil, fmt.Errorf("invalid object type assertion for %s", LogicalSwitchPortType)
+		}
+		if validNames[name] || lsp.Type != "" {
+			continue
+		}
+		lspCopy, err := copystructure.Copy(lsp)
+		if err != nil {
+			panic(err) // should never happen
+		}
+		ports = append(ports, lspCopy.(*goovn.LogicalSwitchPort))
+	}
+	return ports, nil
+}
+
 // Set dhcp4_options uuid on lsp
 func (mock *MockOVNClient) LSPSetDHCPv4Options(lsp string, options string) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
@@ -147,7 +303,7 @@ func (mock *MockOVNClient) LSPGetOptions(lsp string) (map[string]string, error)
 	if err != nil {
 		return nil, err
 	}
-	if lspRet != nil {
+	if lspRet == nil {
 		return nil, fmt.Errorf("no lsp found with name: %s", lsp)
 	}
 	opts := make(map[string]string)
@@ -157,11 +313,62 @@ func (mock *MockOVNClient) LSPGetOptions(lsp string) (map[string]string, error)
 		if !keyOk || !valueOk {
 			continue
 		}
+		if key == goovn.LSPOptionRequestedChassis {
+			value = strings.Join(goovn.ParseRequestedChassis(value), ",")
+		}
 		opts[key] = value
 	}
 	return opts, nil
 }
 
+// Set the ordered list of chassis the LSP may be bound to
+func (mock *MockOVNClient) LSPSetRequestedChassis(lsp string, chassis []string) (*goovn.OvnCommand, error) {
+	return &goovn.OvnCommand{
+		Exe: &MockExecution{
+			handler: mock,
+			op:      OpUpdate,
+			table:   LogicalSwitchPortType,
+			objName: lsp,
+			objUpdate: UpdateCache{
+				FieldType:  LogicalSwitchPortRequestedChassis,
+				FieldValue: chassis,
+			},
+		},
+	}, nil
+}
+
+// Set the addresses a router type LSP answers ARP and ND requests for
+func (mock *MockOVNClient) LSPSetARPProxy(lsp string, addrs []string) (*goovn.OvnCommand, error) {
+	return &goovn.OvnCommand{
+		Exe: &MockExecution{
+			handler: mock,
+			op:      OpUpdate,
+			table:   LogicalSwitchPortType,
+			objName: lsp,
+			objUpdate: UpdateCache{
+				FieldType:  LogicalSwitchPortARPProxy,
+				FieldValue: addrs,
+			},
+		},
+	}, nil
+}
+
+// Set the addresses a router type LSP sends gratuitous ARPs for
+func (mock *MockOVNClient) LSPSetNATAddresses(lsp string, addrs []string) (*goovn.OvnCommand, error) {
+	return &goovn.OvnCommand{
+		Exe: &MockExecution{
+			handler: mock,
+			op:      OpUpdate,
+			table:   LogicalSwitchPortType,
+			objName: lsp,
+			objUpdate: UpdateCache{
+				FieldType:  LogicalSwitchPortNATAddresses,
+				FieldValue: addrs,
+			},
+		},
+	}, nil
+}
+
 // Set dynamic addresses in LSP
 func (mock *MockOVNClient) LSPSetDynamicAddresses(lsp string, address string) (*goovn.OvnCommand, error) {
 	return &goovn.OvnCommand{
@@ -228,6 +435,43 @@ func (mock *MockOVNClient) LSPGetExternalIds(lsp string) (map[string]string, err
 	return extIds, nil
 }
 
+// Set the whole port configuration for LSP
+func (mock *MockOVNClient) LSPSet(lsp string, spec goovn.LSPSpec) (*goovn.OvnCommand, error) {
+	return &goovn.OvnCommand{
+		Exe: &MockExecution{
+			handler: mock,
+			op:      OpUpdate,
+			table:   LogicalSwitchPortType,
+			objName: lsp,
+			objUpdate: UpdateCache{
+				FieldType:  LogicalSwitchPortSpec,
+				FieldValue: spec,
+			},
+		},
+	}, nil
+}
+
+// Replace addresses of an existing LSP with the MAC and IPs and its port_security with portSecurity
+func (mock *MockOVNClient) LSPUpdateAddresses(lsp string, mac net.HardwareAddr, ips []*net.IPNet, portSecurity []string) (*goovn.OvnCommand, error) {
+	addresses := []string{mac.String()}
+	for _, ip := range ips {
+		addresses = append(addresses, ip.IP.String())
+	}
+	addrs := []string{strings.Join(addresses, " ")}
+	return &goovn.OvnCommand{
+		Exe: &MockExecution{
+			handler: mock,
+			op:      OpUpdate,
+			table:   LogicalSwitchPortType,
+			objName: lsp,
+			objUpdate: UpdateCache{
+				FieldType:  LogicalSwitchPortSpec,
+				FieldValue: goovn.LSPSpec{Addresses: addrs, PortSecurity: portSecurity},
+			},
+		},
+	}, nil
+}
+
 func (mock *MockOVNClient) LSPSetType(lsp string, portType string) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
@@ -290,9 +534,87 @@ func (mock *MockOVNClient) updateLSPCache(lspName string, update UpdateCache, mo
 		} else {
 			return fmt.Errorf("type assertion failed for LSP field: %s", update.FieldType)
 		}
+	case LogicalSwitchPortRequestedChassis:
+		klog.V(5).Infof("Setting requested chassis for LSP %s", lspName)
+		chassis, ok := update.FieldValue.([]string)
+		if !ok {
+			return fmt.Errorf("type assertion failed for LSP field: %s", update.FieldType)
+		}
+		if lsp.Options == nil {
+			lsp.Options = make(map[interface{}]interface{})
+		}
+		delete(lsp.Options, goovn.LSPOptionRequestedChassis)
+		if len(chassis) > 0 {
+			lsp.Options[goovn.LSPOptionRequestedChassis] = strings.Join(chassis, ",")
+		}
+	case LogicalSwitchPortARPProxy:
+		klog.V(5).Infof("Setting arp_proxy for LSP %s", lspName)
+		addrs, ok := update.FieldValue.([]string)
+		if !ok {
+			return fmt.Errorf("type assertion failed for LSP field: %s", update.FieldType)
+		}
+		if lsp.Options == nil {
+			lsp.Options = make(map[interface{}]interface{})
+		}
+		delete(lsp.Options, goovn.LSPOptionARPProxy)
+		if len(addrs) > 0 {
+			lsp.Options[goovn.LSPOptionARPProxy] = strings.Join(addrs, " ")
+		}
+	case LogicalSwitchPortNATAddresses:
+		klog.V(5).Infof("Setting nat-addresses for LSP %s", lspName)
+		addrs, ok := update.FieldValue.([]string)
+		if !ok {
+			return fmt.Errorf("type assertion failed for LSP field: %s", update.FieldType)
+		}
+		if lsp.Options == nil {
+			lsp.Options = make(map[interface{}]interface{})
+		}
+		delete(lsp.Options, goovn.LSPOptionNATAddresses)
+		lsp.NATAddresses = nil
+		if len(addrs) > 0 {
+			lsp.Options[goovn.LSPOptionNATAddresses] = strings.Join(addrs, " ")
+			lsp.NATAddresses = addrs
+		}
+	case LogicalSwitchPortSpec:
+		klog.V(5).Infof("Setting port configuration for LSP %s", lspName)
+		spec, ok := update.FieldValue.(goovn.LSPSpec)
+		if !ok {
+			return fmt.Errorf("type assertion failed for LSP field: %s", update.FieldType)
+		}
+		applyLSPSpec(lsp, spec)
 	default:
 		return fmt.Errorf("unrecognized field type: %s", update.FieldType)
 	}
 
 	return nil
 }
+
+// applyLSPSpec sets the fields of lsp that are set in spec
+func applyLSPSpec(lsp *goovn.LogicalSwitchPort, spec goovn.LSPSpec) {
+	if spec.Type != nil {
+		lsp.Type = *spec.Type
+	}
+	if spec.Addresses != nil {
+		lsp.Addresses = spec.Addresses
+	}
+	if spec.DynamicAddresses != nil {
+		lsp.DynamicAddresses = *spec.DynamicAddresses
+	}
+	if spec.PortSecurity != nil {
+		lsp.PortSecurity = spec.PortSecurity
+	}
+	if spec.Options != nil {
+		optMap := make(map[interface{}]interface{})
+		for k, v := range spec.Options {
+			optMap[k] = v
+		}
+		lsp.Options = optMap
+	}
+	if spec.ExternalIDs != nil {
+		extMap := make(map[interface{}]interface{})
+		for k, v := range spec.ExternalIDs {
+			extMap[k] = v
+		}
+		lsp.ExternalID = extMap
+	}
+}
@@ -2,6 +2,7 @@ package testing
 
 import (
 	"fmt"
+	"reflect"
 	"runtime"
 	"sync"
 	"syscall"
@@ -15,6 +16,7 @@ const (
 	LogicalSwitchType            string = "Logical_Switch"
 	LogicalRouterType            string = "Logical_Router"
 	LogicalSwitchPortType        string = "Logical_Switch_Port"
+	LogicalRouterPortType        string = "Logical_Router_Port"
 	LogicalRouterStaticRouteType string = "Logical_Router_Static_Route"
 	ChassisType                  string = "Chassis"
 	ACLType                      string = "ACL"
@@ -45,6 +47,8 @@ type MockExecution struct {
 	objName   string
 	obj       interface{}
 	objUpdate UpdateCache
+	// parent is the name of the switch or router a port is added to
+	parent string
 }
 
 // mock ovn client for testing
@@ -83,6 +87,7 @@ func NewMockOVNClient(db string) *MockOVNClient {
 	mock.cache[LogicalSwitchType] = make(MockObjectCacheByName)
 	mock.cache[ChassisPrivateType] = make(MockObjectCacheByName)
 	mock.cache[LogicalRouterType] = make(MockObjectCacheByName)
+	mock.cache[LogicalRouterPortType] = make(MockObjectCacheByName)
 	mock.cache[LogicalRouterStaticRouteType] = make(MockObjectCacheByName)
 	mock.cache[ACLType] = make(MockObjectCacheByName)
 	mock.cache[PortGroupType] = make(MockObjectCacheByName)
@@ -160,11 +165,23 @@ func (mock *MockOVNClient) ExecuteMockCommand(e *MockExecution) error {
 			return fmt.Errorf("object %s of type %s exists in cache", e.objName, e.table)
 		}
 		cache[e.objName] = e.obj
+		switch e.table {
+		case LogicalSwitchPortType:
+			mock.addSwitchPort(e.parent, e.objName)
+		case LogicalRouterPortType:
+			mock.addRouterPort(e.parent, e.objName)
+		}
 	case OpDelete:
 		if cache, ok = mock.cache[e.table]; !ok {
 			return fmt.Errorf("command to delete entry from %s when cache doesn't exist", e.table)
 		}
 		delete(cache, e.objName)
+		switch e.table {
+		case LogicalSwitchPortType:
+			mock.delSwitchPort(e.objName)
+		case LogicalRouterPortType:
+			mock.delRouterPort(e.objName)
+		}
 	case OpUpdate:
 		if cache, ok = mock.cache[e.table]; !ok {
 			return fmt.Errorf("command to update entry from %s when cache doesn't exist", e.table)
@@ -203,6 +220,36 @@ func (mock *MockOVNClient) Execute(cmds ...*goovn.OvnCommand) error {
 	return aggErrors.NewAggregate(errors)
 }
 
+// Exec command, support multiple commands in one transaction.
+// returns the UUIDs of the objects added by the commands
+func (mock *MockOVNClient) ExecuteR(cmds ...*goovn.OvnCommand) ([]string, error) {
+	if err := mock.Execute(cmds...); err != nil {
+		return nil, err
+	}
+	var uuids []string
+	for _, cmd := range cmds {
+		exe := cmd.Exe.(*MockExecution)
+		if exe.op != OpAdd || exe.obj == nil {
+			continue
+		}
+		uuid := reflect.Indirect(reflect.ValueOf(exe.obj)).FieldByName("UUID")
+		if uuid.IsValid() && uuid.Kind() == reflect.String && uuid.String() != "" {
+			uuids = append(uuids, uuid.String())
+		}
+	}
+	return uuids, nil
+}
+
+// Same as ExecuteR, options don't apply to the mock client
+func (mock *MockOVNClient) ExecuteROptions(opts []goovn.ExecuteOption, cmds ...*goovn.OvnCommand) ([]string, error) {
+	return mock.ExecuteR(cmds...)
+}
+
+// Same as ExecuteR, the mock client never loses its connection
+func (mock *MockOVNClient) ExecuteRRetry(opts goovn.RetryOpts, cmds ...*goovn.OvnCommand) ([]string, error) {
+	return mock.ExecuteR(cmds...)
+}
+
 // updateCache takes an object by name objName and updates it's fields specified as
 // update in the mock ovn client's db cache
 // It also allows faking errors in command execution during updates
@@ -213,6 +260,8 @@ func (mock *MockOVNClient) updateCache(table string, objName string, update Upda
 		return cachedErr
 	}
 	switch table {
+	case LogicalSwitchType:
+		return mock.updateLSCache(objName, update, mockCache)
 	case LogicalSwitchPortType:
 		return mock.updateLSPCache(objName, update, mockCache)
 	case PortGroupType:
@@ -2,6 +2,8 @@ package testing
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
 
 	goovn "github.com/ebay/go-ovn"
 	"github.com/mitchellh/copystructure"
@@ -41,6 +43,16 @@ func (mock *MockOVNClient) PortGroupAdd(group string, ports []string, external_i
 	}, nil
 }
 
+// Creates a new port group together with its ACLs in a single transaction
+func (mock *MockOVNClient) PortGroupAddWithACLs(group string, ports []string, acls []goovn.ACLSpec, external_ids map[string]string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Creates a new port group together with an address set holding the IPs of its ports in a single transaction
+func (mock *MockOVNClient) PortGroupAddWithAddressSet(group string, ports []string, asName string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 // Sets "ports" and/or "external_ids" on the port group named "group". It is an error if group does not exist.
 func (mock *MockOVNClient) PortGroupUpdate(group string, ports []string, external_ids map[string]string) (*goovn.OvnCommand, error) {
 	var pg *goovn.PortGroup
@@ -120,6 +132,38 @@ func (mock *MockOVNClient) PortGroupRemovePort(group string, port string) (*goov
 	}, nil
 }
 
+// Add the desired ports missing from the port group and remove the others.
+func (mock *MockOVNClient) PortGroupReconcilePorts(group string, desired []string) (*goovn.OvnCommand, error) {
+	var pg *goovn.PortGroup
+	if pg, _ = mock.PortGroupGet(group); pg == nil {
+		return nil, goovn.ErrorNotFound
+	}
+
+	// the mock code uses the same UUID for all LSPs, so the ports are compared
+	// with their duplicates, as PortGroupUpdate would set them
+	ports := append([]string{}, desired...)
+	current := append([]string{}, pg.Ports...)
+	sort.Strings(ports)
+	sort.Strings(current)
+	if reflect.DeepEqual(ports, current) {
+		return nil, goovn.ErrorNoChanges
+	}
+
+	return &goovn.OvnCommand{
+		Exe: &MockExecution{
+			handler: mock,
+			op:      OpUpdate,
+			table:   PortGroupType,
+			objName: group,
+			objUpdate: UpdateCache{
+				FieldType:  PgLSPs,
+				FieldValue: desired,
+				UpdateOp:   OpUpdate,
+			},
+		},
+	}, nil
+}
+
 // Deletes port group "group". It is an error if "group" does not exist.
 func (mock *MockOVNClient) PortGroupDel(group string) (*goovn.OvnCommand, error) {
 	if _, err := mock.PortGroupGet(group); err != nil {
@@ -168,6 +212,16 @@ func (mock *MockOVNClient) PortGroupGet(group string) (*goovn.PortGroup, error)
 	return nil, fmt.Errorf("invalid object type assertion for %s", PortGroupType)
 }
 
+// Report whether a match refers to the port group
+func (mock *MockOVNClient) PortGroupIsReferenced(group string) (bool, []string, error) {
+	return false, nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Get the UUIDs of the acls the port group refers to that do not exist
+func (mock *MockOVNClient) PortGroupCheckDanglingACLs(group string) ([]string, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 func listContains(list []string, element string) bool {
 	for _, e := range list {
 		if element == e {
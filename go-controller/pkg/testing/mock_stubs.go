@@ -1,7 +1,10 @@
 package testing
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"time"
 
 	goovn "github.com/ebay/go-ovn"
 	libovsdb "github.com/ebay/libovsdb"
@@ -12,6 +15,11 @@ func (mock *MockOVNClient) ACLAddEntity(entityType goovn.EntityType, entityName,
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
+// Add the ACL described by spec to entity (PORT_GROUP or LOGICAL_SWITCH)
+func (mock *MockOVNClient) ACLAddSpec(entityType goovn.EntityType, entityName string, spec goovn.ACLSpec) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 // Delete acl from entity (PORT_GROUP or LOGICAL_SWITCH)
 func (mock *MockOVNClient) ACLDelEntity(entityType goovn.EntityType, entityName, aclUUID string) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
@@ -27,10 +35,35 @@ func (mock *MockOVNClient) ACLListEntity(entityType goovn.EntityType, entity str
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
+// Get the pairs of acls of an entity sharing a direction and priority but with different actions
+func (mock *MockOVNClient) ACLCheckPriorityCollisions(entityType goovn.EntityType, entity string) ([]goovn.ACLCollision, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Get the UUIDs of the acls that no port group or logical switch refers to
+func (mock *MockOVNClient) ACLCheckOrphaned() ([]string, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 func (mock *MockOVNClient) ACLSetLogging(aclUUID string, newLogflag bool, newMeter, newSeverity string) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
+// Set the meter rate limiting the logging of an ACL
+func (mock *MockOVNClient) ACLSetMeter(aclUUID, meter string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Set the Sample rows of an ACL
+func (mock *MockOVNClient) ACLSetSamples(aclUUID string, sampleNew, sampleEst *string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Set all the columns of an existing ACL to the ones described by spec
+func (mock *MockOVNClient) ACLSet(aclUUID string, spec goovn.ACLSpec) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 func (mock *MockOVNClient) ACLSetMatch(aclUUID, newMatch string) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
@@ -49,8 +82,23 @@ func (mock *MockOVNClient) ASGet(name string) (*goovn.AddressSet, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
+// Report whether a match refers to the AS
+func (mock *MockOVNClient) ASIsReferenced(name string) (bool, []string, error) {
+	return false, nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 // Update address set
-func (mock *MockOVNClient) ASUpdate(name string, addrs []string, external_ids map[string]string) (*goovn.OvnCommand, error) {
+func (mock *MockOVNClient) ASUpdate(name, uuid string, addrs []string, external_ids map[string]string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Add IPs to address set
+func (mock *MockOVNClient) ASAddIPs(name, uuid string, addrs []string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Delete IPs from address set
+func (mock *MockOVNClient) ASDelIPs(name, uuid string, addrs []string) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
@@ -59,6 +107,11 @@ func (mock *MockOVNClient) ASAdd(name string, addrs []string, external_ids map[s
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
+// Add address sets in one command
+func (mock *MockOVNClient) ASAddMany(sets []goovn.AddressSetSpec) (*goovn.OvnCommand, []string, error) {
+	return nil, nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 // Delete addressset
 func (mock *MockOVNClient) ASDel(name string) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
@@ -79,6 +132,11 @@ func (mock *MockOVNClient) LBAdd(name string, vipPort string, protocol string, a
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
+// Add template LB whose VIP and backends reference Chassis_Template_Var variables
+func (mock *MockOVNClient) LBAddTemplate(name string, vipTemplate string, protocol string, backendTemplate string, options map[string]string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 // Delete LB with given name
 func (mock *MockOVNClient) LBDel(name string) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
@@ -94,6 +152,76 @@ func (mock *MockOVNClient) LBSetSelectionFields(name string, selectionFields str
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
+// Set or clear options:reject on the LB
+func (mock *MockOVNClient) ConfigureServiceReject(name string, reject bool) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Set or clear options:hairpin_snat_ip on the LB
+func (mock *MockOVNClient) LBSetHairpinSNATIP(name string, v4, v6 string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Get whether the LB rejects the traffic to a VIP without backends
+func (mock *MockOVNClient) LBGetReject(name string) (bool, error) {
+	return false, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Get the LS and LR a load balancer is attached to
+func (mock *MockOVNClient) LBGetAttachments(name string) (switches, routers []string, err error) {
+	return nil, nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Add a load balancer group with the given LB UUIDs
+func (mock *MockOVNClient) LBGroupAdd(name string, lbs []string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Delete a load balancer group by name
+func (mock *MockOVNClient) LBGroupDel(name string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// List all load balancer groups
+func (mock *MockOVNClient) LBGroupList() ([]*goovn.LoadBalancerGroup, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Set the load balancer groups of a LS
+func (mock *MockOVNClient) LSSetLBGroup(ls string, groups []string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Get the load balancer groups of a LS
+func (mock *MockOVNClient) LSGetLBGroups(ls string) ([]string, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Get the QoS rules attached to a LS
+func (mock *MockOVNClient) LSListQoSRules(ls string) ([]string, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Set the Copp row of a LS
+func (mock *MockOVNClient) LSSetCopp(ls string, copp *string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Create or update the DNS row of a LS
+func (mock *MockOVNClient) ConfigureNodeDNS(ls string, records map[string]string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Set the load balancer groups of a LR
+func (mock *MockOVNClient) LRSetLBGroup(lr string, groups []string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Get the load balancer groups of a LR
+func (mock *MockOVNClient) LRGetLBGroups(lr string) ([]string, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 // Add dhcp options for cidr and provided external_ids
 func (mock *MockOVNClient) DHCPOptionsAdd(cidr string, options map[string]string, external_ids map[string]string) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
@@ -119,11 +247,56 @@ func (mock *MockOVNClient) DHCPOptionsList() ([]*goovn.DHCPOptions, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
+// Add a DHCP relay to the given comma-separated server IPs
+func (mock *MockOVNClient) DHCPRelayAdd(name, serverIPs string, options map[string]string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Delete a DHCP relay by name
+func (mock *MockOVNClient) DHCPRelayDel(name string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// List all DHCP relays
+func (mock *MockOVNClient) DHCPRelayList() ([]*goovn.DHCPRelay, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Relay DHCP on the switch of a router type LSP through its peer router port
+func (mock *MockOVNClient) LSPSetDHCPRelay(lsp, relay string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Pin the MAC of an IP reachable through a logical router port
+func (mock *MockOVNClient) StaticMACBindingAdd(logicalPort, ip, mac string, overrideDynamic bool) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Delete the static MAC binding of an IP on a logical router port
+func (mock *MockOVNClient) StaticMACBindingDel(logicalPort, ip string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// List all static MAC bindings
+func (mock *MockOVNClient) StaticMACBindingList() ([]*goovn.StaticMACBinding, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 // Add qos rule
 func (mock *MockOVNClient) QoSAdd(ls string, direction string, priority int, match string, action map[string]int, bandwidth map[string]int, external_ids map[string]string) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
+// Add qos rule limiting the matching traffic to rateKbps with bursts of up to burstKb
+func (mock *MockOVNClient) QoSAddRateLimit(ls string, direction string, priority int, match string, rateKbps, burstKb int) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Add qos rule marking the matching traffic with the given DSCP value
+func (mock *MockOVNClient) QoSAddDSCP(ls string, direction string, priority int, match string, dscp int) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 // Del qos rule, to delete wildcard specify priority -1 and string options as ""
 func (mock *MockOVNClient) QoSDel(ls string, direction string, priority int, match string) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
@@ -139,6 +312,11 @@ func (mock *MockOVNClient) LRNATAdd(lr string, ntype string, externalIp string,
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
+// Add a dnat_and_snat NAT to Logical Router, distributed on the chassis of the given logical switch port
+func (mock *MockOVNClient) LRNATAddDNATAndSNAT(lr, externalIP, logicalIP, logicalPort, externalMAC string, external_ids map[string]string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 //Del NAT from Logical Router
 func (mock *MockOVNClient) LRNATDel(lr string, ntype string, ip ...string) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
@@ -149,6 +327,11 @@ func (mock *MockOVNClient) LRNATList(lr string) ([]*goovn.NAT, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
+// Get every NAT rule of every Logical Router
+func (mock *MockOVNClient) NATListAll() ([]*goovn.NAT, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 func (mock *MockOVNClient) LRPolicyAdd(lr string, priority int, match string, action string, nexthop *string, nexthops []string, options map[string]string, external_ids map[string]string) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
@@ -162,6 +345,11 @@ func (mock *MockOVNClient) LRPolicyDelByUUID(lr string, uuid string) (*goovn.Ovn
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
+// Delete LR policies by UUID in a single operation
+func (mock *MockOVNClient) LRPolicyDelByUUIDs(lr string, uuids []string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 // Delete all LRPolicies
 func (mock *MockOVNClient) LRPolicyDelAll(lr string) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
@@ -172,11 +360,21 @@ func (mock *MockOVNClient) LRPolicyList(lr string) ([]*goovn.LogicalRouterPolicy
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
+// Set the pkt_mark option of a reroute or allow policy of the LR
+func (mock *MockOVNClient) LRPolicySetPktMark(lr string, policyUUID string, mark uint32) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 // Add Meter with a Meter Band
 func (mock *MockOVNClient) MeterAdd(name, action string, rate int, unit string, external_ids map[string]string, burst int) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
+// Add Meter with one Meter Band per band spec
+func (mock *MockOVNClient) MeterAddBands(name, unit string, fair bool, bands []goovn.MeterBandSpec, external_ids map[string]string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 // Deletes meters
 func (mock *MockOVNClient) MeterDel(name ...string) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
@@ -203,6 +401,11 @@ func (mock *MockOVNClient) EncapList(chname string) ([]*goovn.Encap, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
+// Update in place the encap ip of the chassis with given name or hostname
+func (mock *MockOVNClient) ReconcileChassisEncap(chassisName, newIP string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 // List Chassis rows in chassis_private table
 func (mock *MockOVNClient) ChassisPrivateList() ([]*goovn.ChassisPrivate, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
@@ -213,6 +416,16 @@ func (mock *MockOVNClient) ChassisPrivateGet(chName string) ([]*goovn.ChassisPri
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
+// List datapaths in datapath_binding table
+func (mock *MockOVNClient) DatapathBindingList() ([]*goovn.DatapathBinding, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Get the datapaths of the logical switch or router with the given uuid or name
+func (mock *MockOVNClient) DatapathBindingGet(externalID string) ([]*goovn.DatapathBinding, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 // Set NB_Global table options
 func (mock *MockOVNClient) NBGlobalSetOptions(options map[string]string) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
@@ -223,6 +436,46 @@ func (mock *MockOVNClient) NBGlobalGetOptions() (map[string]string, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
+// Get the mac_prefix option of NB_Global
+func (mock *MockOVNClient) NBGlobalGetMACPrefix() (string, error) {
+	return "", fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Set the mac_prefix option of NB_Global, the first three octets of a unicast MAC
+func (mock *MockOVNClient) NBGlobalSetMACPrefix(prefix string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Get the svc_monitor_mac option of NB_Global
+func (mock *MockOVNClient) NBGlobalGetSvcMonitorMAC() (net.HardwareAddr, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Set the svc_monitor_mac option of NB_Global to a unicast MAC
+func (mock *MockOVNClient) NBGlobalSetSvcMonitorMAC(mac net.HardwareAddr) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Get the max_tunid option ovn-northd sets on NB_Global
+func (mock *MockOVNClient) NBGlobalGetMaxTunID() (int, error) {
+	return 0, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Get the northd_probe_interval option of NB_Global, in milliseconds
+func (mock *MockOVNClient) NBGlobalGetNorthdProbeInterval() (int, error) {
+	return 0, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Set the northd_probe_interval option of NB_Global, in milliseconds, 0 disabling the probes
+func (mock *MockOVNClient) NBGlobalSetNorthdProbeInterval(interval int) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Increment nb_cfg of NB_Global in a transaction of its own and return its new value
+func (mock *MockOVNClient) NBGlobalIncrementNbCfg() (int, error) {
+	return 0, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
 // Set SB_Global table options
 func (mock *MockOVNClient) SBGlobalSetOptions(options map[string]string) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
@@ -233,15 +486,75 @@ func (mock *MockOVNClient) SBGlobalGetOptions() (map[string]string, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
-func (mock *MockOVNClient) AuxKeyValDel(table string, rowName string, auxCol string, kv map[string]*string) (*goovn.OvnCommand, error) {
+// Get the nb_cfg ovn-northd last synced SB_Global with
+func (mock *MockOVNClient) SBGlobalGetNbCfg() (int, error) {
+	return 0, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+func (mock *MockOVNClient) GetExternalIDs(table string, rowName string) (map[string]string, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+func (mock *MockOVNClient) FindDuplicateNames(table string) (map[string][]string, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
-func (mock *MockOVNClient) AuxKeyValSet(table string, rowName string, auxCol string, kv map[string]string) (*goovn.OvnCommand, error) {
+func (mock *MockOVNClient) DedupByName(table, name, keep string) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
-func (mock *MockOVNClient) ExecuteR(cmds ...*goovn.OvnCommand) ([]string, error) {
+func (mock *MockOVNClient) Rename(table, oldName, newName string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+func (mock *MockOVNClient) BulkExternalIDSet(table string, rowNames []string, kv map[string]string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+func (mock *MockOVNClient) DelByExternalID(table, key, value string) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+func (mock *MockOVNClient) MutateColumn(table, rowName, column string, mutator string, value interface{}) (*goovn.OvnCommand, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Get a point-in-time copy of the cache serving the getters, and the function releasing it
+func (mock *MockOVNClient) Snapshot() (*goovn.CacheSnapshot, func()) {
+	return nil, func() {}
+}
+
+func (mock *MockOVNClient) CurrentTxn() string {
+	return ""
+}
+
+// Get the time the cache last received an update
+func (mock *MockOVNClient) LastUpdateTime() time.Time {
+	return time.Time{}
+}
+
+// Get the transaction counts by table
+func (mock *MockOVNClient) TransactionCounts() map[string]goovn.TxnCounts {
+	return nil
+}
+
+// Wait until ovn-controller reports the LSP as up
+func (mock *MockOVNClient) WaitForLSPUp(ctx context.Context, lsp string) error {
+	return fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Get the up state and the chassis binding of the LSP
+func (mock *MockOVNClient) LSPStatus(lsp string) (*goovn.LSPStatus, error) {
+	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Get the name of the chassis the Port_Binding of the logical port is bound to
+func (mock *MockOVNClient) PortBindingChassis(logicalPort string) (string, error) {
+	return "", fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Move a LSP to another LS
+func (mock *MockOVNClient) LSPReattach(lsp, newSwitch string) (*goovn.OvnCommand, error) {
 	return nil, fmt.Errorf("method %s is not implemented yet", functionName())
 }
 
@@ -250,3 +563,18 @@ func (mock *MockOVNClient) GetSchema() libovsdb.DatabaseSchema {
 	var dbSchema libovsdb.DatabaseSchema
 	return dbSchema
 }
+
+// Request the OVSDB lock with the given id
+func (mock *MockOVNClient) Lock(id string) error {
+	return fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Take the OVSDB lock with the given id away from its current owner
+func (mock *MockOVNClient) Steal(id string) error {
+	return fmt.Errorf("method %s is not implemented yet", functionName())
+}
+
+// Release the OVSDB lock with the given id
+func (mock *MockOVNClient) Unlock(id string) error {
+	return fmt.Errorf("method %s is not implemented yet", functionName())
+}
@@ -3,8 +3,11 @@
 package mocks
 
 import (
+	context "context"
 	goovn "github.com/ebay/go-ovn"
 	libovsdb "github.com/ebay/libovsdb"
+	net "net"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -60,6 +63,75 @@ func (_m *Client) ACLAddEntity(entityType goovn.EntityType, entityName string, a
 	return r0, r1
 }
 
+// ACLAddSpec provides a mock function with given fields: entityType, entityName, spec
+func (_m *Client) ACLAddSpec(entityType goovn.EntityType, entityName string, spec goovn.ACLSpec) (*goovn.OvnCommand, error) {
+	ret := _m.Called(entityType, entityName, spec)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(goovn.EntityType, string, goovn.ACLSpec) *goovn.OvnCommand); ok {
+		r0 = rf(entityType, entityName, spec)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(goovn.EntityType, string, goovn.ACLSpec) error); ok {
+		r1 = rf(entityType, entityName, spec)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ACLCheckOrphaned provides a mock function with given fields:
+func (_m *Client) ACLCheckOrphaned() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ACLCheckPriorityCollisions provides a mock function with given fields: entityType, entityName
+func (_m *Client) ACLCheckPriorityCollisions(entityType goovn.EntityType, entityName string) ([]goovn.ACLCollision, error) {
+	ret := _m.Called(entityType, entityName)
+
+	var r0 []goovn.ACLCollision
+	if rf, ok := ret.Get(0).(func(goovn.EntityType, string) []goovn.ACLCollision); ok {
+		r0 = rf(entityType, entityName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]goovn.ACLCollision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(goovn.EntityType, string) error); ok {
+		r1 = rf(entityType, entityName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ACLDel provides a mock function with given fields: ls, direct, match, priority, external_ids
 func (_m *Client) ACLDel(ls string, direct string, match string, priority int, external_ids map[string]string) (*goovn.OvnCommand, error) {
 	ret := _m.Called(ls, direct, match, priority, external_ids)
@@ -152,6 +224,29 @@ func (_m *Client) ACLListEntity(entityType goovn.EntityType, entityName string)
 	return r0, r1
 }
 
+// ACLSet provides a mock function with given fields: aclUUID, spec
+func (_m *Client) ACLSet(aclUUID string, spec goovn.ACLSpec) (*goovn.OvnCommand, error) {
+	ret := _m.Called(aclUUID, spec)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, goovn.ACLSpec) *goovn.OvnCommand); ok {
+		r0 = rf(aclUUID, spec)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, goovn.ACLSpec) error); ok {
+		r1 = rf(aclUUID, spec)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ACLSetLogging provides a mock function with given fields: aclUUID, newLogflag, newMeter, newSeverity
 func (_m *Client) ACLSetLogging(aclUUID string, newLogflag bool, newMeter string, newSeverity string) (*goovn.OvnCommand, error) {
 	ret := _m.Called(aclUUID, newLogflag, newMeter, newSeverity)
@@ -198,6 +293,29 @@ func (_m *Client) ACLSetMatch(aclUUID string, newMatch string) (*goovn.OvnComman
 	return r0, r1
 }
 
+// ACLSetMeter provides a mock function with given fields: aclUUID, meter
+func (_m *Client) ACLSetMeter(aclUUID string, meter string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(aclUUID, meter)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
+		r0 = rf(aclUUID, meter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(aclUUID, meter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ACLSetName provides a mock function with given fields: aclUUID, aclName
 func (_m *Client) ACLSetName(aclUUID string, aclName string) (*goovn.OvnCommand, error) {
 	ret := _m.Called(aclUUID, aclName)
@@ -221,6 +339,29 @@ func (_m *Client) ACLSetName(aclUUID string, aclName string) (*goovn.OvnCommand,
 	return r0, r1
 }
 
+// ACLSetSamples provides a mock function with given fields: aclUUID, sampleNew, sampleEst
+func (_m *Client) ACLSetSamples(aclUUID string, sampleNew *string, sampleEst *string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(aclUUID, sampleNew, sampleEst)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, *string, *string) *goovn.OvnCommand); ok {
+		r0 = rf(aclUUID, sampleNew, sampleEst)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *string, *string) error); ok {
+		r1 = rf(aclUUID, sampleNew, sampleEst)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ASAdd provides a mock function with given fields: name, addrs, external_ids
 func (_m *Client) ASAdd(name string, addrs []string, external_ids map[string]string) (*goovn.OvnCommand, error) {
 	ret := _m.Called(name, addrs, external_ids)
@@ -244,6 +385,61 @@ func (_m *Client) ASAdd(name string, addrs []string, external_ids map[string]str
 	return r0, r1
 }
 
+// ASAddIPs provides a mock function with given fields: name, uuid, addrs
+func (_m *Client) ASAddIPs(name string, uuid string, addrs []string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(name, uuid, addrs)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, []string) *goovn.OvnCommand); ok {
+		r0 = rf(name, uuid, addrs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, []string) error); ok {
+		r1 = rf(name, uuid, addrs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ASAddMany provides a mock function with given fields: sets
+func (_m *Client) ASAddMany(sets []goovn.AddressSetSpec) (*goovn.OvnCommand, []string, error) {
+	ret := _m.Called(sets)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func([]goovn.AddressSetSpec) *goovn.OvnCommand); ok {
+		r0 = rf(sets)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 []string
+	if rf, ok := ret.Get(1).(func([]goovn.AddressSetSpec) []string); ok {
+		r1 = rf(sets)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func([]goovn.AddressSetSpec) error); ok {
+		r2 = rf(sets)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ASDel provides a mock function with given fields: name
 func (_m *Client) ASDel(name string) (*goovn.OvnCommand, error) {
 	ret := _m.Called(name)
@@ -267,6 +463,29 @@ func (_m *Client) ASDel(name string) (*goovn.OvnCommand, error) {
 	return r0, r1
 }
 
+// ASDelIPs provides a mock function with given fields: name, uuid, addrs
+func (_m *Client) ASDelIPs(name string, uuid string, addrs []string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(name, uuid, addrs)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, []string) *goovn.OvnCommand); ok {
+		r0 = rf(name, uuid, addrs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, []string) error); ok {
+		r1 = rf(name, uuid, addrs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ASGet provides a mock function with given fields: name
 func (_m *Client) ASGet(name string) (*goovn.AddressSet, error) {
 	ret := _m.Called(name)
@@ -290,6 +509,36 @@ func (_m *Client) ASGet(name string) (*goovn.AddressSet, error) {
 	return r0, r1
 }
 
+// ASIsReferenced provides a mock function with given fields: name
+func (_m *Client) ASIsReferenced(name string) (bool, []string, error) {
+	ret := _m.Called(name)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 []string
+	if rf, ok := ret.Get(1).(func(string) []string); ok {
+		r1 = rf(name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(name)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ASList provides a mock function with given fields:
 func (_m *Client) ASList() ([]*goovn.AddressSet, error) {
 	ret := _m.Called()
@@ -313,13 +562,13 @@ func (_m *Client) ASList() ([]*goovn.AddressSet, error) {
 	return r0, r1
 }
 
-// ASUpdate provides a mock function with given fields: name, addrs, external_ids
-func (_m *Client) ASUpdate(name string, addrs []string, external_ids map[string]string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(name, addrs, external_ids)
+// ASUpdate provides a mock function with given fields: name, uuid, addrs, external_ids
+func (_m *Client) ASUpdate(name string, uuid string, addrs []string, external_ids map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(name, uuid, addrs, external_ids)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, []string, map[string]string) *goovn.OvnCommand); ok {
-		r0 = rf(name, addrs, external_ids)
+	if rf, ok := ret.Get(0).(func(string, string, []string, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(name, uuid, addrs, external_ids)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -327,8 +576,8 @@ func (_m *Client) ASUpdate(name string, addrs []string, external_ids map[string]
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, []string, map[string]string) error); ok {
-		r1 = rf(name, addrs, external_ids)
+	if rf, ok := ret.Get(1).(func(string, string, []string, map[string]string) error); ok {
+		r1 = rf(name, uuid, addrs, external_ids)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -382,6 +631,29 @@ func (_m *Client) AuxKeyValSet(table string, rowName string, auxCol string, kv m
 	return r0, r1
 }
 
+// BulkExternalIDSet provides a mock function with given fields: table, rowNames, kv
+func (_m *Client) BulkExternalIDSet(table string, rowNames []string, kv map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(table, rowNames, kv)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, []string, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(table, rowNames, kv)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []string, map[string]string) error); ok {
+		r1 = rf(table, rowNames, kv)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ChassisAdd provides a mock function with given fields: name, hostname, etype, ip, external_ids, transport_zones, vtep_lswitches
 func (_m *Client) ChassisAdd(name string, hostname string, etype []string, ip string, external_ids map[string]string, transport_zones []string, vtep_lswitches []string) (*goovn.OvnCommand, error) {
 	ret := _m.Called(name, hostname, etype, ip, external_ids, transport_zones, vtep_lswitches)
@@ -474,6 +746,29 @@ func (_m *Client) ChassisList() ([]*goovn.Chassis, error) {
 	return r0, r1
 }
 
+// ChassisListByTransportZone provides a mock function with given fields: zone
+func (_m *Client) ChassisListByTransportZone(zone string) ([]*goovn.Chassis, error) {
+	ret := _m.Called(zone)
+
+	var r0 []*goovn.Chassis
+	if rf, ok := ret.Get(0).(func(string) []*goovn.Chassis); ok {
+		r0 = rf(zone)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*goovn.Chassis)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(zone)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ChassisPrivateDel provides a mock function with given fields: chName
 func (_m *Client) ChassisPrivateDel(chName string) (*goovn.OvnCommand, error) {
 	ret := _m.Called(chName)
@@ -557,6 +852,66 @@ func (_m *Client) Close() error {
 	return r0
 }
 
+// ConfigureNodeDNS provides a mock function with given fields: ls, records
+func (_m *Client) ConfigureNodeDNS(ls string, records map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(ls, records)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(ls, records)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, map[string]string) error); ok {
+		r1 = rf(ls, records)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConfigureServiceReject provides a mock function with given fields: name, reject
+func (_m *Client) ConfigureServiceReject(name string, reject bool) (*goovn.OvnCommand, error) {
+	ret := _m.Called(name, reject)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, bool) *goovn.OvnCommand); ok {
+		r0 = rf(name, reject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, bool) error); ok {
+		r1 = rf(name, reject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CurrentTxn provides a mock function with given fields:
+func (_m *Client) CurrentTxn() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // DHCPOptionsAdd provides a mock function with given fields: cidr, options, external_ids
 func (_m *Client) DHCPOptionsAdd(cidr string, options map[string]string, external_ids map[string]string) (*goovn.OvnCommand, error) {
 	ret := _m.Called(cidr, options, external_ids)
@@ -672,7 +1027,168 @@ func (_m *Client) DHCPOptionsSet(uuid string, options map[string]string, externa
 	return r0, r1
 }
 
-// EncapList provides a mock function with given fields: chname
+// DHCPRelayAdd provides a mock function with given fields: name, serverIPs, options
+func (_m *Client) DHCPRelayAdd(name string, serverIPs string, options map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(name, serverIPs, options)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(name, serverIPs, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, map[string]string) error); ok {
+		r1 = rf(name, serverIPs, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DHCPRelayDel provides a mock function with given fields: name
+func (_m *Client) DHCPRelayDel(name string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(name)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string) *goovn.OvnCommand); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DHCPRelayList provides a mock function with given fields:
+func (_m *Client) DHCPRelayList() ([]*goovn.DHCPRelay, error) {
+	ret := _m.Called()
+
+	var r0 []*goovn.DHCPRelay
+	if rf, ok := ret.Get(0).(func() []*goovn.DHCPRelay); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*goovn.DHCPRelay)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DatapathBindingGet provides a mock function with given fields: externalID
+func (_m *Client) DatapathBindingGet(externalID string) ([]*goovn.DatapathBinding, error) {
+	ret := _m.Called(externalID)
+
+	var r0 []*goovn.DatapathBinding
+	if rf, ok := ret.Get(0).(func(string) []*goovn.DatapathBinding); ok {
+		r0 = rf(externalID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*goovn.DatapathBinding)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(externalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DatapathBindingList provides a mock function with given fields:
+func (_m *Client) DatapathBindingList() ([]*goovn.DatapathBinding, error) {
+	ret := _m.Called()
+
+	var r0 []*goovn.DatapathBinding
+	if rf, ok := ret.Get(0).(func() []*goovn.DatapathBinding); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*goovn.DatapathBinding)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DedupByName provides a mock function with given fields: table, name, keep
+func (_m *Client) DedupByName(table string, name string, keep string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(table, name, keep)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, string) *goovn.OvnCommand); ok {
+		r0 = rf(table, name, keep)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(table, name, keep)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DelByExternalID provides a mock function with given fields: table, key, value
+func (_m *Client) DelByExternalID(table string, key string, value string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(table, key, value)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, string) *goovn.OvnCommand); ok {
+		r0 = rf(table, key, value)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(table, key, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EncapList provides a mock function with given fields: chname
 func (_m *Client) EncapList(chname string) ([]*goovn.Encap, error) {
 	ret := _m.Called(chname)
 
@@ -744,6 +1260,112 @@ func (_m *Client) ExecuteR(cmds ...*goovn.OvnCommand) ([]string, error) {
 	return r0, r1
 }
 
+// ExecuteROptions provides a mock function with given fields: opts, cmds
+func (_m *Client) ExecuteROptions(opts []goovn.ExecuteOption, cmds ...*goovn.OvnCommand) ([]string, error) {
+	_va := make([]interface{}, len(cmds))
+	for _i := range cmds {
+		_va[_i] = cmds[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, opts)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func([]goovn.ExecuteOption, ...*goovn.OvnCommand) []string); ok {
+		r0 = rf(opts, cmds...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]goovn.ExecuteOption, ...*goovn.OvnCommand) error); ok {
+		r1 = rf(opts, cmds...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExecuteRRetry provides a mock function with given fields: opts, cmds
+func (_m *Client) ExecuteRRetry(opts goovn.RetryOpts, cmds ...*goovn.OvnCommand) ([]string, error) {
+	_va := make([]interface{}, len(cmds))
+	for _i := range cmds {
+		_va[_i] = cmds[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, opts)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(goovn.RetryOpts, ...*goovn.OvnCommand) []string); ok {
+		r0 = rf(opts, cmds...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(goovn.RetryOpts, ...*goovn.OvnCommand) error); ok {
+		r1 = rf(opts, cmds...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDuplicateNames provides a mock function with given fields: table
+func (_m *Client) FindDuplicateNames(table string) (map[string][]string, error) {
+	ret := _m.Called(table)
+
+	var r0 map[string][]string
+	if rf, ok := ret.Get(0).(func(string) map[string][]string); ok {
+		r0 = rf(table)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(table)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExternalIDs provides a mock function with given fields: table, rowName
+func (_m *Client) GetExternalIDs(table string, rowName string) (map[string]string, error) {
+	ret := _m.Called(table, rowName)
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func(string, string) map[string]string); ok {
+		r0 = rf(table, rowName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(table, rowName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSchema provides a mock function with given fields:
 func (_m *Client) GetSchema() libovsdb.DatabaseSchema {
 	ret := _m.Called()
@@ -781,22 +1403,1446 @@ func (_m *Client) LBAdd(name string, vipPort string, protocol string, addrs []st
 	return r0, r1
 }
 
-// LBDel provides a mock function with given fields: name
-func (_m *Client) LBDel(name string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(name)
+// LBAddTemplate provides a mock function with given fields: name, vipTemplate, protocol, backendTemplate, options
+func (_m *Client) LBAddTemplate(name string, vipTemplate string, protocol string, backendTemplate string, options map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(name, vipTemplate, protocol, backendTemplate, options)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, string, string, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(name, vipTemplate, protocol, backendTemplate, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, string, map[string]string) error); ok {
+		r1 = rf(name, vipTemplate, protocol, backendTemplate, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LBDel provides a mock function with given fields: name
+func (_m *Client) LBDel(name string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(name)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string) *goovn.OvnCommand); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LBGet provides a mock function with given fields: name
+func (_m *Client) LBGet(name string) ([]*goovn.LoadBalancer, error) {
+	ret := _m.Called(name)
+
+	var r0 []*goovn.LoadBalancer
+	if rf, ok := ret.Get(0).(func(string) []*goovn.LoadBalancer); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*goovn.LoadBalancer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LBGetAttachments provides a mock function with given fields: name
+func (_m *Client) LBGetAttachments(name string) ([]string, []string, error) {
+	ret := _m.Called(name)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 []string
+	if rf, ok := ret.Get(1).(func(string) []string); ok {
+		r1 = rf(name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(name)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// LBGetReject provides a mock function with given fields: name
+func (_m *Client) LBGetReject(name string) (bool, error) {
+	ret := _m.Called(name)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LBGroupAdd provides a mock function with given fields: name, lbs
+func (_m *Client) LBGroupAdd(name string, lbs []string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(name, lbs)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, []string) *goovn.OvnCommand); ok {
+		r0 = rf(name, lbs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(name, lbs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LBGroupDel provides a mock function with given fields: name
+func (_m *Client) LBGroupDel(name string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(name)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string) *goovn.OvnCommand); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LBGroupList provides a mock function with given fields:
+func (_m *Client) LBGroupList() ([]*goovn.LoadBalancerGroup, error) {
+	ret := _m.Called()
+
+	var r0 []*goovn.LoadBalancerGroup
+	if rf, ok := ret.Get(0).(func() []*goovn.LoadBalancerGroup); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*goovn.LoadBalancerGroup)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LBList provides a mock function with given fields:
+func (_m *Client) LBList() ([]*goovn.LoadBalancer, error) {
+	ret := _m.Called()
+
+	var r0 []*goovn.LoadBalancer
+	if rf, ok := ret.Get(0).(func() []*goovn.LoadBalancer); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*goovn.LoadBalancer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LBSetHairpinSNATIP provides a mock function with given fields: name, v4, v6
+func (_m *Client) LBSetHairpinSNATIP(name string, v4 string, v6 string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(name, v4, v6)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, string) *goovn.OvnCommand); ok {
+		r0 = rf(name, v4, v6)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(name, v4, v6)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LBSetSelectionFields provides a mock function with given fields: name, selectionFields
+func (_m *Client) LBSetSelectionFields(name string, selectionFields string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(name, selectionFields)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
+		r0 = rf(name, selectionFields)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(name, selectionFields)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LBUpdate provides a mock function with given fields: name, vipPort, protocol, addrs
+func (_m *Client) LBUpdate(name string, vipPort string, protocol string, addrs []string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(name, vipPort, protocol, addrs)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, string, []string) *goovn.OvnCommand); ok {
+		r0 = rf(name, vipPort, protocol, addrs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, []string) error); ok {
+		r1 = rf(name, vipPort, protocol, addrs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRAdd provides a mock function with given fields: name, external_ids
+func (_m *Client) LRAdd(name string, external_ids map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(name, external_ids)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(name, external_ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, map[string]string) error); ok {
+		r1 = rf(name, external_ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRDel provides a mock function with given fields: name
+func (_m *Client) LRDel(name string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(name)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string) *goovn.OvnCommand); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRGet provides a mock function with given fields: name
+func (_m *Client) LRGet(name string) ([]*goovn.LogicalRouter, error) {
+	ret := _m.Called(name)
+
+	var r0 []*goovn.LogicalRouter
+	if rf, ok := ret.Get(0).(func(string) []*goovn.LogicalRouter); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*goovn.LogicalRouter)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRGetFull provides a mock function with given fields: name
+func (_m *Client) LRGetFull(name string) (*goovn.LogicalRouterFull, error) {
+	ret := _m.Called(name)
+
+	var r0 *goovn.LogicalRouterFull
+	if rf, ok := ret.Get(0).(func(string) *goovn.LogicalRouterFull); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.LogicalRouterFull)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRGetLBGroups provides a mock function with given fields: lr
+func (_m *Client) LRGetLBGroups(lr string) ([]string, error) {
+	ret := _m.Called(lr)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(lr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(lr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRLBAdd provides a mock function with given fields: lr, lb
+func (_m *Client) LRLBAdd(lr string, lb string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lr, lb)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
+		r0 = rf(lr, lb)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(lr, lb)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRLBDel provides a mock function with given fields: lr, lb
+func (_m *Client) LRLBDel(lr string, lb string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lr, lb)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
+		r0 = rf(lr, lb)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(lr, lb)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRLBList provides a mock function with given fields: lr
+func (_m *Client) LRLBList(lr string) ([]*goovn.LoadBalancer, error) {
+	ret := _m.Called(lr)
+
+	var r0 []*goovn.LoadBalancer
+	if rf, ok := ret.Get(0).(func(string) []*goovn.LoadBalancer); ok {
+		r0 = rf(lr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*goovn.LoadBalancer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(lr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRLBUpdate provides a mock function with given fields: lr, addUUIDs, delUUIDs
+func (_m *Client) LRLBUpdate(lr string, addUUIDs []string, delUUIDs []string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lr, addUUIDs, delUUIDs)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, []string, []string) *goovn.OvnCommand); ok {
+		r0 = rf(lr, addUUIDs, delUUIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []string, []string) error); ok {
+		r1 = rf(lr, addUUIDs, delUUIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRList provides a mock function with given fields:
+func (_m *Client) LRList() ([]*goovn.LogicalRouter, error) {
+	ret := _m.Called()
+
+	var r0 []*goovn.LogicalRouter
+	if rf, ok := ret.Get(0).(func() []*goovn.LogicalRouter); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*goovn.LogicalRouter)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRListByExternalID provides a mock function with given fields: key, value
+func (_m *Client) LRListByExternalID(key string, value string) ([]*goovn.LogicalRouter, error) {
+	ret := _m.Called(key, value)
+
+	var r0 []*goovn.LogicalRouter
+	if rf, ok := ret.Get(0).(func(string, string) []*goovn.LogicalRouter); ok {
+		r0 = rf(key, value)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*goovn.LogicalRouter)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(key, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRNATAdd provides a mock function with given fields: lr, ntype, externalIp, logicalIp, external_ids, logicalPortAndExternalMac
+func (_m *Client) LRNATAdd(lr string, ntype string, externalIp string, logicalIp string, external_ids map[string]string, logicalPortAndExternalMac ...string) (*goovn.OvnCommand, error) {
+	_va := make([]interface{}, len(logicalPortAndExternalMac))
+	for _i := range logicalPortAndExternalMac {
+		_va[_i] = logicalPortAndExternalMac[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, lr, ntype, externalIp, logicalIp, external_ids)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, string, string, map[string]string, ...string) *goovn.OvnCommand); ok {
+		r0 = rf(lr, ntype, externalIp, logicalIp, external_ids, logicalPortAndExternalMac...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, string, map[string]string, ...string) error); ok {
+		r1 = rf(lr, ntype, externalIp, logicalIp, external_ids, logicalPortAndExternalMac...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRNATAddDNATAndSNAT provides a mock function with given fields: lr, externalIP, logicalIP, logicalPort, externalMAC, external_ids
+func (_m *Client) LRNATAddDNATAndSNAT(lr string, externalIP string, logicalIP string, logicalPort string, externalMAC string, external_ids map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lr, externalIP, logicalIP, logicalPort, externalMAC, external_ids)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, string, string, string, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(lr, externalIP, logicalIP, logicalPort, externalMAC, external_ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, string, string, map[string]string) error); ok {
+		r1 = rf(lr, externalIP, logicalIP, logicalPort, externalMAC, external_ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRNATDel provides a mock function with given fields: lr, ntype, ip
+func (_m *Client) LRNATDel(lr string, ntype string, ip ...string) (*goovn.OvnCommand, error) {
+	_va := make([]interface{}, len(ip))
+	for _i := range ip {
+		_va[_i] = ip[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, lr, ntype)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, ...string) *goovn.OvnCommand); ok {
+		r0 = rf(lr, ntype, ip...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, ...string) error); ok {
+		r1 = rf(lr, ntype, ip...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRNATList provides a mock function with given fields: lr
+func (_m *Client) LRNATList(lr string) ([]*goovn.NAT, error) {
+	ret := _m.Called(lr)
+
+	var r0 []*goovn.NAT
+	if rf, ok := ret.Get(0).(func(string) []*goovn.NAT); ok {
+		r0 = rf(lr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*goovn.NAT)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(lr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRPAdd provides a mock function with given fields: lr, lrp, mac, network, peer, external_ids
+func (_m *Client) LRPAdd(lr string, lrp string, mac string, network []string, peer string, external_ids map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lr, lrp, mac, network, peer, external_ids)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, string, []string, string, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(lr, lrp, mac, network, peer, external_ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, []string, string, map[string]string) error); ok {
+		r1 = rf(lr, lrp, mac, network, peer, external_ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRPAddNetworks provides a mock function with given fields: lrp, networks
+func (_m *Client) LRPAddNetworks(lrp string, networks []string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lrp, networks)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, []string) *goovn.OvnCommand); ok {
+		r0 = rf(lrp, networks)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(lrp, networks)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRPDel provides a mock function with given fields: lr, lrp
+func (_m *Client) LRPDel(lr string, lrp string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lr, lrp)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
+		r0 = rf(lr, lrp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(lr, lrp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRPDelNetworks provides a mock function with given fields: lrp, networks
+func (_m *Client) LRPDelNetworks(lrp string, networks []string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lrp, networks)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, []string) *goovn.OvnCommand); ok {
+		r0 = rf(lrp, networks)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(lrp, networks)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRPList provides a mock function with given fields: lr
+func (_m *Client) LRPList(lr string) ([]*goovn.LogicalRouterPort, error) {
+	ret := _m.Called(lr)
+
+	var r0 []*goovn.LogicalRouterPort
+	if rf, ok := ret.Get(0).(func(string) []*goovn.LogicalRouterPort); ok {
+		r0 = rf(lr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*goovn.LogicalRouterPort)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(lr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRPSetOptions provides a mock function with given fields: lrp, options
+func (_m *Client) LRPSetOptions(lrp string, options map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lrp, options)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(lrp, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, map[string]string) error); ok {
+		r1 = rf(lrp, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRPolicyAdd provides a mock function with given fields: lr, priority, match, action, nexthop, nexthops, options, external_ids
+func (_m *Client) LRPolicyAdd(lr string, priority int, match string, action string, nexthop *string, nexthops []string, options map[string]string, external_ids map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lr, priority, match, action, nexthop, nexthops, options, external_ids)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, int, string, string, *string, []string, map[string]string, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(lr, priority, match, action, nexthop, nexthops, options, external_ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, string, string, *string, []string, map[string]string, map[string]string) error); ok {
+		r1 = rf(lr, priority, match, action, nexthop, nexthops, options, external_ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRPolicyDel provides a mock function with given fields: lr, priority, match
+func (_m *Client) LRPolicyDel(lr string, priority int, match *string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lr, priority, match)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, int, *string) *goovn.OvnCommand); ok {
+		r0 = rf(lr, priority, match)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, *string) error); ok {
+		r1 = rf(lr, priority, match)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRPolicyDelAll provides a mock function with given fields: lr
+func (_m *Client) LRPolicyDelAll(lr string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lr)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string) *goovn.OvnCommand); ok {
+		r0 = rf(lr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(lr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRPolicyDelByUUID provides a mock function with given fields: lr, uuid
+func (_m *Client) LRPolicyDelByUUID(lr string, uuid string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lr, uuid)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
+		r0 = rf(lr, uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(lr, uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRPolicyDelByUUIDs provides a mock function with given fields: lr, uuids
+func (_m *Client) LRPolicyDelByUUIDs(lr string, uuids []string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lr, uuids)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, []string) *goovn.OvnCommand); ok {
+		r0 = rf(lr, uuids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(lr, uuids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRPolicyList provides a mock function with given fields: lr
+func (_m *Client) LRPolicyList(lr string) ([]*goovn.LogicalRouterPolicy, error) {
+	ret := _m.Called(lr)
+
+	var r0 []*goovn.LogicalRouterPolicy
+	if rf, ok := ret.Get(0).(func(string) []*goovn.LogicalRouterPolicy); ok {
+		r0 = rf(lr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*goovn.LogicalRouterPolicy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(lr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRPolicySetPktMark provides a mock function with given fields: lr, policyUUID, mark
+func (_m *Client) LRPolicySetPktMark(lr string, policyUUID string, mark uint32) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lr, policyUUID, mark)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, uint32) *goovn.OvnCommand); ok {
+		r0 = rf(lr, policyUUID, mark)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, uint32) error); ok {
+		r1 = rf(lr, policyUUID, mark)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRSRAdd provides a mock function with given fields: lr, ip_prefix, nexthop, output_port, policy, external_ids
+func (_m *Client) LRSRAdd(lr string, ip_prefix string, nexthop string, output_port *string, policy *string, external_ids map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lr, ip_prefix, nexthop, output_port, policy, external_ids)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, string, *string, *string, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(lr, ip_prefix, nexthop, output_port, policy, external_ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, *string, *string, map[string]string) error); ok {
+		r1 = rf(lr, ip_prefix, nexthop, output_port, policy, external_ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRSRDel provides a mock function with given fields: lr, prefix, nexthop, outputPort, policy
+func (_m *Client) LRSRDel(lr string, prefix string, nexthop *string, outputPort *string, policy *string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lr, prefix, nexthop, outputPort, policy)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, *string, *string, *string) *goovn.OvnCommand); ok {
+		r0 = rf(lr, prefix, nexthop, outputPort, policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, *string, *string, *string) error); ok {
+		r1 = rf(lr, prefix, nexthop, outputPort, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRSRDelByUUID provides a mock function with given fields: lr, uuid
+func (_m *Client) LRSRDelByUUID(lr string, uuid string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lr, uuid)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
+		r0 = rf(lr, uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(lr, uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRSRList provides a mock function with given fields: lr
+func (_m *Client) LRSRList(lr string) ([]*goovn.LogicalRouterStaticRoute, error) {
+	ret := _m.Called(lr)
+
+	var r0 []*goovn.LogicalRouterStaticRoute
+	if rf, ok := ret.Get(0).(func(string) []*goovn.LogicalRouterStaticRoute); ok {
+		r0 = rf(lr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*goovn.LogicalRouterStaticRoute)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(lr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LRSetLBGroup provides a mock function with given fields: lr, groups
+func (_m *Client) LRSetLBGroup(lr string, groups []string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lr, groups)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, []string) *goovn.OvnCommand); ok {
+		r0 = rf(lr, groups)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(lr, groups)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LSAdd provides a mock function with given fields: ls
+func (_m *Client) LSAdd(ls string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(ls)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string) *goovn.OvnCommand); ok {
+		r0 = rf(ls)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(ls)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LSDel provides a mock function with given fields: ls
+func (_m *Client) LSDel(ls string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(ls)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string) *goovn.OvnCommand); ok {
+		r0 = rf(ls)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(ls)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LSExtIdsAdd provides a mock function with given fields: ls, external_ids
+func (_m *Client) LSExtIdsAdd(ls string, external_ids map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(ls, external_ids)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(ls, external_ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, map[string]string) error); ok {
+		r1 = rf(ls, external_ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LSExtIdsDel provides a mock function with given fields: ls, external_ids
+func (_m *Client) LSExtIdsDel(ls string, external_ids map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(ls, external_ids)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(ls, external_ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, map[string]string) error); ok {
+		r1 = rf(ls, external_ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LSGet provides a mock function with given fields: ls
+func (_m *Client) LSGet(ls string) ([]*goovn.LogicalSwitch, error) {
+	ret := _m.Called(ls)
+
+	var r0 []*goovn.LogicalSwitch
+	if rf, ok := ret.Get(0).(func(string) []*goovn.LogicalSwitch); ok {
+		r0 = rf(ls)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*goovn.LogicalSwitch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(ls)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LSGetLBGroups provides a mock function with given fields: ls
+func (_m *Client) LSGetLBGroups(ls string) ([]string, error) {
+	ret := _m.Called(ls)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(ls)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(ls)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LSLBAdd provides a mock function with given fields: ls, lb
+func (_m *Client) LSLBAdd(ls string, lb string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(ls, lb)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
+		r0 = rf(ls, lb)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(ls, lb)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LSLBDel provides a mock function with given fields: ls, lb
+func (_m *Client) LSLBDel(ls string, lb string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(ls, lb)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
+		r0 = rf(ls, lb)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(ls, lb)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LSLBList provides a mock function with given fields: ls
+func (_m *Client) LSLBList(ls string) ([]*goovn.LoadBalancer, error) {
+	ret := _m.Called(ls)
+
+	var r0 []*goovn.LoadBalancer
+	if rf, ok := ret.Get(0).(func(string) []*goovn.LoadBalancer); ok {
+		r0 = rf(ls)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*goovn.LoadBalancer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(ls)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LSList provides a mock function with given fields:
+func (_m *Client) LSList() ([]*goovn.LogicalSwitch, error) {
+	ret := _m.Called()
+
+	var r0 []*goovn.LogicalSwitch
+	if rf, ok := ret.Get(0).(func() []*goovn.LogicalSwitch); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*goovn.LogicalSwitch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LSListQoSRules provides a mock function with given fields: ls
+func (_m *Client) LSListQoSRules(ls string) ([]string, error) {
+	ret := _m.Called(ls)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(ls)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(ls)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LSPAdd provides a mock function with given fields: ls, lsUUID, lsp
+func (_m *Client) LSPAdd(ls string, lsUUID string, lsp string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(ls, lsUUID, lsp)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, string) *goovn.OvnCommand); ok {
+		r0 = rf(ls, lsUUID, lsp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(ls, lsUUID, lsp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LSPAddExternal provides a mock function with given fields: ls, lsp, haChassisGroup
+func (_m *Client) LSPAddExternal(ls string, lsp string, haChassisGroup string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(ls, lsp, haChassisGroup)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, string) *goovn.OvnCommand); ok {
+		r0 = rf(ls, lsp, haChassisGroup)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(ls, lsp, haChassisGroup)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LSPAddFull provides a mock function with given fields: ls, lsUUID, lsp, spec
+func (_m *Client) LSPAddFull(ls string, lsUUID string, lsp string, spec goovn.LSPSpec) (*goovn.OvnCommand, error) {
+	ret := _m.Called(ls, lsUUID, lsp, spec)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, string, goovn.LSPSpec) *goovn.OvnCommand); ok {
+		r0 = rf(ls, lsUUID, lsp, spec)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, goovn.LSPSpec) error); ok {
+		r1 = rf(ls, lsUUID, lsp, spec)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LSPDel provides a mock function with given fields: lsp
+func (_m *Client) LSPDel(lsp string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lsp)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string) *goovn.OvnCommand); ok {
+		r0 = rf(lsp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(lsp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LSPGet provides a mock function with given fields: lsp
+func (_m *Client) LSPGet(lsp string) (*goovn.LogicalSwitchPort, error) {
+	ret := _m.Called(lsp)
+
+	var r0 *goovn.LogicalSwitchPort
+	if rf, ok := ret.Get(0).(func(string) *goovn.LogicalSwitchPort); ok {
+		r0 = rf(lsp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.LogicalSwitchPort)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(lsp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LSPGetDHCPv4Options provides a mock function with given fields: lsp
+func (_m *Client) LSPGetDHCPv4Options(lsp string) (*goovn.DHCPOptions, error) {
+	ret := _m.Called(lsp)
 
-	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string) *goovn.OvnCommand); ok {
-		r0 = rf(name)
+	var r0 *goovn.DHCPOptions
+	if rf, ok := ret.Get(0).(func(string) *goovn.DHCPOptions); ok {
+		r0 = rf(lsp)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*goovn.OvnCommand)
+			r0 = ret.Get(0).(*goovn.DHCPOptions)
 		}
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+		r1 = rf(lsp)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -804,22 +2850,22 @@ func (_m *Client) LBDel(name string) (*goovn.OvnCommand, error) {
 	return r0, r1
 }
 
-// LBGet provides a mock function with given fields: name
-func (_m *Client) LBGet(name string) ([]*goovn.LoadBalancer, error) {
-	ret := _m.Called(name)
+// LSPGetDHCPv6Options provides a mock function with given fields: lsp
+func (_m *Client) LSPGetDHCPv6Options(lsp string) (*goovn.DHCPOptions, error) {
+	ret := _m.Called(lsp)
 
-	var r0 []*goovn.LoadBalancer
-	if rf, ok := ret.Get(0).(func(string) []*goovn.LoadBalancer); ok {
-		r0 = rf(name)
+	var r0 *goovn.DHCPOptions
+	if rf, ok := ret.Get(0).(func(string) *goovn.DHCPOptions); ok {
+		r0 = rf(lsp)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*goovn.LoadBalancer)
+			r0 = ret.Get(0).(*goovn.DHCPOptions)
 		}
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+		r1 = rf(lsp)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -827,22 +2873,20 @@ func (_m *Client) LBGet(name string) ([]*goovn.LoadBalancer, error) {
 	return r0, r1
 }
 
-// LBList provides a mock function with given fields:
-func (_m *Client) LBList() ([]*goovn.LoadBalancer, error) {
-	ret := _m.Called()
+// LSPGetDynamicAddresses provides a mock function with given fields: lsp
+func (_m *Client) LSPGetDynamicAddresses(lsp string) (string, error) {
+	ret := _m.Called(lsp)
 
-	var r0 []*goovn.LoadBalancer
-	if rf, ok := ret.Get(0).(func() []*goovn.LoadBalancer); ok {
-		r0 = rf()
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(lsp)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*goovn.LoadBalancer)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(lsp)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -850,22 +2894,22 @@ func (_m *Client) LBList() ([]*goovn.LoadBalancer, error) {
 	return r0, r1
 }
 
-// LBSetSelectionFields provides a mock function with given fields: name, selectionFields
-func (_m *Client) LBSetSelectionFields(name string, selectionFields string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(name, selectionFields)
+// LSPGetExternalIds provides a mock function with given fields: lsp
+func (_m *Client) LSPGetExternalIds(lsp string) (map[string]string, error) {
+	ret := _m.Called(lsp)
 
-	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
-		r0 = rf(name, selectionFields)
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func(string) map[string]string); ok {
+		r0 = rf(lsp)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*goovn.OvnCommand)
+			r0 = ret.Get(0).(map[string]string)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(name, selectionFields)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(lsp)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -873,22 +2917,22 @@ func (_m *Client) LBSetSelectionFields(name string, selectionFields string) (*go
 	return r0, r1
 }
 
-// LBUpdate provides a mock function with given fields: name, vipPort, protocol, addrs
-func (_m *Client) LBUpdate(name string, vipPort string, protocol string, addrs []string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(name, vipPort, protocol, addrs)
+// LSPGetOptions provides a mock function with given fields: lsp
+func (_m *Client) LSPGetOptions(lsp string) (map[string]string, error) {
+	ret := _m.Called(lsp)
 
-	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string, string, []string) *goovn.OvnCommand); ok {
-		r0 = rf(name, vipPort, protocol, addrs)
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func(string) map[string]string); ok {
+		r0 = rf(lsp)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*goovn.OvnCommand)
+			r0 = ret.Get(0).(map[string]string)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, string, []string) error); ok {
-		r1 = rf(name, vipPort, protocol, addrs)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(lsp)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -896,22 +2940,22 @@ func (_m *Client) LBUpdate(name string, vipPort string, protocol string, addrs [
 	return r0, r1
 }
 
-// LRAdd provides a mock function with given fields: name, external_ids
-func (_m *Client) LRAdd(name string, external_ids map[string]string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(name, external_ids)
+// LSPGetUUID provides a mock function with given fields: uuid
+func (_m *Client) LSPGetUUID(uuid string) (*goovn.LogicalSwitchPort, error) {
+	ret := _m.Called(uuid)
 
-	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, map[string]string) *goovn.OvnCommand); ok {
-		r0 = rf(name, external_ids)
+	var r0 *goovn.LogicalSwitchPort
+	if rf, ok := ret.Get(0).(func(string) *goovn.LogicalSwitchPort); ok {
+		r0 = rf(uuid)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*goovn.OvnCommand)
+			r0 = ret.Get(0).(*goovn.LogicalSwitchPort)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, map[string]string) error); ok {
-		r1 = rf(name, external_ids)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -919,22 +2963,22 @@ func (_m *Client) LRAdd(name string, external_ids map[string]string) (*goovn.Ovn
 	return r0, r1
 }
 
-// LRDel provides a mock function with given fields: name
-func (_m *Client) LRDel(name string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(name)
+// LSPList provides a mock function with given fields: ls
+func (_m *Client) LSPList(ls string) ([]*goovn.LogicalSwitchPort, error) {
+	ret := _m.Called(ls)
 
-	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string) *goovn.OvnCommand); ok {
-		r0 = rf(name)
+	var r0 []*goovn.LogicalSwitchPort
+	if rf, ok := ret.Get(0).(func(string) []*goovn.LogicalSwitchPort); ok {
+		r0 = rf(ls)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*goovn.OvnCommand)
+			r0 = ret.Get(0).([]*goovn.LogicalSwitchPort)
 		}
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+		r1 = rf(ls)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -942,22 +2986,22 @@ func (_m *Client) LRDel(name string) (*goovn.OvnCommand, error) {
 	return r0, r1
 }
 
-// LRGet provides a mock function with given fields: name
-func (_m *Client) LRGet(name string) ([]*goovn.LogicalRouter, error) {
-	ret := _m.Called(name)
+// LSPListByType provides a mock function with given fields: ls, portType
+func (_m *Client) LSPListByType(ls string, portType string) ([]*goovn.LogicalSwitchPort, error) {
+	ret := _m.Called(ls, portType)
 
-	var r0 []*goovn.LogicalRouter
-	if rf, ok := ret.Get(0).(func(string) []*goovn.LogicalRouter); ok {
-		r0 = rf(name)
+	var r0 []*goovn.LogicalSwitchPort
+	if rf, ok := ret.Get(0).(func(string, string) []*goovn.LogicalSwitchPort); ok {
+		r0 = rf(ls, portType)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*goovn.LogicalRouter)
+			r0 = ret.Get(0).([]*goovn.LogicalSwitchPort)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(ls, portType)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -965,13 +3009,13 @@ func (_m *Client) LRGet(name string) ([]*goovn.LogicalRouter, error) {
 	return r0, r1
 }
 
-// LRLBAdd provides a mock function with given fields: lr, lb
-func (_m *Client) LRLBAdd(lr string, lb string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(lr, lb)
+// LSPReattach provides a mock function with given fields: lsp, newSwitch
+func (_m *Client) LSPReattach(lsp string, newSwitch string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lsp, newSwitch)
 
 	var r0 *goovn.OvnCommand
 	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
-		r0 = rf(lr, lb)
+		r0 = rf(lsp, newSwitch)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -980,7 +3024,7 @@ func (_m *Client) LRLBAdd(lr string, lb string) (*goovn.OvnCommand, error) {
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(lr, lb)
+		r1 = rf(lsp, newSwitch)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -988,13 +3032,13 @@ func (_m *Client) LRLBAdd(lr string, lb string) (*goovn.OvnCommand, error) {
 	return r0, r1
 }
 
-// LRLBDel provides a mock function with given fields: lr, lb
-func (_m *Client) LRLBDel(lr string, lb string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(lr, lb)
+// LSPSet provides a mock function with given fields: lsp, spec
+func (_m *Client) LSPSet(lsp string, spec goovn.LSPSpec) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lsp, spec)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
-		r0 = rf(lr, lb)
+	if rf, ok := ret.Get(0).(func(string, goovn.LSPSpec) *goovn.OvnCommand); ok {
+		r0 = rf(lsp, spec)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1002,8 +3046,8 @@ func (_m *Client) LRLBDel(lr string, lb string) (*goovn.OvnCommand, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(lr, lb)
+	if rf, ok := ret.Get(1).(func(string, goovn.LSPSpec) error); ok {
+		r1 = rf(lsp, spec)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1011,22 +3055,22 @@ func (_m *Client) LRLBDel(lr string, lb string) (*goovn.OvnCommand, error) {
 	return r0, r1
 }
 
-// LRLBList provides a mock function with given fields: lr
-func (_m *Client) LRLBList(lr string) ([]*goovn.LoadBalancer, error) {
-	ret := _m.Called(lr)
+// LSPSetARPProxy provides a mock function with given fields: lsp, addrs
+func (_m *Client) LSPSetARPProxy(lsp string, addrs []string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lsp, addrs)
 
-	var r0 []*goovn.LoadBalancer
-	if rf, ok := ret.Get(0).(func(string) []*goovn.LoadBalancer); ok {
-		r0 = rf(lr)
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, []string) *goovn.OvnCommand); ok {
+		r0 = rf(lsp, addrs)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*goovn.LoadBalancer)
+			r0 = ret.Get(0).(*goovn.OvnCommand)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(lr)
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(lsp, addrs)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1034,22 +3078,29 @@ func (_m *Client) LRLBList(lr string) ([]*goovn.LoadBalancer, error) {
 	return r0, r1
 }
 
-// LRList provides a mock function with given fields:
-func (_m *Client) LRList() ([]*goovn.LogicalRouter, error) {
-	ret := _m.Called()
+// LSPSetAddress provides a mock function with given fields: lsp, addresses
+func (_m *Client) LSPSetAddress(lsp string, addresses ...string) (*goovn.OvnCommand, error) {
+	_va := make([]interface{}, len(addresses))
+	for _i := range addresses {
+		_va[_i] = addresses[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, lsp)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
-	var r0 []*goovn.LogicalRouter
-	if rf, ok := ret.Get(0).(func() []*goovn.LogicalRouter); ok {
-		r0 = rf()
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, ...string) *goovn.OvnCommand); ok {
+		r0 = rf(lsp, addresses...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*goovn.LogicalRouter)
+			r0 = ret.Get(0).(*goovn.OvnCommand)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string, ...string) error); ok {
+		r1 = rf(lsp, addresses...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1057,20 +3108,13 @@ func (_m *Client) LRList() ([]*goovn.LogicalRouter, error) {
 	return r0, r1
 }
 
-// LRNATAdd provides a mock function with given fields: lr, ntype, externalIp, logicalIp, external_ids, logicalPortAndExternalMac
-func (_m *Client) LRNATAdd(lr string, ntype string, externalIp string, logicalIp string, external_ids map[string]string, logicalPortAndExternalMac ...string) (*goovn.OvnCommand, error) {
-	_va := make([]interface{}, len(logicalPortAndExternalMac))
-	for _i := range logicalPortAndExternalMac {
-		_va[_i] = logicalPortAndExternalMac[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, lr, ntype, externalIp, logicalIp, external_ids)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
+// LSPSetDHCPRelay provides a mock function with given fields: lsp, relay
+func (_m *Client) LSPSetDHCPRelay(lsp string, relay string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lsp, relay)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string, string, string, map[string]string, ...string) *goovn.OvnCommand); ok {
-		r0 = rf(lr, ntype, externalIp, logicalIp, external_ids, logicalPortAndExternalMac...)
+	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
+		r0 = rf(lsp, relay)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1078,8 +3122,8 @@ func (_m *Client) LRNATAdd(lr string, ntype string, externalIp string, logicalIp
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, string, string, map[string]string, ...string) error); ok {
-		r1 = rf(lr, ntype, externalIp, logicalIp, external_ids, logicalPortAndExternalMac...)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(lsp, relay)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1087,20 +3131,13 @@ func (_m *Client) LRNATAdd(lr string, ntype string, externalIp string, logicalIp
 	return r0, r1
 }
 
-// LRNATDel provides a mock function with given fields: lr, ntype, ip
-func (_m *Client) LRNATDel(lr string, ntype string, ip ...string) (*goovn.OvnCommand, error) {
-	_va := make([]interface{}, len(ip))
-	for _i := range ip {
-		_va[_i] = ip[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, lr, ntype)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
+// LSPSetDHCPv4Options provides a mock function with given fields: lsp, options
+func (_m *Client) LSPSetDHCPv4Options(lsp string, options string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lsp, options)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string, ...string) *goovn.OvnCommand); ok {
-		r0 = rf(lr, ntype, ip...)
+	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
+		r0 = rf(lsp, options)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1108,8 +3145,8 @@ func (_m *Client) LRNATDel(lr string, ntype string, ip ...string) (*goovn.OvnCom
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, ...string) error); ok {
-		r1 = rf(lr, ntype, ip...)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(lsp, options)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1117,22 +3154,22 @@ func (_m *Client) LRNATDel(lr string, ntype string, ip ...string) (*goovn.OvnCom
 	return r0, r1
 }
 
-// LRNATList provides a mock function with given fields: lr
-func (_m *Client) LRNATList(lr string) ([]*goovn.NAT, error) {
-	ret := _m.Called(lr)
+// LSPSetDHCPv6Options provides a mock function with given fields: lsp, options
+func (_m *Client) LSPSetDHCPv6Options(lsp string, options string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lsp, options)
 
-	var r0 []*goovn.NAT
-	if rf, ok := ret.Get(0).(func(string) []*goovn.NAT); ok {
-		r0 = rf(lr)
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
+		r0 = rf(lsp, options)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*goovn.NAT)
+			r0 = ret.Get(0).(*goovn.OvnCommand)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(lr)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(lsp, options)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1140,13 +3177,13 @@ func (_m *Client) LRNATList(lr string) ([]*goovn.NAT, error) {
 	return r0, r1
 }
 
-// LRPAdd provides a mock function with given fields: lr, lrp, mac, network, peer, external_ids
-func (_m *Client) LRPAdd(lr string, lrp string, mac string, network []string, peer string, external_ids map[string]string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(lr, lrp, mac, network, peer, external_ids)
+// LSPSetDynamicAddresses provides a mock function with given fields: lsp, address
+func (_m *Client) LSPSetDynamicAddresses(lsp string, address string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lsp, address)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string, string, []string, string, map[string]string) *goovn.OvnCommand); ok {
-		r0 = rf(lr, lrp, mac, network, peer, external_ids)
+	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
+		r0 = rf(lsp, address)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1154,8 +3191,8 @@ func (_m *Client) LRPAdd(lr string, lrp string, mac string, network []string, pe
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, string, []string, string, map[string]string) error); ok {
-		r1 = rf(lr, lrp, mac, network, peer, external_ids)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(lsp, address)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1163,13 +3200,13 @@ func (_m *Client) LRPAdd(lr string, lrp string, mac string, network []string, pe
 	return r0, r1
 }
 
-// LRPDel provides a mock function with given fields: lr, lrp
-func (_m *Client) LRPDel(lr string, lrp string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(lr, lrp)
+// LSPSetExternalIds provides a mock function with given fields: lsp, external_ids
+func (_m *Client) LSPSetExternalIds(lsp string, external_ids map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lsp, external_ids)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
-		r0 = rf(lr, lrp)
+	if rf, ok := ret.Get(0).(func(string, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(lsp, external_ids)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1177,8 +3214,8 @@ func (_m *Client) LRPDel(lr string, lrp string) (*goovn.OvnCommand, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(lr, lrp)
+	if rf, ok := ret.Get(1).(func(string, map[string]string) error); ok {
+		r1 = rf(lsp, external_ids)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1186,22 +3223,22 @@ func (_m *Client) LRPDel(lr string, lrp string) (*goovn.OvnCommand, error) {
 	return r0, r1
 }
 
-// LRPList provides a mock function with given fields: lr
-func (_m *Client) LRPList(lr string) ([]*goovn.LogicalRouterPort, error) {
-	ret := _m.Called(lr)
+// LSPSetNATAddresses provides a mock function with given fields: lsp, addrs
+func (_m *Client) LSPSetNATAddresses(lsp string, addrs []string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lsp, addrs)
 
-	var r0 []*goovn.LogicalRouterPort
-	if rf, ok := ret.Get(0).(func(string) []*goovn.LogicalRouterPort); ok {
-		r0 = rf(lr)
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, []string) *goovn.OvnCommand); ok {
+		r0 = rf(lsp, addrs)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*goovn.LogicalRouterPort)
+			r0 = ret.Get(0).(*goovn.OvnCommand)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(lr)
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(lsp, addrs)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1209,13 +3246,13 @@ func (_m *Client) LRPList(lr string) ([]*goovn.LogicalRouterPort, error) {
 	return r0, r1
 }
 
-// LRPolicyAdd provides a mock function with given fields: lr, priority, match, action, nexthop, nexthops, options, external_ids
-func (_m *Client) LRPolicyAdd(lr string, priority int, match string, action string, nexthop *string, nexthops []string, options map[string]string, external_ids map[string]string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(lr, priority, match, action, nexthop, nexthops, options, external_ids)
+// LSPSetOptions provides a mock function with given fields: lsp, options
+func (_m *Client) LSPSetOptions(lsp string, options map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lsp, options)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, int, string, string, *string, []string, map[string]string, map[string]string) *goovn.OvnCommand); ok {
-		r0 = rf(lr, priority, match, action, nexthop, nexthops, options, external_ids)
+	if rf, ok := ret.Get(0).(func(string, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(lsp, options)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1223,8 +3260,8 @@ func (_m *Client) LRPolicyAdd(lr string, priority int, match string, action stri
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, int, string, string, *string, []string, map[string]string, map[string]string) error); ok {
-		r1 = rf(lr, priority, match, action, nexthop, nexthops, options, external_ids)
+	if rf, ok := ret.Get(1).(func(string, map[string]string) error); ok {
+		r1 = rf(lsp, options)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1232,13 +3269,20 @@ func (_m *Client) LRPolicyAdd(lr string, priority int, match string, action stri
 	return r0, r1
 }
 
-// LRPolicyDel provides a mock function with given fields: lr, priority, match
-func (_m *Client) LRPolicyDel(lr string, priority int, match *string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(lr, priority, match)
+// LSPSetPortSecurity provides a mock function with given fields: lsp, security
+func (_m *Client) LSPSetPortSecurity(lsp string, security ...string) (*goovn.OvnCommand, error) {
+	_va := make([]interface{}, len(security))
+	for _i := range security {
+		_va[_i] = security[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, lsp)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, int, *string) *goovn.OvnCommand); ok {
-		r0 = rf(lr, priority, match)
+	if rf, ok := ret.Get(0).(func(string, ...string) *goovn.OvnCommand); ok {
+		r0 = rf(lsp, security...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1246,8 +3290,8 @@ func (_m *Client) LRPolicyDel(lr string, priority int, match *string) (*goovn.Ov
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, int, *string) error); ok {
-		r1 = rf(lr, priority, match)
+	if rf, ok := ret.Get(1).(func(string, ...string) error); ok {
+		r1 = rf(lsp, security...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1255,13 +3299,13 @@ func (_m *Client) LRPolicyDel(lr string, priority int, match *string) (*goovn.Ov
 	return r0, r1
 }
 
-// LRPolicyDelAll provides a mock function with given fields: lr
-func (_m *Client) LRPolicyDelAll(lr string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(lr)
+// LSPSetRequestedChassis provides a mock function with given fields: lsp, chassis
+func (_m *Client) LSPSetRequestedChassis(lsp string, chassis []string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lsp, chassis)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string) *goovn.OvnCommand); ok {
-		r0 = rf(lr)
+	if rf, ok := ret.Get(0).(func(string, []string) *goovn.OvnCommand); ok {
+		r0 = rf(lsp, chassis)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1269,8 +3313,8 @@ func (_m *Client) LRPolicyDelAll(lr string) (*goovn.OvnCommand, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(lr)
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(lsp, chassis)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1278,13 +3322,13 @@ func (_m *Client) LRPolicyDelAll(lr string) (*goovn.OvnCommand, error) {
 	return r0, r1
 }
 
-// LRPolicyDelByUUID provides a mock function with given fields: lr, uuid
-func (_m *Client) LRPolicyDelByUUID(lr string, uuid string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(lr, uuid)
+// LSPSetType provides a mock function with given fields: lsp, portType
+func (_m *Client) LSPSetType(lsp string, portType string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lsp, portType)
 
 	var r0 *goovn.OvnCommand
 	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
-		r0 = rf(lr, uuid)
+		r0 = rf(lsp, portType)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1293,7 +3337,7 @@ func (_m *Client) LRPolicyDelByUUID(lr string, uuid string) (*goovn.OvnCommand,
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(lr, uuid)
+		r1 = rf(lsp, portType)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1301,22 +3345,22 @@ func (_m *Client) LRPolicyDelByUUID(lr string, uuid string) (*goovn.OvnCommand,
 	return r0, r1
 }
 
-// LRPolicyList provides a mock function with given fields: lr
-func (_m *Client) LRPolicyList(lr string) ([]*goovn.LogicalRouterPolicy, error) {
-	ret := _m.Called(lr)
+// LSPStatus provides a mock function with given fields: lsp
+func (_m *Client) LSPStatus(lsp string) (*goovn.LSPStatus, error) {
+	ret := _m.Called(lsp)
 
-	var r0 []*goovn.LogicalRouterPolicy
-	if rf, ok := ret.Get(0).(func(string) []*goovn.LogicalRouterPolicy); ok {
-		r0 = rf(lr)
+	var r0 *goovn.LSPStatus
+	if rf, ok := ret.Get(0).(func(string) *goovn.LSPStatus); ok {
+		r0 = rf(lsp)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*goovn.LogicalRouterPolicy)
+			r0 = ret.Get(0).(*goovn.LSPStatus)
 		}
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(lr)
+		r1 = rf(lsp)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1324,13 +3368,13 @@ func (_m *Client) LRPolicyList(lr string) ([]*goovn.LogicalRouterPolicy, error)
 	return r0, r1
 }
 
-// LRSRAdd provides a mock function with given fields: lr, ip_prefix, nexthop, output_port, policy, external_ids
-func (_m *Client) LRSRAdd(lr string, ip_prefix string, nexthop string, output_port *string, policy *string, external_ids map[string]string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(lr, ip_prefix, nexthop, output_port, policy, external_ids)
+// LSPUpdateAddresses provides a mock function with given fields: lsp, mac, ips, portSecurity
+func (_m *Client) LSPUpdateAddresses(lsp string, mac net.HardwareAddr, ips []*net.IPNet, portSecurity []string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lsp, mac, ips, portSecurity)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string, string, *string, *string, map[string]string) *goovn.OvnCommand); ok {
-		r0 = rf(lr, ip_prefix, nexthop, output_port, policy, external_ids)
+	if rf, ok := ret.Get(0).(func(string, net.HardwareAddr, []*net.IPNet, []string) *goovn.OvnCommand); ok {
+		r0 = rf(lsp, mac, ips, portSecurity)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1338,8 +3382,8 @@ func (_m *Client) LRSRAdd(lr string, ip_prefix string, nexthop string, output_po
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, string, *string, *string, map[string]string) error); ok {
-		r1 = rf(lr, ip_prefix, nexthop, output_port, policy, external_ids)
+	if rf, ok := ret.Get(1).(func(string, net.HardwareAddr, []*net.IPNet, []string) error); ok {
+		r1 = rf(lsp, mac, ips, portSecurity)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1347,13 +3391,13 @@ func (_m *Client) LRSRAdd(lr string, ip_prefix string, nexthop string, output_po
 	return r0, r1
 }
 
-// LRSRDel provides a mock function with given fields: lr, prefix, nexthop, outputPort, policy
-func (_m *Client) LRSRDel(lr string, prefix string, nexthop *string, outputPort *string, policy *string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(lr, prefix, nexthop, outputPort, policy)
+// LSSetCopp provides a mock function with given fields: ls, copp
+func (_m *Client) LSSetCopp(ls string, copp *string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(ls, copp)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string, *string, *string, *string) *goovn.OvnCommand); ok {
-		r0 = rf(lr, prefix, nexthop, outputPort, policy)
+	if rf, ok := ret.Get(0).(func(string, *string) *goovn.OvnCommand); ok {
+		r0 = rf(ls, copp)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1361,8 +3405,8 @@ func (_m *Client) LRSRDel(lr string, prefix string, nexthop *string, outputPort
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, *string, *string, *string) error); ok {
-		r1 = rf(lr, prefix, nexthop, outputPort, policy)
+	if rf, ok := ret.Get(1).(func(string, *string) error); ok {
+		r1 = rf(ls, copp)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1370,13 +3414,13 @@ func (_m *Client) LRSRDel(lr string, prefix string, nexthop *string, outputPort
 	return r0, r1
 }
 
-// LRSRDelByUUID provides a mock function with given fields: lr, uuid
-func (_m *Client) LRSRDelByUUID(lr string, uuid string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(lr, uuid)
+// LSSetLBGroup provides a mock function with given fields: ls, groups
+func (_m *Client) LSSetLBGroup(ls string, groups []string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(ls, groups)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
-		r0 = rf(lr, uuid)
+	if rf, ok := ret.Get(0).(func(string, []string) *goovn.OvnCommand); ok {
+		r0 = rf(ls, groups)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1384,8 +3428,8 @@ func (_m *Client) LRSRDelByUUID(lr string, uuid string) (*goovn.OvnCommand, erro
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(lr, uuid)
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(ls, groups)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1393,22 +3437,36 @@ func (_m *Client) LRSRDelByUUID(lr string, uuid string) (*goovn.OvnCommand, erro
 	return r0, r1
 }
 
-// LRSRList provides a mock function with given fields: lr
-func (_m *Client) LRSRList(lr string) ([]*goovn.LogicalRouterStaticRoute, error) {
-	ret := _m.Called(lr)
+// LastUpdateTime provides a mock function with given fields:
+func (_m *Client) LastUpdateTime() time.Time {
+	ret := _m.Called()
 
-	var r0 []*goovn.LogicalRouterStaticRoute
-	if rf, ok := ret.Get(0).(func(string) []*goovn.LogicalRouterStaticRoute); ok {
-		r0 = rf(lr)
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
+// LinkSwitchToRouter provides a mock function with given fields: lsw, lsp, lr, lrp, lrpMac, networks, externalIds
+func (_m *Client) LinkSwitchToRouter(lsw string, lsp string, lr string, lrp string, lrpMac string, networks []string, externalIds map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(lsw, lsp, lr, lrp, lrpMac, networks, externalIds)
+
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, string, string, string, []string, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(lsw, lsp, lr, lrp, lrpMac, networks, externalIds)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*goovn.LogicalRouterStaticRoute)
+			r0 = ret.Get(0).(*goovn.OvnCommand)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(lr)
+	if rf, ok := ret.Get(1).(func(string, string, string, string, string, []string, map[string]string) error); ok {
+		r1 = rf(lsw, lsp, lr, lrp, lrpMac, networks, externalIds)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1416,13 +3474,27 @@ func (_m *Client) LRSRList(lr string) ([]*goovn.LogicalRouterStaticRoute, error)
 	return r0, r1
 }
 
-// LSAdd provides a mock function with given fields: ls
-func (_m *Client) LSAdd(ls string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(ls)
+// Lock provides a mock function with given fields: id
+func (_m *Client) Lock(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MeterAdd provides a mock function with given fields: name, action, rate, unit, external_ids, burst
+func (_m *Client) MeterAdd(name string, action string, rate int, unit string, external_ids map[string]string, burst int) (*goovn.OvnCommand, error) {
+	ret := _m.Called(name, action, rate, unit, external_ids, burst)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string) *goovn.OvnCommand); ok {
-		r0 = rf(ls)
+	if rf, ok := ret.Get(0).(func(string, string, int, string, map[string]string, int) *goovn.OvnCommand); ok {
+		r0 = rf(name, action, rate, unit, external_ids, burst)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1430,8 +3502,8 @@ func (_m *Client) LSAdd(ls string) (*goovn.OvnCommand, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(ls)
+	if rf, ok := ret.Get(1).(func(string, string, int, string, map[string]string, int) error); ok {
+		r1 = rf(name, action, rate, unit, external_ids, burst)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1439,13 +3511,13 @@ func (_m *Client) LSAdd(ls string) (*goovn.OvnCommand, error) {
 	return r0, r1
 }
 
-// LSDel provides a mock function with given fields: ls
-func (_m *Client) LSDel(ls string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(ls)
+// MeterAddBands provides a mock function with given fields: name, unit, fair, bands, external_ids
+func (_m *Client) MeterAddBands(name string, unit string, fair bool, bands []goovn.MeterBandSpec, external_ids map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(name, unit, fair, bands, external_ids)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string) *goovn.OvnCommand); ok {
-		r0 = rf(ls)
+	if rf, ok := ret.Get(0).(func(string, string, bool, []goovn.MeterBandSpec, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(name, unit, fair, bands, external_ids)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1453,8 +3525,8 @@ func (_m *Client) LSDel(ls string) (*goovn.OvnCommand, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(ls)
+	if rf, ok := ret.Get(1).(func(string, string, bool, []goovn.MeterBandSpec, map[string]string) error); ok {
+		r1 = rf(name, unit, fair, bands, external_ids)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1462,22 +3534,22 @@ func (_m *Client) LSDel(ls string) (*goovn.OvnCommand, error) {
 	return r0, r1
 }
 
-// LSExtIdsAdd provides a mock function with given fields: ls, external_ids
-func (_m *Client) LSExtIdsAdd(ls string, external_ids map[string]string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(ls, external_ids)
+// MeterBandsList provides a mock function with given fields:
+func (_m *Client) MeterBandsList() ([]*goovn.MeterBand, error) {
+	ret := _m.Called()
 
-	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, map[string]string) *goovn.OvnCommand); ok {
-		r0 = rf(ls, external_ids)
+	var r0 []*goovn.MeterBand
+	if rf, ok := ret.Get(0).(func() []*goovn.MeterBand); ok {
+		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*goovn.OvnCommand)
+			r0 = ret.Get(0).([]*goovn.MeterBand)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, map[string]string) error); ok {
-		r1 = rf(ls, external_ids)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1485,13 +3557,19 @@ func (_m *Client) LSExtIdsAdd(ls string, external_ids map[string]string) (*goovn
 	return r0, r1
 }
 
-// LSExtIdsDel provides a mock function with given fields: ls, external_ids
-func (_m *Client) LSExtIdsDel(ls string, external_ids map[string]string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(ls, external_ids)
+// MeterDel provides a mock function with given fields: name
+func (_m *Client) MeterDel(name ...string) (*goovn.OvnCommand, error) {
+	_va := make([]interface{}, len(name))
+	for _i := range name {
+		_va[_i] = name[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, map[string]string) *goovn.OvnCommand); ok {
-		r0 = rf(ls, external_ids)
+	if rf, ok := ret.Get(0).(func(...string) *goovn.OvnCommand); ok {
+		r0 = rf(name...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1499,8 +3577,8 @@ func (_m *Client) LSExtIdsDel(ls string, external_ids map[string]string) (*goovn
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, map[string]string) error); ok {
-		r1 = rf(ls, external_ids)
+	if rf, ok := ret.Get(1).(func(...string) error); ok {
+		r1 = rf(name...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1508,22 +3586,22 @@ func (_m *Client) LSExtIdsDel(ls string, external_ids map[string]string) (*goovn
 	return r0, r1
 }
 
-// LSGet provides a mock function with given fields: ls
-func (_m *Client) LSGet(ls string) ([]*goovn.LogicalSwitch, error) {
-	ret := _m.Called(ls)
+// MeterList provides a mock function with given fields:
+func (_m *Client) MeterList() ([]*goovn.Meter, error) {
+	ret := _m.Called()
 
-	var r0 []*goovn.LogicalSwitch
-	if rf, ok := ret.Get(0).(func(string) []*goovn.LogicalSwitch); ok {
-		r0 = rf(ls)
+	var r0 []*goovn.Meter
+	if rf, ok := ret.Get(0).(func() []*goovn.Meter); ok {
+		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*goovn.LogicalSwitch)
+			r0 = ret.Get(0).([]*goovn.Meter)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(ls)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1531,13 +3609,13 @@ func (_m *Client) LSGet(ls string) ([]*goovn.LogicalSwitch, error) {
 	return r0, r1
 }
 
-// LSLBAdd provides a mock function with given fields: ls, lb
-func (_m *Client) LSLBAdd(ls string, lb string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(ls, lb)
+// MutateColumn provides a mock function with given fields: table, rowName, column, mutator, value
+func (_m *Client) MutateColumn(table string, rowName string, column string, mutator string, value interface{}) (*goovn.OvnCommand, error) {
+	ret := _m.Called(table, rowName, column, mutator, value)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
-		r0 = rf(ls, lb)
+	if rf, ok := ret.Get(0).(func(string, string, string, string, interface{}) *goovn.OvnCommand); ok {
+		r0 = rf(table, rowName, column, mutator, value)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1545,8 +3623,8 @@ func (_m *Client) LSLBAdd(ls string, lb string) (*goovn.OvnCommand, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(ls, lb)
+	if rf, ok := ret.Get(1).(func(string, string, string, string, interface{}) error); ok {
+		r1 = rf(table, rowName, column, mutator, value)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1554,22 +3632,43 @@ func (_m *Client) LSLBAdd(ls string, lb string) (*goovn.OvnCommand, error) {
 	return r0, r1
 }
 
-// LSLBDel provides a mock function with given fields: ls, lb
-func (_m *Client) LSLBDel(ls string, lb string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(ls, lb)
+// NATListAll provides a mock function with given fields:
+func (_m *Client) NATListAll() ([]*goovn.NAT, error) {
+	ret := _m.Called()
 
-	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
-		r0 = rf(ls, lb)
+	var r0 []*goovn.NAT
+	if rf, ok := ret.Get(0).(func() []*goovn.NAT); ok {
+		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*goovn.OvnCommand)
+			r0 = ret.Get(0).([]*goovn.NAT)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(ls, lb)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NBGlobalGetMACPrefix provides a mock function with given fields:
+func (_m *Client) NBGlobalGetMACPrefix() (string, error) {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1577,22 +3676,20 @@ func (_m *Client) LSLBDel(ls string, lb string) (*goovn.OvnCommand, error) {
 	return r0, r1
 }
 
-// LSLBList provides a mock function with given fields: ls
-func (_m *Client) LSLBList(ls string) ([]*goovn.LoadBalancer, error) {
-	ret := _m.Called(ls)
+// NBGlobalGetMaxTunID provides a mock function with given fields:
+func (_m *Client) NBGlobalGetMaxTunID() (int, error) {
+	ret := _m.Called()
 
-	var r0 []*goovn.LoadBalancer
-	if rf, ok := ret.Get(0).(func(string) []*goovn.LoadBalancer); ok {
-		r0 = rf(ls)
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*goovn.LoadBalancer)
-		}
+		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(ls)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1600,17 +3697,15 @@ func (_m *Client) LSLBList(ls string) ([]*goovn.LoadBalancer, error) {
 	return r0, r1
 }
 
-// LSList provides a mock function with given fields:
-func (_m *Client) LSList() ([]*goovn.LogicalSwitch, error) {
+// NBGlobalGetNorthdProbeInterval provides a mock function with given fields:
+func (_m *Client) NBGlobalGetNorthdProbeInterval() (int, error) {
 	ret := _m.Called()
 
-	var r0 []*goovn.LogicalSwitch
-	if rf, ok := ret.Get(0).(func() []*goovn.LogicalSwitch); ok {
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
 		r0 = rf()
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*goovn.LogicalSwitch)
-		}
+		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
@@ -1623,22 +3718,22 @@ func (_m *Client) LSList() ([]*goovn.LogicalSwitch, error) {
 	return r0, r1
 }
 
-// LSPAdd provides a mock function with given fields: ls, lsp
-func (_m *Client) LSPAdd(ls string, lsp string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(ls, lsp)
+// NBGlobalGetOptions provides a mock function with given fields:
+func (_m *Client) NBGlobalGetOptions() (map[string]string, error) {
+	ret := _m.Called()
 
-	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
-		r0 = rf(ls, lsp)
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func() map[string]string); ok {
+		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*goovn.OvnCommand)
+			r0 = ret.Get(0).(map[string]string)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(ls, lsp)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1646,22 +3741,20 @@ func (_m *Client) LSPAdd(ls string, lsp string) (*goovn.OvnCommand, error) {
 	return r0, r1
 }
 
-// LSPDel provides a mock function with given fields: lsp
-func (_m *Client) LSPDel(lsp string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(lsp)
+// NBGlobalGetSvcMonitorMAC provides a mock function with given fields:
+func (_m *Client) NBGlobalGetSvcMonitorMAC() (net.HardwareAddr, error) {
+	ret := _m.Called()
 
-	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string) *goovn.OvnCommand); ok {
-		r0 = rf(lsp)
+	var r0 net.HardwareAddr
+	if rf, ok := ret.Get(0).(func() net.HardwareAddr); ok {
+		r0 = rf()
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*goovn.OvnCommand)
-		}
+		r0 = ret.Get(0).(net.HardwareAddr)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(lsp)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1669,22 +3762,20 @@ func (_m *Client) LSPDel(lsp string) (*goovn.OvnCommand, error) {
 	return r0, r1
 }
 
-// LSPGet provides a mock function with given fields: lsp
-func (_m *Client) LSPGet(lsp string) (*goovn.LogicalSwitchPort, error) {
-	ret := _m.Called(lsp)
+// NBGlobalIncrementNbCfg provides a mock function with given fields:
+func (_m *Client) NBGlobalIncrementNbCfg() (int, error) {
+	ret := _m.Called()
 
-	var r0 *goovn.LogicalSwitchPort
-	if rf, ok := ret.Get(0).(func(string) *goovn.LogicalSwitchPort); ok {
-		r0 = rf(lsp)
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*goovn.LogicalSwitchPort)
-		}
+		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(lsp)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1692,22 +3783,22 @@ func (_m *Client) LSPGet(lsp string) (*goovn.LogicalSwitchPort, error) {
 	return r0, r1
 }
 
-// LSPGetDHCPv4Options provides a mock function with given fields: lsp
-func (_m *Client) LSPGetDHCPv4Options(lsp string) (*goovn.DHCPOptions, error) {
-	ret := _m.Called(lsp)
+// NBGlobalSetMACPrefix provides a mock function with given fields: prefix
+func (_m *Client) NBGlobalSetMACPrefix(prefix string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(prefix)
 
-	var r0 *goovn.DHCPOptions
-	if rf, ok := ret.Get(0).(func(string) *goovn.DHCPOptions); ok {
-		r0 = rf(lsp)
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string) *goovn.OvnCommand); ok {
+		r0 = rf(prefix)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*goovn.DHCPOptions)
+			r0 = ret.Get(0).(*goovn.OvnCommand)
 		}
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(lsp)
+		r1 = rf(prefix)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1715,22 +3806,22 @@ func (_m *Client) LSPGetDHCPv4Options(lsp string) (*goovn.DHCPOptions, error) {
 	return r0, r1
 }
 
-// LSPGetDHCPv6Options provides a mock function with given fields: lsp
-func (_m *Client) LSPGetDHCPv6Options(lsp string) (*goovn.DHCPOptions, error) {
-	ret := _m.Called(lsp)
+// NBGlobalSetNorthdProbeInterval provides a mock function with given fields: interval
+func (_m *Client) NBGlobalSetNorthdProbeInterval(interval int) (*goovn.OvnCommand, error) {
+	ret := _m.Called(interval)
 
-	var r0 *goovn.DHCPOptions
-	if rf, ok := ret.Get(0).(func(string) *goovn.DHCPOptions); ok {
-		r0 = rf(lsp)
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(int) *goovn.OvnCommand); ok {
+		r0 = rf(interval)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*goovn.DHCPOptions)
+			r0 = ret.Get(0).(*goovn.OvnCommand)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(lsp)
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(interval)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1738,20 +3829,22 @@ func (_m *Client) LSPGetDHCPv6Options(lsp string) (*goovn.DHCPOptions, error) {
 	return r0, r1
 }
 
-// LSPGetDynamicAddresses provides a mock function with given fields: lsp
-func (_m *Client) LSPGetDynamicAddresses(lsp string) (string, error) {
-	ret := _m.Called(lsp)
+// NBGlobalSetOptions provides a mock function with given fields: options
+func (_m *Client) NBGlobalSetOptions(options map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(options)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(lsp)
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(options)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*goovn.OvnCommand)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(lsp)
+	if rf, ok := ret.Get(1).(func(map[string]string) error); ok {
+		r1 = rf(options)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1759,22 +3852,22 @@ func (_m *Client) LSPGetDynamicAddresses(lsp string) (string, error) {
 	return r0, r1
 }
 
-// LSPGetExternalIds provides a mock function with given fields: lsp
-func (_m *Client) LSPGetExternalIds(lsp string) (map[string]string, error) {
-	ret := _m.Called(lsp)
+// NBGlobalSetSvcMonitorMAC provides a mock function with given fields: mac
+func (_m *Client) NBGlobalSetSvcMonitorMAC(mac net.HardwareAddr) (*goovn.OvnCommand, error) {
+	ret := _m.Called(mac)
 
-	var r0 map[string]string
-	if rf, ok := ret.Get(0).(func(string) map[string]string); ok {
-		r0 = rf(lsp)
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(net.HardwareAddr) *goovn.OvnCommand); ok {
+		r0 = rf(mac)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]string)
+			r0 = ret.Get(0).(*goovn.OvnCommand)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(lsp)
+	if rf, ok := ret.Get(1).(func(net.HardwareAddr) error); ok {
+		r1 = rf(mac)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1782,22 +3875,22 @@ func (_m *Client) LSPGetExternalIds(lsp string) (map[string]string, error) {
 	return r0, r1
 }
 
-// LSPGetOptions provides a mock function with given fields: lsp
-func (_m *Client) LSPGetOptions(lsp string) (map[string]string, error) {
-	ret := _m.Called(lsp)
+// OrphanLSPList provides a mock function with given fields: validNames
+func (_m *Client) OrphanLSPList(validNames map[string]bool) ([]*goovn.LogicalSwitchPort, error) {
+	ret := _m.Called(validNames)
 
-	var r0 map[string]string
-	if rf, ok := ret.Get(0).(func(string) map[string]string); ok {
-		r0 = rf(lsp)
+	var r0 []*goovn.LogicalSwitchPort
+	if rf, ok := ret.Get(0).(func(map[string]bool) []*goovn.LogicalSwitchPort); ok {
+		r0 = rf(validNames)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]string)
+			r0 = ret.Get(0).([]*goovn.LogicalSwitchPort)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(lsp)
+	if rf, ok := ret.Get(1).(func(map[string]bool) error); ok {
+		r1 = rf(validNames)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1805,22 +3898,20 @@ func (_m *Client) LSPGetOptions(lsp string) (map[string]string, error) {
 	return r0, r1
 }
 
-// LSPList provides a mock function with given fields: ls
-func (_m *Client) LSPList(ls string) ([]*goovn.LogicalSwitchPort, error) {
-	ret := _m.Called(ls)
+// PortBindingChassis provides a mock function with given fields: logicalPort
+func (_m *Client) PortBindingChassis(logicalPort string) (string, error) {
+	ret := _m.Called(logicalPort)
 
-	var r0 []*goovn.LogicalSwitchPort
-	if rf, ok := ret.Get(0).(func(string) []*goovn.LogicalSwitchPort); ok {
-		r0 = rf(ls)
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(logicalPort)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*goovn.LogicalSwitchPort)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(ls)
+		r1 = rf(logicalPort)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1828,20 +3919,13 @@ func (_m *Client) LSPList(ls string) ([]*goovn.LogicalSwitchPort, error) {
 	return r0, r1
 }
 
-// LSPSetAddress provides a mock function with given fields: lsp, addresses
-func (_m *Client) LSPSetAddress(lsp string, addresses ...string) (*goovn.OvnCommand, error) {
-	_va := make([]interface{}, len(addresses))
-	for _i := range addresses {
-		_va[_i] = addresses[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, lsp)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
+// PortGroupAdd provides a mock function with given fields: group, ports, external_ids
+func (_m *Client) PortGroupAdd(group string, ports []string, external_ids map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(group, ports, external_ids)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, ...string) *goovn.OvnCommand); ok {
-		r0 = rf(lsp, addresses...)
+	if rf, ok := ret.Get(0).(func(string, []string, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(group, ports, external_ids)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1849,8 +3933,8 @@ func (_m *Client) LSPSetAddress(lsp string, addresses ...string) (*goovn.OvnComm
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, ...string) error); ok {
-		r1 = rf(lsp, addresses...)
+	if rf, ok := ret.Get(1).(func(string, []string, map[string]string) error); ok {
+		r1 = rf(group, ports, external_ids)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1858,13 +3942,13 @@ func (_m *Client) LSPSetAddress(lsp string, addresses ...string) (*goovn.OvnComm
 	return r0, r1
 }
 
-// LSPSetDHCPv4Options provides a mock function with given fields: lsp, options
-func (_m *Client) LSPSetDHCPv4Options(lsp string, options string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(lsp, options)
+// PortGroupAddPort provides a mock function with given fields: group, port
+func (_m *Client) PortGroupAddPort(group string, port string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(group, port)
 
 	var r0 *goovn.OvnCommand
 	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
-		r0 = rf(lsp, options)
+		r0 = rf(group, port)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1873,7 +3957,7 @@ func (_m *Client) LSPSetDHCPv4Options(lsp string, options string) (*goovn.OvnCom
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(lsp, options)
+		r1 = rf(group, port)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1881,13 +3965,13 @@ func (_m *Client) LSPSetDHCPv4Options(lsp string, options string) (*goovn.OvnCom
 	return r0, r1
 }
 
-// LSPSetDHCPv6Options provides a mock function with given fields: lsp, options
-func (_m *Client) LSPSetDHCPv6Options(lsp string, options string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(lsp, options)
+// PortGroupAddWithACLs provides a mock function with given fields: group, ports, acls, external_ids
+func (_m *Client) PortGroupAddWithACLs(group string, ports []string, acls []goovn.ACLSpec, external_ids map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(group, ports, acls, external_ids)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
-		r0 = rf(lsp, options)
+	if rf, ok := ret.Get(0).(func(string, []string, []goovn.ACLSpec, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(group, ports, acls, external_ids)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1895,8 +3979,8 @@ func (_m *Client) LSPSetDHCPv6Options(lsp string, options string) (*goovn.OvnCom
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(lsp, options)
+	if rf, ok := ret.Get(1).(func(string, []string, []goovn.ACLSpec, map[string]string) error); ok {
+		r1 = rf(group, ports, acls, external_ids)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1904,13 +3988,13 @@ func (_m *Client) LSPSetDHCPv6Options(lsp string, options string) (*goovn.OvnCom
 	return r0, r1
 }
 
-// LSPSetDynamicAddresses provides a mock function with given fields: lsp, address
-func (_m *Client) LSPSetDynamicAddresses(lsp string, address string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(lsp, address)
+// PortGroupAddWithAddressSet provides a mock function with given fields: group, ports, asName
+func (_m *Client) PortGroupAddWithAddressSet(group string, ports []string, asName string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(group, ports, asName)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
-		r0 = rf(lsp, address)
+	if rf, ok := ret.Get(0).(func(string, []string, string) *goovn.OvnCommand); ok {
+		r0 = rf(group, ports, asName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1918,8 +4002,8 @@ func (_m *Client) LSPSetDynamicAddresses(lsp string, address string) (*goovn.Ovn
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(lsp, address)
+	if rf, ok := ret.Get(1).(func(string, []string, string) error); ok {
+		r1 = rf(group, ports, asName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1927,22 +4011,22 @@ func (_m *Client) LSPSetDynamicAddresses(lsp string, address string) (*goovn.Ovn
 	return r0, r1
 }
 
-// LSPSetExternalIds provides a mock function with given fields: lsp, external_ids
-func (_m *Client) LSPSetExternalIds(lsp string, external_ids map[string]string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(lsp, external_ids)
+// PortGroupCheckDanglingACLs provides a mock function with given fields: group
+func (_m *Client) PortGroupCheckDanglingACLs(group string) ([]string, error) {
+	ret := _m.Called(group)
 
-	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, map[string]string) *goovn.OvnCommand); ok {
-		r0 = rf(lsp, external_ids)
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(group)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*goovn.OvnCommand)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, map[string]string) error); ok {
-		r1 = rf(lsp, external_ids)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(group)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1950,13 +4034,13 @@ func (_m *Client) LSPSetExternalIds(lsp string, external_ids map[string]string)
 	return r0, r1
 }
 
-// LSPSetOptions provides a mock function with given fields: lsp, options
-func (_m *Client) LSPSetOptions(lsp string, options map[string]string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(lsp, options)
+// PortGroupDel provides a mock function with given fields: group
+func (_m *Client) PortGroupDel(group string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(group)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, map[string]string) *goovn.OvnCommand); ok {
-		r0 = rf(lsp, options)
+	if rf, ok := ret.Get(0).(func(string) *goovn.OvnCommand); ok {
+		r0 = rf(group)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -1964,8 +4048,8 @@ func (_m *Client) LSPSetOptions(lsp string, options map[string]string) (*goovn.O
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, map[string]string) error); ok {
-		r1 = rf(lsp, options)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(group)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1973,29 +4057,22 @@ func (_m *Client) LSPSetOptions(lsp string, options map[string]string) (*goovn.O
 	return r0, r1
 }
 
-// LSPSetPortSecurity provides a mock function with given fields: lsp, security
-func (_m *Client) LSPSetPortSecurity(lsp string, security ...string) (*goovn.OvnCommand, error) {
-	_va := make([]interface{}, len(security))
-	for _i := range security {
-		_va[_i] = security[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, lsp)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
+// PortGroupGet provides a mock function with given fields: group
+func (_m *Client) PortGroupGet(group string) (*goovn.PortGroup, error) {
+	ret := _m.Called(group)
 
-	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, ...string) *goovn.OvnCommand); ok {
-		r0 = rf(lsp, security...)
+	var r0 *goovn.PortGroup
+	if rf, ok := ret.Get(0).(func(string) *goovn.PortGroup); ok {
+		r0 = rf(group)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*goovn.OvnCommand)
+			r0 = ret.Get(0).(*goovn.PortGroup)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, ...string) error); ok {
-		r1 = rf(lsp, security...)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(group)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2003,36 +4080,43 @@ func (_m *Client) LSPSetPortSecurity(lsp string, security ...string) (*goovn.Ovn
 	return r0, r1
 }
 
-// LSPSetType provides a mock function with given fields: lsp, portType
-func (_m *Client) LSPSetType(lsp string, portType string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(lsp, portType)
+// PortGroupIsReferenced provides a mock function with given fields: group
+func (_m *Client) PortGroupIsReferenced(group string) (bool, []string, error) {
+	ret := _m.Called(group)
 
-	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
-		r0 = rf(lsp, portType)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(group)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*goovn.OvnCommand)
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 []string
+	if rf, ok := ret.Get(1).(func(string) []string); ok {
+		r1 = rf(group)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(lsp, portType)
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(group)
 	} else {
-		r1 = ret.Error(1)
+		r2 = ret.Error(2)
 	}
 
-	return r0, r1
+	return r0, r1, r2
 }
 
-// LinkSwitchToRouter provides a mock function with given fields: lsw, lsp, lr, lrp, lrpMac, networks, externalIds
-func (_m *Client) LinkSwitchToRouter(lsw string, lsp string, lr string, lrp string, lrpMac string, networks []string, externalIds map[string]string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(lsw, lsp, lr, lrp, lrpMac, networks, externalIds)
+// PortGroupReconcilePorts provides a mock function with given fields: group, desired
+func (_m *Client) PortGroupReconcilePorts(group string, desired []string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(group, desired)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string, string, string, string, []string, map[string]string) *goovn.OvnCommand); ok {
-		r0 = rf(lsw, lsp, lr, lrp, lrpMac, networks, externalIds)
+	if rf, ok := ret.Get(0).(func(string, []string) *goovn.OvnCommand); ok {
+		r0 = rf(group, desired)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -2040,8 +4124,8 @@ func (_m *Client) LinkSwitchToRouter(lsw string, lsp string, lr string, lrp stri
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, string, string, string, []string, map[string]string) error); ok {
-		r1 = rf(lsw, lsp, lr, lrp, lrpMac, networks, externalIds)
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(group, desired)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2049,13 +4133,13 @@ func (_m *Client) LinkSwitchToRouter(lsw string, lsp string, lr string, lrp stri
 	return r0, r1
 }
 
-// MeterAdd provides a mock function with given fields: name, action, rate, unit, external_ids, burst
-func (_m *Client) MeterAdd(name string, action string, rate int, unit string, external_ids map[string]string, burst int) (*goovn.OvnCommand, error) {
-	ret := _m.Called(name, action, rate, unit, external_ids, burst)
+// PortGroupRemovePort provides a mock function with given fields: group, port
+func (_m *Client) PortGroupRemovePort(group string, port string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(group, port)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string, int, string, map[string]string, int) *goovn.OvnCommand); ok {
-		r0 = rf(name, action, rate, unit, external_ids, burst)
+	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
+		r0 = rf(group, port)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -2063,8 +4147,8 @@ func (_m *Client) MeterAdd(name string, action string, rate int, unit string, ex
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, int, string, map[string]string, int) error); ok {
-		r1 = rf(name, action, rate, unit, external_ids, burst)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(group, port)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2072,22 +4156,22 @@ func (_m *Client) MeterAdd(name string, action string, rate int, unit string, ex
 	return r0, r1
 }
 
-// MeterBandsList provides a mock function with given fields:
-func (_m *Client) MeterBandsList() ([]*goovn.MeterBand, error) {
-	ret := _m.Called()
+// PortGroupUpdate provides a mock function with given fields: group, ports, external_ids
+func (_m *Client) PortGroupUpdate(group string, ports []string, external_ids map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(group, ports, external_ids)
 
-	var r0 []*goovn.MeterBand
-	if rf, ok := ret.Get(0).(func() []*goovn.MeterBand); ok {
-		r0 = rf()
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, []string, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(group, ports, external_ids)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*goovn.MeterBand)
+			r0 = ret.Get(0).(*goovn.OvnCommand)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string, []string, map[string]string) error); ok {
+		r1 = rf(group, ports, external_ids)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2095,19 +4179,13 @@ func (_m *Client) MeterBandsList() ([]*goovn.MeterBand, error) {
 	return r0, r1
 }
 
-// MeterDel provides a mock function with given fields: name
-func (_m *Client) MeterDel(name ...string) (*goovn.OvnCommand, error) {
-	_va := make([]interface{}, len(name))
-	for _i := range name {
-		_va[_i] = name[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
+// QoSAdd provides a mock function with given fields: ls, direction, priority, match, action, bandwidth, external_ids
+func (_m *Client) QoSAdd(ls string, direction string, priority int, match string, action map[string]int, bandwidth map[string]int, external_ids map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(ls, direction, priority, match, action, bandwidth, external_ids)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(...string) *goovn.OvnCommand); ok {
-		r0 = rf(name...)
+	if rf, ok := ret.Get(0).(func(string, string, int, string, map[string]int, map[string]int, map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(ls, direction, priority, match, action, bandwidth, external_ids)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -2115,8 +4193,8 @@ func (_m *Client) MeterDel(name ...string) (*goovn.OvnCommand, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(...string) error); ok {
-		r1 = rf(name...)
+	if rf, ok := ret.Get(1).(func(string, string, int, string, map[string]int, map[string]int, map[string]string) error); ok {
+		r1 = rf(ls, direction, priority, match, action, bandwidth, external_ids)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2124,22 +4202,22 @@ func (_m *Client) MeterDel(name ...string) (*goovn.OvnCommand, error) {
 	return r0, r1
 }
 
-// MeterList provides a mock function with given fields:
-func (_m *Client) MeterList() ([]*goovn.Meter, error) {
-	ret := _m.Called()
+// QoSAddDSCP provides a mock function with given fields: ls, direction, priority, match, dscp
+func (_m *Client) QoSAddDSCP(ls string, direction string, priority int, match string, dscp int) (*goovn.OvnCommand, error) {
+	ret := _m.Called(ls, direction, priority, match, dscp)
 
-	var r0 []*goovn.Meter
-	if rf, ok := ret.Get(0).(func() []*goovn.Meter); ok {
-		r0 = rf()
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, int, string, int) *goovn.OvnCommand); ok {
+		r0 = rf(ls, direction, priority, match, dscp)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*goovn.Meter)
+			r0 = ret.Get(0).(*goovn.OvnCommand)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string, string, int, string, int) error); ok {
+		r1 = rf(ls, direction, priority, match, dscp)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2147,22 +4225,22 @@ func (_m *Client) MeterList() ([]*goovn.Meter, error) {
 	return r0, r1
 }
 
-// NBGlobalGetOptions provides a mock function with given fields:
-func (_m *Client) NBGlobalGetOptions() (map[string]string, error) {
-	ret := _m.Called()
+// QoSAddRateLimit provides a mock function with given fields: ls, direction, priority, match, rateKbps, burstKb
+func (_m *Client) QoSAddRateLimit(ls string, direction string, priority int, match string, rateKbps int, burstKb int) (*goovn.OvnCommand, error) {
+	ret := _m.Called(ls, direction, priority, match, rateKbps, burstKb)
 
-	var r0 map[string]string
-	if rf, ok := ret.Get(0).(func() map[string]string); ok {
-		r0 = rf()
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string, int, string, int, int) *goovn.OvnCommand); ok {
+		r0 = rf(ls, direction, priority, match, rateKbps, burstKb)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]string)
+			r0 = ret.Get(0).(*goovn.OvnCommand)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string, string, int, string, int, int) error); ok {
+		r1 = rf(ls, direction, priority, match, rateKbps, burstKb)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2170,13 +4248,13 @@ func (_m *Client) NBGlobalGetOptions() (map[string]string, error) {
 	return r0, r1
 }
 
-// NBGlobalSetOptions provides a mock function with given fields: options
-func (_m *Client) NBGlobalSetOptions(options map[string]string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(options)
+// QoSDel provides a mock function with given fields: ls, direction, priority, match
+func (_m *Client) QoSDel(ls string, direction string, priority int, match string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(ls, direction, priority, match)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(map[string]string) *goovn.OvnCommand); ok {
-		r0 = rf(options)
+	if rf, ok := ret.Get(0).(func(string, string, int, string) *goovn.OvnCommand); ok {
+		r0 = rf(ls, direction, priority, match)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -2184,8 +4262,8 @@ func (_m *Client) NBGlobalSetOptions(options map[string]string) (*goovn.OvnComma
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(map[string]string) error); ok {
-		r1 = rf(options)
+	if rf, ok := ret.Get(1).(func(string, string, int, string) error); ok {
+		r1 = rf(ls, direction, priority, match)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2193,22 +4271,22 @@ func (_m *Client) NBGlobalSetOptions(options map[string]string) (*goovn.OvnComma
 	return r0, r1
 }
 
-// PortGroupAdd provides a mock function with given fields: group, ports, external_ids
-func (_m *Client) PortGroupAdd(group string, ports []string, external_ids map[string]string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(group, ports, external_ids)
+// QoSList provides a mock function with given fields: ls
+func (_m *Client) QoSList(ls string) ([]*goovn.QoS, error) {
+	ret := _m.Called(ls)
 
-	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, []string, map[string]string) *goovn.OvnCommand); ok {
-		r0 = rf(group, ports, external_ids)
+	var r0 []*goovn.QoS
+	if rf, ok := ret.Get(0).(func(string) []*goovn.QoS); ok {
+		r0 = rf(ls)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*goovn.OvnCommand)
+			r0 = ret.Get(0).([]*goovn.QoS)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, []string, map[string]string) error); ok {
-		r1 = rf(group, ports, external_ids)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(ls)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2216,13 +4294,13 @@ func (_m *Client) PortGroupAdd(group string, ports []string, external_ids map[st
 	return r0, r1
 }
 
-// PortGroupAddPort provides a mock function with given fields: group, port
-func (_m *Client) PortGroupAddPort(group string, port string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(group, port)
+// ReconcileChassisEncap provides a mock function with given fields: chassisName, newIP
+func (_m *Client) ReconcileChassisEncap(chassisName string, newIP string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(chassisName, newIP)
 
 	var r0 *goovn.OvnCommand
 	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
-		r0 = rf(group, port)
+		r0 = rf(chassisName, newIP)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -2231,7 +4309,7 @@ func (_m *Client) PortGroupAddPort(group string, port string) (*goovn.OvnCommand
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(group, port)
+		r1 = rf(chassisName, newIP)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2239,13 +4317,13 @@ func (_m *Client) PortGroupAddPort(group string, port string) (*goovn.OvnCommand
 	return r0, r1
 }
 
-// PortGroupDel provides a mock function with given fields: group
-func (_m *Client) PortGroupDel(group string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(group)
+// Rename provides a mock function with given fields: table, oldName, newName
+func (_m *Client) Rename(table string, oldName string, newName string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(table, oldName, newName)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string) *goovn.OvnCommand); ok {
-		r0 = rf(group)
+	if rf, ok := ret.Get(0).(func(string, string, string) *goovn.OvnCommand); ok {
+		r0 = rf(table, oldName, newName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -2253,8 +4331,8 @@ func (_m *Client) PortGroupDel(group string) (*goovn.OvnCommand, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(group)
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(table, oldName, newName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2262,22 +4340,20 @@ func (_m *Client) PortGroupDel(group string) (*goovn.OvnCommand, error) {
 	return r0, r1
 }
 
-// PortGroupGet provides a mock function with given fields: group
-func (_m *Client) PortGroupGet(group string) (*goovn.PortGroup, error) {
-	ret := _m.Called(group)
+// SBGlobalGetNbCfg provides a mock function with given fields:
+func (_m *Client) SBGlobalGetNbCfg() (int, error) {
+	ret := _m.Called()
 
-	var r0 *goovn.PortGroup
-	if rf, ok := ret.Get(0).(func(string) *goovn.PortGroup); ok {
-		r0 = rf(group)
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*goovn.PortGroup)
-		}
+		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(group)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2285,22 +4361,22 @@ func (_m *Client) PortGroupGet(group string) (*goovn.PortGroup, error) {
 	return r0, r1
 }
 
-// PortGroupRemovePort provides a mock function with given fields: group, port
-func (_m *Client) PortGroupRemovePort(group string, port string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(group, port)
+// SBGlobalGetOptions provides a mock function with given fields:
+func (_m *Client) SBGlobalGetOptions() (map[string]string, error) {
+	ret := _m.Called()
 
-	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
-		r0 = rf(group, port)
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func() map[string]string); ok {
+		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*goovn.OvnCommand)
+			r0 = ret.Get(0).(map[string]string)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(group, port)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2308,13 +4384,13 @@ func (_m *Client) PortGroupRemovePort(group string, port string) (*goovn.OvnComm
 	return r0, r1
 }
 
-// PortGroupUpdate provides a mock function with given fields: group, ports, external_ids
-func (_m *Client) PortGroupUpdate(group string, ports []string, external_ids map[string]string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(group, ports, external_ids)
+// SBGlobalSetOptions provides a mock function with given fields: options
+func (_m *Client) SBGlobalSetOptions(options map[string]string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(options)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, []string, map[string]string) *goovn.OvnCommand); ok {
-		r0 = rf(group, ports, external_ids)
+	if rf, ok := ret.Get(0).(func(map[string]string) *goovn.OvnCommand); ok {
+		r0 = rf(options)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -2322,8 +4398,8 @@ func (_m *Client) PortGroupUpdate(group string, ports []string, external_ids map
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, []string, map[string]string) error); ok {
-		r1 = rf(group, ports, external_ids)
+	if rf, ok := ret.Get(1).(func(map[string]string) error); ok {
+		r1 = rf(options)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2331,36 +4407,38 @@ func (_m *Client) PortGroupUpdate(group string, ports []string, external_ids map
 	return r0, r1
 }
 
-// QoSAdd provides a mock function with given fields: ls, direction, priority, match, action, bandwidth, external_ids
-func (_m *Client) QoSAdd(ls string, direction string, priority int, match string, action map[string]int, bandwidth map[string]int, external_ids map[string]string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(ls, direction, priority, match, action, bandwidth, external_ids)
+// Snapshot provides a mock function with given fields:
+func (_m *Client) Snapshot() (*goovn.CacheSnapshot, func()) {
+	ret := _m.Called()
 
-	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string, int, string, map[string]int, map[string]int, map[string]string) *goovn.OvnCommand); ok {
-		r0 = rf(ls, direction, priority, match, action, bandwidth, external_ids)
+	var r0 *goovn.CacheSnapshot
+	if rf, ok := ret.Get(0).(func() *goovn.CacheSnapshot); ok {
+		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*goovn.OvnCommand)
+			r0 = ret.Get(0).(*goovn.CacheSnapshot)
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, int, string, map[string]int, map[string]int, map[string]string) error); ok {
-		r1 = rf(ls, direction, priority, match, action, bandwidth, external_ids)
+	var r1 func()
+	if rf, ok := ret.Get(1).(func() func()); ok {
+		r1 = rf()
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func())
+		}
 	}
 
 	return r0, r1
 }
 
-// QoSDel provides a mock function with given fields: ls, direction, priority, match
-func (_m *Client) QoSDel(ls string, direction string, priority int, match string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(ls, direction, priority, match)
+// StaticMACBindingAdd provides a mock function with given fields: logicalPort, ip, mac, overrideDynamic
+func (_m *Client) StaticMACBindingAdd(logicalPort string, ip string, mac string, overrideDynamic bool) (*goovn.OvnCommand, error) {
+	ret := _m.Called(logicalPort, ip, mac, overrideDynamic)
 
 	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(string, string, int, string) *goovn.OvnCommand); ok {
-		r0 = rf(ls, direction, priority, match)
+	if rf, ok := ret.Get(0).(func(string, string, string, bool) *goovn.OvnCommand); ok {
+		r0 = rf(logicalPort, ip, mac, overrideDynamic)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*goovn.OvnCommand)
@@ -2368,8 +4446,8 @@ func (_m *Client) QoSDel(ls string, direction string, priority int, match string
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, int, string) error); ok {
-		r1 = rf(ls, direction, priority, match)
+	if rf, ok := ret.Get(1).(func(string, string, string, bool) error); ok {
+		r1 = rf(logicalPort, ip, mac, overrideDynamic)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2377,22 +4455,22 @@ func (_m *Client) QoSDel(ls string, direction string, priority int, match string
 	return r0, r1
 }
 
-// QoSList provides a mock function with given fields: ls
-func (_m *Client) QoSList(ls string) ([]*goovn.QoS, error) {
-	ret := _m.Called(ls)
+// StaticMACBindingDel provides a mock function with given fields: logicalPort, ip
+func (_m *Client) StaticMACBindingDel(logicalPort string, ip string) (*goovn.OvnCommand, error) {
+	ret := _m.Called(logicalPort, ip)
 
-	var r0 []*goovn.QoS
-	if rf, ok := ret.Get(0).(func(string) []*goovn.QoS); ok {
-		r0 = rf(ls)
+	var r0 *goovn.OvnCommand
+	if rf, ok := ret.Get(0).(func(string, string) *goovn.OvnCommand); ok {
+		r0 = rf(logicalPort, ip)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*goovn.QoS)
+			r0 = ret.Get(0).(*goovn.OvnCommand)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(ls)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(logicalPort, ip)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2400,16 +4478,16 @@ func (_m *Client) QoSList(ls string) ([]*goovn.QoS, error) {
 	return r0, r1
 }
 
-// SBGlobalGetOptions provides a mock function with given fields:
-func (_m *Client) SBGlobalGetOptions() (map[string]string, error) {
+// StaticMACBindingList provides a mock function with given fields:
+func (_m *Client) StaticMACBindingList() ([]*goovn.StaticMACBinding, error) {
 	ret := _m.Called()
 
-	var r0 map[string]string
-	if rf, ok := ret.Get(0).(func() map[string]string); ok {
+	var r0 []*goovn.StaticMACBinding
+	if rf, ok := ret.Get(0).(func() []*goovn.StaticMACBinding); ok {
 		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]string)
+			r0 = ret.Get(0).([]*goovn.StaticMACBinding)
 		}
 	}
 
@@ -2423,25 +4501,60 @@ func (_m *Client) SBGlobalGetOptions() (map[string]string, error) {
 	return r0, r1
 }
 
-// SBGlobalSetOptions provides a mock function with given fields: options
-func (_m *Client) SBGlobalSetOptions(options map[string]string) (*goovn.OvnCommand, error) {
-	ret := _m.Called(options)
+// Steal provides a mock function with given fields: id
+func (_m *Client) Steal(id string) error {
+	ret := _m.Called(id)
 
-	var r0 *goovn.OvnCommand
-	if rf, ok := ret.Get(0).(func(map[string]string) *goovn.OvnCommand); ok {
-		r0 = rf(options)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TransactionCounts provides a mock function with given fields:
+func (_m *Client) TransactionCounts() map[string]goovn.TxnCounts {
+	ret := _m.Called()
+
+	var r0 map[string]goovn.TxnCounts
+	if rf, ok := ret.Get(0).(func() map[string]goovn.TxnCounts); ok {
+		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*goovn.OvnCommand)
+			r0 = ret.Get(0).(map[string]goovn.TxnCounts)
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(map[string]string) error); ok {
-		r1 = rf(options)
+	return r0
+}
+
+// Unlock provides a mock function with given fields: id
+func (_m *Client) Unlock(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
+}
+
+// WaitForLSPUp provides a mock function with given fields: ctx, lsp
+func (_m *Client) WaitForLSPUp(ctx context.Context, lsp string) error {
+	ret := _m.Called(ctx, lsp)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, lsp)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
@@ -1,6 +1,8 @@
 package testing
 
 import (
+	goovn "github.com/ebay/go-ovn"
+	"github.com/ebay/libovsdb"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -58,3 +60,10 @@ func ProcessMockFn(mockObj *mock.Mock, mArgs TestifyMockHelper) {
 		call.Times(mArgs.CallTimes)
 	}
 }
+
+// MockCommand returns a go-ovn command for a mock to return and to be
+// expected back by Execute. The table of its single operation is name, which
+// tells the commands apart when they are compared.
+func MockCommand(name string) *goovn.OvnCommand {
+	return &goovn.OvnCommand{Operations: []libovsdb.Operation{{Table: name}}}
+}
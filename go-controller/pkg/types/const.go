@@ -113,3 +113,26 @@ const (
 	// Geneve header length for IPv6 (https://github.com/openshift/cluster-network-operator/pull/720#issuecomment-664020823)
 	GeneveHeaderLengthIPv6 = GeneveHeaderLengthIPv4 + 20
 )
+
+// OVN Northbound table names used with ovn-nbctl. They are spelled in lower
+// case, as most callers pass them; ovn-nbctl matches them case-insensitively.
+const (
+	OVNNBTableACL                      = "acl"
+	OVNNBTableGatewayChassis           = "gateway_chassis"
+	OVNNBTableLoadBalancer             = "load_balancer"
+	OVNNBTableLogicalRouter            = "logical_router"
+	OVNNBTableLogicalRouterPolicy      = "logical_router_policy"
+	OVNNBTableLogicalRouterPort        = "logical_router_port"
+	OVNNBTableLogicalRouterStaticRoute = "logical_router_static_route"
+	OVNNBTableLogicalSwitch            = "logical_switch"
+	OVNNBTableLogicalSwitchPort        = "logical_switch_port"
+	OVNNBTableMeter                    = "meter"
+	OVNNBTableNAT                      = "nat"
+	OVNNBTableNBGlobal                 = "nb_global"
+	OVNNBTablePortGroup                = "port_group"
+)
+
+// OVN Southbound table names used with ovn-sbctl, spelled like the northbound ones
+const (
+	OVNSBTableSBGlobal = "sb_global"
+)
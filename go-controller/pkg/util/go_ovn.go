@@ -15,7 +15,43 @@ import (
 	"k8s.io/klog/v2"
 )
 
-func NewOVNNBClient() (goovn.Client, error) {
+// sbIgnoredColumns are southbound columns that ovnkube doesn't read and that
+// every ovn-controller bumps whenever it catches up with a northbound change
+var sbIgnoredColumns = map[string][]string{
+	goovn.TableChassis:        {"nb_cfg"},
+	goovn.TableChassisPrivate: {"nb_cfg", "nb_cfg_timestamp"},
+}
+
+// ignoredColumns returns the columns whose changes the client of db doesn't
+// apply to its cache
+func ignoredColumns(db string) map[string][]string {
+	if db == goovn.DBSB {
+		return sbIgnoredColumns
+	}
+	return nil
+}
+
+// newClientConfig returns the configuration of the client of db at address.
+// The northbound client is given the southbound client, if any, to resolve
+// the chassis binding of the logical switch ports from.
+func newClientConfig(address, db string, sbClient goovn.Client) *goovn.Config {
+	cfg := &goovn.Config{
+		Db:            db,
+		Addr:          address,
+		Reconnect:     true,
+		LeaderOnly:    true,
+		Timeout:       time.Minute,
+		IgnoreColumns: ignoredColumns(db),
+	}
+	if db == goovn.DBNB {
+		cfg.SBClient = sbClient
+	}
+	return cfg
+}
+
+// NewOVNNBClient returns the northbound client, which reads the port bindings
+// from sbClient
+func NewOVNNBClient(sbClient goovn.Client) (goovn.Client, error) {
 	var (
 		err      error
 		nbClient goovn.Client
@@ -25,11 +61,11 @@ func NewOVNNBClient() (goovn.Client, error) {
 	case config.OvnDBSchemeSSL:
 		nbClient, err = initGoOvnSslClient(config.OvnNorth.Cert,
 			config.OvnNorth.PrivKey, config.OvnNorth.CACert,
-			config.OvnNorth.GetURL(), goovn.DBNB, config.OvnNorth.CertCommonName)
+			newClientConfig(config.OvnNorth.GetURL(), goovn.DBNB, sbClient), config.OvnNorth.CertCommonName)
 	case config.OvnDBSchemeTCP:
-		nbClient, err = initGoOvnTcpClient(config.OvnNorth.GetURL(), goovn.DBNB)
+		nbClient, err = initGoOvnTcpClient(newClientConfig(config.OvnNorth.GetURL(), goovn.DBNB, sbClient))
 	case config.OvnDBSchemeUnix:
-		nbClient, err = initGoOvnUnixClient(config.OvnNorth.GetURL(), goovn.DBNB)
+		nbClient, err = initGoOvnUnixClient(newClientConfig(config.OvnNorth.GetURL(), goovn.DBNB, sbClient))
 	default:
 		err = fmt.Errorf("invalid db scheme: %s when initializing the OVN NB Client",
 			config.OvnNorth.Scheme)
@@ -53,11 +89,11 @@ func NewOVNSBClient() (goovn.Client, error) {
 	case config.OvnDBSchemeSSL:
 		sbClient, err = initGoOvnSslClient(config.OvnSouth.Cert,
 			config.OvnSouth.PrivKey, config.OvnSouth.CACert,
-			config.OvnSouth.GetURL(), goovn.DBSB, config.OvnSouth.CertCommonName)
+			newClientConfig(config.OvnSouth.GetURL(), goovn.DBSB, nil), config.OvnSouth.CertCommonName)
 	case config.OvnDBSchemeTCP:
-		sbClient, err = initGoOvnTcpClient(config.OvnSouth.GetURL(), goovn.DBSB)
+		sbClient, err = initGoOvnTcpClient(newClientConfig(config.OvnSouth.GetURL(), goovn.DBSB, nil))
 	case config.OvnDBSchemeUnix:
-		sbClient, err = initGoOvnUnixClient(config.OvnSouth.GetURL(), goovn.DBSB)
+		sbClient, err = initGoOvnUnixClient(newClientConfig(config.OvnSouth.GetURL(), goovn.DBSB, nil))
 	default:
 		err = fmt.Errorf("invalid db scheme: %s when initializing the OVN SB Client",
 			config.OvnSouth.Scheme)
@@ -71,7 +107,8 @@ func NewOVNSBClient() (goovn.Client, error) {
 	return sbClient, nil
 }
 
-func initGoOvnSslClient(certFile, privKeyFile, caCertFile, address, db, serverName string) (goovn.Client, error) {
+func initGoOvnSslClient(certFile, privKeyFile, caCertFile string, cfg *goovn.Config, serverName string) (goovn.Client, error) {
+	address, db := cfg.Addr, cfg.Db
 	cert, err := tls.LoadX509KeyPair(certFile, privKeyFile)
 	if err != nil {
 		return nil, fmt.Errorf("error generating x509 certs for ovndbapi: %s", err)
@@ -88,14 +125,8 @@ func initGoOvnSslClient(certFile, privKeyFile, caCertFile, address, db, serverNa
 		ServerName:   serverName,
 	}
 	tlsConfig.BuildNameToCertificate()
-	ovndbclient, err := goovn.NewClient(&goovn.Config{
-		Db:         db,
-		Addr:       address,
-		TLSConfig:  tlsConfig,
-		Reconnect:  true,
-		LeaderOnly: true,
-		Timeout:    time.Minute,
-	})
+	cfg.TLSConfig = tlsConfig
+	ovndbclient, err := goovn.NewClient(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("error creating SSL OVNDBClient for database %s at address %s: %s", db, address, err)
 	}
@@ -156,14 +187,9 @@ func updateSslKeyPair(ovndb, certFile, privKeyFile string, tlsConfig *tls.Config
 	return nil
 }
 
-func initGoOvnTcpClient(address, db string) (goovn.Client, error) {
-	ovndbclient, err := goovn.NewClient(&goovn.Config{
-		Db:         db,
-		Addr:       address,
-		Reconnect:  true,
-		LeaderOnly: true,
-		Timeout:    time.Minute,
-	})
+func initGoOvnTcpClient(cfg *goovn.Config) (goovn.Client, error) {
+	address, db := cfg.Addr, cfg.Db
+	ovndbclient, err := goovn.NewClient(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("error creating TCP OVNDBClient for address %s: %s", address, err)
 	}
@@ -171,14 +197,9 @@ func initGoOvnTcpClient(address, db string) (goovn.Client, error) {
 	return ovndbclient, nil
 }
 
-func initGoOvnUnixClient(address, db string) (goovn.Client, error) {
-	ovndbclient, err := goovn.NewClient(&goovn.Config{
-		Db:         db,
-		Addr:       address,
-		Reconnect:  true,
-		LeaderOnly: true,
-		Timeout:    time.Minute,
-	})
+func initGoOvnUnixClient(cfg *goovn.Config) (goovn.Client, error) {
+	address, db := cfg.Addr, cfg.Db
+	ovndbclient, err := goovn.NewClient(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("error creating UNIX OVNDBClient for address %s: %s", address, err)
 	}
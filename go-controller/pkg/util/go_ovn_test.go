@@ -61,3 +61,33 @@ func TestOvnNBLSPDel(t *testing.T) {
 		})
 	}
 }
+
+func TestNewClientConfig(t *testing.T) {
+	sbClient := new(go_ovn_mocks.Client)
+
+	tests := []struct {
+		desc             string
+		db               string
+		expSBClient      goovn.Client
+		expIgnoreColumns map[string][]string
+	}{
+		{
+			desc:        "the northbound client reads the port bindings from the southbound client",
+			db:          goovn.DBNB,
+			expSBClient: sbClient,
+		},
+		{
+			desc:             "the southbound client ignores the nb_cfg columns",
+			db:               goovn.DBSB,
+			expIgnoreColumns: sbIgnoredColumns,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			cfg := newClientConfig("unix:/var/run/ovn/ovn.sock", tc.db, sbClient)
+			assert.Equal(t, tc.db, cfg.Db)
+			assert.Equal(t, tc.expSBClient, cfg.SBClient)
+			assert.Equal(t, tc.expIgnoreColumns, cfg.IgnoreColumns)
+		})
+	}
+}
@@ -1,15 +1,18 @@
 package util
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"errors"
 	"fmt"
 	"math/big"
 	"net"
+	"sort"
 	"strconv"
 	"strings"
 
 	goovn "github.com/ebay/go-ovn"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	utilnet "k8s.io/utils/net"
 )
 
@@ -34,6 +37,12 @@ func intToIP(i *big.Int) net.IP {
 
 // ParsePortAddresses parses the MAC and IPs of the given logical switch port
 func ParsePortAddresses(lsp *goovn.LogicalSwitchPort) (net.HardwareAddr, []net.IP, error) {
+	// ports read from the client carry their parsed addresses, the others
+	// and the malformed ones are parsed here
+	if lsp.MAC != nil {
+		return lsp.MAC, lsp.IPs, nil
+	}
+
 	var addresses []string
 
 	if lsp.DynamicAddresses == "" {
@@ -82,7 +91,7 @@ func GetPortAddresses(portName string, ovnNBClient goovn.Client) (net.HardwareAd
 // GetLRPAddrs returns the addresses for the given logical router port
 func GetLRPAddrs(portName string) ([]*net.IPNet, error) {
 	networks := []*net.IPNet{}
-	output, stderr, err := RunOVNNbctl("--if-exist", "get", "logical_router_port", portName, "networks")
+	output, stderr, err := RunOVNNbctl("--if-exist", "get", types.OVNNBTableLogicalRouterPort, portName, OVNColumnNetworks)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get logical router port %s, "+
 			"stderr: %q, error: %v", portName, stderr, err)
@@ -194,6 +203,24 @@ func HWAddrToIPv6LLA(hwaddr net.HardwareAddr) net.IP {
 	}
 }
 
+// ComputePortSecurity returns the port_security of a logical switch port
+// with the given MAC and IPs. IPv6 neighbor discovery uses the link local
+// address derived from the MAC, which is added when any IP is IPv6.
+func ComputePortSecurity(mac net.HardwareAddr, ips []*net.IPNet) []string {
+	entry := []string{mac.String()}
+	hasIPv6 := false
+	for _, ip := range ips {
+		entry = append(entry, ip.IP.String())
+		if utilnet.IsIPv6(ip.IP) {
+			hasIPv6 = true
+		}
+	}
+	if hasIPv6 {
+		entry = append(entry, HWAddrToIPv6LLA(mac).String())
+	}
+	return []string{strings.Join(entry, " ")}
+}
+
 // JoinIPs joins the string forms of an array of net.IP, as with strings.Join
 func JoinIPs(ips []net.IP, sep string) string {
 	b := &strings.Builder{}
@@ -291,3 +318,156 @@ func MatchAllIPStringFamily(isIPv6 bool, ipStrings []string) ([]string, error) {
 	}
 	return nil, NoIPError
 }
+
+// parseIPv4LeadingZeros parses a dotted IPv4 address whose octets may have
+// leading zeros, which net.ParseIP rejects. The octets are decimal.
+func parseIPv4LeadingZeros(s string) net.IP {
+	octets := strings.Split(s, ".")
+	if len(octets) != 4 {
+		return nil
+	}
+	ip := make(net.IP, net.IPv4len)
+	for i, octet := range octets {
+		v, err := strconv.ParseUint(octet, 10, 8)
+		if err != nil {
+			return nil
+		}
+		ip[i] = byte(v)
+	}
+	return ip
+}
+
+// CanonicalizeIP returns the canonical form of an IP or CIDR string, so that
+// equal addresses are stored once in OVN: IPv4 octets lose their leading
+// zeros, IPv6 addresses are compressed and lower case, and IPv4-mapped IPv6
+// addresses become IPv4. Only the form of a CIDR changes, its host bits are
+// kept.
+func CanonicalizeIP(s string) (string, error) {
+	addr, prefix := s, ""
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		addr, prefix = s[:i], s[i+1:]
+	}
+
+	var ip net.IP
+	isIPv6 := strings.Contains(addr, ":")
+	if isIPv6 {
+		ip = net.ParseIP(addr)
+	} else {
+		ip = parseIPv4LeadingZeros(addr)
+	}
+	if ip == nil {
+		return "", fmt.Errorf("invalid IP address %q", s)
+	}
+	if len(prefix) == 0 {
+		return ip.String(), nil
+	}
+
+	bits, err := strconv.Atoi(prefix)
+	maxBits := 32
+	if isIPv6 {
+		maxBits = 128
+	}
+	if err != nil || bits < 0 || bits > maxBits {
+		return "", fmt.Errorf("invalid prefix length in %q", s)
+	}
+	if isIPv6 && ip.To4() != nil {
+		// an IPv4-mapped CIDR is written as IPv4 by ip.String()
+		if bits < 96 {
+			return "", fmt.Errorf("IPv4-mapped CIDR %q has a prefix shorter than 96", s)
+		}
+		bits -= 96
+	}
+	return fmt.Sprintf("%s/%d", ip.String(), bits), nil
+}
+
+// maxExcludeIPsRange is the largest number of addresses ParseExcludeIPs
+// expands from a single "start..end" range
+const maxExcludeIPsRange = 65536
+
+// ParseExcludeIPs parses the value of a logical switch's other-config:exclude_ips,
+// a space separated list of IPs and "start..end" ranges, and returns every
+// excluded IP
+func ParseExcludeIPs(s string) ([]net.IP, error) {
+	var ips []net.IP
+	for _, entry := range strings.Fields(s) {
+		bounds := strings.Split(entry, "..")
+		if len(bounds) > 2 {
+			return nil, fmt.Errorf("invalid exclude_ips range %q", entry)
+		}
+		start := net.ParseIP(bounds[0])
+		if start == nil {
+			return nil, fmt.Errorf("invalid IP %q in exclude_ips entry %q", bounds[0], entry)
+		}
+		if len(bounds) == 1 {
+			ips = append(ips, start)
+			continue
+		}
+		end := net.ParseIP(bounds[1])
+		if end == nil {
+			return nil, fmt.Errorf("invalid IP %q in exclude_ips entry %q", bounds[1], entry)
+		}
+		if utilnet.IsIPv6(start) != utilnet.IsIPv6(end) {
+			return nil, fmt.Errorf("exclude_ips range %q mixes IP families", entry)
+		}
+		startInt, endInt := ipToInt(start), ipToInt(end)
+		if startInt.Cmp(endInt) > 0 {
+			return nil, fmt.Errorf("exclude_ips range %q ends before it starts", entry)
+		}
+		size := big.NewInt(0).Sub(endInt, startInt)
+		if size.Cmp(big.NewInt(maxExcludeIPsRange)) >= 0 {
+			return nil, fmt.Errorf("exclude_ips range %q is larger than %d addresses", entry, maxExcludeIPsRange)
+		}
+		ipLen := net.IPv6len
+		if !utilnet.IsIPv6(start) {
+			ipLen = net.IPv4len
+		}
+		for i := startInt; i.Cmp(endInt) <= 0; i = big.NewInt(0).Add(i, big.NewInt(1)) {
+			ip := make(net.IP, ipLen)
+			b := i.Bytes()
+			copy(ip[ipLen-len(b):], b)
+			ips = append(ips, ip)
+		}
+	}
+	return ips, nil
+}
+
+// FormatExcludeIPs returns the exclude_ips value excluding ips, with runs of
+// consecutive IPs collapsed into "start..end" ranges. IPv4 addresses are
+// listed before IPv6 ones.
+func FormatExcludeIPs(ips []net.IP) string {
+	sorted := make([]net.IP, 0, len(ips))
+	for _, ip := range ips {
+		if v4 := ip.To4(); v4 != nil {
+			sorted = append(sorted, v4)
+		} else if v6 := ip.To16(); v6 != nil {
+			sorted = append(sorted, v6)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i]) != len(sorted[j]) {
+			return len(sorted[i]) < len(sorted[j])
+		}
+		return bytes.Compare(sorted[i], sorted[j]) < 0
+	})
+
+	var entries []string
+	for i := 0; i < len(sorted); {
+		start, end := sorted[i], sorted[i]
+		for i++; i < len(sorted); i++ {
+			if len(sorted[i]) != len(end) {
+				break
+			}
+			diff := big.NewInt(0).Sub(ipToInt(sorted[i]), ipToInt(end))
+			if diff.Cmp(big.NewInt(1)) > 0 {
+				break
+			}
+			end = sorted[i]
+		}
+		if start.Equal(end) {
+			entries = append(entries, start.String())
+		} else {
+			entries = append(entries, start.String()+".."+end.String())
+		}
+	}
+	return strings.Join(entries, " ")
+}
@@ -134,6 +134,53 @@ func TestGetPortAddresses(t *testing.T) {
 	}
 }
 
+func TestParsePortAddresses(t *testing.T) {
+	mac := ovntest.MustParseMAC("0a:00:00:00:00:01")
+	ips := []net.IP{ovntest.MustParseIP("192.168.1.3")}
+	tests := []struct {
+		desc     string
+		inpPort  *goovn.LogicalSwitchPort
+		macExp   net.HardwareAddr
+		ipsExp   []net.IP
+		errMatch string
+	}{
+		{
+			desc:    "the addresses parsed by the client are returned",
+			inpPort: &goovn.LogicalSwitchPort{Addresses: []string{"router"}, MAC: mac, IPs: ips},
+			macExp:  mac,
+			ipsExp:  ips,
+		},
+		{
+			desc:    "unparsed addresses are parsed",
+			inpPort: &goovn.LogicalSwitchPort{Addresses: []string{"0a:00:00:00:00:01 192.168.1.3"}},
+			macExp:  mac,
+			ipsExp:  ips,
+		},
+		{
+			desc:    "dynamic addresses without a dynamic assignment are empty",
+			inpPort: &goovn.LogicalSwitchPort{Addresses: []string{"dynamic"}},
+		},
+		{
+			desc:     "addresses left unparsed by the client are reported",
+			inpPort:  &goovn.LogicalSwitchPort{Name: "TEST_PORT", Addresses: []string{"router"}},
+			errMatch: "failed to parse logical switch port \"TEST_PORT\" MAC",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			hwAddr, ipList, err := ParsePortAddresses(tc.inpPort)
+			if tc.errMatch != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMatch)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.macExp, hwAddr)
+				assert.Equal(t, tc.ipsExp, ipList)
+			}
+		})
+	}
+}
+
 func TestGetOVSPortMACAddress(t *testing.T) {
 	mockKexecIface := new(mock_k8s_io_utils_exec.Interface)
 	mockExecRunner := new(mocks.ExecRunner)
@@ -383,6 +430,45 @@ func TestIPAddrToHWAddr(t *testing.T) {
 	}
 }
 
+func TestComputePortSecurity(t *testing.T) {
+	tests := []struct {
+		desc   string
+		inpMAC string
+		inpIPs []string
+		outExp []string
+	}{
+		{
+			desc:   "IPv4 only",
+			inpMAC: "0a:58:0a:80:00:05",
+			inpIPs: []string{"10.128.0.5/24"},
+			outExp: []string{"0a:58:0a:80:00:05 10.128.0.5"},
+		},
+		{
+			desc:   "IPv6 only adds the link local address",
+			inpMAC: "0a:58:0a:80:00:05",
+			inpIPs: []string{"fd00:10:244::5/64"},
+			outExp: []string{"0a:58:0a:80:00:05 fd00:10:244::5 fe80::858:aff:fe80:5"},
+		},
+		{
+			desc:   "dual-stack adds the link local address after the pod IPs",
+			inpMAC: "0a:58:0a:80:00:05",
+			inpIPs: []string{"10.128.0.5/24", "fd00:10:244::5/64"},
+			outExp: []string{"0a:58:0a:80:00:05 10.128.0.5 fd00:10:244::5 fe80::858:aff:fe80:5"},
+		},
+		{
+			desc:   "no IPs",
+			inpMAC: "0a:58:0a:80:00:05",
+			outExp: []string{"0a:58:0a:80:00:05"},
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			res := ComputePortSecurity(ovntest.MustParseMAC(tc.inpMAC), ovntest.MustParseIPNets(tc.inpIPs...))
+			assert.Equal(t, tc.outExp, res)
+		})
+	}
+}
+
 func TestJoinIPs(t *testing.T) {
 	tests := []struct {
 		desc         string
@@ -484,3 +570,212 @@ func TestJoinIPNetIPs(t *testing.T) {
 		})
 	}
 }
+
+func TestParseExcludeIPs(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		outExp   []string
+		errMatch string
+	}{
+		{
+			desc:  "empty value excludes nothing",
+			input: "",
+		},
+		{
+			desc:   "single IPs",
+			input:  "10.1.1.2 10.1.1.5",
+			outExp: []string{"10.1.1.2", "10.1.1.5"},
+		},
+		{
+			desc:   "range is expanded inclusively",
+			input:  "10.1.1.254..10.1.2.1",
+			outExp: []string{"10.1.1.254", "10.1.1.255", "10.1.2.0", "10.1.2.1"},
+		},
+		{
+			desc:   "dual-stack IPs and ranges",
+			input:  "10.1.1.2..10.1.1.3 fd00:10:244::ffff..fd00:10:244::1:0 fd00::2",
+			outExp: []string{"10.1.1.2", "10.1.1.3", "fd00:10:244::ffff", "fd00:10:244::1:0", "fd00::2"},
+		},
+		{
+			desc:     "invalid IP",
+			input:    "10.1.1.2 10.1.1",
+			errMatch: "invalid IP \"10.1.1\"",
+		},
+		{
+			desc:     "invalid range end",
+			input:    "10.1.1.2..",
+			errMatch: "invalid IP \"\"",
+		},
+		{
+			desc:     "too many range separators",
+			input:    "10.1.1.2..10.1.1.3..10.1.1.4",
+			errMatch: "invalid exclude_ips range",
+		},
+		{
+			desc:     "range mixing IP families",
+			input:    "10.1.1.2..fd00::2",
+			errMatch: "mixes IP families",
+		},
+		{
+			desc:     "range ending before its start",
+			input:    "10.1.1.3..10.1.1.2",
+			errMatch: "ends before it starts",
+		},
+		{
+			desc:     "range that is too large",
+			input:    "fd00::..fd00::1:0",
+			errMatch: "is larger than",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			res, err := ParseExcludeIPs(tc.input)
+			if tc.errMatch != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMatch)
+				return
+			}
+			assert.Nil(t, err)
+			var resStrings []string
+			for _, ip := range res {
+				resStrings = append(resStrings, ip.String())
+			}
+			assert.Equal(t, tc.outExp, resStrings)
+		})
+	}
+}
+
+func TestFormatExcludeIPs(t *testing.T) {
+	tests := []struct {
+		desc      string
+		inpIPList []net.IP
+		outExp    string
+	}{
+		{
+			desc:   "empty list",
+			outExp: "",
+		},
+		{
+			desc:      "single IP",
+			inpIPList: ovntest.MustParseIPs("10.1.1.2"),
+			outExp:    "10.1.1.2",
+		},
+		{
+			desc:      "consecutive IPs are collapsed into a range",
+			inpIPList: ovntest.MustParseIPs("10.1.1.3", "10.1.1.2"),
+			outExp:    "10.1.1.2..10.1.1.3",
+		},
+		{
+			desc:      "ranges and single IPs, with duplicates",
+			inpIPList: ovntest.MustParseIPs("10.1.1.9", "10.1.2.0", "10.1.1.255", "10.1.1.2", "10.1.1.9"),
+			outExp:    "10.1.1.2 10.1.1.9 10.1.1.255..10.1.2.0",
+		},
+		{
+			desc:      "dual-stack lists IPv4 first and never mixes families in a range",
+			inpIPList: ovntest.MustParseIPs("fd00::2", "10.1.1.2", "fd00::1", "::ffff:10.1.1.3"),
+			outExp:    "10.1.1.2..10.1.1.3 fd00::1..fd00::2",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			res := FormatExcludeIPs(tc.inpIPList)
+			assert.Equal(t, tc.outExp, res)
+			parsed, err := ParseExcludeIPs(res)
+			assert.Nil(t, err)
+			assert.Equal(t, res, FormatExcludeIPs(parsed))
+		})
+	}
+}
+
+func TestCanonicalizeIP(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		outExp   string
+		errMatch string
+	}{
+		{
+			desc:   "canonical IPv4 is unchanged",
+			input:  "10.1.0.5",
+			outExp: "10.1.0.5",
+		},
+		{
+			desc:   "IPv4 leading zeros are removed",
+			input:  "10.1.0.005",
+			outExp: "10.1.0.5",
+		},
+		{
+			desc:   "IPv4 leading zeros are decimal",
+			input:  "010.001.000.010",
+			outExp: "10.1.0.10",
+		},
+		{
+			desc:   "uppercase IPv6 is lowered",
+			input:  "FE80::1",
+			outExp: "fe80::1",
+		},
+		{
+			desc:   "IPv6 is compressed",
+			input:  "fd00:0010:0244:0000:0000:0000:0000:0005",
+			outExp: "fd00:10:244::5",
+		},
+		{
+			desc:   "IPv4-mapped IPv6 becomes IPv4",
+			input:  "::FFFF:10.1.0.5",
+			outExp: "10.1.0.5",
+		},
+		{
+			desc:   "IPv4 CIDR keeps its host bits",
+			input:  "10.1.0.005/24",
+			outExp: "10.1.0.5/24",
+		},
+		{
+			desc:   "IPv6 CIDR",
+			input:  "FD00:10:244:0::/64",
+			outExp: "fd00:10:244::/64",
+		},
+		{
+			desc:   "IPv4-mapped CIDR becomes IPv4",
+			input:  "::ffff:10.1.0.0/120",
+			outExp: "10.1.0.0/24",
+		},
+		{
+			desc:     "IPv4-mapped CIDR with a short prefix",
+			input:    "::ffff:0.0.0.0/80",
+			errMatch: "prefix shorter than 96",
+		},
+		{
+			desc:     "octet out of range",
+			input:    "10.1.0.256",
+			errMatch: "invalid IP address",
+		},
+		{
+			desc:     "hexadecimal octet",
+			input:    "10.1.0.0x5",
+			errMatch: "invalid IP address",
+		},
+		{
+			desc:     "invalid prefix length",
+			input:    "10.1.0.0/33",
+			errMatch: "invalid prefix length",
+		},
+		{
+			desc:     "empty string",
+			input:    "",
+			errMatch: "invalid IP address",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			res, err := CanonicalizeIP(tc.input)
+			if tc.errMatch != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMatch)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.outExp, res)
+			}
+		})
+	}
+}
@@ -36,7 +36,7 @@ func UpdateRouterSNAT(router string, externalIP net.IP, logicalSubnet *net.IPNet
 	}
 
 	// Search for exact match to see if entry already exists
-	uuids, stderr, err := RunOVNNbctl("--columns", "_uuid", "--format=csv", "--no-headings", "find", "nat",
+	uuids, stderr, err := RunOVNNbctl("--columns", OVNColumnUUID, "--format=csv", "--no-headings", "find", ovntypes.OVNNBTableNAT,
 		fmt.Sprintf("external_ip=\"%s\"", externalIP.String()),
 		"type="+natType,
 		fmt.Sprintf("logical_ip=\"%s\"", logicalIPVal),
@@ -51,7 +51,7 @@ func UpdateRouterSNAT(router string, externalIP net.IP, logicalSubnet *net.IPNet
 			continue
 		}
 		// Potential matches found, check logical_router
-		routerID, stderr, err := RunOVNNbctl("--columns", "_uuid", "--no-headings", "find", "logical_router",
+		routerID, stderr, err := RunOVNNbctl("--columns", OVNColumnUUID, "--no-headings", "find", ovntypes.OVNNBTableLogicalRouter,
 			"name="+router,
 			"nat{>=}"+uuid,
 		)
@@ -138,7 +138,7 @@ func GetDatapathUUID(datapathName string) (string, error) {
 // Finds any OVN Load Balancer based on external ID and value
 func FindOVNLoadBalancer(externalID, externalValue string) (string, string, error) {
 	out, stderr, err := RunOVNNbctl("--data=bare",
-		"--no-heading", "--columns=_uuid", "find", "load_balancer",
+		"--no-heading", "--columns=_uuid", "find", ovntypes.OVNNBTableLoadBalancer,
 		"external_ids:"+externalID+"="+externalValue)
 	if err != nil {
 		return "", stderr, err
@@ -0,0 +1,373 @@
+package util
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// matchFields are the top-level names of the OVN logical fields, the part of a
+// field name before the first dot, e.g. ip4 for ip4.src
+var matchFields = map[string]bool{
+	"inport": true, "outport": true, "flags": true, "pkt": true,
+	"eth": true, "vlan": true, "arp": true, "rarp": true,
+	"ip": true, "ip4": true, "ip6": true,
+	"icmp": true, "icmp4": true, "icmp6": true,
+	"nd": true, "nd_ns": true, "nd_na": true, "nd_ra": true, "nd_rs": true,
+	"tcp": true, "udp": true, "sctp": true,
+	"igmp": true, "mldv1": true, "mldv2": true, "bfd": true,
+	"ct": true, "ct_mark": true, "ct_label": true, "ct_state": true,
+	"ct_nw_src": true, "ct_nw_dst": true, "ct_ip6_src": true, "ct_ip6_dst": true,
+	"ct_nw_proto": true, "ct_tp_src": true, "ct_tp_dst": true,
+}
+
+// matchRegister matches the register fields, e.g. reg0, xreg1 or xxreg0
+var matchRegister = regexp.MustCompile(`^x{0,2}reg[0-9]+$`)
+
+var matchIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+var matchSubfield = regexp.MustCompile(`^[0-9]+(\.\.[0-9]+)?$`)
+
+type matchTokenType int
+
+const (
+	matchTokenEnd matchTokenType = iota
+	matchTokenField
+	matchTokenConstant
+	matchTokenString
+	// references to an address set, $name, or to a port group, @name
+	matchTokenReference
+	matchTokenSubfield
+	matchTokenOperator
+)
+
+type matchToken struct {
+	typ   matchTokenType
+	value string
+}
+
+// ValidateMatch checks that match is a well-formed OVN match expression, so
+// that a mistake is caught before the match is written to the database rather
+// than when ovn-northd fails to turn it into flows. It is a lightweight check,
+// not the OVN parser: it rejects syntax errors such as unbalanced parentheses,
+// unquoted strings, malformed constants and unknown fields, but not, e.g.,
+// relations between a field and a constant of the wrong type.
+func ValidateMatch(match string) error {
+	tokens, err := lexMatch(match)
+	if err != nil {
+		return fmt.Errorf("invalid match %q: %v", match, err)
+	}
+	p := &matchParser{tokens: tokens}
+	if err := p.parseExpression(); err != nil {
+		return fmt.Errorf("invalid match %q: %v", match, err)
+	}
+	if next := p.peek(); next.typ != matchTokenEnd {
+		return fmt.Errorf("invalid match %q: unexpected %q", match, next.value)
+	}
+	return nil
+}
+
+func isMatchWordChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '.' || c == ':' || c == '/'
+}
+
+// lexMatch splits a match into tokens, dropping the comments
+func lexMatch(match string) ([]matchToken, error) {
+	var tokens []matchToken
+	for i := 0; i < len(match); {
+		c := match[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(match[i:], "/*"):
+			end := strings.Index(match[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i += end + 4
+		case strings.HasPrefix(match[i:], "//"):
+			end := strings.IndexByte(match[i:], '\n')
+			if end < 0 {
+				end = len(match) - i
+			}
+			i += end
+		case c == '"':
+			end := i + 1
+			for ; end < len(match) && match[end] != '"'; end++ {
+				if match[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(match) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, matchToken{matchTokenString, match[i : end+1]})
+			i = end + 1
+		case c == '$' || c == '@':
+			end := i + 1
+			for end < len(match) && isMatchWordChar(match[end]) {
+				end++
+			}
+			if !matchIdentifier.MatchString(match[i+1 : end]) {
+				return nil, fmt.Errorf("invalid reference %q", match[i:end])
+			}
+			tokens = append(tokens, matchToken{matchTokenReference, match[i:end]})
+			i = end
+		case c == '[':
+			end := strings.IndexByte(match[i:], ']')
+			if end < 0 || !matchSubfield.MatchString(match[i+1:i+end]) {
+				return nil, fmt.Errorf("invalid subfield at %q", match[i:])
+			}
+			tokens = append(tokens, matchToken{matchTokenSubfield, match[i : i+end+1]})
+			i += end + 1
+		case isMatchWordChar(c):
+			end := i
+			for end < len(match) && isMatchWordChar(match[end]) {
+				end++
+			}
+			word := match[i:end]
+			if c >= '0' && c <= '9' || strings.Contains(word, ":") {
+				if !isMatchConstant(word) {
+					return nil, fmt.Errorf("invalid constant %q", word)
+				}
+				tokens = append(tokens, matchToken{matchTokenConstant, word})
+			} else {
+				if !matchIdentifier.MatchString(word) {
+					return nil, fmt.Errorf("invalid name %q", word)
+				}
+				tokens = append(tokens, matchToken{matchTokenField, word})
+			}
+			i = end
+		default:
+			op := ""
+			for _, candidate := range []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")", "{", "}", ","} {
+				if strings.HasPrefix(match[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q", c)
+			}
+			tokens = append(tokens, matchToken{matchTokenOperator, op})
+			i += len(op)
+		}
+	}
+	return tokens, nil
+}
+
+// isMatchConstant tells whether word is an integer, an IP address or a MAC
+// address, optionally followed by a /mask, or by a /prefix length for IPs
+func isMatchConstant(word string) bool {
+	value := word
+	mask := ""
+	if slash := strings.IndexByte(word, '/'); slash >= 0 {
+		value, mask = word[:slash], word[slash+1:]
+	}
+	isInteger := func(s string) bool {
+		_, err := strconv.ParseUint(s, 0, 64)
+		return err == nil
+	}
+	if isInteger(value) {
+		return mask == "" || isInteger(mask)
+	}
+	if ip := net.ParseIP(value); ip != nil {
+		if mask == "" {
+			return true
+		}
+		if maskIP := net.ParseIP(mask); maskIP != nil {
+			return (ip.To4() == nil) == (maskIP.To4() == nil)
+		}
+		bits := 128
+		if ip.To4() != nil {
+			bits = 32
+		}
+		length, err := strconv.Atoi(mask)
+		return err == nil && length >= 0 && length <= bits
+	}
+	if _, err := net.ParseMAC(value); err == nil && len(value) == len("00:00:00:00:00:00") {
+		if mask == "" {
+			return true
+		}
+		_, err := net.ParseMAC(mask)
+		return err == nil && len(mask) == len(value)
+	}
+	return false
+}
+
+type matchParser struct {
+	tokens []matchToken
+	next   int
+}
+
+func (p *matchParser) peek() matchToken {
+	if p.next < len(p.tokens) {
+		return p.tokens[p.next]
+	}
+	return matchToken{matchTokenEnd, "end of match"}
+}
+
+func (p *matchParser) consume() matchToken {
+	token := p.peek()
+	if token.typ != matchTokenEnd {
+		p.next++
+	}
+	return token
+}
+
+func (p *matchParser) consumeOperator(op string) bool {
+	if token := p.peek(); token.typ == matchTokenOperator && token.value == op {
+		p.next++
+		return true
+	}
+	return false
+}
+
+func isMatchRelation(token matchToken) bool {
+	if token.typ != matchTokenOperator {
+		return false
+	}
+	switch token.value {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+// parseExpression parses terms joined by && or ||, which OVN requires to be
+// parenthesized when used together
+func (p *matchParser) parseExpression() error {
+	if err := p.parseTerm(); err != nil {
+		return err
+	}
+	joiner := ""
+	for {
+		token := p.peek()
+		if token.typ != matchTokenOperator || (token.value != "&&" && token.value != "||") {
+			return nil
+		}
+		if joiner != "" && joiner != token.value {
+			return fmt.Errorf("&& and || must be parenthesized when used together")
+		}
+		joiner = p.consume().value
+		if err := p.parseTerm(); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *matchParser) parseTerm() error {
+	if p.consumeOperator("!") {
+		return p.parseTerm()
+	}
+	if p.consumeOperator("(") {
+		if err := p.parseExpression(); err != nil {
+			return err
+		}
+		if !p.consumeOperator(")") {
+			return fmt.Errorf("missing ) before %q", p.peek().value)
+		}
+		return nil
+	}
+
+	token := p.consume()
+	switch token.typ {
+	case matchTokenField:
+		if token.value == "is_chassis_resident" {
+			if !p.consumeOperator("(") || p.consume().typ != matchTokenString || !p.consumeOperator(")") {
+				return fmt.Errorf("is_chassis_resident takes a quoted port name")
+			}
+			return nil
+		}
+		if err := p.parseField(token); err != nil {
+			return err
+		}
+		// a field on its own is a predicate, e.g. tcp or ct.est
+		if !isMatchRelation(p.peek()) {
+			return nil
+		}
+		return p.parseValue(p.consume().value)
+	case matchTokenConstant:
+		// a range, e.g. 1 <= tcp.dst <= 100, or the constant 0 or 1
+		if !isMatchRelation(p.peek()) {
+			if token.value != "0" && token.value != "1" {
+				return fmt.Errorf("constant %q is not a relation", token.value)
+			}
+			return nil
+		}
+		p.consume()
+		field := p.consume()
+		if field.typ != matchTokenField {
+			return fmt.Errorf("expected a field after %q, got %q", token.value, field.value)
+		}
+		if err := p.parseField(field); err != nil {
+			return err
+		}
+		if !isMatchRelation(p.peek()) {
+			return nil
+		}
+		return p.parseValue(p.consume().value)
+	case matchTokenString:
+		return fmt.Errorf("string %s is not a relation", token.value)
+	case matchTokenEnd:
+		return fmt.Errorf("unexpected end of match")
+	}
+	return fmt.Errorf("unexpected %q", token.value)
+}
+
+// parseField checks that field is a known logical field, and consumes the
+// subfield following it, if any
+func (p *matchParser) parseField(field matchToken) error {
+	name := strings.SplitN(field.value, ".", 2)[0]
+	if !matchFields[name] && !matchRegister.MatchString(name) {
+		return fmt.Errorf("unknown field %q", field.value)
+	}
+	if p.peek().typ == matchTokenSubfield {
+		p.consume()
+	}
+	return nil
+}
+
+// parseValue parses the right-hand side of a relation: a constant, a string,
+// an address set or port group reference, or a set of those. OVN only orders
+// numeric values.
+func (p *matchParser) parseValue(relation string) error {
+	ordered := relation != "==" && relation != "!="
+	if p.consumeOperator("{") {
+		if ordered {
+			return fmt.Errorf("%s cannot compare to a set", relation)
+		}
+		for count := 0; !p.consumeOperator("}"); count++ {
+			if count > 0 {
+				// the commas between the values are optional
+				p.consumeOperator(",")
+			}
+			if err := p.parseScalar(false); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return p.parseScalar(ordered)
+}
+
+func (p *matchParser) parseScalar(ordered bool) error {
+	token := p.consume()
+	switch token.typ {
+	case matchTokenConstant:
+		return nil
+	case matchTokenString, matchTokenReference:
+		if ordered {
+			return fmt.Errorf("cannot order %s", token.value)
+		}
+		return nil
+	case matchTokenField:
+		return fmt.Errorf("unquoted string %q", token.value)
+	case matchTokenEnd:
+		return fmt.Errorf("missing value at end of match")
+	}
+	return fmt.Errorf("expected a value, got %q", token.value)
+}
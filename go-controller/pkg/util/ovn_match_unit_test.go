@@ -0,0 +1,121 @@
+package util
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMatch(t *testing.T) {
+	tests := []struct {
+		desc     string
+		match    string
+		errMatch string
+	}{
+		{
+			desc:  "accepts a port group policy match",
+			match: `ip4.src == $a10066014282442356 && outport == @a13757631697825269621`,
+		},
+		{
+			desc:  "accepts a reroute policy match with a comment",
+			match: `inport == "rtos-node1" && ip4.dst == 172.18.0.2 /* node1 */`,
+		},
+		{
+			desc:  "accepts parenthesized disjunctions",
+			match: `(ip4.dst == 1.2.3.4/32 || ip6.dst == 2001::/64) && (ip4.src == $testv4 || ip6.src == $testv6) && inport == "rtoj-GR_node1"`,
+		},
+		{
+			desc:  "accepts predicates, negations and ranges",
+			match: `!ct.est && tcp && 1000 <= tcp.dst <= 2000 && ct_label.blocked == 1`,
+		},
+		{
+			desc:  "accepts sets, subfields and masked constants",
+			match: `eth.src == {0a:58:0a:80:00:05, 0a:58:0a:80:00:06} && reg0[8..11] == 0xf/0xf && ip6.src == fd00::/ffff::`,
+		},
+		{
+			desc:  "accepts the constant 1",
+			match: "1",
+		},
+		{
+			desc:  "accepts is_chassis_resident",
+			match: `is_chassis_resident("cr-rtos-node1")`,
+		},
+		{
+			desc:     "rejects unbalanced parentheses",
+			match:    `(ip4.src == 10.0.0.1 && tcp`,
+			errMatch: "missing )",
+		},
+		{
+			desc:     "rejects an extra closing parenthesis",
+			match:    `ip4.src == 10.0.0.1)`,
+			errMatch: `unexpected ")"`,
+		},
+		{
+			desc:     "rejects an unknown field",
+			match:    `ipv4.src == 10.0.0.1`,
+			errMatch: `unknown field "ipv4.src"`,
+		},
+		{
+			desc:     "rejects an unquoted string",
+			match:    `inport == rtos_node1`,
+			errMatch: `unquoted string "rtos_node1"`,
+		},
+		{
+			desc:     "rejects && and || used together",
+			match:    `tcp && ip4 || ip6`,
+			errMatch: "must be parenthesized",
+		},
+		{
+			desc:     "rejects an invalid IP",
+			match:    `ip4.dst == 10.0.0.300`,
+			errMatch: `invalid constant "10.0.0.300"`,
+		},
+		{
+			desc:     "rejects an invalid prefix length",
+			match:    `ip4.dst == 10.0.0.0/33`,
+			errMatch: `invalid constant "10.0.0.0/33"`,
+		},
+		{
+			desc:     "rejects a single =",
+			match:    `tcp.dst = 80`,
+			errMatch: `unexpected character '='`,
+		},
+		{
+			desc:     "rejects ordering a string",
+			match:    `inport > "rtos-node1"`,
+			errMatch: "cannot order",
+		},
+		{
+			desc:     "rejects a missing value",
+			match:    `tcp.dst ==`,
+			errMatch: "missing value",
+		},
+		{
+			desc:     "rejects an unterminated string",
+			match:    `inport == "rtos-node1`,
+			errMatch: "unterminated string",
+		},
+		{
+			desc:     "rejects an unterminated comment",
+			match:    `tcp /* comment`,
+			errMatch: "unterminated comment",
+		},
+		{
+			desc:     "rejects an empty match",
+			match:    "",
+			errMatch: "unexpected end of match",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			err := ValidateMatch(tc.match)
+			if tc.errMatch != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMatch)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
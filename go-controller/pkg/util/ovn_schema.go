@@ -0,0 +1,61 @@
+package util
+
+// OVN database column names used when talking to ovn-nbctl, ovn-sbctl and
+// the go-ovn client. A misspelled column is frequently a silent no-op (e.g. a
+// --if-exists remove), so callers should reference these constants rather
+// than string literals. The table names live in pkg/types, which pkg/config
+// can import as well.
+
+// Column names shared by several OVN tables
+const (
+	OVNColumnUUID           = "_uuid"
+	OVNColumnName           = "name"
+	OVNColumnExternalIDs    = "external_ids"
+	OVNColumnOptions        = "options"
+	OVNColumnOtherConfig    = "other_config"
+	OVNColumnAddresses      = "addresses"
+	OVNColumnMAC            = "mac"
+	OVNColumnNetworks       = "networks"
+	OVNColumnACLs           = "acls"
+	OVNColumnLoadBalancer   = "load_balancer"
+	OVNColumnPolicies       = "policies"
+	OVNColumnStaticRoutes   = "static_routes"
+	OVNColumnNAT            = "nat"
+	OVNColumnVIPs           = "vips"
+	OVNColumnGatewayChassis = "gateway_chassis"
+)
+
+// OVNNbctlOtherConfig is the spelling of the other_config column that the
+// ovn-nbctl commands use; ovn-nbctl treats '-' and '_' alike in column names,
+// but the go-ovn client and the OVSDB schema only know OVNColumnOtherConfig.
+const OVNNbctlOtherConfig = "other-config"
+
+// Keys of the Logical_Switch other_config column
+const (
+	OVNOtherConfigSubnet     = "subnet"
+	OVNOtherConfigIPv6Prefix = "ipv6_prefix"
+	OVNOtherConfigExcludeIPs = "exclude_ips"
+
+	// IGMP/MLD snooping and querier
+	OVNOtherConfigMcastSnoop   = "mcast_snoop"
+	OVNOtherConfigMcastQuerier = "mcast_querier"
+	OVNOtherConfigMcastEthSrc  = "mcast_eth_src"
+	OVNOtherConfigMcastIP4Src  = "mcast_ip4_src"
+	OVNOtherConfigMcastIP6Src  = "mcast_ip6_src"
+)
+
+// Keys of the Logical_Switch_Port options column
+const (
+	OVNOptionRequestedChassis = "requested-chassis"
+	OVNOptionRouterPort       = "router-port"
+)
+
+// Keys of the Logical_Router_Port options column
+const (
+	OVNOptionGatewayMTU = "gateway_mtu"
+)
+
+// Keys of the Logical_Router options column
+const (
+	OVNOptionMcastRelay = "mcast_relay"
+)
@@ -856,7 +856,7 @@ func DetectSCTPSupport() (bool, error) {
 // and therefore set version number to OvnCurrentTopologyVersion
 func DetermineOVNTopoVersionFromOVN() (int, error) {
 	ver := 0
-	stdout, stderr, err := RunOVNNbctl("--data=bare", "--no-headings", "--columns=name", "find", "logical_router",
+	stdout, stderr, err := RunOVNNbctl("--data=bare", "--no-headings", "--columns=name", "find", types.OVNNBTableLogicalRouter,
 		fmt.Sprintf("name=%s", types.OVNClusterRouter))
 	if err != nil {
 		return ver, fmt.Errorf("failed in retrieving %s to determine the current version of OVN logical topology: "+
@@ -867,7 +867,7 @@ func DetermineOVNTopoVersionFromOVN() (int, error) {
 		return math.MaxInt32, nil
 	}
 
-	stdout, stderr, err = RunOVNNbctl("--if-exists", "get", "logical_router", types.OVNClusterRouter,
+	stdout, stderr, err = RunOVNNbctl("--if-exists", "get", types.OVNNBTableLogicalRouter, types.OVNClusterRouter,
 		"external_ids:k8s-ovn-topo-version")
 	if err != nil {
 		return 0, fmt.Errorf("failed to determine the current version of OVN logical topology: stderr: %q, error: %v",
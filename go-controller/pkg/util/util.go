@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"hash/fnv"
 	"net"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -95,28 +96,29 @@ func UpdateNodeSwitchExcludeIPs(nodeName string, subnet *net.IPNet) error {
 
 	mgmtIfAddr := GetNodeManagementIfAddr(subnet)
 	hybridOverlayIfAddr := GetNodeHybridOverlayIfAddr(subnet)
-	var excludeIPs string
+	var excluded []net.IP
 	if config.HybridOverlay.Enabled {
 		if haveHybridOverlayPort && haveManagementPort {
 			// no excluded IPs required
 		} else if !haveHybridOverlayPort && !haveManagementPort {
 			// exclude both
-			excludeIPs = mgmtIfAddr.IP.String() + ".." + hybridOverlayIfAddr.IP.String()
+			excluded = []net.IP{mgmtIfAddr.IP, hybridOverlayIfAddr.IP}
 		} else if haveHybridOverlayPort {
 			// exclude management port IP
-			excludeIPs = mgmtIfAddr.IP.String()
+			excluded = []net.IP{mgmtIfAddr.IP}
 		} else if haveManagementPort {
 			// exclude hybrid overlay port IP
-			excludeIPs = hybridOverlayIfAddr.IP.String()
+			excluded = []net.IP{hybridOverlayIfAddr.IP}
 		}
 	} else if !haveManagementPort {
 		// exclude management port IP
-		excludeIPs = mgmtIfAddr.IP.String()
+		excluded = []net.IP{mgmtIfAddr.IP}
 	}
+	excludeIPs := FormatExcludeIPs(excluded)
 
-	args := []string{"--", "--if-exists", "remove", "logical_switch", nodeName, "other-config", "exclude_ips"}
+	args := []string{"--", "--if-exists", "remove", types.OVNNBTableLogicalSwitch, nodeName, OVNNbctlOtherConfig, OVNOtherConfigExcludeIPs}
 	if len(excludeIPs) > 0 {
-		args = []string{"--", "--if-exists", "set", "logical_switch", nodeName, "other-config:exclude_ips=" + excludeIPs}
+		args = []string{"--", "--if-exists", "set", types.OVNNBTableLogicalSwitch, nodeName, OVNNbctlOtherConfig + ":" + OVNOtherConfigExcludeIPs + "=" + excludeIPs}
 	}
 
 	_, stderr, err = RunOVNNbctl(args...)
@@ -199,6 +201,27 @@ func HashForOVN(s string) string {
 
 }
 
+// VerifyHashConsistency checks that the names of existing OVN address sets or
+// port groups still match the HashForOVN hash of the unhashed names they were
+// created from, given as a map of stored name to unhashed name. A stored name
+// may carry a "_"-separated suffix after the hash, as the default deny port
+// groups do. Names that don't match would no longer be found by their owners
+// after a change of the hashing scheme.
+func VerifyHashConsistency(names map[string]string) error {
+	var invalid []string
+	for name, unhashed := range names {
+		hashed := HashForOVN(unhashed)
+		if name != hashed && !strings.HasPrefix(name, hashed+"_") {
+			invalid = append(invalid, fmt.Sprintf("%s (expected %s for %q)", name, hashed, unhashed))
+		}
+	}
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return fmt.Errorf("names not generated by HashForOVN: %s", strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
 // UpdateIPsSlice will search for values of oldIPs in the slice "s" and update it with newIPs values of same IP family
 func UpdateIPsSlice(s, oldIPs, newIPs []string) []string {
 	n := make([]string, len(s))
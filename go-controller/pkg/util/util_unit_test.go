@@ -3,9 +3,11 @@ package util
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
@@ -370,3 +372,88 @@ func TestFilterIPsSlice(t *testing.T) {
 		})
 	}
 }
+
+func TestHashForOVNGolden(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/hash_for_ovn.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pairs := 0
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			t.Fatalf("malformed golden line %d: %q", i+1, line)
+		}
+		input, output := fields[0], fields[1]
+		if input == "-" {
+			input = ""
+		}
+		assert.Equal(t, output, HashForOVN(input), "hash of %q", input)
+		pairs++
+	}
+	assert.NotZero(t, pairs)
+}
+
+func TestVerifyHashConsistency(t *testing.T) {
+	tests := []struct {
+		desc     string
+		names    map[string]string
+		errMatch string
+	}{
+		{
+			desc: "accepts names generated by HashForOVN",
+			names: map[string]string{
+				HashForOVN("namespace1_v4"): "namespace1_v4",
+				HashForOVN(""):              "",
+			},
+		},
+		{
+			desc: "accepts port group names with a suffix after the hash",
+			names: map[string]string{
+				HashForOVN("namespace1") + "_ingressDefaultDeny": "namespace1",
+				HashForOVN("namespace1") + "_egressDefaultDeny":  "namespace1",
+			},
+		},
+		{
+			desc: "accepts no names",
+		},
+		{
+			desc: "rejects names that are not hashed",
+			names: map[string]string{
+				HashForOVN("ns"): "ns",
+				"namespace1_v4":  "namespace1_v4",
+			},
+			errMatch: "names not generated by HashForOVN: namespace1_v4 (expected " + HashForOVN("namespace1_v4") + " for \"namespace1_v4\")",
+		},
+		{
+			desc:     "rejects names hashed with another scheme",
+			names:    map[string]string{"a12345": "namespace1_v4"},
+			errMatch: "a12345",
+		},
+		{
+			desc:     "rejects the hash of another name",
+			names:    map[string]string{HashForOVN("namespace2") + "_ingressDefaultDeny": "namespace1"},
+			errMatch: HashForOVN("namespace2") + "_ingressDefaultDeny",
+		},
+		{
+			desc:     "rejects suffixes not separated from the hash",
+			names:    map[string]string{HashForOVN("namespace1") + "ingressDefaultDeny": "namespace1"},
+			errMatch: "ingressDefaultDeny",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			err := VerifyHashConsistency(tc.names)
+			if tc.errMatch == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMatch)
+			}
+		})
+	}
+}
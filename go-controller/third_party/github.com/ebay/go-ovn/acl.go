@@ -0,0 +1,761 @@
+/**
+ * Copyright (c) 2017 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ebay/libovsdb"
+)
+
+// ACL ovnnb item
+type ACL struct {
+	UUID       string
+	Name       string
+	Action     string
+	Direction  string
+	Match      string
+	Priority   int
+	Log        bool
+	Meter      []string
+	Severity   string
+	SampleNew  string
+	SampleEst  string
+	ExternalID map[interface{}]interface{}
+	// ApplyAfterLB is set when the ACL is applied after load balancing
+	ApplyAfterLB bool
+}
+
+// ACLDirection is the direction of the traffic an ACL applies to, relative to
+// the logical port
+type ACLDirection string
+
+const (
+	// ACLDirectionToLport applies the ACL to the traffic sent to the port
+	ACLDirectionToLport ACLDirection = "to-lport"
+	// ACLDirectionFromLport applies the ACL to the traffic received from the port
+	ACLDirectionFromLport ACLDirection = "from-lport"
+)
+
+// ACLOptionApplyAfterLB is the option applying a from-lport ACL after the load
+// balancers, to the traffic with its destination already translated
+const ACLOptionApplyAfterLB = "apply-after-lb"
+
+// ACLSpec describes an ACL to be created
+type ACLSpec struct {
+	Name        string
+	Direction   ACLDirection
+	Match       string
+	Action      string
+	Priority    int
+	ExternalIDs map[string]string
+	Log         bool
+	Meter       string
+	Severity    string
+	// ApplyAfterLB applies the ACL after load balancing, e.g. to match the
+	// backend rather than the VIP of hairpinned traffic
+	ApplyAfterLB bool
+}
+
+func validateACLDirection(direction ACLDirection) error {
+	switch direction {
+	case ACLDirectionToLport, ACLDirectionFromLport:
+		return nil
+	}
+	return fmt.Errorf("invalid ACL direction %q, must be %s or %s", direction, ACLDirectionToLport, ACLDirectionFromLport)
+}
+
+func (odbi *ovndb) getACLUUIDByRow(entityType EntityType, entity string, row OVNRow) (string, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	var tableName string
+
+	switch entityType {
+	case LOGICAL_SWITCH:
+		tableName = TableLogicalSwitch
+	case PORT_GROUP:
+		tableName = TablePortGroup
+	default:
+		return "", ErrorOption
+	}
+
+	tableCache, ok := odbi.cache[tableName]
+	if !ok {
+		return "", ErrorSchema
+	}
+
+	for _, drows := range tableCache {
+		if rlsw, ok := drows.Fields["name"].(string); ok && rlsw == entity {
+			acls := drows.Fields["acls"]
+			if acls != nil {
+				switch acls.(type) {
+				case libovsdb.OvsSet:
+					if as, ok := acls.(libovsdb.OvsSet); ok {
+						for _, a := range as.GoSet {
+							if va, ok := a.(libovsdb.UUID); ok {
+								cacheACL, ok := odbi.cache[TableACL][va.GoUUID]
+								if !ok {
+									return "", ErrorSchema
+								}
+								for field, value := range row {
+									switch field {
+									case "action":
+										if rowString(cacheACL, "action") != value {
+											goto unmatched
+										}
+									case "direction":
+										if rowString(cacheACL, "direction") != value {
+											goto unmatched
+										}
+									case "match":
+										if rowString(cacheACL, "match") != value {
+											goto unmatched
+										}
+									case "priority":
+										if rowInt(cacheACL, "priority") != value {
+											goto unmatched
+										}
+									case "log":
+										if rowBool(cacheACL, "log") != value {
+											goto unmatched
+										}
+									case "external_ids":
+										if value != nil && !odbi.oMapContians(rowMap(cacheACL, "external_ids"), value.(*libovsdb.OvsMap).GoMap) {
+											goto unmatched
+										}
+									}
+								}
+								return va.GoUUID, nil
+							}
+						unmatched:
+						}
+						return "", ErrorNotFound
+					}
+				case libovsdb.UUID:
+					if va, ok := acls.(libovsdb.UUID); ok {
+						cacheACL, ok := odbi.cache[TableACL][va.GoUUID]
+						if !ok {
+							return "", ErrorSchema
+						}
+
+						for field, value := range row {
+							switch field {
+							case "action":
+								if rowString(cacheACL, "action") != value {
+									goto out
+								}
+							case "direction":
+								if rowString(cacheACL, "direction") != value {
+									goto out
+								}
+							case "match":
+								if rowString(cacheACL, "match") != value {
+									goto out
+								}
+							case "priority":
+								if rowInt(cacheACL, "priority") != value {
+									goto out
+								}
+							case "log":
+								if rowBool(cacheACL, "log") != value {
+									goto out
+								}
+							case "external_ids":
+								if value != nil && !odbi.oMapContians(rowMap(cacheACL, "external_ids"), value.(*libovsdb.OvsMap).GoMap) {
+									goto out
+								}
+							}
+						}
+						return va.GoUUID, nil
+					out:
+					}
+				}
+			}
+		}
+	}
+	return "", ErrorNotFound
+}
+
+// newACLRow builds the row of a new ACL, validating its direction and logging
+// configuration
+func (odbi *ovndb) newACLRow(spec ACLSpec) (OVNRow, error) {
+	if err := validateACLDirection(spec.Direction); err != nil {
+		return nil, err
+	}
+	row := make(OVNRow)
+	row["direction"] = string(spec.Direction)
+	row["match"] = spec.Match
+	row["priority"] = spec.Priority
+
+	if spec.ExternalIDs != nil {
+		oMap, err := libovsdb.NewOvsMap(spec.ExternalIDs)
+		if err != nil {
+			return nil, err
+		}
+		row["external_ids"] = oMap
+	}
+
+	row["name"] = spec.Name
+	row["action"] = spec.Action
+	row["log"] = spec.Log
+	if spec.ApplyAfterLB {
+		// OVN releases without the option would apply the ACL before the
+		// load balancers, the ordering the option is meant to change
+		if !odbi.columnSupported(TableACL, "options") {
+			return nil, ErrorSchema
+		}
+		oMap, err := libovsdb.NewOvsMap(map[string]string{ACLOptionApplyAfterLB: "true"})
+		if err != nil {
+			return nil, err
+		}
+		row["options"] = oMap
+	}
+	if spec.Log {
+		// a meter that doesn't exist would silently disable logging,
+		// no meter at all logs without a rate limit
+		if len(spec.Meter) > 0 {
+			if !odbi.meterFind(spec.Meter) {
+				return nil, ErrorNotFound
+			}
+			row["meter"] = spec.Meter
+		}
+		switch severity := spec.Severity; severity {
+		case "alert", "debug", "info", "notice", "warning":
+			row["severity"] = severity
+		case "":
+			row["severity"] = "info"
+		default:
+			return nil, ErrorOption
+		}
+	}
+	return row, nil
+}
+
+func (odbi *ovndb) aclAddImp(entityType EntityType, entityName, aclName, direct, match, action string, priority int, external_ids map[string]string, logflag bool, meter, severity string) (*OvnCommand, error) {
+	return odbi.aclAddSpecImp(entityType, entityName, ACLSpec{
+		Name:        aclName,
+		Direction:   ACLDirection(direct),
+		Match:       match,
+		Action:      action,
+		Priority:    priority,
+		ExternalIDs: external_ids,
+		Log:         logflag,
+		Meter:       meter,
+		Severity:    severity,
+	})
+}
+
+func (odbi *ovndb) aclAddSpecImp(entityType EntityType, entityName string, spec ACLSpec) (*OvnCommand, error) {
+	var table string
+
+	switch entityType {
+	case LOGICAL_SWITCH:
+		table = TableLogicalSwitch
+	case PORT_GROUP:
+		table = TablePortGroup
+	default:
+		return nil, ErrorOption
+	}
+
+	namedUUID, err := newRowUUID()
+	if err != nil {
+		return nil, err
+	}
+	row := make(OVNRow)
+	row["direction"] = string(spec.Direction)
+	row["match"] = spec.Match
+	row["priority"] = spec.Priority
+
+	_, err = odbi.getACLUUIDByRow(entityType, entityName, row)
+	switch err {
+	case ErrorNotFound:
+		break
+	case nil:
+		return nil, ErrorExist
+	default:
+		return nil, err
+	}
+
+	row, err = odbi.newACLRow(spec)
+	if err != nil {
+		return nil, err
+	}
+	insertOp := libovsdb.Operation{
+		Op:       opInsert,
+		Table:    TableACL,
+		Row:      row,
+		UUIDName: namedUUID,
+	}
+
+	mutateUUID := []libovsdb.UUID{stringToGoUUID(namedUUID)}
+	mutateSet, err := libovsdb.NewOvsSet(mutateUUID)
+	if err != nil {
+		return nil, err
+	}
+	mutation := libovsdb.NewMutation("acls", opInsert, mutateSet)
+	condition := libovsdb.NewCondition("name", "==", entityName)
+
+	// simple mutate operation
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     table,
+		Mutations: []interface{}{mutation},
+		Where:     []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{insertOp, mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) aclSetNameImp(aclUUID, aclName string) (*OvnCommand, error) {
+	if _, ok := odbi.cache[TableACL][aclUUID]; !ok {
+		return nil, ErrorNotFound
+	}
+
+	row := make(OVNRow)
+	row["name"] = aclName
+
+	condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(aclUUID))
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableACL,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) aclSetMatchImp(aclUUID, newMatch string) (*OvnCommand, error) {
+	if _, ok := odbi.cache[TableACL][aclUUID]; !ok {
+		return nil, ErrorNotFound
+	}
+
+	row := make(OVNRow)
+	row["match"] = newMatch
+
+	condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(aclUUID))
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableACL,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+// aclSetSamplesImp sets the Sample rows used for new and established
+// connections matching an ACL, a nil UUID clears the corresponding column
+func (odbi *ovndb) aclSetSamplesImp(aclUUID string, sampleNew, sampleEst *string) (*OvnCommand, error) {
+	if !odbi.columnSupported(TableACL, "sample_new") {
+		return nil, ErrorSchema
+	}
+	odbi.cachemutex.RLock()
+	_, ok := odbi.cache[TableACL][aclUUID]
+	odbi.cachemutex.RUnlock()
+	if !ok {
+		return nil, ErrorNotFound
+	}
+
+	row := make(OVNRow)
+	var err error
+	if row["sample_new"], err = optionalUUID(sampleNew); err != nil {
+		return nil, err
+	}
+	if row["sample_est"], err = optionalUUID(sampleEst); err != nil {
+		return nil, err
+	}
+
+	condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(aclUUID))
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableACL,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) aclSetMeterImp(aclUUID, meter string) (*OvnCommand, error) {
+	odbi.cachemutex.RLock()
+	_, ok := odbi.cache[TableACL][aclUUID]
+	odbi.cachemutex.RUnlock()
+	if !ok {
+		return nil, ErrorNotFound
+	}
+
+	row := make(OVNRow)
+	if len(meter) > 0 {
+		if !odbi.meterFind(meter) {
+			return nil, ErrorNotFound
+		}
+		row["meter"] = meter
+	} else {
+		// clear the meter so logging is no longer rate limited
+		row["meter"] = libovsdb.OvsSet{GoSet: []interface{}{}}
+	}
+
+	condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(aclUUID))
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableACL,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) aCLSetLoggingImp(aclUUID string, newLogflag bool, newMeter, newSeverity string) (*OvnCommand, error) {
+	if _, ok := odbi.cache[TableACL][aclUUID]; !ok {
+		return nil, ErrorNotFound
+	}
+
+	row := make(OVNRow)
+	row["log"] = newLogflag
+	if newLogflag {
+		if len(newMeter) > 0 {
+			if !odbi.meterFind(newMeter) {
+				return nil, ErrorNotFound
+			}
+			row["meter"] = newMeter
+		}
+		switch newSeverity {
+		case "alert", "debug", "info", "notice", "warning":
+			row["severity"] = newSeverity
+		case "":
+			row["severity"] = "info"
+		default:
+			return nil, ErrorOption
+		}
+	}
+
+	condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(aclUUID))
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableACL,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+// aclSetImp sets all the columns of an existing ACL described by spec, in
+// place so that traffic matching it is never left without an ACL. The optional
+// columns spec leaves empty are cleared.
+func (odbi *ovndb) aclSetImp(aclUUID string, spec ACLSpec) (*OvnCommand, error) {
+	odbi.cachemutex.RLock()
+	_, ok := odbi.cache[TableACL][aclUUID]
+	odbi.cachemutex.RUnlock()
+	if !ok {
+		return nil, ErrorNotFound
+	}
+
+	row, err := odbi.newACLRow(spec)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := row["external_ids"]; !ok {
+		oMap, err := libovsdb.NewOvsMap(map[string]string{})
+		if err != nil {
+			return nil, err
+		}
+		row["external_ids"] = oMap
+	}
+	if _, ok := row["meter"]; !ok {
+		row["meter"] = libovsdb.OvsSet{GoSet: []interface{}{}}
+	}
+	if _, ok := row["options"]; !ok && odbi.columnSupported(TableACL, "options") {
+		oMap, err := libovsdb.NewOvsMap(map[string]string{})
+		if err != nil {
+			return nil, err
+		}
+		row["options"] = oMap
+	}
+
+	condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(aclUUID))
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableACL,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) aclDelImp(entityType EntityType, entityName, direct, match string, priority int, external_ids map[string]string) (*OvnCommand, error) {
+	row := make(OVNRow)
+
+	if direct != "" {
+		row["direction"] = direct
+	}
+	if match != "" {
+		row["match"] = match
+	}
+	//in ovn priority is greater than/equal 0,
+	//if input the priority < 0, lots of acls will be deleted if matches direct and match condition judgement.
+	if priority >= 0 {
+		row["priority"] = priority
+	}
+
+	if external_ids != nil {
+		oMap, err := libovsdb.NewOvsMap(external_ids)
+		if err != nil {
+			return nil, err
+		}
+		row["external_ids"] = oMap
+	}
+
+	aclUUID, err := odbi.getACLUUIDByRow(entityType, entityName, row)
+	if err != nil {
+		return nil, err
+	}
+
+	return odbi.aclDelUUIDImp(entityType, entityName, aclUUID)
+}
+
+func (odbi *ovndb) aclDelUUIDImp(entityType EntityType, entityName, aclUUID string) (*OvnCommand, error) {
+	if _, ok := odbi.cache[TableACL][aclUUID]; !ok {
+		return nil, ErrorNotFound
+	}
+
+	var table string
+	switch entityType {
+	case LOGICAL_SWITCH:
+		if _, err := odbi.LSGet(entityName); err != nil {
+			return nil, ErrorNotFound
+		}
+		table = TableLogicalSwitch
+	case PORT_GROUP:
+		if _, err := odbi.PortGroupGet(entityName); err != nil {
+			return nil, ErrorNotFound
+		}
+		table = TablePortGroup
+	default:
+		return nil, ErrorOption
+	}
+
+	wherecondition := []interface{}{}
+	uuidcondition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(aclUUID))
+	wherecondition = append(wherecondition, uuidcondition)
+	deleteOp := libovsdb.Operation{
+		Op:    opDelete,
+		Table: TableACL,
+		Where: wherecondition,
+	}
+
+	mutation := libovsdb.NewMutation("acls", opDelete, stringToGoUUID(aclUUID))
+	condition := libovsdb.NewCondition("name", "==", entityName)
+
+	// Simple mutate operation
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     table,
+		Mutations: []interface{}{mutation},
+		Where:     []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{mutateOp, deleteOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) rowToACL(uuid string) *ACL {
+	cacheACL, ok := odbi.cache[TableACL][uuid]
+	if !ok {
+		return nil
+	}
+
+	var meter []string
+	switch cacheACL.Fields["meter"].(type) {
+	case string:
+		meter = []string{rowString(cacheACL, "meter")}
+	case libovsdb.OvsSet:
+		for _, a := range cacheACL.Fields["meter"].(libovsdb.OvsSet).GoSet {
+			meter = append(meter, a.(string))
+		}
+	default:
+	}
+
+	severity := ""
+	switch cacheACL.Fields["severity"].(type) {
+	case string:
+		severity = rowString(cacheACL, "severity")
+	case libovsdb.OvsSet:
+		for _, a := range cacheACL.Fields["severity"].(libovsdb.OvsSet).GoSet {
+			severity = a.(string)
+		}
+	default:
+	}
+
+	acl := &ACL{
+		UUID:       uuid,
+		Name:       rowString(cacheACL, "name"),
+		Action:     rowString(cacheACL, "action"),
+		Direction:  rowString(cacheACL, "direction"),
+		Match:      rowString(cacheACL, "match"),
+		Priority:   rowInt(cacheACL, "priority"),
+		Log:        rowBool(cacheACL, "log"),
+		Meter:      meter,
+		Severity:   severity,
+		SampleNew:  rowUUID(cacheACL, "sample_new"),
+		SampleEst:  rowUUID(cacheACL, "sample_est"),
+		ExternalID: rowMap(cacheACL, "external_ids"),
+	}
+	if options, ok := rowMap(cacheACL, "options")[ACLOptionApplyAfterLB].(string); ok {
+		acl.ApplyAfterLB = options == "true"
+	}
+
+	return acl
+}
+
+// Get all acl by entity
+func (odbi *ovndb) aclListImp(entityType EntityType, entity string) ([]*ACL, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	var tableName string
+
+	switch entityType {
+	case LOGICAL_SWITCH:
+		tableName = TableLogicalSwitch
+	case PORT_GROUP:
+		tableName = TablePortGroup
+	default:
+		return nil, ErrorOption
+	}
+
+	tableCache, ok := odbi.cache[tableName]
+	if !ok {
+		return nil, ErrorSchema
+	}
+
+	for _, drows := range tableCache {
+		if rowName, ok := drows.Fields["name"].(string); ok && rowName == entity {
+			acls := drows.Fields["acls"]
+			if acls != nil {
+				switch acls.(type) {
+				case libovsdb.OvsSet:
+					if as, ok := acls.(libovsdb.OvsSet); ok {
+						listACL := make([]*ACL, 0, len(as.GoSet))
+						for _, a := range as.GoSet {
+							if va, ok := a.(libovsdb.UUID); ok {
+								if !odbi.rowCached(TableACL, va.GoUUID) {
+									continue
+								}
+								ta := odbi.rowToACL(va.GoUUID)
+								listACL = append(listACL, ta)
+							}
+						}
+						return listACL, nil
+					}
+				case libovsdb.UUID:
+					if va, ok := acls.(libovsdb.UUID); ok {
+						if !odbi.rowCached(TableACL, va.GoUUID) {
+							return []*ACL{}, nil
+						}
+						ta := odbi.rowToACL(va.GoUUID)
+						return []*ACL{ta}, nil
+					}
+				}
+			}
+			return []*ACL{}, nil
+		}
+	}
+	return nil, ErrorNotFound
+}
+
+// ACLCollision is a pair of ACLs of the same entity with the same direction
+// and priority but different actions, which OVN evaluates in no defined order
+type ACLCollision struct {
+	First  *ACL
+	Second *ACL
+}
+
+func (odbi *ovndb) aclCheckPriorityCollisionsImp(entityType EntityType, entity string) ([]ACLCollision, error) {
+	list, err := odbi.aclListImp(entityType, entity)
+	if err != nil {
+		return nil, err
+	}
+	// acls referenced by the entity but missing from the cache are nil
+	acls := make([]*ACL, 0, len(list))
+	for _, acl := range list {
+		if acl != nil {
+			acls = append(acls, acl)
+		}
+	}
+	sort.Slice(acls, func(i, j int) bool { return acls[i].UUID < acls[j].UUID })
+
+	var collisions []ACLCollision
+	for i, first := range acls {
+		for _, second := range acls[i+1:] {
+			if first.Direction == second.Direction && first.Priority == second.Priority &&
+				first.Action != second.Action {
+				collisions = append(collisions, ACLCollision{First: first, Second: second})
+			}
+		}
+	}
+	return collisions, nil
+}
+
+// pgCheckDanglingACLsImp returns the sorted UUIDs of the ACLs the port group
+// refers to that are not in the ACL table, which OVN silently ignores, e.g.
+// after a partial transaction
+func (odbi *ovndb) pgCheckDanglingACLsImp(group string) ([]string, error) {
+	pg, err := odbi.pgGetImp(group)
+	if err != nil {
+		return nil, err
+	}
+
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+	var dangling []string
+	for _, uuid := range pg.ACLs {
+		if !odbi.rowCached(TableACL, uuid) {
+			dangling = append(dangling, uuid)
+		}
+	}
+	sort.Strings(dangling)
+	return dangling, nil
+}
+
+// aclCheckOrphanedImp returns the sorted UUIDs of the ACLs that no port group
+// or logical switch refers to, and that OVN thus never applies
+func (odbi *ovndb) aclCheckOrphanedImp() ([]string, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	referenced := make(map[string]bool)
+	for _, table := range []string{TablePortGroup, TableLogicalSwitch} {
+		for _, row := range odbi.cache[table] {
+			for _, uuid := range rowReferences(row.Fields["acls"]) {
+				referenced[uuid] = true
+			}
+		}
+	}
+	var orphaned []string
+	for uuid := range odbi.cache[TableACL] {
+		if !referenced[uuid] {
+			orphaned = append(orphaned, uuid)
+		}
+	}
+	sort.Strings(orphaned)
+	return orphaned, nil
+}
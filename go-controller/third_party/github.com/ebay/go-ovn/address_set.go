@@ -0,0 +1,283 @@
+/**
+ * Copyright (c) 2017 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ebay/libovsdb"
+)
+
+// AddressSet ovnnb item
+type AddressSet struct {
+	UUID       string
+	Version    string
+	Name       string
+	Addresses  []string
+	ExternalID map[interface{}]interface{}
+}
+
+// AddressSetSpec describes an address set to be added
+type AddressSetSpec struct {
+	Name        string
+	Addresses   []string
+	ExternalIDs map[string]string
+}
+
+func (odbi *ovndb) asUpdateImp(name, uuid string, addrs []string, external_ids map[string]string) (*OvnCommand, error) {
+	row := make(OVNRow)
+	row["name"] = name
+	addresses, err := libovsdb.NewOvsSet(addrs)
+	if err != nil {
+		return nil, err
+	}
+
+	row["addresses"] = addresses
+	if external_ids != nil {
+		oMap, err := libovsdb.NewOvsMap(external_ids)
+		if err != nil {
+			return nil, err
+		}
+		row["external_ids"] = oMap
+	}
+	condition := libovsdb.NewCondition("name", "==", name)
+	if uuid != "" {
+		condition = libovsdb.NewCondition("_uuid", "==", stringToGoUUID(uuid))
+	}
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableAddressSet,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) asAddIPImp(name, uuid string, addrs []string) (*OvnCommand, error) {
+	addresses, err := libovsdb.NewOvsSet(addrs)
+	if err != nil {
+		return nil, err
+	}
+	mutation := libovsdb.NewMutation("addresses", "insert", addresses)
+	condition := libovsdb.NewCondition("name", "==", name)
+	if uuid != "" {
+		condition = libovsdb.NewCondition("_uuid", "==", stringToGoUUID(uuid))
+	}
+	updateOp := libovsdb.Operation{
+		Op:    opMutate,
+		Table: TableAddressSet,
+		Mutations: []interface{}{mutation},
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) asDelIPImp(name, uuid string, addrs []string) (*OvnCommand, error) {
+	addresses, err := libovsdb.NewOvsSet(addrs)
+	if err != nil {
+		return nil, err
+	}
+	mutation := libovsdb.NewMutation("addresses", "delete", addresses)
+	condition := libovsdb.NewCondition("name", "==", name)
+	if uuid != "" {
+		condition = libovsdb.NewCondition("_uuid", "==", stringToGoUUID(uuid))
+	}
+	updateOp := libovsdb.Operation{
+		Op:    opMutate,
+		Table: TableAddressSet,
+		Mutations: []interface{}{mutation},
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) asAddImp(name string, addrs []string, external_ids map[string]string) (*OvnCommand, error) {
+	row := make(OVNRow)
+	row["name"] = name
+	//should support the -is-exist flag here.
+
+	if uuid := odbi.getRowUUID(TableAddressSet, row); len(uuid) > 0 {
+		return nil, ErrorExist
+	}
+
+	if external_ids != nil {
+		oMap, err := libovsdb.NewOvsMap(external_ids)
+		if err != nil {
+			return nil, err
+		}
+		row["external_ids"] = oMap
+	}
+	addresses, err := libovsdb.NewOvsSet(addrs)
+	if err != nil {
+		return nil, err
+	}
+	row["addresses"] = addresses
+	insertOp := libovsdb.Operation{
+		Op:    opInsert,
+		Table: TableAddressSet,
+		Row:   row,
+	}
+	operations := []libovsdb.Operation{insertOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+// asAddManyImp inserts every address set of sets in one command. Sets whose
+// name already exists, in the cache or earlier in sets, are skipped and their
+// names returned; ErrorExist is returned if every set already exists.
+func (odbi *ovndb) asAddManyImp(sets []AddressSetSpec) (*OvnCommand, []string, error) {
+	if len(sets) == 0 {
+		return nil, nil, ErrorOption
+	}
+
+	var existing []string
+	var operations []libovsdb.Operation
+	added := make(map[string]bool, len(sets))
+	for _, set := range sets {
+		cmd, err := odbi.asAddImp(set.Name, set.Addresses, set.ExternalIDs)
+		if err == ErrorExist || added[set.Name] {
+			existing = append(existing, set.Name)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		added[set.Name] = true
+		operations = append(operations, cmd.Operations...)
+	}
+	if len(operations) == 0 {
+		return nil, existing, ErrorExist
+	}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, existing, nil
+}
+
+// TODO fix to get as from cache directly
+func (odbi *ovndb) asGetImp(name string) (*AddressSet, error) {
+	listAS, err := odbi.ASList()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range listAS {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+	return nil, ErrorNotFound
+}
+
+func (odbi *ovndb) asDelImp(name string) (*OvnCommand, error) {
+	condition := libovsdb.NewCondition("name", "==", name)
+	deleteOp := libovsdb.Operation{
+		Op:    opDelete,
+		Table: TableAddressSet,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{deleteOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+// Get all addressset
+func (odbi *ovndb) asListImp() ([]*AddressSet, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheAddressSet, ok := odbi.cache[TableAddressSet]
+	if !ok {
+		return nil, ErrorSchema
+	}
+
+	listAS := make([]*AddressSet, 0, len(cacheAddressSet))
+	for uuid, drows := range cacheAddressSet {
+		ta := &AddressSet{
+			UUID:       uuid,
+			Version:    rowVersion(&drows),
+			Name:       rowString(drows, "name"),
+			ExternalID: rowMap(drows, "external_ids"),
+		}
+		addresses := []string{}
+		as := drows.Fields["addresses"]
+		switch as.(type) {
+		case libovsdb.OvsSet:
+			//TODO: is it possible return interface type directly instead of GoSet
+			if goset, ok := drows.Fields["addresses"].(libovsdb.OvsSet); ok {
+				for _, i := range goset.GoSet {
+					addresses = append(addresses, i.(string))
+				}
+			}
+		case string:
+			if v, ok := drows.Fields["addresses"].(string); ok {
+				addresses = append(addresses, v)
+			}
+		}
+		ta.Addresses = addresses
+		listAS = append(listAS, ta)
+	}
+	return listAS, nil
+}
+
+// matchReferencingTables are the tables whose match column may refer to an
+// address set as $name or to a port group as @name.
+var matchReferencingTables = []string{TableACL, TableLogicalRouterPolicy, TableQoS}
+
+// isMatchIdentChar reports whether c continues an identifier in an OVN match,
+// so that a reference to "$as1" is not taken for one to "$as10".
+func isMatchIdentChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '.' || c == ':'
+}
+
+// matchReferences reports whether match refers to token as a whole identifier
+func matchReferences(match, token string) bool {
+	for start := 0; ; {
+		i := strings.Index(match[start:], token)
+		if i < 0 {
+			return false
+		}
+		end := start + i + len(token)
+		if end == len(match) || !isMatchIdentChar(match[end]) {
+			return true
+		}
+		start = end
+	}
+}
+
+// matchReferencesImp returns the UUIDs of the cached rows of the match
+// referencing tables whose match refers to token. Tables missing from the
+// schema are skipped.
+func (odbi *ovndb) matchReferencesImp(token string) (bool, []string, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	var uuids []string
+	for _, table := range matchReferencingTables {
+		for uuid, drows := range odbi.cache[table] {
+			if matchReferences(rowString(drows, "match"), token) {
+				uuids = append(uuids, uuid)
+			}
+		}
+	}
+	sort.Strings(uuids)
+	return len(uuids) > 0, uuids, nil
+}
+
+func (odbi *ovndb) asIsReferencedImp(name string) (bool, []string, error) {
+	return odbi.matchReferencesImp("$" + name)
+}
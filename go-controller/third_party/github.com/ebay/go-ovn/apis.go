@@ -0,0 +1,287 @@
+/**
+ * Copyright (c) 2017 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ebay/libovsdb"
+)
+
+// OvnCommand ovnnb command
+type OvnCommand struct {
+	Operations []libovsdb.Operation
+	Exe        Execution
+	Results    [][]map[string]interface{}
+}
+
+// Execute sends command to ovnnb
+func (ocmd *OvnCommand) Execute() error {
+	return ocmd.Exe.Execute(ocmd)
+}
+
+// OperationInfo is a readable description of an operation of a command, for
+// logging and for tests asserting on what a command does. In all values sets
+// are converted to slices, maps to map[string]interface{} and UUIDs to strings.
+type OperationInfo struct {
+	Op    string
+	Table string
+	// Fields are the columns written by an insert or an update
+	Fields map[string]interface{}
+	// Mutations and Where are the mutations and the conditions of the
+	// operation as [column, mutator or function, value] triples
+	Mutations [][3]interface{}
+	Where     [][3]interface{}
+}
+
+// String formats the operation on a single line, e.g.
+// "update Logical_Switch_Port addresses=[0a:58:0a:80:00:05 10.128.0.5] where name == pod1"
+func (info OperationInfo) String() string {
+	parts := []string{info.Op, info.Table}
+	columns := make([]string, 0, len(info.Fields))
+	for column := range info.Fields {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	for _, column := range columns {
+		parts = append(parts, fmt.Sprintf("%s=%v", column, info.Fields[column]))
+	}
+	for _, mutation := range info.Mutations {
+		parts = append(parts, fmt.Sprintf("%v %v %v", mutation[0], mutation[1], mutation[2]))
+	}
+	for i, condition := range info.Where {
+		if i == 0 {
+			parts = append(parts, "where")
+		} else {
+			parts = append(parts, "and")
+		}
+		parts = append(parts, fmt.Sprintf("%v %v %v", condition[0], condition[1], condition[2]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Describe returns a description of each operation of the command. The
+// commands of mock clients have no operations.
+func (ocmd *OvnCommand) Describe() []OperationInfo {
+	infos := make([]OperationInfo, 0, len(ocmd.Operations))
+	for _, op := range ocmd.Operations {
+		info := OperationInfo{
+			Op:    op.Op,
+			Table: op.Table,
+		}
+		if len(op.Row) > 0 {
+			info.Fields = make(map[string]interface{}, len(op.Row))
+			for column, value := range op.Row {
+				info.Fields[column] = readableValue(value)
+			}
+		}
+		info.Mutations = readableTriples(op.Mutations)
+		info.Where = readableTriples(op.Where)
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// readableTriples converts mutations or conditions, which are built as
+// []interface{}{column, mutator or function, value}
+func readableTriples(triples []interface{}) [][3]interface{} {
+	var readable [][3]interface{}
+	for _, triple := range triples {
+		t, ok := triple.([]interface{})
+		if !ok || len(t) != 3 {
+			continue
+		}
+		readable = append(readable, [3]interface{}{t[0], t[1], readableValue(t[2])})
+	}
+	return readable
+}
+
+func readableValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *libovsdb.OvsSet:
+		if v == nil {
+			return []interface{}{}
+		}
+		return readableValue(*v)
+	case libovsdb.OvsSet:
+		elems := make([]interface{}, 0, len(v.GoSet))
+		for _, elem := range v.GoSet {
+			elems = append(elems, readableValue(elem))
+		}
+		return elems
+	case *libovsdb.OvsMap:
+		if v == nil {
+			return map[string]interface{}{}
+		}
+		return readableValue(*v)
+	case libovsdb.OvsMap:
+		m := make(map[string]interface{}, len(v.GoMap))
+		for key, val := range v.GoMap {
+			m[fmt.Sprint(readableValue(key))] = readableValue(val)
+		}
+		return m
+	case libovsdb.UUID:
+		return v.GoUUID
+	}
+	return value
+}
+
+// Execution executes multiple ovnnb commands
+type Execution interface {
+	//Excute multi-commands
+	Execute(cmds ...*OvnCommand) error
+}
+
+// ExecuteOption customizes a single ExecuteROptions call
+type ExecuteOption func(*executeOptions)
+
+type executeOptions struct {
+	timeout time.Duration
+	waits   []libovsdb.Operation
+}
+
+// WithTimeout overrides the client timeout for one transaction
+func WithTimeout(d time.Duration) ExecuteOption {
+	return func(o *executeOptions) {
+		o.timeout = d
+	}
+}
+
+// WithRowVersion makes the transaction apply only if the row with the given
+// UUID still has the version read from the cache, e.g. LogicalSwitchPort.Version.
+// Otherwise nothing is written and ErrorVersionMismatch is returned, so that
+// the caller can re-read the row and retry instead of overwriting a concurrent
+// change.
+func WithRowVersion(table, uuid, version string) ExecuteOption {
+	return func(o *executeOptions) {
+		o.waits = append(o.waits, libovsdb.Operation{
+			Op:      opWait,
+			Table:   table,
+			Timeout: 0,
+			Where:   []interface{}{libovsdb.NewCondition("_uuid", "==", stringToGoUUID(uuid))},
+			Columns: []string{"_version"},
+			Until:   "==",
+			Rows:    []map[string]interface{}{{"_version": stringToGoUUID(version)}},
+		})
+	}
+}
+
+// RetryOpts customizes a single ExecuteRRetry call
+type RetryOpts struct {
+	// ReconnectTimeout bounds the wait for the client to reconnect before the
+	// transaction is resubmitted, the client timeout is used when it is zero
+	ReconnectTimeout time.Duration
+	// Options apply to both submissions of the transaction
+	Options []ExecuteOption
+}
+
+// TxnCounts counts the transactions operating on a table. A transaction is
+// counted once for every table its operations refer to.
+type TxnCounts struct {
+	Succeeded uint64
+	Failed    uint64
+	// Retried counts the transactions resubmitted by ExecuteRRetry, the
+	// outcome of the resubmission is counted as well
+	Retried uint64
+}
+
+// OVNDisconnectedCallback executed when ovn client disconnects
+type OVNDisconnectedCallback func()
+
+// OVNConnectedCallback executed when ovn client reconnects. cacheReset is true
+// when the server could not resume from the last transaction seen by the
+// client and the cache was rebuilt from a full dump.
+type OVNConnectedCallback func(cacheReset bool)
+
+// OVNLockCallback executed when the OVSDB lock with the given id is acquired
+// or lost, the latter either because another client stole it or because the
+// connection to the server was lost.
+type OVNLockCallback func(id string, acquired bool)
+
+// OVNLeaderCallback executed when the server the client is connected to
+// becomes, or stops being, the leader of the clustered database. Standalone
+// databases always report a leader.
+type OVNLeaderCallback func(isLeader bool)
+
+// OVNCacheLimitCallback executed when the number of cached rows of a table, or
+// of all tables when table is empty, goes over the configured limit.
+type OVNCacheLimitCallback func(table string, rows, limit int)
+
+// OVNSignal notifies on changes to ovnnb
+type OVNSignal interface {
+	OnLogicalSwitchCreate(ls *LogicalSwitch)
+	OnLogicalSwitchDelete(ls *LogicalSwitch)
+
+	OnLogicalPortCreate(lp *LogicalSwitchPort)
+	OnLogicalPortDelete(lp *LogicalSwitchPort)
+
+	OnLogicalRouterCreate(lr *LogicalRouter)
+	OnLogicalRouterDelete(lr *LogicalRouter)
+
+	OnLogicalRouterPortCreate(lrp *LogicalRouterPort)
+	OnLogicalRouterPortDelete(lrp *LogicalRouterPort)
+
+	OnLogicalRouterStaticRouteCreate(lrsr *LogicalRouterStaticRoute)
+	OnLogicalRouterStaticRouteDelete(lrsr *LogicalRouterStaticRoute)
+
+	OnACLCreate(acl *ACL)
+	OnACLDelete(acl *ACL)
+
+	OnDHCPOptionsCreate(dhcp *DHCPOptions)
+	OnDHCPOptionsDelete(dhcp *DHCPOptions)
+
+	OnQoSCreate(qos *QoS)
+	OnQoSDelete(qos *QoS)
+
+	OnLoadBalancerCreate(ls *LoadBalancer)
+	OnLoadBalancerDelete(ls *LoadBalancer)
+
+	OnMeterCreate(meter *Meter)
+	OnMeterDelete(meter *Meter)
+
+	OnMeterBandCreate(band *MeterBand)
+	OnMeterBandDelete(band *MeterBand)
+
+	// Create/delete chassis from south bound db
+	OnChassisCreate(ch *Chassis)
+	OnChassisDelete(ch *Chassis)
+
+	// Create/delete encap from south bound db
+	OnEncapCreate(ch *Encap)
+	OnEncapDelete(ch *Encap)
+}
+
+// OVNBatchSignal is optionally implemented by an OVNSignal to be told when the
+// callbacks for the rows of a monitor update start and end, so that it can
+// defer expensive recomputation until the whole update is applied. Both are
+// called with the cache locked, like the OVNSignal callbacks.
+type OVNBatchSignal interface {
+	OnBatchStart()
+	OnBatchEnd()
+}
+
+// OVNNotifier ovnnb and ovnsb notifier
+type OVNNotifier interface {
+	Update(context interface{}, tableUpdates libovsdb.TableUpdates)
+	Locked([]interface{})
+	Stolen([]interface{})
+	Echo([]interface{})
+	Disconnected(client *libovsdb.OvsdbClient)
+}
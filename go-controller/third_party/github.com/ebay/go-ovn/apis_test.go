@@ -0,0 +1,92 @@
+package goovn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		desc     string
+		op       libovsdb.Operation
+		expInfo  OperationInfo
+		expShort string
+	}{
+		{
+			desc: "insert with a set, a map and a UUID",
+			op: libovsdb.Operation{
+				Op:    opInsert,
+				Table: TableLogicalSwitchPort,
+				Row: OVNRow{
+					"name":           "ns_pod1",
+					"addresses":      libovsdb.OvsSet{GoSet: []interface{}{"0a:58:0a:80:00:05 10.128.0.5"}},
+					"external_ids":   &libovsdb.OvsMap{GoMap: map[interface{}]interface{}{"pod": "true"}},
+					"dhcpv4_options": libovsdb.UUID{GoUUID: "dhcp1"},
+				},
+			},
+			expInfo: OperationInfo{
+				Op:    opInsert,
+				Table: TableLogicalSwitchPort,
+				Fields: map[string]interface{}{
+					"name":           "ns_pod1",
+					"addresses":      []interface{}{"0a:58:0a:80:00:05 10.128.0.5"},
+					"external_ids":   map[string]interface{}{"pod": "true"},
+					"dhcpv4_options": "dhcp1",
+				},
+			},
+			expShort: "insert Logical_Switch_Port addresses=[0a:58:0a:80:00:05 10.128.0.5] dhcpv4_options=dhcp1 external_ids=map[pod:true] name=ns_pod1",
+		},
+		{
+			desc: "mutate with a set of UUIDs",
+			op: libovsdb.Operation{
+				Op:        opMutate,
+				Table:     TableLogicalSwitch,
+				Mutations: []interface{}{[]interface{}{"ports", opInsert, libovsdb.OvsSet{GoSet: []interface{}{libovsdb.UUID{GoUUID: "lsp1"}}}}},
+				Where:     []interface{}{[]interface{}{"name", "==", "node1"}},
+			},
+			expInfo: OperationInfo{
+				Op:        opMutate,
+				Table:     TableLogicalSwitch,
+				Mutations: [][3]interface{}{{"ports", opInsert, []interface{}{"lsp1"}}},
+				Where:     [][3]interface{}{{"name", "==", "node1"}},
+			},
+			expShort: "mutate Logical_Switch ports insert [lsp1] where name == node1",
+		},
+		{
+			desc: "delete with several conditions and nil values",
+			op: libovsdb.Operation{
+				Op:    opDelete,
+				Table: TableACL,
+				Where: []interface{}{
+					[]interface{}{"_uuid", "==", libovsdb.UUID{GoUUID: "acl1"}},
+					[]interface{}{"external_ids", "includes", (*libovsdb.OvsMap)(nil)},
+					[]interface{}{"malformed"},
+				},
+			},
+			expInfo: OperationInfo{
+				Op:    opDelete,
+				Table: TableACL,
+				Where: [][3]interface{}{
+					{"_uuid", "==", "acl1"},
+					{"external_ids", "includes", map[string]interface{}{}},
+				},
+			},
+			expShort: "delete ACL where _uuid == acl1 and external_ids includes map[]",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			cmd := &OvnCommand{Operations: []libovsdb.Operation{tc.op}}
+			infos := cmd.Describe()
+			assert.Equal(t, []OperationInfo{tc.expInfo}, infos)
+			assert.Equal(t, tc.expShort, infos[0].String())
+		})
+	}
+
+	t.Run("commands without operations", func(t *testing.T) {
+		assert.Empty(t, (&OvnCommand{}).Describe())
+	})
+}
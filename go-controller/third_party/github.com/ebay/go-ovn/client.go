@@ -0,0 +1,1937 @@
+/**
+ * Copyright (c) 2017 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+
+	"crypto/tls"
+	"time"
+
+	"github.com/ebay/libovsdb"
+
+	"k8s.io/klog/v2"
+)
+
+type EntityType string
+
+const (
+	PORT_GROUP       EntityType = "PORT_GROUP"
+	LOGICAL_SWITCH   EntityType = "LOGICAL_SWITCH"
+	ZERO_TRANSACTION string = "00000000-0000-0000-0000-000000000000"
+)
+
+// Client ovnnb/sb client
+// Note: We can create different clients for ovn nb and sb each in future.
+type Client interface {
+	// Get logical switch by name
+	LSGet(ls string) ([]*LogicalSwitch, error)
+	// Create ls named SWITCH
+	LSAdd(ls string) (*OvnCommand, error)
+	// Del ls and all its ports
+	LSDel(ls string) (*OvnCommand, error)
+	// Get all logical switches
+	LSList() ([]*LogicalSwitch, error)
+	// Add external_ids to logical switch
+	LSExtIdsAdd(ls string, external_ids map[string]string) (*OvnCommand, error)
+	// Del external_ids from logical_switch
+	LSExtIdsDel(ls string, external_ids map[string]string) (*OvnCommand, error)
+	// Link logical switch to router
+	LinkSwitchToRouter(lsw, lsp, lr, lrp, lrpMac string, networks []string, externalIds map[string]string) (*OvnCommand, error)
+
+	// Get logical switch port by name
+	LSPGet(lsp string) (*LogicalSwitchPort, error)
+	// Get logical switch port by name
+	LSPGetUUID(uuid string) (*LogicalSwitchPort, error)
+	// Add logical port PORT on SWITCH
+	LSPAdd(ls string, lsUUID string, lsp string) (*OvnCommand, error)
+	// Add external logical port PORT on SWITCH, bound to the named HA chassis group
+	LSPAddExternal(ls string, lsp string, haChassisGroup string) (*OvnCommand, error)
+	// Delete PORT from its attached switch
+	LSPDel(lsp string) (*OvnCommand, error)
+	// Set addressset per lport
+	LSPSetAddress(lsp string, addresses ...string) (*OvnCommand, error)
+	// Set port security per lport
+	LSPSetPortSecurity(lsp string, security ...string) (*OvnCommand, error)
+	// Set logical switch port type
+	LSPSetType(lsp string, portType string) (*OvnCommand, error)
+	// Get all lport by lswitch
+	LSPList(ls string) ([]*LogicalSwitchPort, error)
+	// Get the lports of lswitch with the given type, "" for VIF ports
+	LSPListByType(ls, portType string) ([]*LogicalSwitchPort, error)
+	// Get all untyped lports whose name is not in validNames, regardless of their external_ids
+	OrphanLSPList(validNames map[string]bool) ([]*LogicalSwitchPort, error)
+
+	// Add LB to LSW
+	LSLBAdd(ls string, lb string) (*OvnCommand, error)
+	// Delete LB from LSW
+	LSLBDel(ls string, lb string) (*OvnCommand, error)
+	// List Load balancers for a LSW
+	LSLBList(ls string) ([]*LoadBalancer, error)
+	// Get the UUIDs of the QoS rules attached to ls
+	LSListQoSRules(ls string) ([]string, error)
+
+	// Add ACL to entity (PORT_GROUP or LOGICAL_SWITCH)
+	ACLAddEntity(entityType EntityType, entityName, aclName, direct, match, action string, priority int, external_ids map[string]string, logflag bool, meter, severity string) (*OvnCommand, error)
+	// Add the ACL described by spec to entity (PORT_GROUP or LOGICAL_SWITCH)
+	ACLAddSpec(entityType EntityType, entityName string, spec ACLSpec) (*OvnCommand, error)
+	// Deprecated in favor of ACLAddEntity(). Add ACL to logical switch.
+	ACLAdd(ls, direct, match, action string, priority int, external_ids map[string]string, logflag bool, meter string, severity string) (*OvnCommand, error)
+	// Set name for ACL
+	ACLSetName(aclUUID, aclName string) (*OvnCommand, error)
+	// Set match criteria for ACL
+	ACLSetMatch(aclUUID, newMatch string) (*OvnCommand, error)
+	// Set logging for ACL
+	ACLSetLogging(aclUUID string, newLogflag bool, newMeter, newSeverity string) (*OvnCommand, error)
+	// Set the meter rate limiting the logging of an ACL, an empty meter removes the limit
+	ACLSetMeter(aclUUID, meter string) (*OvnCommand, error)
+	// Set the Sample rows of an ACL for new and established connections, nil clears them
+	ACLSetSamples(aclUUID string, sampleNew, sampleEst *string) (*OvnCommand, error)
+	// Set all the columns of an existing ACL to the ones described by spec
+	ACLSet(aclUUID string, spec ACLSpec) (*OvnCommand, error)
+	// Delete acl from entity (PORT_GROUP or LOGICAL_SWITCH)
+	ACLDelEntity(entityType EntityType, entityName, aclUUID string) (*OvnCommand, error)
+	// Deprecated in favor of ACLDelEntity(). Delete acl from logical switch
+	ACLDel(ls, direct, match string, priority int, external_ids map[string]string) (*OvnCommand, error)
+	// Get all acl by entity
+	ACLListEntity(entityType EntityType, entityName string) ([]*ACL, error)
+	// Get the pairs of acls of an entity sharing a direction and priority but with different actions
+	ACLCheckPriorityCollisions(entityType EntityType, entityName string) ([]ACLCollision, error)
+	// Get the UUIDs of the acls that no port group or logical switch refers to
+	ACLCheckOrphaned() ([]string, error)
+	// Deprecated in favor of ACLListEntity(). Get all acl by logical switch
+	ACLList(ls string) ([]*ACL, error)
+
+	// Get AS
+	ASGet(name string) (*AddressSet, error)
+	// Update address set
+	ASUpdate(name, uuid string, addrs []string, external_ids map[string]string) (*OvnCommand, error)
+	// Add addressset
+	ASAdd(name string, addrs []string, external_ids map[string]string) (*OvnCommand, error)
+	// Add address sets in one command, skipping and returning the names of the ones that already exist
+	ASAddMany(sets []AddressSetSpec) (*OvnCommand, []string, error)
+	ASAddIPs(name, uuid string, addrs []string) (*OvnCommand, error)
+	ASDelIPs(name, uuid string, addrs []string) (*OvnCommand, error)
+	// Delete addressset
+	ASDel(name string) (*OvnCommand, error)
+	// Get all AS
+	ASList() ([]*AddressSet, error)
+	// Report whether an ACL, router policy or QoS match refers to the AS as $name,
+	// with the UUIDs of the referencing rows
+	ASIsReferenced(name string) (bool, []string, error)
+
+	// Get LR with given name
+	LRGet(name string) ([]*LogicalRouter, error)
+	// Get LR with given name along with its ports, static routes, NAT rules, policies and load balancers
+	LRGetFull(name string) (*LogicalRouterFull, error)
+	// Add LR with given name
+	LRAdd(name string, external_ids map[string]string) (*OvnCommand, error)
+	// Delete LR with given name
+	LRDel(name string) (*OvnCommand, error)
+	// Get LRs
+	LRList() ([]*LogicalRouter, error)
+	// Get the LRs whose external_ids map key to value
+	LRListByExternalID(key, value string) ([]*LogicalRouter, error)
+
+	// Add LRP with given name on given lr
+	LRPAdd(lr string, lrp string, mac string, network []string, peer string, external_ids map[string]string) (*OvnCommand, error)
+	// Delete LRP with given name on given lr
+	LRPDel(lr string, lrp string) (*OvnCommand, error)
+	// Get all lrp by lr
+	LRPList(lr string) ([]*LogicalRouterPort, error)
+	// Set options on LRP, options not in the given map are left untouched
+	LRPSetOptions(lrp string, options map[string]string) (*OvnCommand, error)
+	// Add CIDRs to the networks of LRP, its other networks are left untouched
+	LRPAddNetworks(lrp string, networks []string) (*OvnCommand, error)
+	// Remove CIDRs from the networks of LRP, at least one network must remain
+	LRPDelNetworks(lrp string, networks []string) (*OvnCommand, error)
+
+	// Add LRSR with given ip_prefix on given lr
+	LRSRAdd(lr string, ip_prefix string, nexthop string, output_port *string, policy *string, external_ids map[string]string) (*OvnCommand, error)
+	// Delete LRSR with given ip_prefix, nexthop, outputPort and policy on given lr
+	LRSRDel(lr string, prefix string, nexthop, outputPort, policy *string) (*OvnCommand, error)
+	// Delete LRSR by uuid given lr
+	LRSRDelByUUID(lr, uuid string) (*OvnCommand, error)
+	// Get all LRSRs by lr
+	LRSRList(lr string) ([]*LogicalRouterStaticRoute, error)
+
+	// Add LRPolicy
+	LRPolicyAdd(lr string, priority int, match string, action string, nexthop *string, nexthops []string, options map[string]string, external_ids map[string]string) (*OvnCommand, error)
+	// Delete a LR policy by priority and optionally match
+	LRPolicyDel(lr string, priority int, match *string) (*OvnCommand, error)
+	// Delete a LR policy by UUID
+	LRPolicyDelByUUID(lr string, uuid string) (*OvnCommand, error)
+	// Delete LR policies by UUID in a single operation, UUIDs not attached to the LR are ignored
+	LRPolicyDelByUUIDs(lr string, uuids []string) (*OvnCommand, error)
+	// Delete all LRPolicies
+	LRPolicyDelAll(lr string) (*OvnCommand, error)
+	// Get all LRPolicies by LR
+	LRPolicyList(lr string) ([]*LogicalRouterPolicy, error)
+	// Set the pkt_mark option of a reroute or allow policy of the LR
+	LRPolicySetPktMark(lr string, policyUUID string, mark uint32) (*OvnCommand, error)
+
+	// Add LB to LR
+	LRLBAdd(lr string, lb string) (*OvnCommand, error)
+	// Delete LB from LR
+	LRLBDel(lr string, lb string) (*OvnCommand, error)
+	// List Load balancers for a LR
+	LRLBList(lr string) ([]*LoadBalancer, error)
+	// Add and remove LBs, given by UUID, on a LR in a single operation
+	LRLBUpdate(lr string, addUUIDs []string, delUUIDs []string) (*OvnCommand, error)
+
+	// Get LB with given name
+	LBGet(name string) ([]*LoadBalancer, error)
+	// Add LB
+	LBAdd(name string, vipPort string, protocol string, addrs []string) (*OvnCommand, error)
+	// Add template LB whose VIP and backends reference Chassis_Template_Var variables, e.g. "^vip:^port"
+	LBAddTemplate(name string, vipTemplate string, protocol string, backendTemplate string, options map[string]string) (*OvnCommand, error)
+	// Delete LB with given name
+	LBDel(name string) (*OvnCommand, error)
+	// Update existing LB
+	LBUpdate(name string, vipPort string, protocol string, addrs []string) (*OvnCommand, error)
+	// Set selection fields for LB session affinity
+	LBSetSelectionFields(name string, selectionFields string) (*OvnCommand, error)
+	// Set or clear options:reject on the LB, so that a VIP without backends rejects its traffic
+	ConfigureServiceReject(name string, reject bool) (*OvnCommand, error)
+	// Set options:hairpin_snat_ip on the LB to the given IPv4 and IPv6 addresses, clearing it when both are empty
+	LBSetHairpinSNATIP(name string, v4, v6 string) (*OvnCommand, error)
+	// Get whether the LB rejects the traffic to a VIP without backends
+	LBGetReject(name string) (bool, error)
+	// Get the names of the LS and LR a load balancer is attached to, directly or
+	// through a load balancer group
+	LBGetAttachments(name string) (switches, routers []string, err error)
+	// Get LBs
+	LBList() ([]*LoadBalancer, error)
+
+	// Add a load balancer group with the given LB UUIDs, not supported by older OVN schemas
+	LBGroupAdd(name string, lbs []string) (*OvnCommand, error)
+	// Delete a load balancer group by name
+	LBGroupDel(name string) (*OvnCommand, error)
+	// List all load balancer groups
+	LBGroupList() ([]*LoadBalancerGroup, error)
+	// Set the load balancer groups, by name, of a LS; an empty list detaches all of them
+	LSSetLBGroup(ls string, groups []string) (*OvnCommand, error)
+	// Get the names of the load balancer groups of a LS
+	LSGetLBGroups(ls string) ([]string, error)
+	// Set the control plane protection (Copp row) of a LS, nil clears it
+	LSSetCopp(ls string, copp *string) (*OvnCommand, error)
+	// Create or update the DNS row of a LS with the given records and attach it to the LS
+	ConfigureNodeDNS(ls string, records map[string]string) (*OvnCommand, error)
+	// Set the load balancer groups, by name, of a LR; an empty list detaches all of them
+	LRSetLBGroup(lr string, groups []string) (*OvnCommand, error)
+	// Get the names of the load balancer groups of a LR
+	LRGetLBGroups(lr string) ([]string, error)
+
+	// Set dhcp4_options uuid on lsp
+	LSPSetDHCPv4Options(lsp string, options string) (*OvnCommand, error)
+	// Get dhcp4_options from lsp
+	LSPGetDHCPv4Options(lsp string) (*DHCPOptions, error)
+	// Set dhcp6_options uuid on lsp
+	LSPSetDHCPv6Options(lsp string, options string) (*OvnCommand, error)
+	// Get dhcp6_options from lsp
+	LSPGetDHCPv6Options(lsp string) (*DHCPOptions, error)
+	// Set options in LSP
+	LSPSetOptions(lsp string, options map[string]string) (*OvnCommand, error)
+	// Get options from LSP
+	LSPGetOptions(lsp string) (map[string]string, error)
+	// Set the ordered list of chassis the LSP may be bound to, an empty list clears it
+	LSPSetRequestedChassis(lsp string, chassis []string) (*OvnCommand, error)
+	// Set the MAC, IPs and CIDRs a router type LSP answers ARP and ND requests for, an empty list clears them
+	LSPSetARPProxy(lsp string, addrs []string) (*OvnCommand, error)
+	// Set the addresses a router type LSP sends gratuitous ARPs for, "router" or a MAC followed by IPs,
+	// an empty list clears them
+	LSPSetNATAddresses(lsp string, addrs []string) (*OvnCommand, error)
+	// Replace the addresses of an existing LSP with the MAC and IPs and its port_security with portSecurity,
+	// which must hold the IPv6 link-local address of the MAC for IPv6 ports, preserving its UUID
+	LSPUpdateAddresses(lsp string, mac net.HardwareAddr, ips []*net.IPNet, portSecurity []string) (*OvnCommand, error)
+	// Set dynamic addresses in LSP
+	LSPSetDynamicAddresses(lsp string, address string) (*OvnCommand, error)
+	// Get dynamic addresses from LSP
+	LSPGetDynamicAddresses(lsp string) (string, error)
+	// Set external_ids for LSP
+	LSPSetExternalIds(lsp string, external_ids map[string]string) (*OvnCommand, error)
+	// Get external_ids from LSP
+	LSPGetExternalIds(lsp string) (map[string]string, error)
+	// Set type, enabled, addresses, port_security, options and external_ids of LSP in one operation
+	LSPSet(lsp string, spec LSPSpec) (*OvnCommand, error)
+	// Add LSP with the type, enabled, addresses, port_security, options and external_ids of spec in a single insert
+	LSPAddFull(ls string, lsUUID string, lsp string, spec LSPSpec) (*OvnCommand, error)
+	// Move LSP to another LS in one transaction, keeping its configuration. The port is
+	// recreated: its UUID changes and references to it, e.g. from port groups, must be
+	// updated by the caller
+	LSPReattach(lsp, newSwitch string) (*OvnCommand, error)
+	// Add dhcp options for cidr and provided external_ids
+	DHCPOptionsAdd(cidr string, options map[string]string, external_ids map[string]string) (*OvnCommand, error)
+	// Set dhcp options and set external_ids for specific uuid
+	DHCPOptionsSet(uuid string, options map[string]string, external_ids map[string]string) (*OvnCommand, error)
+	// Del dhcp options via provided external_ids
+	DHCPOptionsDel(uuid string) (*OvnCommand, error)
+	// Get single dhcp via provided uuid
+	DHCPOptionsGet(uuid string) (*DHCPOptions, error)
+	// List dhcp options
+	DHCPOptionsList() ([]*DHCPOptions, error)
+	// Add a DHCP relay to the given comma-separated server IPs, not supported by older OVN schemas
+	DHCPRelayAdd(name, serverIPs string, options map[string]string) (*OvnCommand, error)
+	// Delete a DHCP relay by name, it must not be attached to any port
+	DHCPRelayDel(name string) (*OvnCommand, error)
+	// List all DHCP relays
+	DHCPRelayList() ([]*DHCPRelay, error)
+	// Relay DHCP on the switch of a router type LSP through its peer router port, an empty relay detaches it
+	LSPSetDHCPRelay(lsp, relay string) (*OvnCommand, error)
+	// Pin the MAC of an IP reachable through a logical router port, not supported by older OVN schemas
+	StaticMACBindingAdd(logicalPort, ip, mac string, overrideDynamic bool) (*OvnCommand, error)
+	// Delete the static MAC binding of an IP on a logical router port
+	StaticMACBindingDel(logicalPort, ip string) (*OvnCommand, error)
+	// List all static MAC bindings
+	StaticMACBindingList() ([]*StaticMACBinding, error)
+
+	// Add qos rule
+	QoSAdd(ls string, direction string, priority int, match string, action map[string]int, bandwidth map[string]int, external_ids map[string]string) (*OvnCommand, error)
+	// Add qos rule limiting the matching traffic to rateKbps with bursts of up to burstKb, 0 for the OVN default
+	QoSAddRateLimit(ls string, direction string, priority int, match string, rateKbps, burstKb int) (*OvnCommand, error)
+	// Add qos rule marking the matching traffic with the given DSCP value
+	QoSAddDSCP(ls string, direction string, priority int, match string, dscp int) (*OvnCommand, error)
+	// Del qos rule, to delete wildcard specify priority -1 and string options as ""
+	QoSDel(ls string, direction string, priority int, match string) (*OvnCommand, error)
+	// Get qos rules by logical switch
+	QoSList(ls string) ([]*QoS, error)
+
+	//Add NAT to Logical Router
+	LRNATAdd(lr string, ntype string, externalIp string, logicalIp string, external_ids map[string]string, logicalPortAndExternalMac ...string) (*OvnCommand, error)
+	// Add a dnat_and_snat NAT to Logical Router, distributed on the chassis of the given logical switch port
+	LRNATAddDNATAndSNAT(lr, externalIP, logicalIP, logicalPort, externalMAC string, external_ids map[string]string) (*OvnCommand, error)
+	//Del NAT from Logical Router
+	LRNATDel(lr string, ntype string, ip ...string) (*OvnCommand, error)
+	// Get NAT List by Logical Router
+	LRNATList(lr string) ([]*NAT, error)
+	// Get every NAT rule of every Logical Router, with its router set
+	NATListAll() ([]*NAT, error)
+	// Add Meter with a Meter Band
+	MeterAdd(name, action string, rate int, unit string, external_ids map[string]string, burst int) (*OvnCommand, error)
+	// Add Meter with one Meter Band per band spec, metering each flow separately when fair is set
+	MeterAddBands(name, unit string, fair bool, bands []MeterBandSpec, external_ids map[string]string) (*OvnCommand, error)
+	// Deletes meters
+	MeterDel(name ...string) (*OvnCommand, error)
+	// List Meters
+	MeterList() ([]*Meter, error)
+	// List Meter Bands
+	MeterBandsList() ([]*MeterBand, error)
+	// Exec command, support mul-commands in one transaction.
+	Execute(cmds ...*OvnCommand) error
+	// Same as Execute, but returns a UUID for each object created.
+	ExecuteR(cmds ...*OvnCommand) ([]string, error)
+	// Same as ExecuteR, but applies per-call options such as WithTimeout.
+	ExecuteROptions(opts []ExecuteOption, cmds ...*OvnCommand) ([]string, error)
+	// Same as ExecuteROptions, but if the transaction is lost with the connection, e.g. on a
+	// leader change, waits for the client to reconnect and resubmits it once. The commands
+	// must be idempotent, since the lost transaction may have been committed.
+	ExecuteRRetry(opts RetryOpts, cmds ...*OvnCommand) ([]string, error)
+	// Get the id of the last transaction the cache is synced to
+	CurrentTxn() string
+	// Get the time the cache last received an update from the server, including the initial dump
+	LastUpdateTime() time.Time
+	// Get, by table, the number of transactions writing to it that succeeded, failed or were resubmitted
+	TransactionCounts() map[string]TxnCounts
+	// Wait until ovn-controller reports the LSP as up, or ctx is done
+	WaitForLSPUp(ctx context.Context, lsp string) error
+	// Get the up state and the chassis binding of the LSP
+	LSPStatus(lsp string) (*LSPStatus, error)
+	// Get the name of the chassis the Port_Binding of the logical port is bound to, empty while unbound
+	PortBindingChassis(logicalPort string) (string, error)
+
+	// Add chassis with given name
+	ChassisAdd(name string, hostname string, etype []string, ip string, external_ids map[string]string,
+		transport_zones []string, vtep_lswitches []string) (*OvnCommand, error)
+	// Delete chassis with given name
+	ChassisDel(chName string) (*OvnCommand, error)
+	// Get chassis by hostname or name
+	ChassisGet(chname string) ([]*Chassis, error)
+	// List chassis
+	ChassisList() ([]*Chassis, error)
+	// List chassis in the given transport zone
+	ChassisListByTransportZone(zone string) ([]*Chassis, error)
+
+	// Delete Chassis row from Chassis_Private with given name
+	ChassisPrivateDel(chName string) (*OvnCommand, error)
+	// List Chassis rows in chassis_private table
+	ChassisPrivateList() ([]*ChassisPrivate, error)
+	// Get Chassis row in chassis_private table by given name
+	ChassisPrivateGet(chName string) ([]*ChassisPrivate, error)
+
+	// List datapaths in datapath_binding table
+	DatapathBindingList() ([]*DatapathBinding, error)
+	// Get the datapaths of the logical switch or router with the given uuid or name
+	DatapathBindingGet(externalID string) ([]*DatapathBinding, error)
+
+	// Get encaps by chassis name
+	EncapList(chname string) ([]*Encap, error)
+	// Update in place the encap ip of the chassis with given name or hostname, keeping its tunnels and bindings
+	ReconcileChassisEncap(chassisName, newIP string) (*OvnCommand, error)
+
+	// Set NB_Global table options
+	NBGlobalSetOptions(options map[string]string) (*OvnCommand, error)
+
+	// Get NB_Global table options
+	NBGlobalGetOptions() (map[string]string, error)
+	// Get the mac_prefix option of NB_Global
+	NBGlobalGetMACPrefix() (string, error)
+	// Set the mac_prefix option of NB_Global, the first three octets of a unicast MAC
+	NBGlobalSetMACPrefix(prefix string) (*OvnCommand, error)
+	// Get the svc_monitor_mac option of NB_Global
+	NBGlobalGetSvcMonitorMAC() (net.HardwareAddr, error)
+	// Set the svc_monitor_mac option of NB_Global to a unicast MAC
+	NBGlobalSetSvcMonitorMAC(mac net.HardwareAddr) (*OvnCommand, error)
+	// Get the max_tunid option ovn-northd sets on NB_Global
+	NBGlobalGetMaxTunID() (int, error)
+	// Get the northd_probe_interval option of NB_Global, in milliseconds
+	NBGlobalGetNorthdProbeInterval() (int, error)
+	// Set the northd_probe_interval option of NB_Global, in milliseconds, 0 disabling the probes
+	NBGlobalSetNorthdProbeInterval(interval int) (*OvnCommand, error)
+	// Increment nb_cfg of NB_Global in a transaction of its own and return its new value
+	NBGlobalIncrementNbCfg() (int, error)
+
+	// Set SB_Global table options
+	SBGlobalSetOptions(options map[string]string) (*OvnCommand, error)
+
+	// Get SB_Global table options
+	SBGlobalGetOptions() (map[string]string, error)
+
+	// Get the nb_cfg ovn-northd last synced SB_Global with
+	SBGlobalGetNbCfg() (int, error)
+
+	// Creates a new port group in the Port_Group table named "group" with optional "ports"  and "external_ids".
+	PortGroupAdd(group string, ports []string, external_ids map[string]string) (*OvnCommand, error)
+	// Creates a new port group together with its ACLs in a single transaction
+	PortGroupAddWithACLs(group string, ports []string, acls []ACLSpec, external_ids map[string]string) (*OvnCommand, error)
+	// Creates a new port group together with an address set holding the IPs of its ports in a single transaction
+	PortGroupAddWithAddressSet(group string, ports []string, asName string) (*OvnCommand, error)
+	// Sets "ports" and/or "external_ids" on the port group named "group". It is an error if group does not exist.
+	PortGroupUpdate(group string, ports []string, external_ids map[string]string) (*OvnCommand, error)
+	// Add port to port group.
+	PortGroupAddPort(group string, port string) (*OvnCommand, error)
+	// Remove port from port group.
+	PortGroupRemovePort(group string, port string) (*OvnCommand, error)
+	// Add the desired ports missing from the port group and remove the others in a single mutate.
+	// It returns ErrorNoChanges if the group has the desired ports already.
+	PortGroupReconcilePorts(group string, desired []string) (*OvnCommand, error)
+	// Deletes port group "group". It is an error if "group" does not exist.
+	PortGroupDel(group string) (*OvnCommand, error)
+	// Get PortGroup data structure if it exists
+	PortGroupGet(group string) (*PortGroup, error)
+	// Report whether an ACL, router policy or QoS match refers to the port group as @group,
+	// with the UUIDs of the referencing rows
+	PortGroupIsReferenced(group string) (bool, []string, error)
+	// Get the UUIDs of the acls the port group refers to that do not exist
+	PortGroupCheckDanglingACLs(group string) ([]string, error)
+
+	// Request the OVSDB lock with the given id; it is requested again after a reconnect
+	Lock(id string) error
+	// Take the OVSDB lock with the given id away from its current owner
+	Steal(id string) error
+	// Release the OVSDB lock with the given id, or cancel the pending request for it
+	Unlock(id string) error
+
+	// Close connection to OVN
+	Close() error
+
+	// GetSchema() returns ovn-db schema
+	GetSchema() libovsdb.DatabaseSchema
+
+	// AuxKeyValSet() sets keys/values for a column of OvsMap type, e.g., 'external_ids', 'other_config'.
+	AuxKeyValSet(table string, rowName string, auxCol string, kv map[string]string) (*OvnCommand, error)
+	// AuxKeyValDel() removes keys/values for a column of OvsMap type, e.g., 'external_ids', 'other_config'.
+	// special value of 'nil' removes the given key regardless of its value
+	AuxKeyValDel(table string, rowName string, auxCol string, kv map[string]*string) (*OvnCommand, error)
+	// BulkExternalIDSet() merges kv into the external_ids of the rows with the given names in any table,
+	// in one transaction. The names no row has are skipped.
+	BulkExternalIDSet(table string, rowNames []string, kv map[string]string) (*OvnCommand, error)
+	// DelByExternalID() deletes the rows of any table whose external_ids have key set to value, and the
+	// references other rows hold to them, e.g. all the NAT rules of a feature, in one transaction.
+	DelByExternalID(table, key, value string) (*OvnCommand, error)
+	// GetExternalIDs() returns the external_ids of the row with the given name in any table.
+	GetExternalIDs(table string, rowName string) (map[string]string, error)
+	// MutateColumn() mutates any column of the row with the given name in any table, for columns
+	// without typed support. mutator is one of insert, delete, +=, -=, *=, /= and %=.
+	MutateColumn(table, rowName, column string, mutator string, value interface{}) (*OvnCommand, error)
+	// FindDuplicateNames() returns the UUIDs of the rows sharing a name in any table, by name.
+	FindDuplicateNames(table string) (map[string][]string, error)
+	// DedupByName() deletes all the rows of table named name but one, keep or, with
+	// DedupKeepMostReferenced, the most referenced, and moves the references to the deleted rows
+	// over to the row kept.
+	DedupByName(table, name, keep string) (*OvnCommand, error)
+	// Rename() renames the row of table named oldName, updating its name column only, e.g. a logical
+	// switch or a load balancer. It fails with ErrorExist when another row of table is named newName,
+	// and with ErrorSchema when table has no name column.
+	Rename(table, oldName, newName string) (*OvnCommand, error)
+
+	// Get a point-in-time copy of the cache serving the getters, and the function releasing it
+	Snapshot() (*CacheSnapshot, func())
+}
+
+var _ Client = &ovndb{}
+
+type ovndb struct {
+	client       *libovsdb.OvsdbClient
+	clientLock   sync.RWMutex
+	disconnSig   chan struct{}
+	cache        map[string]map[string]libovsdb.Row
+	cachemutex   sync.RWMutex
+	tranmutex    sync.RWMutex
+	signalCB     OVNSignal
+	disconnectCB OVNDisconnectedCallback
+	connectedCB  OVNConnectedCallback
+	db           string
+	endpoints    []string
+	curEndpoint  int
+	tableCols    map[string][]string
+	cfgTableCols map[string][]string
+	tlsConfig    *tls.Config
+	reconn       bool
+	currentTxn   string
+	lastUpdate   time.Time
+	cacheReset   bool
+	leaderOnly   bool
+	timeout      time.Duration
+
+	serverCache      map[string]map[string]libovsdb.Row
+	serverTableCols  map[string][]string
+	serverCacheMutex sync.RWMutex
+
+	// channels closed on the next cache update of a table
+	cacheWaiters      map[string][]chan struct{}
+	cacheWaitersMutex sync.Mutex
+
+	// requested OVSDB locks and whether they are currently held
+	locks      map[string]bool
+	locksMutex sync.Mutex
+	lockCB     OVNLockCallback
+
+	// cache size alarms; cacheLimitExceeded is keyed by table, "" for the
+	// whole cache, and only accessed with cachemutex held
+	maxCachedRows      int
+	maxCachedTableRows map[string]int
+	cacheLimitCB       OVNCacheLimitCallback
+	cacheLimitExceeded map[string]bool
+
+	sortListResults bool
+
+	// last leader state reported to leaderCB, only accessed while
+	// populating the server cache
+	leaderCB    OVNLeaderCallback
+	leaderKnown bool
+	isLeader    bool
+	// closed once the callback of the last reported change returned
+	leaderCBDone chan struct{}
+
+	// comment added to every transaction, none if empty
+	txnOrigin string
+
+	skipColumnDefaults bool
+
+	// columns, by table, whose changes are not applied to the cache
+	ignoreColumns map[string]map[string]bool
+
+	// rows monitored in the scoped tables, all of them if nil
+	monitorScope *MonitorScope
+
+	// southbound client LSPStatus resolves the chassis of the ports with
+	sbClient Client
+
+	// transaction outcomes by table
+	txnCounts      map[string]*TxnCounts
+	txnCountsMutex sync.Mutex
+
+	// a cache snapshot, which has no client to execute commands with
+	snapshot bool
+}
+
+func (c *ovndb) serverIsLeader() bool {
+	dbTable, ok := c.serverCache[TableDatabase]
+	if !ok {
+		return true
+	}
+	for _, row := range dbTable {
+		fName, ok := row.Fields["name"]
+		if !ok {
+			continue
+		}
+		name, ok := fName.(string)
+		if !ok || name != c.db {
+			continue
+		}
+
+		fModel, ok := row.Fields["model"]
+		if !ok {
+			continue
+		}
+		model, ok := fModel.(string)
+		if !ok || model != "clustered" {
+			continue
+		}
+		fLeader, ok := row.Fields["leader"]
+		if !ok {
+			continue
+		}
+		leader, ok := fLeader.(bool)
+		if !ok {
+			continue
+		}
+		return leader
+	}
+	return true
+}
+
+func (c *ovndb) nextEndpoint() {
+	c.curEndpoint = (c.curEndpoint + 1) % len(c.endpoints)
+}
+
+func (c *ovndb) connect() error {
+	c.clientLock.Lock()
+	defer c.clientLock.Unlock()
+
+	var err error
+	for i := 0; i < len(c.endpoints); i++ {
+		addr := c.endpoints[c.curEndpoint]
+		klog.Infof("[%s %s] connecting...", addr, c.db)
+		c.client, err = libovsdb.Connect(c.timeout, addr, c.tlsConfig)
+		if err == nil {
+			if err = c.connectEndpoint(); err == nil {
+				// success
+				klog.Infof("[%s] connected to %s", c.db, addr)
+				return nil
+			}
+		}
+		klog.Infof("[%s] failed to connect to %s (trying next endpoint): %v", c.db, addr, err)
+
+		c.nextEndpoint()
+
+		if c.client != nil {
+			// Unregister notifier to suppress the Disconnect notifier
+			// from triggering reconnect attempts
+			if err := c.client.Unregister(ovnNotifier{c}); err != nil {
+				klog.Warningf("failed to unregister event handler before disconnect: %v", err)
+			}
+			c.client.Disconnect()
+			c.client = nil
+		}
+	}
+	return fmt.Errorf("failed to connect to all %s DB endpoints %v", c.db, c.endpoints)
+}
+
+func (c *ovndb) connectEndpoint() error {
+	// Locking the cache mutex to ensure the cache is filled before
+	// events from the notifier are handled.
+	c.cachemutex.Lock()
+	defer c.cachemutex.Unlock()
+	c.serverCacheMutex.Lock()
+	defer c.serverCacheMutex.Unlock()
+
+	// We register the notifier, events start coming in but the
+	// mutex is locked
+	notifier := ovnNotifier{c}
+	c.client.Register(notifier)
+
+	c.cacheReset = c.currentTxn == ZERO_TRANSACTION
+	if c.cacheReset {
+		// The first time we connect we initialize the cache, so any deletions
+		// happened while reconnecting are handled correctly. The cache
+		// survives reconnections as the db server will send us changes
+		// since the last transaction
+		c.cache = make(map[string]map[string]libovsdb.Row)
+	}
+	c.tableCols = c.cfgTableCols
+	c.serverCache = make(map[string]map[string]libovsdb.Row)
+	c.logUnavailableSchema(c.db)
+
+	for _, db := range []string{c.db, DBServer} {
+		lastTxn := c.currentTxn
+		initial, resumed, err := c.monitorTables(db, db)
+		if err != nil {
+			return fmt.Errorf("failed to monitor db %s tables: %v", db, err)
+		}
+		if db == c.db && !resumed && !c.cacheReset {
+			// The server no longer has our last transaction in its
+			// history and sent a full dump instead of the changes since
+			// then; start over so rows deleted meanwhile are dropped
+			klog.Warningf("[%s] unable to resume from transaction %s; rebuilding the cache", c.db, lastTxn)
+			c.cache = make(map[string]map[string]libovsdb.Row)
+			c.cacheReset = true
+		}
+
+		// We do the initial dump and populate the cache, we have the mutex
+		c.populateCache2(db, *initial, false)
+	}
+
+	if c.leaderOnly && !c.serverIsLeader() {
+		return fmt.Errorf("leader-only requested; disconnecting from follower")
+	}
+
+	return nil
+}
+
+func NewClient(cfg *Config) (Client, error) {
+	db := cfg.Db
+	// db string should strictly be OVN_Northbound or OVN_Southbound
+	switch db {
+	case DBNB, DBSB:
+		break
+	case "":
+		db = DBNB
+	default:
+		return nil, fmt.Errorf("Valid db names are: %s and %s", DBNB, DBSB)
+	}
+
+	if cfg.SBClient != nil {
+		if db != DBNB {
+			return nil, fmt.Errorf("a southbound client is only used by a northbound client, not by a %s one", db)
+		}
+		if err := checkSBClient(cfg.SBClient); err != nil {
+			return nil, err
+		}
+	}
+
+	ovndb := newOvndb(cfg, db)
+
+	// handle disconnect for incoming messages when not leader
+	go func(){
+		for {
+			select {
+			case <-ovndb.disconnSig:
+				ovndb.disconnect()
+			}
+		}
+	}()
+
+	err := ovndb.connect()
+	if err != nil {
+		return nil, err
+	}
+	return ovndb, nil
+}
+
+// newOvndb returns the client of db configured by cfg, not connected yet
+func newOvndb(cfg *Config, db string) *ovndb {
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = time.Minute
+	}
+
+	ovndb := &ovndb{
+		signalCB:     cfg.SignalCB,
+		disconnectCB: cfg.DisconnectCB,
+		connectedCB:  cfg.OnConnected,
+		lockCB:       cfg.OnLockChange,
+		locks:        make(map[string]bool),
+
+		maxCachedRows:      cfg.MaxCachedRows,
+		maxCachedTableRows: cfg.MaxCachedTableRows,
+		cacheLimitCB:       cfg.OnCacheLimitExceeded,
+		cacheLimitExceeded: make(map[string]bool),
+		sortListResults:    cfg.SortListResults,
+		leaderCB:           cfg.OnLeaderChange,
+		txnOrigin:          cfg.TransactionOrigin,
+		skipColumnDefaults: cfg.SkipColumnDefaults,
+		ignoreColumns:      make(map[string]map[string]bool),
+		monitorScope:       cfg.MonitorScope,
+		sbClient:           cfg.SBClient,
+		txnCounts:          make(map[string]*TxnCounts),
+		disconnSig:         make(chan struct{}, 1),
+		db:                 db,
+		tableCols:          cfg.TableCols,
+		cfgTableCols:       cfg.TableCols,
+		endpoints:          strings.Split(cfg.Addr, ","),
+		curEndpoint:        0,
+		tlsConfig:          cfg.TLSConfig,
+		reconn:             cfg.Reconnect,
+		currentTxn:         ZERO_TRANSACTION,
+		leaderOnly:         cfg.LeaderOnly,
+		timeout:            timeout,
+	}
+	for table, columns := range cfg.IgnoreColumns {
+		ovndb.ignoreColumns[table] = make(map[string]bool, len(columns))
+		for _, column := range columns {
+			ovndb.ignoreColumns[table][column] = true
+		}
+	}
+	return ovndb
+}
+
+// checkSBClient verifies that the southbound client LSPStatus reads the port
+// bindings from monitors the Port_Binding and Chassis tables
+func checkSBClient(sbClient Client) error {
+	sb, ok := sbClient.(*ovndb)
+	if !ok {
+		return nil
+	}
+	if sb.db != DBSB {
+		return fmt.Errorf("the southbound client is connected to %s", sb.db)
+	}
+	// without table columns configured, every table of the schema known to
+	// the library is monitored
+	if len(sb.cfgTableCols) == 0 {
+		return nil
+	}
+	for _, table := range []string{TablePortBinding, TableChassis} {
+		if _, ok := sb.cfgTableCols[table]; !ok {
+			return fmt.Errorf("the southbound client doesn't monitor %s", table)
+		}
+	}
+	return nil
+}
+
+func (c *ovndb) reconnect() {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	go func() {
+		cacheReset := func() bool {
+			c.tranmutex.Lock()
+			defer c.tranmutex.Unlock()
+			klog.Infof("[%s] disconnected from %s; reconnecting ... ", c.db, c.endpoints[c.curEndpoint])
+			retry := 0
+			for range ticker.C {
+				if err := c.connect(); err != nil {
+					if retry < 10 {
+						klog.Warningf("[%s] reconnect failed (%v); retry...", c.db, err)
+					} else if retry == 10 {
+						klog.Warningf("[%s] reconnect failed (%v); continue retrying but log will be supressed.",
+							c.db, err)
+					}
+					retry++
+					continue
+				}
+				klog.Infof("[%s] reconnected to %s after %d retries.",
+					c.db, c.endpoints[c.curEndpoint], retry)
+				ticker.Stop()
+				break
+			}
+			c.cachemutex.RLock()
+			defer c.cachemutex.RUnlock()
+			return c.cacheReset
+		}()
+		// the server released our locks with the old connection
+		c.relock()
+		// called without holding the transaction lock so that the
+		// callback is free to execute commands
+		if c.connectedCB != nil {
+			c.connectedCB(cacheReset)
+		}
+	}()
+}
+
+// filterTablesFromSchema checks whether tables in
+// NBTablesOrder / SBTablesOrder exists in current ovn-db schema
+func (c *ovndb) filterTablesFromSchema(db string) []string {
+	var tables []string
+	// get the table list based on the DB
+	if db == DBNB {
+		tables = NBTablesOrder
+	} else if db == DBSB {
+		tables = SBTablesOrder
+	} else if db == DBServer {
+		tables = ServerTablesOrder
+	}
+
+	dbSchema := c.client.Schema[db]
+	schemaTables := make([]string, 0)
+	for _, table := range tables {
+		if _, ok := dbSchema.Tables[table]; ok {
+			schemaTables = append(schemaTables, table)
+		}
+	}
+	return schemaTables
+}
+
+// optionalColumns are the columns, by table, that the library reads or writes
+// and that older OVN releases lack. The parsers leave the fields of missing
+// columns to their zero value.
+var optionalColumns = map[string]map[string][]string{
+	DBNB: {
+		TableLogicalSwitch:       {"copp", "forwarding_groups", "load_balancer_group"},
+		TableLogicalRouter:       {"load_balancer_group"},
+		TableLogicalSwitchPort:   {"ha_chassis_group"},
+		TableACL:                 {"options", "sample_new", "sample_est"},
+		TableLoadBalancer:        {"options", "selection_fields"},
+		TableLogicalRouterPolicy: {"nexthops", "options"},
+	},
+	DBSB: {
+		TableChassis: {"transport_zones"},
+	},
+}
+
+// logUnavailableSchema logs the tables and optional columns the library knows
+// of that the schema of db lacks, i.e. the features unavailable on the OVN
+// release connected to
+func (c *ovndb) logUnavailableSchema(db string) {
+	tables := NBTablesOrder
+	if db == DBSB {
+		tables = SBTablesOrder
+	}
+	dbSchema := c.client.Schema[db]
+	var missing []string
+	for _, table := range tables {
+		tableSchema, ok := dbSchema.Tables[table]
+		if !ok {
+			missing = append(missing, table)
+			continue
+		}
+		for _, column := range optionalColumns[db][table] {
+			if _, ok := tableSchema.Columns[column]; !ok {
+				missing = append(missing, table+":"+column)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		klog.Infof("[%s] schema version %s lacks tables and columns, the features using them are unavailable: %s",
+			db, dbSchema.Version, strings.Join(missing, ", "))
+	}
+}
+
+// monitorTables starts watching the given database for changes. Must be called
+// with the clientLock held. The returned bool tells whether monitoring resumed
+// from the last transaction seen, in which case the updates only contain the
+// changes since then; it is always false for the server database.
+func (c *ovndb) monitorTables(db string, jsonContext interface{}) (*libovsdb.TableUpdates2, bool, error) {
+	tables := c.filterTablesFromSchema(db)
+
+	var tableCols *map[string][]string
+	if db == DBServer {
+		tableCols = &c.serverTableCols
+	} else {
+		tableCols = &c.tableCols
+	}
+
+	// verify whether user specified table and its columns are legit
+	if len(*tableCols) != 0 {
+		supportedTableMaps := make(map[string]bool)
+		for _, table := range tables {
+			supportedTableMaps[table] = true
+		}
+		for table, columns := range *tableCols {
+			if _, ok := supportedTableMaps[table]; ok {
+				// TODO: adding support for specific columns requires more work.
+				// All of the rowTo<TableName>() functions need to be fixed for
+				// the missing columns.
+				if len(columns) != 0 {
+					return nil, false, fmt.Errorf("providing specific columns is not supported yet")
+				}
+			} else {
+				return nil, false, fmt.Errorf("specified table %q in database %q not supported by the library",
+					table, db)
+			}
+		}
+	} else {
+		*tableCols = make(map[string][]string)
+		for _, table := range tables {
+			(*tableCols)[table] = []string{}
+		}
+	}
+	requests := make(map[string]libovsdb.MonitorRequest)
+	for table, columns := range *tableCols {
+		requests[table] = libovsdb.MonitorRequest{
+			Columns: columns,
+			Select: libovsdb.MonitorSelect{
+				Initial: true,
+				Insert:  true,
+				Delete:  true,
+				Modify:  true,
+			}}
+	}
+	if db != DBServer && c.monitorScope != nil {
+		for _, table := range c.monitorScope.Tables {
+			request, ok := requests[table]
+			if !ok {
+				return nil, false, fmt.Errorf("scoped table %q in database %q is not monitored", table, db)
+			}
+			where, err := c.monitorScopeConditions(db, table)
+			if err != nil {
+				return nil, false, err
+			}
+			request.Where = where
+			requests[table] = request
+		}
+	}
+	var updates *libovsdb.TableUpdates2
+	var resumed bool
+	var err error
+	if db == DBServer {
+		updates, err = c.client.Monitor2(db, jsonContext, requests)
+	} else {
+		var currentTxn string
+		updates, currentTxn, resumed, err = c.client.Monitor3(db, jsonContext, requests, c.currentTxn)
+		if err == nil && len(currentTxn) > 0 {
+			c.currentTxn = currentTxn
+		}
+	}
+	return updates, resumed, err
+}
+
+// monitorScopeConditions returns the conditions selecting the rows of table
+// that are in the monitor scope, from the columns of table that it has
+func (c *ovndb) monitorScopeConditions(db, table string) ([]interface{}, error) {
+	columns := c.client.Schema[db].Tables[table].Columns
+	var where []interface{}
+	if _, ok := columns["name"]; ok {
+		for _, name := range c.monitorScope.Names {
+			where = append(where, libovsdb.NewCondition("name", "==", name))
+		}
+	}
+	if _, ok := columns["external_ids"]; ok {
+		keys := make([]string, 0, len(c.monitorScope.ExternalIDs))
+		for key := range c.monitorScope.ExternalIDs {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			extID, err := libovsdb.NewOvsMap(map[string]string{key: c.monitorScope.ExternalIDs[key]})
+			if err != nil {
+				return nil, err
+			}
+			where = append(where, libovsdb.NewCondition("external_ids", "includes", extID))
+		}
+	}
+	// no condition would select all the rows of the table
+	if len(where) == 0 {
+		return nil, fmt.Errorf("the monitor scope selects no column of table %q in database %q", table, db)
+	}
+	return where, nil
+}
+
+func (c *ovndb) close() error {
+	// a cache snapshot has no client
+	if c.client != nil {
+		c.client.Disconnect()
+	}
+	return nil
+}
+
+func (c *ovndb) disconnect() {
+	c.clientLock.Lock()
+	defer c.clientLock.Unlock()
+	if c.client != nil {
+		c.client.Disconnect()
+		c.client = nil
+	}
+}
+
+func (odbi *ovndb) getClient() (*libovsdb.OvsdbClient, error) {
+	odbi.clientLock.RLock()
+	defer odbi.clientLock.RUnlock()
+	if odbi.client == nil {
+		return nil, fmt.Errorf("client is disconnected")
+	}
+	return odbi.client, nil
+}
+
+// TODO return proper error
+func (c *ovndb) Close() error {
+	c.tranmutex.Lock()
+	defer c.tranmutex.Unlock()
+	return c.close()
+}
+
+// Lock requests the OVSDB lock with the given id, and requests it again after
+// a reconnect
+func (c *ovndb) Lock(id string) error {
+	return c.lockImp(id)
+}
+
+// Steal takes the OVSDB lock with the given id away from its current owner
+func (c *ovndb) Steal(id string) error {
+	return c.stealImp(id)
+}
+
+// Unlock releases the OVSDB lock with the given id, or cancels the pending
+// request for it
+func (c *ovndb) Unlock(id string) error {
+	return c.unlockImp(id)
+}
+
+func (c *ovndb) getSchema(db string) libovsdb.DatabaseSchema {
+	return c.client.Schema[db]
+}
+
+func (c *ovndb) WaitForLSPUp(ctx context.Context, lsp string) error {
+	return c.lspWaitForUpImp(ctx, lsp)
+}
+
+func (c *ovndb) LSPStatus(lsp string) (*LSPStatus, error) {
+	return c.lspStatusImp(lsp)
+}
+
+func (c *ovndb) PortBindingChassis(logicalPort string) (string, error) {
+	return c.portBindingChassisImp(logicalPort)
+}
+
+func (c *ovndb) Snapshot() (*CacheSnapshot, func()) {
+	return c.snapshotImp()
+}
+
+func (c *ovndb) CurrentTxn() string {
+	c.cachemutex.RLock()
+	defer c.cachemutex.RUnlock()
+	return c.currentTxn
+}
+
+func (c *ovndb) LastUpdateTime() time.Time {
+	c.cachemutex.RLock()
+	defer c.cachemutex.RUnlock()
+	return c.lastUpdate
+}
+
+func (c *ovndb) TransactionCounts() map[string]TxnCounts {
+	return c.txnCountsImp()
+}
+
+func (c *ovndb) GetSchema() libovsdb.DatabaseSchema {
+	c.tranmutex.RLock()
+	defer c.tranmutex.RUnlock()
+	if client, _ := c.getClient(); client != nil {
+		return client.Schema[c.db]
+	}
+	return libovsdb.DatabaseSchema{
+		Tables: make(map[string]libovsdb.TableSchema),
+	}
+}
+
+func (c *ovndb) EncapList(chname string) ([]*Encap, error) {
+	list, err := c.encapListImp(chname)
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) ReconcileChassisEncap(chassisName, newIP string) (*OvnCommand, error) {
+	return c.reconcileChassisEncapImp(chassisName, newIP)
+}
+
+func (c *ovndb) ChassisGet(name string) ([]*Chassis, error) {
+	return c.chassisGetImp(name)
+}
+
+func (c *ovndb) ChassisList() ([]*Chassis, error) {
+	list, err := c.chassisListImp()
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) ChassisListByTransportZone(zone string) ([]*Chassis, error) {
+	list, err := c.chassisListByTransportZoneImp(zone)
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) ChassisAdd(name string, hostname string, etype []string, ip string,
+	external_ids map[string]string, transport_zones []string, vtep_lswitches []string) (*OvnCommand, error) {
+	return c.chassisAddImp(name, hostname, etype, ip, external_ids, transport_zones, vtep_lswitches)
+}
+
+func (c *ovndb) ChassisDel(name string) (*OvnCommand, error) {
+	return c.chassisDelImp(name)
+}
+
+func (c *ovndb) chassisPrivateAdd(name string, external_ids map[string]string) (*OvnCommand, error) {
+	return c.chassisPrivateAddImp(name, external_ids)
+}
+
+func (c *ovndb) ChassisPrivateList() ([]*ChassisPrivate, error) {
+	list, err := c.chassisPrivateListImp()
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) ChassisPrivateGet(name string) ([]*ChassisPrivate, error) {
+	return c.chassisPrivateGetImp(name)
+}
+
+func (c *ovndb) ChassisPrivateDel(name string) (*OvnCommand, error) {
+	return c.chassisPrivateDelImp(name)
+}
+
+func (c *ovndb) DatapathBindingList() ([]*DatapathBinding, error) {
+	list, err := c.datapathBindingListImp()
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) DatapathBindingGet(externalID string) ([]*DatapathBinding, error) {
+	return c.datapathBindingGetImp(externalID)
+}
+
+func (c *ovndb) LSAdd(ls string) (*OvnCommand, error) {
+	return c.lsAddImp(ls)
+}
+
+func (c *ovndb) LSDel(ls string) (*OvnCommand, error) {
+	return c.lsDelImp(ls)
+}
+
+func (c *ovndb) LSList() ([]*LogicalSwitch, error) {
+	list, err := c.lsListImp()
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) LSExtIdsAdd(ls string, external_ids map[string]string) (*OvnCommand, error) {
+	return c.lsExtIdsAddImp(ls, external_ids)
+}
+
+func (c *ovndb) LSExtIdsDel(ls string, external_ids map[string]string) (*OvnCommand, error) {
+	return c.lsExtIdsDelImp(ls, external_ids)
+}
+
+func (c *ovndb) LSPGet(lsp string) (*LogicalSwitchPort, error) {
+	return c.lspGetImp(lsp)
+}
+
+func (c *ovndb) LSPGetUUID(uuid string) (*LogicalSwitchPort, error) {
+	return c.lspGetByUUIDImp(uuid)
+}
+
+func (c *ovndb) LSPAdd(ls string, lsUUID string, lsp string) (*OvnCommand, error) {
+	return c.lspAddImp(ls, lsUUID, lsp)
+}
+
+func (c *ovndb) LSPAddExternal(ls string, lsp string, haChassisGroup string) (*OvnCommand, error) {
+	return c.lspAddExternalImp(ls, lsp, haChassisGroup)
+}
+
+func (c *ovndb) LinkSwitchToRouter(lsw, lsp, lr, lrp, lrpMac string, networks []string, externalIds map[string]string) (*OvnCommand, error) {
+	return c.linkSwitchToRouterImp(lsw, lsp, lr, lrp, lrpMac, networks, externalIds)
+}
+
+func (c *ovndb) LSPDel(lsp string) (*OvnCommand, error) {
+	return c.lspDelImp(lsp)
+}
+
+func (c *ovndb) LSPSetAddress(lsp string, addresses ...string) (*OvnCommand, error) {
+	return c.lspSetAddressImp(lsp, addresses...)
+}
+
+func (c *ovndb) LSPSetPortSecurity(lsp string, security ...string) (*OvnCommand, error) {
+	return c.lspSetPortSecurityImp(lsp, security...)
+}
+
+func (c *ovndb) LSPSetType(lsp string, portType string) (*OvnCommand, error) {
+	return c.lspSetTypeImp(lsp, portType)
+}
+
+func (c *ovndb) LSPSetDHCPv4Options(lsp string, options string) (*OvnCommand, error) {
+	return c.lspSetDHCPv4OptionsImp(lsp, options)
+}
+
+func (c *ovndb) LSPGetDHCPv4Options(lsp string) (*DHCPOptions, error) {
+	return c.lspGetDHCPv4OptionsImp(lsp)
+}
+
+func (c *ovndb) LSPSetDHCPv6Options(lsp string, options string) (*OvnCommand, error) {
+	return c.lspSetDHCPv6OptionsImp(lsp, options)
+}
+
+func (c *ovndb) LSPGetDHCPv6Options(lsp string) (*DHCPOptions, error) {
+	return c.lspGetDHCPv6OptionsImp(lsp)
+}
+
+func (c *ovndb) LSPSetOptions(lsp string, options map[string]string) (*OvnCommand, error) {
+	return c.lspSetOptionsImp(lsp, options)
+}
+
+func (c *ovndb) LSPSetRequestedChassis(lsp string, chassis []string) (*OvnCommand, error) {
+	return c.lspSetRequestedChassisImp(lsp, chassis)
+}
+
+func (c *ovndb) LSPSetARPProxy(lsp string, addrs []string) (*OvnCommand, error) {
+	return c.lspSetARPProxyImp(lsp, addrs)
+}
+
+func (c *ovndb) LSPSetNATAddresses(lsp string, addrs []string) (*OvnCommand, error) {
+	return c.lspSetNATAddressesImp(lsp, addrs)
+}
+
+func (c *ovndb) LSPGetOptions(lsp string) (map[string]string, error) {
+	return c.lspGetOptionsImp(lsp)
+}
+
+func (c *ovndb) LSPUpdateAddresses(lsp string, mac net.HardwareAddr, ips []*net.IPNet, portSecurity []string) (*OvnCommand, error) {
+	return c.lspUpdateAddressesImp(lsp, mac, ips, portSecurity)
+}
+
+func (c *ovndb) LSPSetDynamicAddresses(lsp string, address string) (*OvnCommand, error) {
+	return c.lspSetDynamicAddressesImp(lsp, address)
+}
+
+func (c *ovndb) LSPGetDynamicAddresses(lsp string) (string, error) {
+	return c.lspGetDynamicAddressesImp(lsp)
+}
+
+func (c *ovndb) LSPSetExternalIds(lsp string, external_ids map[string]string) (*OvnCommand, error) {
+	return c.lspSetExternalIdsImp(lsp, external_ids)
+}
+
+func (c *ovndb) LSPGetExternalIds(lsp string) (map[string]string, error) {
+	return c.lspGetExternalIdsImp(lsp)
+}
+
+func (c *ovndb) LSPSet(lsp string, spec LSPSpec) (*OvnCommand, error) {
+	return c.lspSetImp(lsp, spec)
+}
+
+func (c *ovndb) LSPAddFull(ls string, lsUUID string, lsp string, spec LSPSpec) (*OvnCommand, error) {
+	return c.lspAddFullImp(ls, lsUUID, lsp, spec)
+}
+
+func (c *ovndb) LSPReattach(lsp, newSwitch string) (*OvnCommand, error) {
+	return c.lspReattachImp(lsp, newSwitch)
+}
+
+func (c *ovndb) LSLBAdd(ls string, lb string) (*OvnCommand, error) {
+	return c.lslbAddImp(ls, lb)
+}
+
+func (c *ovndb) LSLBDel(ls string, lb string) (*OvnCommand, error) {
+	return c.lslbDelImp(ls, lb)
+}
+
+func (c *ovndb) LSLBList(ls string) ([]*LoadBalancer, error) {
+	list, err := c.lslbListImp(ls)
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) LSListQoSRules(ls string) ([]string, error) {
+	return c.lsListQoSRulesImp(ls)
+}
+
+func (c *ovndb) LRAdd(name string, external_ids map[string]string) (*OvnCommand, error) {
+	return c.lrAddImp(name, external_ids)
+}
+
+func (c *ovndb) LRDel(name string) (*OvnCommand, error) {
+	return c.lrDelImp(name)
+}
+
+func (c *ovndb) LRList() ([]*LogicalRouter, error) {
+	list, err := c.lrListImp()
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) LRListByExternalID(key, value string) ([]*LogicalRouter, error) {
+	list, err := c.lrListByExternalIDImp(key, value)
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) LRPAdd(lr string, lrp string, mac string, network []string, peer string, external_ids map[string]string) (*OvnCommand, error) {
+	return c.lrpAddImp(lr, lrp, mac, network, peer, external_ids)
+}
+
+func (c *ovndb) LRPDel(lr string, lrp string) (*OvnCommand, error) {
+	return c.lrpDelImp(lr, lrp)
+}
+
+func (c *ovndb) LRPList(lr string) ([]*LogicalRouterPort, error) {
+	list, err := c.lrpListImp(lr)
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) LRPSetOptions(lrp string, options map[string]string) (*OvnCommand, error) {
+	return c.lrpSetOptionsImp(lrp, options)
+}
+
+func (c *ovndb) LRPAddNetworks(lrp string, networks []string) (*OvnCommand, error) {
+	return c.lrpMutateNetworksImp(lrp, networks, opInsert)
+}
+
+func (c *ovndb) LRPDelNetworks(lrp string, networks []string) (*OvnCommand, error) {
+	return c.lrpMutateNetworksImp(lrp, networks, opDelete)
+}
+
+func (c *ovndb) LRSRAdd(lr string, ip_prefix string, nexthop string, output_port *string, policy *string, external_ids map[string]string) (*OvnCommand, error) {
+	return c.lrsrAddImp(lr, ip_prefix, nexthop, output_port, policy, external_ids)
+}
+
+func (c *ovndb) LRSRDel(lr string, prefix string, nexthop, outputPort, policy *string) (*OvnCommand, error) {
+	return c.lrsrDelImp(lr, prefix, nexthop, outputPort, policy)
+}
+
+func (c *ovndb) LRSRDelByUUID(lr, uuid string) (*OvnCommand, error) {
+	return c.lrsrDelByUUIDImp(lr, uuid)
+}
+
+func (c *ovndb) LRSRList(lr string) ([]*LogicalRouterStaticRoute, error) {
+	list, err := c.lrsrListImp(lr)
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) LRLBAdd(lr string, lb string) (*OvnCommand, error) {
+	return c.lrlbAddImp(lr, lb)
+}
+
+func (c *ovndb) LRPolicyAdd(lr string, priority int, match string, action string, nexthop *string, nexthops []string, options map[string]string, external_ids map[string]string) (*OvnCommand, error) {
+	return c.lrpolicyAddImp(lr, priority, match, action, nexthop, nexthops, options, external_ids)
+}
+
+func (c *ovndb) LRPolicyDel(lr string, priority int, match *string) (*OvnCommand, error) {
+	return c.lrpolicyDelImp(lr, priority, match)
+}
+
+func (c *ovndb) LRPolicyDelByUUID(lr string, uuid string) (*OvnCommand, error) {
+	return c.lrpolicyDelByUUIDImp(lr, uuid)
+}
+
+func (c *ovndb) LRPolicyDelByUUIDs(lr string, uuids []string) (*OvnCommand, error) {
+	return c.lrpolicyDelByUUIDsImp(lr, uuids)
+}
+
+func (c *ovndb) LRPolicyDelAll(lr string) (*OvnCommand, error) {
+	return c.lrpolicyDelAllImp(lr)
+}
+
+func (c *ovndb) LRPolicySetPktMark(lr string, policyUUID string, mark uint32) (*OvnCommand, error) {
+	return c.lrPolicySetPktMarkImp(lr, policyUUID, mark)
+}
+
+func (c *ovndb) LRPolicyList(lr string) ([]*LogicalRouterPolicy, error) {
+	list, err := c.lrPolicyListImp(lr)
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) LRLBDel(lr string, lb string) (*OvnCommand, error) {
+	return c.lrlbDelImp(lr, lb)
+}
+
+func (c *ovndb) LRLBList(lr string) ([]*LoadBalancer, error) {
+	list, err := c.lrlbListImp(lr)
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) LRLBUpdate(lr string, addUUIDs []string, delUUIDs []string) (*OvnCommand, error) {
+	return c.lrlbUpdateImp(lr, addUUIDs, delUUIDs)
+}
+
+func (c *ovndb) LBAdd(name string, vipPort string, protocol string, addrs []string) (*OvnCommand, error) {
+	return c.lbAddImp(name, vipPort, protocol, addrs)
+}
+
+func (c *ovndb) LBAddTemplate(name string, vipTemplate string, protocol string, backendTemplate string, options map[string]string) (*OvnCommand, error) {
+	return c.lbAddTemplateImp(name, vipTemplate, protocol, backendTemplate, options)
+}
+
+func (c *ovndb) LBUpdate(name string, vipPort string, protocol string, addrs []string) (*OvnCommand, error) {
+	return c.lbUpdateImp(name, vipPort, protocol, addrs)
+}
+
+func (c *ovndb) LBDel(name string) (*OvnCommand, error) {
+	return c.lbDelImp(name)
+}
+
+func (c *ovndb) LBSetSelectionFields(name string, selectionFields string) (*OvnCommand, error) {
+	return c.lbSetSelectionFieldsImp(name, selectionFields)
+}
+
+func (c *ovndb) ConfigureServiceReject(name string, reject bool) (*OvnCommand, error) {
+	return c.configureServiceRejectImp(name, reject)
+}
+
+func (c *ovndb) LBSetHairpinSNATIP(name string, v4, v6 string) (*OvnCommand, error) {
+	return c.lbSetHairpinSNATIPImp(name, v4, v6)
+}
+
+func (c *ovndb) LBGetReject(name string) (bool, error) {
+	return c.lbGetRejectImp(name)
+}
+
+func (c *ovndb) LBGetAttachments(name string) (switches, routers []string, err error) {
+	return c.lbGetAttachmentsImp(name)
+}
+
+func (c *ovndb) LBList() ([]*LoadBalancer, error) {
+	list, err := c.lbListImp()
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) ACLAddEntity(entityType EntityType, entityName, aclName, direct, match, action string, priority int, external_ids map[string]string, logflag bool, meter, severity string) (*OvnCommand, error) {
+	return c.aclAddImp(entityType, entityName, aclName, direct, match, action, priority, external_ids, logflag, meter, severity)
+}
+
+func (c *ovndb) ACLAddSpec(entityType EntityType, entityName string, spec ACLSpec) (*OvnCommand, error) {
+	return c.aclAddSpecImp(entityType, entityName, spec)
+}
+
+func (c *ovndb) ACLAdd(ls, direct, match, action string, priority int, external_ids map[string]string, logflag bool, meter string, severity string) (*OvnCommand, error) {
+	return c.aclAddImp(LOGICAL_SWITCH, ls, "", direct, match, action, priority, external_ids, logflag, meter, severity)
+}
+
+func (c *ovndb) ACLSetName(aclUUID, aclName string) (*OvnCommand, error) {
+	return c.aclSetNameImp(aclUUID, aclName)
+}
+
+func (c *ovndb) ACLSetMatch(aclUUID, newMatch string) (*OvnCommand, error) {
+	return c.aclSetMatchImp(aclUUID, newMatch)
+}
+
+func (c *ovndb) ACLSetLogging(aclUUID string, newLogflag bool, newMeter, newSeverity string) (*OvnCommand, error) {
+	return c.aCLSetLoggingImp(aclUUID, newLogflag, newMeter, newSeverity)
+}
+
+func (c *ovndb) ACLSetMeter(aclUUID, meter string) (*OvnCommand, error) {
+	return c.aclSetMeterImp(aclUUID, meter)
+}
+
+func (c *ovndb) ACLSetSamples(aclUUID string, sampleNew, sampleEst *string) (*OvnCommand, error) {
+	return c.aclSetSamplesImp(aclUUID, sampleNew, sampleEst)
+}
+
+func (c *ovndb) ACLSet(aclUUID string, spec ACLSpec) (*OvnCommand, error) {
+	return c.aclSetImp(aclUUID, spec)
+}
+
+func (c *ovndb) ACLDelEntity(entityType EntityType, entityName, aclUUID string) (*OvnCommand, error) {
+	return c.aclDelUUIDImp(entityType, entityName, aclUUID)
+}
+
+func (c *ovndb) ACLDel(ls, direct, match string, priority int, external_ids map[string]string) (*OvnCommand, error) {
+	return c.aclDelImp(LOGICAL_SWITCH, ls, direct, match, priority, external_ids)
+}
+
+func (c *ovndb) ASAdd(name string, addrs []string, external_ids map[string]string) (*OvnCommand, error) {
+	return c.asAddImp(name, addrs, external_ids)
+}
+
+func (c *ovndb) ASAddMany(sets []AddressSetSpec) (*OvnCommand, []string, error) {
+	return c.asAddManyImp(sets)
+}
+
+func (c *ovndb) ASAddIPs(name, uuid string, addrs []string) (*OvnCommand, error) {
+	return c.asAddIPImp(name, uuid, addrs)
+}
+
+func (c *ovndb) ASDelIPs(name, uuid string, addrs []string) (*OvnCommand, error) {
+	return c.asDelIPImp(name, uuid, addrs)
+}
+
+func (c *ovndb) ASDel(name string) (*OvnCommand, error) {
+	return c.asDelImp(name)
+}
+
+func (c *ovndb) ASUpdate(name, uuid string, addrs []string, external_ids map[string]string) (*OvnCommand, error) {
+	return c.asUpdateImp(name, uuid, addrs, external_ids)
+}
+
+func (c *ovndb) QoSAdd(ls string, direction string, priority int, match string, action map[string]int, bandwidth map[string]int, external_ids map[string]string) (*OvnCommand, error) {
+	return c.qosAddImp(ls, direction, priority, match, action, bandwidth, external_ids)
+}
+
+func (c *ovndb) QoSAddRateLimit(ls string, direction string, priority int, match string, rateKbps, burstKb int) (*OvnCommand, error) {
+	return c.qosAddRateLimitImp(ls, direction, priority, match, rateKbps, burstKb)
+}
+
+func (c *ovndb) QoSAddDSCP(ls string, direction string, priority int, match string, dscp int) (*OvnCommand, error) {
+	return c.qosAddDSCPImp(ls, direction, priority, match, dscp)
+}
+
+func (c *ovndb) QoSDel(ls string, direction string, priority int, match string) (*OvnCommand, error) {
+	return c.qosDelImp(ls, direction, priority, match)
+}
+
+func (c *ovndb) QoSList(ls string) ([]*QoS, error) {
+	list, err := c.qosListImp(ls)
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) Execute(cmds ...*OvnCommand) error {
+	return c.execute(cmds...)
+}
+
+func (c *ovndb) ExecuteR(cmds ...*OvnCommand) ([]string, error) {
+	return c.executeR(cmds...)
+}
+
+func (c *ovndb) ExecuteROptions(opts []ExecuteOption, cmds ...*OvnCommand) ([]string, error) {
+	return c.executeROptions(opts, cmds...)
+}
+
+func (c *ovndb) ExecuteRRetry(opts RetryOpts, cmds ...*OvnCommand) ([]string, error) {
+	return c.executeRRetryImp(opts, cmds...)
+}
+
+func (c *ovndb) LSGet(ls string) ([]*LogicalSwitch, error) {
+	return c.lsGetImp(ls)
+}
+
+func (c *ovndb) LSPList(ls string) ([]*LogicalSwitchPort, error) {
+	list, err := c.lspListImp(ls)
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) LSPListByType(ls, portType string) ([]*LogicalSwitchPort, error) {
+	list, err := c.lspListByTypeImp(ls, portType)
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) OrphanLSPList(validNames map[string]bool) ([]*LogicalSwitchPort, error) {
+	list, err := c.orphanLSPListImp(validNames)
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) ACLListEntity(entityType EntityType, entity string) ([]*ACL, error) {
+	list, err := c.aclListImp(entityType, entity)
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) ACLCheckPriorityCollisions(entityType EntityType, entity string) ([]ACLCollision, error) {
+	return c.aclCheckPriorityCollisionsImp(entityType, entity)
+}
+
+func (c *ovndb) ACLCheckOrphaned() ([]string, error) {
+	return c.aclCheckOrphanedImp()
+}
+
+func (c *ovndb) ACLList(ls string) ([]*ACL, error) {
+	list, err := c.aclListImp(LOGICAL_SWITCH, ls)
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) ASList() ([]*AddressSet, error) {
+	list, err := c.asListImp()
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) ASGet(name string) (*AddressSet, error) {
+	return c.asGetImp(name)
+}
+
+func (c *ovndb) ASIsReferenced(name string) (bool, []string, error) {
+	return c.asIsReferencedImp(name)
+}
+
+func (c *ovndb) LRGet(name string) ([]*LogicalRouter, error) {
+	return c.lrGetImp(name)
+}
+
+func (c *ovndb) LRGetFull(name string) (*LogicalRouterFull, error) {
+	return c.lrGetFullImp(name)
+}
+
+func (c *ovndb) LBGet(name string) ([]*LoadBalancer, error) {
+	return c.lbGetImp(name)
+}
+
+func (c *ovndb) DHCPOptionsAdd(cidr string, options map[string]string, external_ids map[string]string) (*OvnCommand, error) {
+	return c.dhcpOptionsAddImp(cidr, options, external_ids)
+}
+
+func (c *ovndb) DHCPOptionsSet(uuid string, options map[string]string, external_ids map[string]string) (*OvnCommand, error) {
+	return c.dhcpOptionsSetImp(uuid, options, external_ids)
+}
+
+func (c *ovndb) DHCPOptionsDel(uuid string) (*OvnCommand, error) {
+	return c.dhcpOptionsDelImp(uuid)
+}
+
+func (c *ovndb) DHCPOptionsGet(uuid string) (*DHCPOptions, error) {
+	return c.dhcpOptionsGetImp(uuid)
+}
+
+func (c *ovndb) DHCPOptionsList() ([]*DHCPOptions, error) {
+	list, err := c.dhcpOptionsListImp()
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) DHCPRelayAdd(name, serverIPs string, options map[string]string) (*OvnCommand, error) {
+	return c.dhcpRelayAddImp(name, serverIPs, options)
+}
+
+func (c *ovndb) DHCPRelayDel(name string) (*OvnCommand, error) {
+	return c.dhcpRelayDelImp(name)
+}
+
+func (c *ovndb) DHCPRelayList() ([]*DHCPRelay, error) {
+	list, err := c.dhcpRelayListImp()
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) LSPSetDHCPRelay(lsp, relay string) (*OvnCommand, error) {
+	return c.lspSetDHCPRelayImp(lsp, relay)
+}
+
+func (c *ovndb) StaticMACBindingAdd(logicalPort, ip, mac string, overrideDynamic bool) (*OvnCommand, error) {
+	return c.staticMACBindingAddImp(logicalPort, ip, mac, overrideDynamic)
+}
+
+func (c *ovndb) StaticMACBindingDel(logicalPort, ip string) (*OvnCommand, error) {
+	return c.staticMACBindingDelImp(logicalPort, ip)
+}
+
+func (c *ovndb) StaticMACBindingList() ([]*StaticMACBinding, error) {
+	list, err := c.staticMACBindingListImp()
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) LBGroupAdd(name string, lbs []string) (*OvnCommand, error) {
+	return c.lbGroupAddImp(name, lbs)
+}
+
+func (c *ovndb) LBGroupDel(name string) (*OvnCommand, error) {
+	return c.lbGroupDelImp(name)
+}
+
+func (c *ovndb) LBGroupList() ([]*LoadBalancerGroup, error) {
+	list, err := c.lbGroupListImp()
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) LSSetLBGroup(ls string, groups []string) (*OvnCommand, error) {
+	return c.setLBGroupImp(TableLogicalSwitch, ls, groups)
+}
+
+func (c *ovndb) LSGetLBGroups(ls string) ([]string, error) {
+	return c.getLBGroupsImp(TableLogicalSwitch, ls)
+}
+
+func (c *ovndb) LSSetCopp(ls string, copp *string) (*OvnCommand, error) {
+	return c.lsSetCoppImp(ls, copp)
+}
+
+func (c *ovndb) ConfigureNodeDNS(ls string, records map[string]string) (*OvnCommand, error) {
+	return c.configureNodeDNSImp(ls, records)
+}
+
+func (c *ovndb) LRSetLBGroup(lr string, groups []string) (*OvnCommand, error) {
+	return c.setLBGroupImp(TableLogicalRouter, lr, groups)
+}
+
+func (c *ovndb) LRGetLBGroups(lr string) ([]string, error) {
+	return c.getLBGroupsImp(TableLogicalRouter, lr)
+}
+
+func (c *ovndb) LRNATAdd(lr string, ntype string, externalIp string, logicalIp string, external_ids map[string]string, logicalPortAndExternalMac ...string) (*OvnCommand, error) {
+	return c.lrNatAddImp(lr, ntype, externalIp, logicalIp, external_ids, logicalPortAndExternalMac...)
+}
+
+func (c *ovndb) LRNATAddDNATAndSNAT(lr, externalIP, logicalIP, logicalPort, externalMAC string, external_ids map[string]string) (*OvnCommand, error) {
+	return c.lrNatAddDNATAndSNATImp(lr, externalIP, logicalIP, logicalPort, externalMAC, external_ids)
+}
+
+func (c *ovndb) LRNATDel(lr string, ntype string, ip ...string) (*OvnCommand, error) {
+	return c.lrNatDelImp(lr, ntype, ip...)
+}
+
+func (c *ovndb) LRNATList(lr string) ([]*NAT, error) {
+	list, err := c.lrNatListImp(lr)
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) NATListAll() ([]*NAT, error) {
+	list, err := c.natListAllImp()
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) MeterAdd(name, action string, rate int, unit string, external_ids map[string]string, burst int) (*OvnCommand, error) {
+	return c.meterAddImp(name, action, rate, unit, external_ids, burst)
+}
+
+func (c *ovndb) MeterAddBands(name, unit string, fair bool, bands []MeterBandSpec, external_ids map[string]string) (*OvnCommand, error) {
+	return c.meterAddBandsImp(name, unit, fair, bands, external_ids)
+}
+
+func (c *ovndb) MeterDel(name ...string) (*OvnCommand, error) {
+	return c.meterDelImp(name...)
+}
+
+func (c *ovndb) MeterList() ([]*Meter, error) {
+	list, err := c.meterListImp()
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) MeterBandsList() ([]*MeterBand, error) {
+	list, err := c.meterBandsListImp()
+	c.sortList(list)
+	return list, err
+}
+
+func (c *ovndb) NBGlobalSetOptions(options map[string]string) (*OvnCommand, error) {
+	return c.nbGlobalSetOptionsImp(options)
+}
+
+func (c *ovndb) NBGlobalGetOptions() (map[string]string, error) {
+	return c.nbGlobalGetOptionsImp()
+}
+
+func (c *ovndb) NBGlobalGetMACPrefix() (string, error) {
+	return c.nbGlobalGetMACPrefixImp()
+}
+
+func (c *ovndb) NBGlobalSetMACPrefix(prefix string) (*OvnCommand, error) {
+	return c.nbGlobalSetMACPrefixImp(prefix)
+}
+
+func (c *ovndb) NBGlobalGetSvcMonitorMAC() (net.HardwareAddr, error) {
+	return c.nbGlobalGetSvcMonitorMACImp()
+}
+
+func (c *ovndb) NBGlobalSetSvcMonitorMAC(mac net.HardwareAddr) (*OvnCommand, error) {
+	return c.nbGlobalSetSvcMonitorMACImp(mac)
+}
+
+func (c *ovndb) NBGlobalGetMaxTunID() (int, error) {
+	return c.nbGlobalGetMaxTunIDImp()
+}
+
+func (c *ovndb) NBGlobalGetNorthdProbeInterval() (int, error) {
+	return c.nbGlobalGetNorthdProbeIntervalImp()
+}
+
+func (c *ovndb) NBGlobalSetNorthdProbeInterval(interval int) (*OvnCommand, error) {
+	return c.nbGlobalSetNorthdProbeIntervalImp(interval)
+}
+
+func (c *ovndb) NBGlobalIncrementNbCfg() (int, error) {
+	return c.nbGlobalIncrementNbCfgImp()
+}
+
+func (c *ovndb) SBGlobalSetOptions(options map[string]string) (*OvnCommand, error) {
+	return c.sbGlobalSetOptionsImp(options)
+}
+
+func (c *ovndb) SBGlobalGetOptions() (map[string]string, error) {
+	return c.sbGlobalGetOptionsImp()
+}
+
+func (c *ovndb) SBGlobalGetNbCfg() (int, error) {
+	return c.sbGlobalGetNbCfgImp()
+}
+
+func (c *ovndb) PortGroupAdd(group string, ports []string, external_ids map[string]string) (*OvnCommand, error) {
+	return c.pgAddImp(group, ports, external_ids)
+}
+
+func (c *ovndb) PortGroupAddWithAddressSet(group string, ports []string, asName string) (*OvnCommand, error) {
+	return c.pgAddWithAddressSetImp(group, ports, asName)
+}
+
+func (c *ovndb) PortGroupAddWithACLs(group string, ports []string, acls []ACLSpec, external_ids map[string]string) (*OvnCommand, error) {
+	return c.pgAddWithACLsImp(group, ports, acls, external_ids)
+}
+
+func (c *ovndb) PortGroupUpdate(group string, ports []string, external_ids map[string]string) (*OvnCommand, error) {
+	return c.pgUpdateImp(group, ports, external_ids)
+}
+
+func (c *ovndb) PortGroupAddPort(group string, port string) (*OvnCommand, error) {
+	return c.pgAddPortImp(group, port)
+}
+
+func (c *ovndb) PortGroupRemovePort(group string, port string) (*OvnCommand, error) {
+	return c.pgRemovePortImp(group, port)
+}
+
+func (c *ovndb) PortGroupReconcilePorts(group string, desired []string) (*OvnCommand, error) {
+	return c.pgReconcilePortsImp(group, desired)
+}
+
+func (c *ovndb) PortGroupDel(group string) (*OvnCommand, error) {
+	return c.pgDelImp(group)
+}
+
+func (c *ovndb) PortGroupGet(group string) (*PortGroup, error) {
+	return c.pgGetImp(group)
+}
+
+func (c *ovndb) PortGroupIsReferenced(group string) (bool, []string, error) {
+	return c.pgIsReferencedImp(group)
+}
+
+func (c *ovndb) PortGroupCheckDanglingACLs(group string) ([]string, error) {
+	return c.pgCheckDanglingACLsImp(group)
+}
+
+// these functions are helpers for unit-tests, but not part of the API
+
+func (c *ovndb) nbGlobalAdd(options map[string]string) (*OvnCommand, error) {
+	return c.nbGlobalAddImp(options)
+}
+
+func (c *ovndb) nbGlobalDel() (*OvnCommand, error) {
+	return c.nbGlobalDelImp()
+}
+
+func (c *ovndb) sbGlobalAdd(options map[string]string) (*OvnCommand, error) {
+	return c.sbGlobalAddImp(options)
+}
+
+func (c *ovndb) sbGlobalDel() (*OvnCommand, error) {
+	return c.sbGlobalDelImp()
+}
+
+func (c *ovndb) AuxKeyValSet(table string, rowName string, auxCol string, kv map[string]string) (*OvnCommand, error) {
+	return c.auxKeyValSet(table, rowName, auxCol, kv)
+}
+
+func (c *ovndb) AuxKeyValDel(table string, rowName string, auxCol string, kv map[string]*string) (*OvnCommand, error) {
+	return c.auxKeyValDel(table, rowName, auxCol, kv)
+}
+
+func (c *ovndb) BulkExternalIDSet(table string, rowNames []string, kv map[string]string) (*OvnCommand, error) {
+	return c.bulkExternalIDSetImp(table, rowNames, kv)
+}
+
+func (c *ovndb) DelByExternalID(table, key, value string) (*OvnCommand, error) {
+	return c.delByExternalIDImp(table, key, value)
+}
+
+func (c *ovndb) GetExternalIDs(table string, rowName string) (map[string]string, error) {
+	return c.getExternalIDsImp(table, rowName)
+}
+
+func (c *ovndb) MutateColumn(table, rowName, column string, mutator string, value interface{}) (*OvnCommand, error) {
+	return c.mutateColumnImp(table, rowName, column, mutator, value)
+}
+
+func (c *ovndb) FindDuplicateNames(table string) (map[string][]string, error) {
+	return c.findDuplicateNamesImp(table)
+}
+
+func (c *ovndb) DedupByName(table, name, keep string) (*OvnCommand, error) {
+	return c.dedupByNameImp(table, name, keep)
+}
+
+func (c *ovndb) Rename(table, oldName, newName string) (*OvnCommand, error) {
+	return c.renameImp(table, oldName, newName)
+}
@@ -0,0 +1,376 @@
+/**
+ * Copyright (c) 2021 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
+)
+
+// ClientPool spreads the reads of a Client over several connections. It
+// holds a writer connection, opened with the given Config, and a number of
+// reader connections, each with its own cache. The commands are built from
+// and executed through the writer, so they are pinned to the leader when
+// Config.LeaderOnly is set, while the getters and lists are served by the
+// readers in turn.
+//
+// The caches of the connections are updated independently, so a read served
+// by a reader may not reflect a transaction the writer just completed, and
+// two consecutive reads may see the database at different points in time.
+// Callers that need to read their own writes, or a consistent state across
+// several reads, should use Writer or take a Snapshot, which is a copy of the
+// writer cache.
+//
+// The callbacks of the Config are only registered on the writer. A reader that
+// gets disconnected reconnects when Config.Reconnect is set; otherwise it keeps
+// serving its last cache.
+type ClientPool struct {
+	Client
+	readers []Client
+	next    uint32
+}
+
+// NewClientPool connects the writer and the given number of readers, each
+// reader starting on a different endpoint of Config.Addr so that the reads are
+// also spread over the servers of a cluster.
+func NewClientPool(cfg *Config, readers int) (*ClientPool, error) {
+	return newClientPool(cfg, readers, NewClient)
+}
+
+// newClientPool builds the pool from the connections newClient opens
+func newClientPool(cfg *Config, readers int, newClient func(*Config) (Client, error)) (*ClientPool, error) {
+	if readers < 1 {
+		return nil, fmt.Errorf("a client pool needs at least one reader, got %d", readers)
+	}
+	writer, err := newClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	pool := &ClientPool{Client: writer}
+
+	endpoints := strings.Split(cfg.Addr, ",")
+	for i := 0; i < readers; i++ {
+		readerCfg := *cfg
+		readerCfg.SignalCB = nil
+		readerCfg.DisconnectCB = nil
+		readerCfg.OnConnected = nil
+		readerCfg.OnLockChange = nil
+		readerCfg.OnLeaderChange = nil
+		readerCfg.OnCacheLimitExceeded = nil
+		readerCfg.LeaderOnly = false
+		shift := i % len(endpoints)
+		readerCfg.Addr = strings.Join(append(endpoints[shift:len(endpoints):len(endpoints)], endpoints[:shift]...), ",")
+		reader, err := newClient(&readerCfg)
+		if err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("failed to connect reader %d of the client pool: %v", i, err)
+		}
+		pool.readers = append(pool.readers, reader)
+	}
+	return pool, nil
+}
+
+// Writer returns the connection the commands are executed through
+func (p *ClientPool) Writer() Client {
+	return p.Client
+}
+
+// reader returns the next reader in turn
+func (p *ClientPool) reader() Client {
+	return p.readers[atomic.AddUint32(&p.next, 1)%uint32(len(p.readers))]
+}
+
+// Close closes the writer and the readers, it returns the first error met
+func (p *ClientPool) Close() error {
+	err := p.Client.Close()
+	for _, reader := range p.readers {
+		if rerr := reader.Close(); err == nil {
+			err = rerr
+		}
+	}
+	return err
+}
+
+func (p *ClientPool) LSGet(ls string) ([]*LogicalSwitch, error) {
+	return p.reader().LSGet(ls)
+}
+
+func (p *ClientPool) LSList() ([]*LogicalSwitch, error) {
+	return p.reader().LSList()
+}
+
+func (p *ClientPool) LSPGet(lsp string) (*LogicalSwitchPort, error) {
+	return p.reader().LSPGet(lsp)
+}
+
+func (p *ClientPool) LSPGetUUID(uuid string) (*LogicalSwitchPort, error) {
+	return p.reader().LSPGetUUID(uuid)
+}
+
+func (p *ClientPool) LSPList(ls string) ([]*LogicalSwitchPort, error) {
+	return p.reader().LSPList(ls)
+}
+
+func (p *ClientPool) LSPListByType(ls, portType string) ([]*LogicalSwitchPort, error) {
+	return p.reader().LSPListByType(ls, portType)
+}
+
+func (p *ClientPool) OrphanLSPList(validNames map[string]bool) ([]*LogicalSwitchPort, error) {
+	return p.reader().OrphanLSPList(validNames)
+}
+
+func (p *ClientPool) LSLBList(ls string) ([]*LoadBalancer, error) {
+	return p.reader().LSLBList(ls)
+}
+
+func (p *ClientPool) LSListQoSRules(ls string) ([]string, error) {
+	return p.reader().LSListQoSRules(ls)
+}
+
+func (p *ClientPool) ACLListEntity(entityType EntityType, entityName string) ([]*ACL, error) {
+	return p.reader().ACLListEntity(entityType, entityName)
+}
+
+func (p *ClientPool) ACLCheckPriorityCollisions(entityType EntityType, entityName string) ([]ACLCollision, error) {
+	return p.reader().ACLCheckPriorityCollisions(entityType, entityName)
+}
+
+func (p *ClientPool) ACLCheckOrphaned() ([]string, error) {
+	return p.reader().ACLCheckOrphaned()
+}
+
+func (p *ClientPool) ACLList(ls string) ([]*ACL, error) {
+	return p.reader().ACLList(ls)
+}
+
+func (p *ClientPool) ASGet(name string) (*AddressSet, error) {
+	return p.reader().ASGet(name)
+}
+
+func (p *ClientPool) ASList() ([]*AddressSet, error) {
+	return p.reader().ASList()
+}
+
+func (p *ClientPool) ASIsReferenced(name string) (bool, []string, error) {
+	return p.reader().ASIsReferenced(name)
+}
+
+func (p *ClientPool) LRGet(name string) ([]*LogicalRouter, error) {
+	return p.reader().LRGet(name)
+}
+
+func (p *ClientPool) LRGetFull(name string) (*LogicalRouterFull, error) {
+	return p.reader().LRGetFull(name)
+}
+
+func (p *ClientPool) LRList() ([]*LogicalRouter, error) {
+	return p.reader().LRList()
+}
+
+func (p *ClientPool) LRListByExternalID(key, value string) ([]*LogicalRouter, error) {
+	return p.reader().LRListByExternalID(key, value)
+}
+
+func (p *ClientPool) LRPList(lr string) ([]*LogicalRouterPort, error) {
+	return p.reader().LRPList(lr)
+}
+
+func (p *ClientPool) LRSRList(lr string) ([]*LogicalRouterStaticRoute, error) {
+	return p.reader().LRSRList(lr)
+}
+
+func (p *ClientPool) LRPolicyList(lr string) ([]*LogicalRouterPolicy, error) {
+	return p.reader().LRPolicyList(lr)
+}
+
+func (p *ClientPool) LRLBList(lr string) ([]*LoadBalancer, error) {
+	return p.reader().LRLBList(lr)
+}
+
+func (p *ClientPool) LBGet(name string) ([]*LoadBalancer, error) {
+	return p.reader().LBGet(name)
+}
+
+func (p *ClientPool) LBGetReject(name string) (bool, error) {
+	return p.reader().LBGetReject(name)
+}
+
+func (p *ClientPool) LBGetAttachments(name string) (switches, routers []string, err error) {
+	return p.reader().LBGetAttachments(name)
+}
+
+func (p *ClientPool) LBList() ([]*LoadBalancer, error) {
+	return p.reader().LBList()
+}
+
+func (p *ClientPool) LBGroupList() ([]*LoadBalancerGroup, error) {
+	return p.reader().LBGroupList()
+}
+
+func (p *ClientPool) LSGetLBGroups(ls string) ([]string, error) {
+	return p.reader().LSGetLBGroups(ls)
+}
+
+func (p *ClientPool) LRGetLBGroups(lr string) ([]string, error) {
+	return p.reader().LRGetLBGroups(lr)
+}
+
+func (p *ClientPool) LSPGetDHCPv4Options(lsp string) (*DHCPOptions, error) {
+	return p.reader().LSPGetDHCPv4Options(lsp)
+}
+
+func (p *ClientPool) LSPGetDHCPv6Options(lsp string) (*DHCPOptions, error) {
+	return p.reader().LSPGetDHCPv6Options(lsp)
+}
+
+func (p *ClientPool) LSPGetOptions(lsp string) (map[string]string, error) {
+	return p.reader().LSPGetOptions(lsp)
+}
+
+func (p *ClientPool) LSPGetDynamicAddresses(lsp string) (string, error) {
+	return p.reader().LSPGetDynamicAddresses(lsp)
+}
+
+func (p *ClientPool) LSPGetExternalIds(lsp string) (map[string]string, error) {
+	return p.reader().LSPGetExternalIds(lsp)
+}
+
+func (p *ClientPool) DHCPOptionsGet(uuid string) (*DHCPOptions, error) {
+	return p.reader().DHCPOptionsGet(uuid)
+}
+
+func (p *ClientPool) DHCPOptionsList() ([]*DHCPOptions, error) {
+	return p.reader().DHCPOptionsList()
+}
+
+func (p *ClientPool) DHCPRelayList() ([]*DHCPRelay, error) {
+	return p.reader().DHCPRelayList()
+}
+
+func (p *ClientPool) StaticMACBindingList() ([]*StaticMACBinding, error) {
+	return p.reader().StaticMACBindingList()
+}
+
+func (p *ClientPool) QoSList(ls string) ([]*QoS, error) {
+	return p.reader().QoSList(ls)
+}
+
+func (p *ClientPool) LRNATList(lr string) ([]*NAT, error) {
+	return p.reader().LRNATList(lr)
+}
+
+func (p *ClientPool) NATListAll() ([]*NAT, error) {
+	return p.reader().NATListAll()
+}
+
+func (p *ClientPool) MeterList() ([]*Meter, error) {
+	return p.reader().MeterList()
+}
+
+func (p *ClientPool) MeterBandsList() ([]*MeterBand, error) {
+	return p.reader().MeterBandsList()
+}
+
+func (p *ClientPool) LSPStatus(lsp string) (*LSPStatus, error) {
+	return p.reader().LSPStatus(lsp)
+}
+
+func (p *ClientPool) PortBindingChassis(logicalPort string) (string, error) {
+	return p.reader().PortBindingChassis(logicalPort)
+}
+
+func (p *ClientPool) ChassisGet(chname string) ([]*Chassis, error) {
+	return p.reader().ChassisGet(chname)
+}
+
+func (p *ClientPool) ChassisList() ([]*Chassis, error) {
+	return p.reader().ChassisList()
+}
+
+func (p *ClientPool) ChassisListByTransportZone(zone string) ([]*Chassis, error) {
+	return p.reader().ChassisListByTransportZone(zone)
+}
+
+func (p *ClientPool) ChassisPrivateList() ([]*ChassisPrivate, error) {
+	return p.reader().ChassisPrivateList()
+}
+
+func (p *ClientPool) ChassisPrivateGet(chName string) ([]*ChassisPrivate, error) {
+	return p.reader().ChassisPrivateGet(chName)
+}
+
+func (p *ClientPool) DatapathBindingList() ([]*DatapathBinding, error) {
+	return p.reader().DatapathBindingList()
+}
+
+func (p *ClientPool) DatapathBindingGet(externalID string) ([]*DatapathBinding, error) {
+	return p.reader().DatapathBindingGet(externalID)
+}
+
+func (p *ClientPool) EncapList(chname string) ([]*Encap, error) {
+	return p.reader().EncapList(chname)
+}
+
+func (p *ClientPool) NBGlobalGetOptions() (map[string]string, error) {
+	return p.reader().NBGlobalGetOptions()
+}
+
+func (p *ClientPool) NBGlobalGetMACPrefix() (string, error) {
+	return p.reader().NBGlobalGetMACPrefix()
+}
+
+func (p *ClientPool) NBGlobalGetSvcMonitorMAC() (net.HardwareAddr, error) {
+	return p.reader().NBGlobalGetSvcMonitorMAC()
+}
+
+func (p *ClientPool) NBGlobalGetMaxTunID() (int, error) {
+	return p.reader().NBGlobalGetMaxTunID()
+}
+
+func (p *ClientPool) NBGlobalGetNorthdProbeInterval() (int, error) {
+	return p.reader().NBGlobalGetNorthdProbeInterval()
+}
+
+func (p *ClientPool) SBGlobalGetOptions() (map[string]string, error) {
+	return p.reader().SBGlobalGetOptions()
+}
+
+func (p *ClientPool) SBGlobalGetNbCfg() (int, error) {
+	return p.reader().SBGlobalGetNbCfg()
+}
+
+func (p *ClientPool) PortGroupGet(group string) (*PortGroup, error) {
+	return p.reader().PortGroupGet(group)
+}
+
+func (p *ClientPool) PortGroupIsReferenced(group string) (bool, []string, error) {
+	return p.reader().PortGroupIsReferenced(group)
+}
+
+func (p *ClientPool) PortGroupCheckDanglingACLs(group string) ([]string, error) {
+	return p.reader().PortGroupCheckDanglingACLs(group)
+}
+
+func (p *ClientPool) GetExternalIDs(table string, rowName string) (map[string]string, error) {
+	return p.reader().GetExternalIDs(table, rowName)
+}
+
+func (p *ClientPool) FindDuplicateNames(table string) (map[string][]string, error) {
+	return p.reader().FindDuplicateNames(table)
+}
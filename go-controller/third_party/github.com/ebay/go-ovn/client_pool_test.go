@@ -0,0 +1,180 @@
+package goovn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubClient is a connection of the client pool tests, it records the calls
+// it serves
+type stubClient struct {
+	Client
+	name   string
+	cfg    Config
+	calls  *[]string
+	closed bool
+}
+
+func (c *stubClient) record(call string) {
+	*c.calls = append(*c.calls, c.name+" "+call)
+}
+
+func (c *stubClient) LSGet(ls string) ([]*LogicalSwitch, error) {
+	c.record("LSGet")
+	return []*LogicalSwitch{{Name: ls}}, nil
+}
+
+func (c *stubClient) LSPList(ls string) ([]*LogicalSwitchPort, error) {
+	c.record("LSPList")
+	return nil, nil
+}
+
+func (c *stubClient) LSAdd(ls string) (*OvnCommand, error) {
+	c.record("LSAdd")
+	return &OvnCommand{Exe: c}, nil
+}
+
+func (c *stubClient) Execute(cmds ...*OvnCommand) error {
+	c.record("Execute")
+	return nil
+}
+
+func (c *stubClient) Snapshot() (*CacheSnapshot, func()) {
+	c.record("Snapshot")
+	return &CacheSnapshot{Client: c}, func() {}
+}
+
+func (c *stubClient) Close() error {
+	c.closed = true
+	return nil
+}
+
+// newStubPool returns a pool of the given number of readers over stub clients,
+// the writer first, and the calls they record
+func newStubPool(t *testing.T, cfg *Config, readers int) (*ClientPool, []*stubClient, *[]string) {
+	calls := &[]string{}
+	var clients []*stubClient
+	pool, err := newClientPool(cfg, readers, func(cfg *Config) (Client, error) {
+		name := "writer"
+		if len(clients) > 0 {
+			name = fmt.Sprintf("reader%d", len(clients)-1)
+		}
+		client := &stubClient{name: name, cfg: *cfg, calls: calls}
+		clients = append(clients, client)
+		return client, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to create the client pool: %v", err)
+	}
+	return pool, clients, calls
+}
+
+func TestNewClientPool(t *testing.T) {
+	cfg := &Config{
+		Addr:                 "tcp:10.0.0.1:6641,tcp:10.0.0.2:6641,tcp:10.0.0.3:6641",
+		SignalCB:             struct{ OVNSignal }{},
+		DisconnectCB:         func() {},
+		OnConnected:          func(bool) {},
+		OnLockChange:         func(string, bool) {},
+		OnLeaderChange:       func(bool) {},
+		OnCacheLimitExceeded: func(string, int, int) {},
+		LeaderOnly:           true,
+		Reconnect:            true,
+	}
+	_, clients, _ := newStubPool(t, cfg, 4)
+	assert.Len(t, clients, 5)
+
+	// the writer keeps the config as is
+	writer := clients[0].cfg
+	assert.Equal(t, cfg.Addr, writer.Addr)
+	assert.NotNil(t, writer.SignalCB)
+	assert.NotNil(t, writer.DisconnectCB)
+	assert.NotNil(t, writer.OnConnected)
+	assert.NotNil(t, writer.OnLockChange)
+	assert.NotNil(t, writer.OnLeaderChange)
+	assert.NotNil(t, writer.OnCacheLimitExceeded)
+	assert.True(t, writer.LeaderOnly)
+
+	expAddrs := []string{
+		"tcp:10.0.0.1:6641,tcp:10.0.0.2:6641,tcp:10.0.0.3:6641",
+		"tcp:10.0.0.2:6641,tcp:10.0.0.3:6641,tcp:10.0.0.1:6641",
+		"tcp:10.0.0.3:6641,tcp:10.0.0.1:6641,tcp:10.0.0.2:6641",
+		"tcp:10.0.0.1:6641,tcp:10.0.0.2:6641,tcp:10.0.0.3:6641",
+	}
+	for i, client := range clients[1:] {
+		reader := client.cfg
+		assert.Equal(t, expAddrs[i], reader.Addr, "reader %d", i)
+		assert.Nil(t, reader.SignalCB, "reader %d", i)
+		assert.Nil(t, reader.DisconnectCB, "reader %d", i)
+		assert.Nil(t, reader.OnConnected, "reader %d", i)
+		assert.Nil(t, reader.OnLockChange, "reader %d", i)
+		assert.Nil(t, reader.OnLeaderChange, "reader %d", i)
+		assert.Nil(t, reader.OnCacheLimitExceeded, "reader %d", i)
+		assert.False(t, reader.LeaderOnly, "reader %d", i)
+		assert.True(t, reader.Reconnect, "reader %d", i)
+	}
+	// the addresses of the config are left alone
+	assert.Equal(t, "tcp:10.0.0.1:6641,tcp:10.0.0.2:6641,tcp:10.0.0.3:6641", cfg.Addr)
+}
+
+func TestNewClientPoolErrors(t *testing.T) {
+	_, err := newClientPool(&Config{Addr: "unix:/tmp/nb.sock"}, 0, func(*Config) (Client, error) {
+		t.Fatal("no connection expected without readers")
+		return nil, nil
+	})
+	assert.Error(t, err)
+
+	// the connections already opened are closed when a reader fails
+	calls := &[]string{}
+	var clients []*stubClient
+	_, err = newClientPool(&Config{Addr: "unix:/tmp/nb.sock"}, 3, func(*Config) (Client, error) {
+		if len(clients) == 2 {
+			return nil, fmt.Errorf("connection refused")
+		}
+		client := &stubClient{calls: calls}
+		clients = append(clients, client)
+		return client, nil
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to connect reader 1")
+	for _, client := range clients {
+		assert.True(t, client.closed)
+	}
+}
+
+func TestClientPoolRouting(t *testing.T) {
+	pool, clients, calls := newStubPool(t, &Config{Addr: "unix:/tmp/nb.sock"}, 3)
+
+	// the reads rotate over the readers
+	for i := 0; i < 4; i++ {
+		_, err := pool.LSGet("node1")
+		assert.Nil(t, err)
+	}
+	_, err := pool.LSPList("node1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"reader1 LSGet",
+		"reader2 LSGet",
+		"reader0 LSGet",
+		"reader1 LSGet",
+		"reader2 LSPList",
+	}, *calls)
+
+	// the commands, their execution and the snapshots go through the writer
+	*calls = nil
+	cmd, err := pool.LSAdd("node1")
+	assert.Nil(t, err)
+	assert.Nil(t, pool.Execute(cmd))
+	snapshot, release := pool.Snapshot()
+	release()
+	assert.Equal(t, clients[0], snapshot.Client)
+	assert.Equal(t, clients[0], pool.Writer())
+	assert.Equal(t, []string{"writer LSAdd", "writer Execute", "writer Snapshot"}, *calls)
+
+	assert.Nil(t, pool.Close())
+	for _, client := range clients {
+		assert.True(t, client.closed, client.name)
+	}
+}
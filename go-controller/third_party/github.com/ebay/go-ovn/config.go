@@ -0,0 +1,90 @@
+/**
+ * Copyright (c) 2017 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"crypto/tls"
+	"time"
+)
+
+// Config ovn nb and sb db client config
+type Config struct {
+	Db           string
+	Addr         string
+	TLSConfig    *tls.Config
+	SignalCB     OVNSignal
+	DisconnectCB OVNDisconnectedCallback // Callback that is called when disconnected, if "Reconnect" is false.
+	OnConnected  OVNConnectedCallback    // Callback that is called after reconnecting, if "Reconnect" is true.
+	OnLockChange OVNLockCallback         // Callback that is called when a lock requested with Lock or Steal is acquired or lost.
+	Reconnect    bool                    // Automatically reconnect when disconnected
+	TableCols    map[string][]string     // List of tables and their cols to be monitored
+	LeaderOnly   bool
+	Timeout      time.Duration
+	// MaxCachedRows raises an alarm when the cache holds more rows in total, 0 disables it
+	MaxCachedRows int
+	// MaxCachedTableRows raises an alarm when a table holds more rows than its limit
+	MaxCachedTableRows map[string]int
+	// OnCacheLimitExceeded is called, in its own goroutine, when one of the above limits is exceeded
+	OnCacheLimitExceeded OVNCacheLimitCallback
+	// SortListResults returns the results of the List calls sorted by name and UUID
+	// instead of in cache order, which is random
+	SortListResults bool
+	// OnLeaderChange is called with the leader state of the server once it is first
+	// known and whenever it changes afterwards, independently of LeaderOnly. It runs
+	// in its own goroutine, so it may use the client, and the changes are reported
+	// in order, each call starting once the previous one returned.
+	OnLeaderChange OVNLeaderCallback
+	// TransactionOrigin, when set, is added as a comment to every transaction so that
+	// changes can be attributed to this client in the database log
+	TransactionOrigin string
+	// SkipColumnDefaults stops filling the columns the server didn't send with their
+	// schema defaults when rows are added to the cache. The server leaves out the
+	// columns that hold their default value, so the cached rows then hold the set
+	// columns only, and the getters return zero values for the rest. A pod port
+	// sets 5 of the 15 Logical_Switch_Port columns, and BenchmarkCacheColumnDefaults
+	// measures 1164 instead of 2284 bytes cached per pod port, about 11MB instead
+	// of 22MB for 10000 pods.
+	SkipColumnDefaults bool
+	// IgnoreColumns lists, per table, high churn columns whose changes are not
+	// applied to the cache, e.g. heartbeat timestamps. An update changing only
+	// those columns doesn't signal the row either. The columns are dropped from
+	// new rows as well, so they read as their default value, or as missing with
+	// SkipColumnDefaults.
+	IgnoreColumns map[string][]string
+	// MonitorScope, when set, narrows the rows monitored, and so cached, in some
+	// tables, e.g. to the switch and ports of a single node.
+	MonitorScope *MonitorScope
+	// SBClient, when set on a northbound client, is the southbound client that
+	// LSPStatus reads the chassis binding of the ports from
+	SBClient Client
+}
+
+// MonitorScope restricts the rows of Tables that the client monitors to those
+// named one of Names or whose external_ids hold any of ExternalIDs; the other
+// tables are monitored in full. The server filters the rows, so the rows out
+// of scope are neither sent nor cached.
+//
+// The getters only see the cached rows: the rows of other nodes are not found
+// by name, and the lists skip the references to rows out of scope, e.g. the
+// ports of a switch that are not in scope. Command builders that look rows up
+// by name fail with ErrorNotFound for rows out of scope, so a scoped client is
+// only suited to work on the rows in its scope.
+type MonitorScope struct {
+	Tables      []string
+	Names       []string
+	ExternalIDs map[string]string
+}
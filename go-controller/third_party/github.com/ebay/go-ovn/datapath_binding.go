@@ -0,0 +1,103 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"fmt"
+
+	"github.com/ebay/libovsdb"
+)
+
+// Datapath_Binding table OVN SB
+type DatapathBinding struct {
+	UUID       string
+	TunnelKey  int
+	ExternalID map[interface{}]interface{}
+}
+
+// external_ids keys set by ovn-northd on the datapath of a logical switch or router
+const (
+	DatapathExternalIDLogicalSwitch = "logical-switch"
+	DatapathExternalIDLogicalRouter = "logical-router"
+	DatapathExternalIDName          = "name"
+)
+
+func (odbi *ovndb) datapathBindingListImp() ([]*DatapathBinding, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheDatapathBinding, ok := odbi.cache[TableDatapathBinding]
+	if !ok {
+		return nil, ErrorSchema
+	}
+
+	listDatapathBinding := make([]*DatapathBinding, 0, len(cacheDatapathBinding))
+	for uuid := range cacheDatapathBinding {
+		dp, err := odbi.rowToDatapathBinding(uuid)
+		if err != nil {
+			return nil, err
+		}
+		listDatapathBinding = append(listDatapathBinding, dp)
+	}
+	return listDatapathBinding, nil
+}
+
+// datapathBindingGetImp returns the datapaths of the logical switch or router
+// with the given NB UUID or name
+func (odbi *ovndb) datapathBindingGetImp(externalID string) ([]*DatapathBinding, error) {
+	var listDatapathBinding []*DatapathBinding
+
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheDatapathBinding, ok := odbi.cache[TableDatapathBinding]
+	if !ok {
+		return nil, ErrorSchema
+	}
+
+	for uuid, drows := range cacheDatapathBinding {
+		extIDs, ok := drows.Fields["external_ids"].(libovsdb.OvsMap)
+		if !ok {
+			continue
+		}
+		for _, key := range []string{DatapathExternalIDLogicalSwitch, DatapathExternalIDLogicalRouter, DatapathExternalIDName} {
+			if extIDs.GoMap[key] == externalID {
+				dp, err := odbi.rowToDatapathBinding(uuid)
+				if err != nil {
+					return nil, err
+				}
+				listDatapathBinding = append(listDatapathBinding, dp)
+				break
+			}
+		}
+	}
+	return listDatapathBinding, nil
+}
+
+func (odbi *ovndb) rowToDatapathBinding(uuid string) (*DatapathBinding, error) {
+	cacheDatapathBinding, ok := odbi.cache[TableDatapathBinding][uuid]
+	if !ok {
+		return nil, fmt.Errorf("row in datapath_binding with uuid %s not found", uuid)
+	}
+
+	dp := &DatapathBinding{
+		UUID:       uuid,
+		TunnelKey:  rowInt(cacheDatapathBinding, "tunnel_key"),
+		ExternalID: rowMap(cacheDatapathBinding, "external_ids"),
+	}
+	return dp, nil
+}
@@ -0,0 +1,315 @@
+/**
+ * Copyright (c) 2021 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ebay/libovsdb"
+)
+
+// rowReferences returns the UUIDs held by a uuid or set of uuids column
+func rowReferences(value interface{}) []string {
+	switch refs := value.(type) {
+	case libovsdb.UUID:
+		return []string{refs.GoUUID}
+	case libovsdb.OvsSet:
+		uuids := []string{}
+		for _, ref := range refs.GoSet {
+			if uuid, ok := ref.(libovsdb.UUID); ok {
+				uuids = append(uuids, uuid.GoUUID)
+			}
+		}
+		return uuids
+	}
+	return nil
+}
+
+// rowHolder is a column of a row holding references
+type rowHolder struct {
+	table, column, uuid string
+}
+
+// rowReference is a reference held by a rowHolder to another row
+type rowReference struct {
+	rowHolder
+	held string
+	// single references are held by a column of at most one UUID, which can
+	// only be cleared when it is optional
+	single, optional bool
+	inMap, weak      bool
+}
+
+// referencesTo returns the references held by the rows of any table to the
+// given rows of table. The caller must hold the cache lock.
+func (odbi *ovndb) referencesTo(schema libovsdb.DatabaseSchema, table string, uuids map[string]bool) []rowReference {
+	refersTo := func(base *libovsdb.BaseType) bool {
+		if base == nil || base.Type != libovsdb.TypeUUID {
+			return false
+		}
+		ref, _ := base.RefTable()
+		return ref == table
+	}
+	var refs []rowReference
+	for refTableName, refTable := range schema.Tables {
+		for column, columnSchema := range refTable.Columns {
+			typeObj := columnSchema.TypeObj
+			if typeObj == nil {
+				continue
+			}
+			base := typeObj.Key
+			if !refersTo(base) {
+				base = typeObj.Value
+				if !refersTo(base) {
+					continue
+				}
+			}
+			refType, _ := base.RefType()
+			mapRef := columnSchema.Type == libovsdb.TypeMap
+			for refUUID, drows := range odbi.cache[refTableName] {
+				var held []string
+				if mapRef {
+					if m, ok := drows.Fields[column].(libovsdb.OvsMap); ok {
+						for k, v := range m.GoMap {
+							held = append(held, rowReferences(k)...)
+							held = append(held, rowReferences(v)...)
+						}
+					}
+				} else {
+					held = rowReferences(drows.Fields[column])
+				}
+				for _, uuid := range held {
+					if uuids[uuid] {
+						refs = append(refs, rowReference{
+							rowHolder: rowHolder{refTableName, column, refUUID},
+							held:      uuid,
+							single:    typeObj.Max() == 1,
+							optional:  typeObj.Min() == 0,
+							inMap:     mapRef,
+							weak:      refType == libovsdb.Weak,
+						})
+					}
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// findDuplicateNamesImp returns the UUIDs, sorted, of the rows of table
+// sharing a name, by name. Tables without a name column return ErrorSchema.
+func (odbi *ovndb) findDuplicateNamesImp(table string) (map[string][]string, error) {
+	if !odbi.columnSupported(table, "name") {
+		return nil, ErrorSchema
+	}
+
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	names := make(map[string][]string)
+	for uuid, drows := range odbi.cache[table] {
+		if name, ok := drows.Fields["name"].(string); ok {
+			names[name] = append(names[name], uuid)
+		}
+	}
+	duplicates := make(map[string][]string)
+	for name, uuids := range names {
+		if len(uuids) > 1 {
+			sort.Strings(uuids)
+			duplicates[name] = uuids
+		}
+	}
+	return duplicates, nil
+}
+
+// DedupKeepMostReferenced, passed as the row to keep to DedupByName, keeps
+// the duplicate referenced by the most rows, which is the one in use, the
+// lowest UUID breaking ties. The cache does not record when rows were
+// created, so the oldest row cannot be told apart.
+const DedupKeepMostReferenced = ""
+
+// dedupByNameImp keeps one of the rows of table named name, keep, or the most
+// referenced one when keep is DedupKeepMostReferenced, and deletes the others
+// after moving the references to them over to the row kept.
+func (odbi *ovndb) dedupByNameImp(table, name, keep string) (*OvnCommand, error) {
+	if !odbi.columnSupported(table, "name") {
+		return nil, ErrorSchema
+	}
+	schema := odbi.GetSchema()
+
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	var uuids []string
+	for uuid, drows := range odbi.cache[table] {
+		if rname, ok := drows.Fields["name"].(string); ok && rname == name {
+			uuids = append(uuids, uuid)
+		}
+	}
+	switch len(uuids) {
+	case 0:
+		return nil, ErrorNotFound
+	case 1:
+		return nil, ErrorNoChanges
+	}
+	sort.Strings(uuids)
+
+	duplicates := make(map[string]bool, len(uuids))
+	for _, uuid := range uuids {
+		duplicates[uuid] = true
+	}
+	refs := odbi.referencesTo(schema, table, duplicates)
+	refCount := make(map[string]int, len(uuids))
+	for _, ref := range refs {
+		// the references between the duplicates do not tell which one is in use
+		if ref.table != table || !duplicates[ref.uuid] {
+			refCount[ref.held]++
+		}
+	}
+
+	if keep == DedupKeepMostReferenced {
+		keep = uuids[0]
+		for _, uuid := range uuids[1:] {
+			if refCount[uuid] > refCount[keep] {
+				keep = uuid
+			}
+		}
+	} else if !duplicates[keep] {
+		return nil, fmt.Errorf("row %s is not one of the %s rows named %s: %v", keep, table, name, uuids)
+	}
+
+	var removed []libovsdb.UUID
+	for _, uuid := range uuids {
+		if uuid != keep {
+			removed = append(removed, stringToGoUUID(uuid))
+		}
+	}
+	// a row holding several of the duplicates in a column is mutated once
+	single := make(map[rowHolder]bool)
+	var holders []rowHolder
+	for _, ref := range refs {
+		// the rows deleted need not be updated
+		if ref.held == keep || (ref.table == table && ref.uuid != keep && duplicates[ref.uuid]) {
+			continue
+		}
+		if ref.inMap {
+			return nil, fmt.Errorf("cannot move the reference to %s row %s held by the %s map of %s row %s",
+				table, ref.held, ref.column, ref.table, ref.uuid)
+		}
+		if _, ok := single[ref.rowHolder]; !ok {
+			holders = append(holders, ref.rowHolder)
+		}
+		single[ref.rowHolder] = ref.single
+	}
+	sort.Slice(holders, func(i, j int) bool {
+		if holders[i].table != holders[j].table {
+			return holders[i].table < holders[j].table
+		}
+		if holders[i].uuid != holders[j].uuid {
+			return holders[i].uuid < holders[j].uuid
+		}
+		return holders[i].column < holders[j].column
+	})
+
+	var operations []libovsdb.Operation
+	for _, holder := range holders {
+		condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(holder.uuid))
+		if single[holder] {
+			operations = append(operations, libovsdb.Operation{
+				Op:    opUpdate,
+				Table: holder.table,
+				Row:   OVNRow{holder.column: stringToGoUUID(keep)},
+				Where: []interface{}{condition},
+			})
+			continue
+		}
+		delSet, err := libovsdb.NewOvsSet(removed)
+		if err != nil {
+			return nil, err
+		}
+		insSet, err := libovsdb.NewOvsSet([]libovsdb.UUID{stringToGoUUID(keep)})
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, libovsdb.Operation{
+			Op:    opMutate,
+			Table: holder.table,
+			Mutations: []interface{}{
+				libovsdb.NewMutation(holder.column, opDelete, delSet),
+				libovsdb.NewMutation(holder.column, opInsert, insSet),
+			},
+			Where: []interface{}{condition},
+		})
+	}
+	for _, uuid := range removed {
+		operations = append(operations, libovsdb.Operation{
+			Op:    opDelete,
+			Table: table,
+			Where: []interface{}{libovsdb.NewCondition("_uuid", "==", uuid)},
+		})
+	}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+// renameImp renames the row of table named oldName, updating its name column
+// only. The references to the row are by UUID and follow it, but the lookups
+// by name, here and in other tools, find it under newName as soon as the
+// transaction commits, so no other row of table may be named newName. Tables
+// without a name column return ErrorSchema.
+func (odbi *ovndb) renameImp(table, oldName, newName string) (*OvnCommand, error) {
+	if len(oldName) == 0 || len(newName) == 0 {
+		return nil, fmt.Errorf("cannot rename %s row %q to %q, names cannot be empty", table, oldName, newName)
+	}
+	if oldName == newName {
+		return nil, ErrorNoChanges
+	}
+	if !odbi.columnSupported(table, "name") {
+		return nil, ErrorSchema
+	}
+
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	var uuids []string
+	for uuid, drows := range odbi.cache[table] {
+		switch name, _ := drows.Fields["name"].(string); name {
+		case newName:
+			return nil, ErrorExist
+		case oldName:
+			uuids = append(uuids, uuid)
+		}
+	}
+	switch len(uuids) {
+	case 0:
+		return nil, ErrorNotFound
+	case 1:
+	default:
+		// the duplicates are to be removed with dedupByNameImp first
+		sort.Strings(uuids)
+		return nil, fmt.Errorf("cannot rename %s row %s, %d rows have the name: %v", table, oldName, len(uuids), uuids)
+	}
+
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: table,
+		Row:   OVNRow{"name": newName},
+		Where: []interface{}{libovsdb.NewCondition("_uuid", "==", stringToGoUUID(uuids[0]))},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
@@ -0,0 +1,371 @@
+package goovn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+const dedupTestSchema = `{
+	"Load_Balancer": {"columns": {"name": {"type": "string"}}},
+	"Logical_Switch": {"columns": {
+		"name": {"type": "string"},
+		"load_balancer": {"type": {"key": {"type": "uuid", "refTable": "Load_Balancer"}, "min": 0, "max": "unlimited"}}}},
+	"Logical_Router": {"columns": {
+		"name": {"type": "string"},
+		"load_balancer": {"type": {"key": {"type": "uuid", "refTable": "Load_Balancer"}, "min": 0, "max": "unlimited"}},
+		"main_lb": {"type": {"key": {"type": "uuid", "refTable": "Load_Balancer"}, "min": 0, "max": 1}},
+		"lb_by_vip": {"type": {"key": "string", "value": {"type": "uuid", "refTable": "Load_Balancer"}, "min": 0, "max": "unlimited"}}}},
+	"NAT": {"columns": {"external_ip": {"type": "string"}}}
+}`
+
+// lbSet returns a set column referencing the given load balancers
+func lbSet(uuids ...string) libovsdb.OvsSet {
+	set := libovsdb.OvsSet{}
+	for _, uuid := range uuids {
+		set.GoSet = append(set.GoSet, libovsdb.UUID{GoUUID: uuid})
+	}
+	return set
+}
+
+func TestFindDuplicateNames(t *testing.T) {
+	lbRow := func(name string) libovsdb.Row {
+		return libovsdb.Row{Fields: map[string]interface{}{"name": name}}
+	}
+	odbi := &ovndb{
+		db:     DBNB,
+		client: newTestSchemaClient(t, DBNB, dedupTestSchema),
+		cache: map[string]map[string]libovsdb.Row{
+			TableLoadBalancer: {
+				"lb3": lbRow("lb-a"),
+				"lb1": lbRow("lb-a"),
+				"lb2": lbRow("lb-b"),
+				"lb5": lbRow("lb-c"),
+				"lb4": lbRow("lb-c"),
+				"lb6": lbRow("lb-c"),
+			},
+			TableLogicalSwitch: {
+				"ls1": {Fields: map[string]interface{}{"name": "node1"}},
+				"ls2": {Fields: map[string]interface{}{"name": "node2"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		desc   string
+		table  string
+		exp    map[string][]string
+		expErr error
+	}{
+		{
+			desc:  "returns the sorted UUIDs of the duplicates by name",
+			table: TableLoadBalancer,
+			exp: map[string][]string{
+				"lb-a": {"lb1", "lb3"},
+				"lb-c": {"lb4", "lb5", "lb6"},
+			},
+		},
+		{
+			desc:  "no duplicates",
+			table: TableLogicalSwitch,
+			exp:   map[string][]string{},
+		},
+		{
+			desc:  "supported table without rows",
+			table: TableLogicalRouter,
+			exp:   map[string][]string{},
+		},
+		{
+			desc:   "table without a name column",
+			table:  TableNAT,
+			expErr: ErrorSchema,
+		},
+		{
+			desc:   "table not in the schema",
+			table:  TableACL,
+			expErr: ErrorSchema,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			duplicates, err := odbi.FindDuplicateNames(tc.table)
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.exp, duplicates)
+		})
+	}
+}
+
+func TestDedupByName(t *testing.T) {
+	newDB := func(holders map[string]map[string]libovsdb.Row) *ovndb {
+		cache := map[string]map[string]libovsdb.Row{
+			TableLoadBalancer: {
+				"lb1": {Fields: map[string]interface{}{"name": "lb-dup"}},
+				"lb2": {Fields: map[string]interface{}{"name": "lb-dup"}},
+				"lb3": {Fields: map[string]interface{}{"name": "lb-dup"}},
+				"lb4": {Fields: map[string]interface{}{"name": "lb-single"}},
+			},
+		}
+		for table, rows := range holders {
+			cache[table] = rows
+		}
+		return &ovndb{db: DBNB, client: newTestSchemaClient(t, DBNB, dedupTestSchema), cache: cache}
+	}
+
+	tests := []struct {
+		desc    string
+		holders map[string]map[string]libovsdb.Row
+		table   string
+		name    string
+		keep    string
+		expOps  []string
+		expErr  string
+	}{
+		{
+			desc: "keeps the most referenced row by default",
+			holders: map[string]map[string]libovsdb.Row{
+				TableLogicalSwitch: {
+					"ls1": {Fields: map[string]interface{}{"load_balancer": lbSet("lb1")}},
+					"ls2": {Fields: map[string]interface{}{"load_balancer": lbSet("lb2")}},
+				},
+				TableLogicalRouter: {
+					"lr1": {Fields: map[string]interface{}{"load_balancer": lbSet("lb2")}},
+				},
+			},
+			table: TableLoadBalancer,
+			name:  "lb-dup",
+			keep:  DedupKeepMostReferenced,
+			expOps: []string{
+				"mutate Logical_Switch load_balancer delete [lb1 lb3] load_balancer insert [lb2] where _uuid == ls1",
+				"delete Load_Balancer where _uuid == lb1",
+				"delete Load_Balancer where _uuid == lb3",
+			},
+		},
+		{
+			desc:  "keeps the lowest UUID when no row is referenced",
+			table: TableLoadBalancer,
+			name:  "lb-dup",
+			keep:  DedupKeepMostReferenced,
+			expOps: []string{
+				"delete Load_Balancer where _uuid == lb2",
+				"delete Load_Balancer where _uuid == lb3",
+			},
+		},
+		{
+			desc: "keeps the row chosen by the caller",
+			holders: map[string]map[string]libovsdb.Row{
+				TableLogicalSwitch: {
+					"ls1": {Fields: map[string]interface{}{"load_balancer": lbSet("lb1")}},
+					"ls2": {Fields: map[string]interface{}{"load_balancer": lbSet("lb2")}},
+				},
+				TableLogicalRouter: {
+					"lr1": {Fields: map[string]interface{}{"load_balancer": lbSet("lb2", "lb3")}},
+				},
+			},
+			table: TableLoadBalancer,
+			name:  "lb-dup",
+			keep:  "lb1",
+			expOps: []string{
+				"mutate Logical_Router load_balancer delete [lb2 lb3] load_balancer insert [lb1] where _uuid == lr1",
+				"mutate Logical_Switch load_balancer delete [lb2 lb3] load_balancer insert [lb1] where _uuid == ls2",
+				"delete Load_Balancer where _uuid == lb2",
+				"delete Load_Balancer where _uuid == lb3",
+			},
+		},
+		{
+			desc: "re-points single references",
+			holders: map[string]map[string]libovsdb.Row{
+				TableLogicalRouter: {
+					"lr1": {Fields: map[string]interface{}{"main_lb": libovsdb.UUID{GoUUID: "lb3"}}},
+					"lr2": {Fields: map[string]interface{}{"main_lb": libovsdb.UUID{GoUUID: "lb2"}}},
+				},
+			},
+			table: TableLoadBalancer,
+			name:  "lb-dup",
+			keep:  "lb2",
+			expOps: []string{
+				"update Logical_Router main_lb=lb2 where _uuid == lr1",
+				"delete Load_Balancer where _uuid == lb1",
+				"delete Load_Balancer where _uuid == lb3",
+			},
+		},
+		{
+			desc: "cannot move a reference held by a map",
+			holders: map[string]map[string]libovsdb.Row{
+				TableLogicalRouter: {
+					"lr1": {Fields: map[string]interface{}{
+						"lb_by_vip": libovsdb.OvsMap{GoMap: map[interface{}]interface{}{"10.0.0.1": libovsdb.UUID{GoUUID: "lb3"}}},
+					}},
+				},
+			},
+			table:  TableLoadBalancer,
+			name:   "lb-dup",
+			keep:   "lb1",
+			expErr: "cannot move the reference to Load_Balancer row lb3 held by the lb_by_vip map of Logical_Router row lr1",
+		},
+		{
+			desc:   "the row to keep is not one of the duplicates",
+			table:  TableLoadBalancer,
+			name:   "lb-dup",
+			keep:   "lb4",
+			expErr: "row lb4 is not one of the Load_Balancer rows named lb-dup: [lb1 lb2 lb3]",
+		},
+		{
+			desc:   "single row",
+			table:  TableLoadBalancer,
+			name:   "lb-single",
+			expErr: ErrorNoChanges.Error(),
+		},
+		{
+			desc:   "no row has the name",
+			table:  TableLoadBalancer,
+			name:   "lb-missing",
+			expErr: ErrorNotFound.Error(),
+		},
+		{
+			desc:   "supported table without rows",
+			table:  TableLogicalSwitch,
+			name:   "node1",
+			expErr: ErrorNotFound.Error(),
+		},
+		{
+			desc:   "table without a name column",
+			table:  TableNAT,
+			name:   "nat1",
+			expErr: ErrorSchema.Error(),
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			cmd, err := newDB(tc.holders).DedupByName(tc.table, tc.name, tc.keep)
+			if tc.expErr != "" {
+				assert.EqualError(t, err, tc.expErr)
+				assert.Nil(t, cmd)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, fmt.Sprint(tc.expOps), fmt.Sprint(cmd.Describe()))
+		})
+	}
+}
+
+func TestRename(t *testing.T) {
+	nameColumn := map[string]*libovsdb.ColumnSchema{"name": {Type: libovsdb.TypeString}}
+	odbi := &ovndb{
+		db: DBNB,
+		client: &libovsdb.OvsdbClient{Schema: map[string]libovsdb.DatabaseSchema{
+			DBNB: {Tables: map[string]libovsdb.TableSchema{
+				TableLogicalSwitch: {Columns: nameColumn},
+				TableLoadBalancer:  {Columns: nameColumn},
+				TableLogicalRouter: {Columns: nameColumn},
+				TableNAT:           {Columns: map[string]*libovsdb.ColumnSchema{"external_ip": {Type: libovsdb.TypeString}}},
+			}},
+		}},
+		cache: map[string]map[string]libovsdb.Row{
+			TableLogicalSwitch: {
+				"ls1": {Fields: map[string]interface{}{"name": "node1"}},
+				"ls2": {Fields: map[string]interface{}{"name": "node2"}},
+			},
+			TableLoadBalancer: {
+				"lb1": {Fields: map[string]interface{}{"name": "lb-dup"}},
+				"lb2": {Fields: map[string]interface{}{"name": "lb-dup"}},
+				"lb3": {Fields: map[string]interface{}{"name": "Service_ns1/svc1_TCP_cluster"}},
+			},
+			TableNAT: {
+				"nat1": {Fields: map[string]interface{}{"external_ip": "172.18.0.2"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		desc    string
+		table   string
+		oldName string
+		newName string
+		expOp   string
+		expErr  error
+	}{
+		{
+			desc:    "renames the row by UUID",
+			table:   TableLogicalSwitch,
+			oldName: "node1",
+			newName: "node3",
+			expOp:   "update Logical_Switch name=node3 where _uuid == ls1",
+		},
+		{
+			desc:    "renames a load balancer",
+			table:   TableLoadBalancer,
+			oldName: "Service_ns1/svc1_TCP_cluster",
+			newName: "Service_ns1/svc1_TCP_node_router_node1",
+			expOp:   "update Load_Balancer name=Service_ns1/svc1_TCP_node_router_node1 where _uuid == lb3",
+		},
+		{
+			desc:    "missing source row",
+			table:   TableLogicalSwitch,
+			oldName: "node4",
+			newName: "node3",
+			expErr:  ErrorNotFound,
+		},
+		{
+			desc:    "supported table without rows",
+			table:   TableLogicalRouter,
+			oldName: "GR_node1",
+			newName: "GR_node2",
+			expErr:  ErrorNotFound,
+		},
+		{
+			desc:    "target name already taken",
+			table:   TableLogicalSwitch,
+			oldName: "node1",
+			newName: "node2",
+			expErr:  ErrorExist,
+		},
+		{
+			desc:    "table without a name column",
+			table:   TableNAT,
+			oldName: "nat-a",
+			newName: "nat-b",
+			expErr:  ErrorSchema,
+		},
+		{
+			desc:    "table not in the schema",
+			table:   TableStaticMACBinding,
+			oldName: "node1",
+			newName: "node3",
+			expErr:  ErrorSchema,
+		},
+		{
+			desc:    "same name",
+			table:   TableLogicalSwitch,
+			oldName: "node1",
+			newName: "node1",
+			expErr:  ErrorNoChanges,
+		},
+		{
+			desc:    "empty name",
+			table:   TableLogicalSwitch,
+			oldName: "node1",
+			expErr:  fmt.Errorf("cannot rename Logical_Switch row \"node1\" to \"\", names cannot be empty"),
+		},
+		{
+			desc:    "duplicate source rows",
+			table:   TableLoadBalancer,
+			oldName: "lb-dup",
+			newName: "lb-new",
+			expErr:  fmt.Errorf("cannot rename Load_Balancer row lb-dup, 2 rows have the name: [lb1 lb2]"),
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			cmd, err := odbi.Rename(tc.table, tc.oldName, tc.newName)
+			if tc.expErr != nil {
+				assert.Equal(t, tc.expErr, err)
+				assert.Nil(t, cmd)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, fmt.Sprint([]string{tc.expOp}), fmt.Sprint(cmd.Describe()))
+		})
+	}
+}
@@ -0,0 +1,218 @@
+/**
+ * Copyright (c) 2017 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"fmt"
+
+	"github.com/ebay/libovsdb"
+)
+
+// DHCPRelay ovnnb item, only present in newer OVN schemas
+type DHCPRelay struct {
+	UUID       string
+	Name       string
+	Servers    string
+	Options    map[interface{}]interface{}
+	ExternalID map[interface{}]interface{}
+}
+
+// LSOtherConfigDHCPRelayPort is the Logical_Switch other_config key naming the
+// router port through which DHCP requests of the switch are relayed.
+const LSOtherConfigDHCPRelayPort = "dhcp_relay_port"
+
+func (odbi *ovndb) rowToDHCPRelay(uuid string) *DHCPRelay {
+	cacheDHCPRelay, ok := odbi.cache[TableDHCPRelay][uuid]
+	if !ok {
+		return nil
+	}
+
+	relay := &DHCPRelay{
+		UUID:       uuid,
+		Name:       rowString(cacheDHCPRelay, "name"),
+		Options:    rowMap(cacheDHCPRelay, "options"),
+		ExternalID: rowMap(cacheDHCPRelay, "external_ids"),
+	}
+	if servers, ok := cacheDHCPRelay.Fields["servers"].(string); ok {
+		relay.Servers = servers
+	}
+	return relay
+}
+
+// dhcpRelaySupported tells whether the server schema has the DHCP_Relay table
+func (odbi *ovndb) dhcpRelaySupported() bool {
+	return odbi.tableSupported(TableDHCPRelay)
+}
+
+func (odbi *ovndb) dhcpRelayAddImp(name, serverIPs string, options map[string]string) (*OvnCommand, error) {
+	if len(name) == 0 {
+		return nil, fmt.Errorf("DHCP relay name cannot be empty")
+	}
+	if !odbi.dhcpRelaySupported() {
+		return nil, ErrorSchema
+	}
+	if uuid := odbi.getRowUUID(TableDHCPRelay, OVNRow{"name": name}); len(uuid) > 0 {
+		return nil, ErrorExist
+	}
+
+	namedUUID, err := newRowUUID()
+	if err != nil {
+		return nil, err
+	}
+	row := make(OVNRow)
+	row["name"] = name
+	if len(serverIPs) > 0 {
+		row["servers"] = serverIPs
+	}
+	if options != nil {
+		oMap, err := libovsdb.NewOvsMap(options)
+		if err != nil {
+			return nil, err
+		}
+		row["options"] = oMap
+	}
+
+	insertOp := libovsdb.Operation{
+		Op:       opInsert,
+		Table:    TableDHCPRelay,
+		Row:      row,
+		UUIDName: namedUUID,
+	}
+	operations := []libovsdb.Operation{insertOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) dhcpRelayDelImp(name string) (*OvnCommand, error) {
+	if !odbi.dhcpRelaySupported() {
+		return nil, ErrorSchema
+	}
+	if uuid := odbi.getRowUUID(TableDHCPRelay, OVNRow{"name": name}); len(uuid) == 0 {
+		return nil, ErrorNotFound
+	}
+
+	condition := libovsdb.NewCondition("name", "==", name)
+	deleteOp := libovsdb.Operation{
+		Op:    opDelete,
+		Table: TableDHCPRelay,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{deleteOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) dhcpRelayListImp() ([]*DHCPRelay, error) {
+	if !odbi.dhcpRelaySupported() {
+		return nil, ErrorSchema
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheDHCPRelay := odbi.cache[TableDHCPRelay]
+	listRelay := make([]*DHCPRelay, 0, len(cacheDHCPRelay))
+	for uuid := range cacheDHCPRelay {
+		listRelay = append(listRelay, odbi.rowToDHCPRelay(uuid))
+	}
+	return listRelay, nil
+}
+
+// lspRouterPort returns the UUID of a router type LSP and the name of its
+// peer router port
+func (odbi *ovndb) lspRouterPort(lsp string) (string, string, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	for uuid, drows := range odbi.cache[TableLogicalSwitchPort] {
+		if name, ok := drows.Fields["name"].(string); !ok || name != lsp {
+			continue
+		}
+		if portType, _ := drows.Fields["type"].(string); portType != "router" {
+			return "", "", fmt.Errorf("LSP %s is not a router port", lsp)
+		}
+		options, ok := drows.Fields["options"].(libovsdb.OvsMap)
+		if !ok {
+			return "", "", fmt.Errorf("LSP %s has no peer router port", lsp)
+		}
+		lrp, ok := options.GoMap["router-port"].(string)
+		if !ok || len(lrp) == 0 {
+			return "", "", fmt.Errorf("LSP %s has no peer router port", lsp)
+		}
+		return uuid, lrp, nil
+	}
+	return "", "", ErrorNotFound
+}
+
+// In OVN the relay is configured on the router port facing the switch, and
+// enabled on the switch by naming the switch side of that port.
+func (odbi *ovndb) lspSetDHCPRelayImp(lsp, relay string) (*OvnCommand, error) {
+	if !odbi.dhcpRelaySupported() {
+		return nil, ErrorSchema
+	}
+	lspUUID, lrp, err := odbi.lspRouterPort(lsp)
+	if err != nil {
+		return nil, err
+	}
+	lsUUID, err := odbi.getRowUUIDContainsUUID(TableLogicalSwitch, "ports", lspUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var relayRef interface{}
+	if len(relay) > 0 {
+		relayUUID := odbi.getRowUUID(TableDHCPRelay, OVNRow{"name": relay})
+		if len(relayUUID) == 0 {
+			return nil, ErrorNotFound
+		}
+		relayRef = stringToGoUUID(relayUUID)
+	} else {
+		// an empty relay detaches the current one
+		relayRef, err = libovsdb.NewOvsSet([]libovsdb.UUID{})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	row := make(OVNRow)
+	row["dhcp_relay"] = relayRef
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableLogicalRouterPort,
+		Row:   row,
+		Where: []interface{}{libovsdb.NewCondition("name", "==", lrp)},
+	}
+
+	delSet, err := libovsdb.NewOvsSet([]string{LSOtherConfigDHCPRelayPort})
+	if err != nil {
+		return nil, err
+	}
+	mutations := []interface{}{libovsdb.NewMutation("other_config", opDelete, delSet)}
+	if len(relay) > 0 {
+		insMap, err := libovsdb.NewOvsMap(map[string]string{LSOtherConfigDHCPRelayPort: lsp})
+		if err != nil {
+			return nil, err
+		}
+		mutations = append(mutations, libovsdb.NewMutation("other_config", opInsert, insMap))
+	}
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     TableLogicalSwitch,
+		Mutations: mutations,
+		Where:     []interface{}{libovsdb.NewCondition("_uuid", "==", stringToGoUUID(lsUUID))},
+	}
+
+	operations := []libovsdb.Operation{updateOp, mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
@@ -0,0 +1,201 @@
+package goovn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDHCPRelayTestDB(relays map[string]libovsdb.Row) *ovndb {
+	return newTestDB(DBNB, map[string][]string{
+		TableDHCPRelay:         {"name", "servers", "options", "external_ids"},
+		TableLogicalRouterPort: {"name", "dhcp_relay"},
+		TableLogicalSwitch:     {"name", "ports", "other_config"},
+		TableLogicalSwitchPort: {"name", "type", "options"},
+	}, map[string]map[string]libovsdb.Row{
+		TableDHCPRelay: relays,
+		TableLogicalSwitch: {
+			"ls1": {Fields: map[string]interface{}{"name": "node1", "ports": libovsdb.OvsSet{GoSet: []interface{}{
+				libovsdb.UUID{GoUUID: "lsp1"}, libovsdb.UUID{GoUUID: "lsp2"},
+			}}}},
+		},
+		TableLogicalSwitchPort: {
+			"lsp1": {Fields: map[string]interface{}{
+				"name":    "stor-node1",
+				"type":    "router",
+				"options": libovsdb.OvsMap{GoMap: map[interface{}]interface{}{"router-port": "rtos-node1"}},
+			}},
+			"lsp2": {Fields: map[string]interface{}{"name": "ns_pod1", "type": ""}},
+		},
+	})
+}
+
+func TestDHCPRelayAdd(t *testing.T) {
+	relays := map[string]libovsdb.Row{"relay1": {Fields: map[string]interface{}{"name": "relay1"}}}
+	tests := []struct {
+		desc     string
+		relays   map[string]libovsdb.Row
+		name     string
+		servers  string
+		options  map[string]string
+		noSchema bool
+		expOp    string
+		expErr   error
+	}{
+		{
+			desc:    "creates the first relay of an empty table",
+			name:    "relay1",
+			servers: "10.0.0.1",
+			options: map[string]string{"foo": "bar"},
+			expOp:   "insert DHCP_Relay name=relay1 options=map[foo:bar] servers=10.0.0.1",
+		},
+		{
+			desc:   "creates a relay without servers",
+			relays: relays,
+			name:   "relay2",
+			expOp:  "insert DHCP_Relay name=relay2",
+		},
+		{
+			desc:   "rejects a duplicate name",
+			relays: relays,
+			name:   "relay1",
+			expErr: ErrorExist,
+		},
+		{
+			desc:   "rejects an empty name",
+			expErr: fmt.Errorf("DHCP relay name cannot be empty"),
+		},
+		{
+			desc:     "fails without the table in the schema",
+			name:     "relay1",
+			noSchema: true,
+			expErr:   ErrorSchema,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			odbi := newDHCPRelayTestDB(tc.relays)
+			if tc.noSchema {
+				delete(odbi.client.Schema[DBNB].Tables, TableDHCPRelay)
+			}
+			cmd, err := odbi.DHCPRelayAdd(tc.name, tc.servers, tc.options)
+			if tc.expErr != nil {
+				assert.Equal(t, tc.expErr, err)
+				assert.Nil(t, cmd)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, fmt.Sprint([]string{tc.expOp}), fmt.Sprint(cmd.Describe()))
+		})
+	}
+}
+
+func TestDHCPRelayDel(t *testing.T) {
+	odbi := newDHCPRelayTestDB(map[string]libovsdb.Row{"relay1": {Fields: map[string]interface{}{"name": "relay1"}}})
+	cmd, err := odbi.DHCPRelayDel("relay1")
+	assert.Nil(t, err)
+	assert.Equal(t, fmt.Sprint([]string{"delete DHCP_Relay where name == relay1"}), fmt.Sprint(cmd.Describe()))
+
+	_, err = odbi.DHCPRelayDel("relay2")
+	assert.Equal(t, ErrorNotFound, err)
+	_, err = newDHCPRelayTestDB(nil).DHCPRelayDel("relay1")
+	assert.Equal(t, ErrorNotFound, err)
+}
+
+func TestDHCPRelayList(t *testing.T) {
+	odbi := newDHCPRelayTestDB(map[string]libovsdb.Row{
+		"relay2": {Fields: map[string]interface{}{
+			"name":         "relay2",
+			"servers":      libovsdb.OvsSet{},
+			"options":      libovsdb.OvsMap{GoMap: map[interface{}]interface{}{}},
+			"external_ids": libovsdb.OvsMap{GoMap: map[interface{}]interface{}{}},
+		}},
+		"relay1": {Fields: map[string]interface{}{
+			"name":         "relay1",
+			"servers":      "10.0.0.1",
+			"options":      libovsdb.OvsMap{GoMap: map[interface{}]interface{}{"foo": "bar"}},
+			"external_ids": libovsdb.OvsMap{GoMap: map[interface{}]interface{}{}},
+		}},
+	})
+	list, err := odbi.DHCPRelayList()
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []*DHCPRelay{
+		{UUID: "relay1", Name: "relay1", Servers: "10.0.0.1", Options: map[interface{}]interface{}{"foo": "bar"}, ExternalID: map[interface{}]interface{}{}},
+		{UUID: "relay2", Name: "relay2", Options: map[interface{}]interface{}{}, ExternalID: map[interface{}]interface{}{}},
+	}, list)
+
+	list, err = newDHCPRelayTestDB(nil).DHCPRelayList()
+	assert.Nil(t, err)
+	assert.Empty(t, list)
+
+	odbi = newDHCPRelayTestDB(nil)
+	delete(odbi.client.Schema[DBNB].Tables, TableDHCPRelay)
+	_, err = odbi.DHCPRelayList()
+	assert.Equal(t, ErrorSchema, err)
+}
+
+func TestLSPSetDHCPRelay(t *testing.T) {
+	relays := map[string]libovsdb.Row{"relay1": {Fields: map[string]interface{}{"name": "relay1"}}}
+	tests := []struct {
+		desc   string
+		relays map[string]libovsdb.Row
+		lsp    string
+		relay  string
+		expOps []string
+		expErr error
+	}{
+		{
+			desc:   "enables the relay on the router port and the switch",
+			relays: relays,
+			lsp:    "stor-node1",
+			relay:  "relay1",
+			expOps: []string{
+				"update Logical_Router_Port dhcp_relay=relay1 where name == rtos-node1",
+				"mutate Logical_Switch other_config delete [dhcp_relay_port] other_config insert map[dhcp_relay_port:stor-node1] where _uuid == ls1",
+			},
+		},
+		{
+			desc:  "disables the relay",
+			lsp:   "stor-node1",
+			relay: "",
+			expOps: []string{
+				"update Logical_Router_Port dhcp_relay=[] where name == rtos-node1",
+				"mutate Logical_Switch other_config delete [dhcp_relay_port] where _uuid == ls1",
+			},
+		},
+		{
+			desc:   "fails for a missing relay",
+			lsp:    "stor-node1",
+			relay:  "relay1",
+			expErr: ErrorNotFound,
+		},
+		{
+			desc:   "fails for a missing port",
+			relays: relays,
+			lsp:    "stor-node2",
+			relay:  "relay1",
+			expErr: ErrorNotFound,
+		},
+		{
+			desc:   "fails for a port that is not a router port",
+			relays: relays,
+			lsp:    "ns_pod1",
+			relay:  "relay1",
+			expErr: fmt.Errorf("LSP ns_pod1 is not a router port"),
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			cmd, err := newDHCPRelayTestDB(tc.relays).LSPSetDHCPRelay(tc.lsp, tc.relay)
+			if tc.expErr != nil {
+				assert.Equal(t, tc.expErr, err)
+				assert.Nil(t, cmd)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, fmt.Sprint(tc.expOps), fmt.Sprint(cmd.Describe()))
+		})
+	}
+}
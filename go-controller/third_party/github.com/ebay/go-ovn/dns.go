@@ -0,0 +1,96 @@
+/**
+ * Copyright (c) 2017 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"github.com/ebay/libovsdb"
+)
+
+// dnsSwitchKey is the external_ids key of a DNS row naming the logical switch
+// the row was configured for by ConfigureNodeDNS
+const dnsSwitchKey = "logical_switch"
+
+func (odbi *ovndb) configureNodeDNSImp(lsw string, records map[string]string) (*OvnCommand, error) {
+	if !odbi.tableSupported(TableDNS) {
+		return nil, ErrorSchema
+	}
+	lswUUID := odbi.getRowUUID(TableLogicalSwitch, OVNRow{"name": lsw})
+	if len(lswUUID) == 0 {
+		return nil, ErrorNotFound
+	}
+
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	if records == nil {
+		records = map[string]string{}
+	}
+	recordsMap, err := libovsdb.NewOvsMap(records)
+	if err != nil {
+		return nil, err
+	}
+
+	var dnsUUID string
+	for uuid, drows := range odbi.cache[TableDNS] {
+		if extIDs := rowMap(drows, "external_ids"); extIDs[dnsSwitchKey] == lsw {
+			dnsUUID = uuid
+			break
+		}
+	}
+
+	var operations []libovsdb.Operation
+	var dnsRef libovsdb.UUID
+	if dnsUUID != "" {
+		// the row exists already: replace its records and make sure the
+		// switch still refers to it, which is a no-op if it does
+		operations = append(operations, libovsdb.Operation{
+			Op:    opUpdate,
+			Table: TableDNS,
+			Row:   OVNRow{"records": recordsMap},
+			Where: []interface{}{libovsdb.NewCondition("_uuid", "==", stringToGoUUID(dnsUUID))},
+		})
+		dnsRef = stringToGoUUID(dnsUUID)
+	} else {
+		namedUUID, err := newRowUUID()
+		if err != nil {
+			return nil, err
+		}
+		extIDs, err := libovsdb.NewOvsMap(map[string]string{dnsSwitchKey: lsw})
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, libovsdb.Operation{
+			Op:       opInsert,
+			Table:    TableDNS,
+			Row:      OVNRow{"records": recordsMap, "external_ids": extIDs},
+			UUIDName: namedUUID,
+		})
+		dnsRef = stringToGoUUID(namedUUID)
+	}
+
+	mutateSet, err := libovsdb.NewOvsSet([]libovsdb.UUID{dnsRef})
+	if err != nil {
+		return nil, err
+	}
+	operations = append(operations, libovsdb.Operation{
+		Op:        opMutate,
+		Table:     TableLogicalSwitch,
+		Mutations: []interface{}{libovsdb.NewMutation("dns_records", opInsert, mutateSet)},
+		Where:     []interface{}{libovsdb.NewCondition("_uuid", "==", stringToGoUUID(lswUUID))},
+	})
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
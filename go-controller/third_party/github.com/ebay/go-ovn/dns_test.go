@@ -0,0 +1,72 @@
+package goovn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDNSTestDB(dns map[string]libovsdb.Row) *ovndb {
+	return newTestDB(DBNB, map[string][]string{
+		TableDNS:           {"records", "external_ids"},
+		TableLogicalSwitch: {"name", "dns_records"},
+	}, map[string]map[string]libovsdb.Row{
+		TableDNS: dns,
+		TableLogicalSwitch: {
+			"ls1": {Fields: map[string]interface{}{"name": "node1"}},
+		},
+	})
+}
+
+func TestConfigureNodeDNS(t *testing.T) {
+	records := map[string]string{"pod1.ns1": "10.128.0.5"}
+
+	t.Run("creates the first DNS row of an empty table", func(t *testing.T) {
+		cmd, err := newDNSTestDB(nil).ConfigureNodeDNS("node1", records)
+		assert.Nil(t, err)
+		infos := cmd.Describe()
+		if assert.Len(t, infos, 2) {
+			assert.Equal(t, opInsert, infos[0].Op)
+			assert.Equal(t, TableDNS, infos[0].Table)
+			assert.Equal(t, map[string]interface{}{
+				"records":      map[string]interface{}{"pod1.ns1": "10.128.0.5"},
+				"external_ids": map[string]interface{}{dnsSwitchKey: "node1"},
+			}, infos[0].Fields)
+			assert.Equal(t, opMutate, infos[1].Op)
+			assert.Equal(t, TableLogicalSwitch, infos[1].Table)
+			assert.Equal(t, [][3]interface{}{{"_uuid", "==", "ls1"}}, infos[1].Where)
+		}
+	})
+
+	t.Run("replaces the records of the switch row", func(t *testing.T) {
+		odbi := newDNSTestDB(map[string]libovsdb.Row{
+			"dns1": {Fields: map[string]interface{}{
+				"records":      libovsdb.OvsMap{GoMap: map[interface{}]interface{}{"old.ns1": "10.128.0.4"}},
+				"external_ids": libovsdb.OvsMap{GoMap: map[interface{}]interface{}{dnsSwitchKey: "node1"}},
+			}},
+			"dns2": {Fields: map[string]interface{}{
+				"external_ids": libovsdb.OvsMap{GoMap: map[interface{}]interface{}{dnsSwitchKey: "node2"}},
+			}},
+		})
+		cmd, err := odbi.ConfigureNodeDNS("node1", records)
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprint([]string{
+			"update DNS records=map[pod1.ns1:10.128.0.5] where _uuid == dns1",
+			"mutate Logical_Switch dns_records insert [dns1] where _uuid == ls1",
+		}), fmt.Sprint(cmd.Describe()))
+	})
+
+	t.Run("fails for a missing switch", func(t *testing.T) {
+		_, err := newDNSTestDB(nil).ConfigureNodeDNS("node2", records)
+		assert.Equal(t, ErrorNotFound, err)
+	})
+
+	t.Run("fails without the table in the schema", func(t *testing.T) {
+		odbi := newDNSTestDB(nil)
+		delete(odbi.client.Schema[DBNB].Tables, TableDNS)
+		_, err := odbi.ConfigureNodeDNS("node1", records)
+		assert.Equal(t, ErrorSchema, err)
+	})
+}
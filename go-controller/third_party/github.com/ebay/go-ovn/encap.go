@@ -0,0 +1,139 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/ebay/libovsdb"
+)
+
+// Encap table OVN SB
+type Encap struct {
+	UUID        string
+	ChassisName string
+	Ip          string
+	Options     map[interface{}]interface{}
+	Encaptype   string
+}
+
+func (odbi *ovndb) encapListImp(chassisName string) ([]*Encap, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheChassis, ok := odbi.cache[TableChassis]
+	if !ok {
+		return nil, ErrorNotFound
+	}
+
+	for _, drows := range cacheChassis {
+		if ch, ok := drows.Fields["name"].(string); ok && ch == chassisName {
+			if enc, ok := drows.Fields["encaps"]; ok {
+				switch enc.(type) {
+				case libovsdb.UUID:
+					if enuid, ok := enc.(libovsdb.UUID); ok {
+						cenc, err := odbi.rowToEncap(enuid.GoUUID)
+						if err != nil {
+							return nil, err
+						}
+						return []*Encap{cenc}, nil
+					} else {
+						return nil, fmt.Errorf("type libovsdb.UUID casting failed")
+					}
+				case libovsdb.OvsSet:
+					if en, ok := enc.(libovsdb.OvsSet); ok {
+						encaps := make([]*Encap, 0, len(en.GoSet))
+						for _, e := range en.GoSet {
+							if euid, ok := e.(libovsdb.UUID); ok {
+								enc, err := odbi.rowToEncap(euid.GoUUID)
+								if err != nil {
+									return nil, err
+								}
+								encaps = append(encaps, enc)
+							}
+						}
+						return encaps, nil
+					} else {
+						return nil, fmt.Errorf("type libovsdb.OvsSet casting failed")
+					}
+				}
+			}
+			return []*Encap{}, nil
+		}
+	}
+	return nil, ErrorNotFound
+}
+
+// reconcileChassisEncapImp updates in place the ip of the encaps of the given
+// chassis that don't match newIP. Deleting and recreating the chassis instead
+// would tear down every tunnel to it and drop its port bindings.
+func (odbi *ovndb) reconcileChassisEncapImp(chassisName, newIP string) (*OvnCommand, error) {
+	if net.ParseIP(newIP) == nil {
+		return nil, fmt.Errorf("invalid encap ip %q for chassis %s", newIP, chassisName)
+	}
+
+	chassis, err := odbi.chassisGetImp(chassisName)
+	if err != nil {
+		return nil, err
+	}
+	if len(chassis) == 0 {
+		return nil, ErrorNotFound
+	}
+
+	var operations []libovsdb.Operation
+	for _, ch := range chassis {
+		encaps, err := odbi.encapListImp(ch.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, encap := range encaps {
+			if encap.Ip == newIP {
+				continue
+			}
+			row := make(OVNRow)
+			row["ip"] = newIP
+			condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(encap.UUID))
+			updateOp := libovsdb.Operation{
+				Op:    opUpdate,
+				Table: TableEncap,
+				Row:   row,
+				Where: []interface{}{condition},
+			}
+			operations = append(operations, updateOp)
+		}
+	}
+	if len(operations) == 0 {
+		return nil, ErrorNoChanges
+	}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) rowToEncap(uuid string) (*Encap, error) {
+	cacheEncaps, ok := odbi.cache[TableEncap][uuid]
+	if !ok {
+		return nil, fmt.Errorf("Encap with uuid%s not found", uuid)
+	}
+	en := &Encap{
+		UUID:        uuid,
+		ChassisName: rowString(cacheEncaps, "chassis_name"),
+		Ip:          rowString(cacheEncaps, "ip"),
+		Options:     rowMap(cacheEncaps, "options"),
+		Encaptype:   rowString(cacheEncaps, "type"),
+	}
+	return en, nil
+}
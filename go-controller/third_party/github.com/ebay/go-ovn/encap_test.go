@@ -0,0 +1,90 @@
+package goovn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcileChassisEncap(t *testing.T) {
+	newDB := func() *ovndb {
+		return &ovndb{
+			db: DBSB,
+			cache: map[string]map[string]libovsdb.Row{
+				TableChassis: {
+					"chassis1": {Fields: map[string]interface{}{
+						"name":     "chassis-1",
+						"hostname": "node1",
+						"encaps": libovsdb.OvsSet{GoSet: []interface{}{
+							libovsdb.UUID{GoUUID: "encap1"},
+							libovsdb.UUID{GoUUID: "encap2"},
+						}},
+					}},
+					"chassis2": {Fields: map[string]interface{}{
+						"name":     "chassis-2",
+						"hostname": "node2",
+						"encaps":   libovsdb.UUID{GoUUID: "encap3"},
+					}},
+				},
+				TableEncap: {
+					"encap1": {Fields: map[string]interface{}{"chassis_name": "chassis-1", "ip": "10.0.0.1", "type": "geneve"}},
+					"encap2": {Fields: map[string]interface{}{"chassis_name": "chassis-1", "ip": "10.0.0.5", "type": "stt"}},
+					"encap3": {Fields: map[string]interface{}{"chassis_name": "chassis-2", "ip": "10.0.0.2", "type": "geneve"}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		desc    string
+		chassis string
+		ip      string
+		expOps  []string
+		expErr  error
+	}{
+		{
+			desc:    "no-op when the ip is unchanged",
+			chassis: "chassis-2",
+			ip:      "10.0.0.2",
+			expErr:  ErrorNoChanges,
+		},
+		{
+			desc:    "updates the encap whose ip changed",
+			chassis: "chassis-2",
+			ip:      "10.0.0.3",
+			expOps:  []string{"update Encap ip=10.0.0.3 where _uuid == encap3"},
+		},
+		{
+			desc:    "updates only the encaps of the chassis that don't match",
+			chassis: "node1",
+			ip:      "10.0.0.5",
+			expOps:  []string{"update Encap ip=10.0.0.5 where _uuid == encap1"},
+		},
+		{
+			desc:    "unknown chassis",
+			chassis: "chassis-3",
+			ip:      "10.0.0.3",
+			expErr:  ErrorNotFound,
+		},
+		{
+			desc:    "invalid ip",
+			chassis: "chassis-2",
+			ip:      "10.0.0",
+			expErr:  fmt.Errorf("invalid encap ip %q for chassis chassis-2", "10.0.0"),
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			cmd, err := newDB().ReconcileChassisEncap(tc.chassis, tc.ip)
+			if tc.expErr != nil {
+				assert.Equal(t, tc.expErr, err)
+				assert.Nil(t, cmd)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, fmt.Sprint(tc.expOps), fmt.Sprint(cmd.Describe()))
+		})
+	}
+}
@@ -0,0 +1,270 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ebay/libovsdb"
+)
+
+func (odbi *ovndb) addGlobalTableRowImp(options map[string]string, table string) (*OvnCommand, error) {
+	namedUUID, err := newRowUUID()
+	if err != nil {
+		return nil, err
+	}
+	row := make(OVNRow)
+
+	optionsMap, err := libovsdb.NewOvsMap(options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	row["options"] = optionsMap
+
+	if uuid := odbi.getRowUUID(table, row); len(uuid) > 0 {
+		return nil, ErrorExist
+	}
+
+	insertOp := libovsdb.Operation{
+		Op:       opInsert,
+		Table:    table,
+		Row:      row,
+		UUIDName: namedUUID,
+	}
+
+	operations := []libovsdb.Operation{insertOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) delGlobalTableRowImp(table string) (*OvnCommand, error) {
+	if table == "" {
+		return nil, fmt.Errorf("Invalid table name passed to delete")
+	}
+
+	uuid, err := func() (string, error) {
+		odbi.cachemutex.RLock()
+		defer odbi.cachemutex.RUnlock()
+		cacheGlobal, ok := odbi.cache[table]
+		if !ok {
+			return "", fmt.Errorf("Table %s not found in cache %v", table, odbi.cache)
+		}
+		for uuid, _ := range cacheGlobal {
+			return uuid, nil
+		}
+		return "", fmt.Errorf("No row found in %s table", table)
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(uuid))
+	deleteOp := libovsdb.Operation{
+		Op:    opDelete,
+		Table: table,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{deleteOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) globalSetOptionsImp(options map[string]string, table string) (*OvnCommand, error) {
+	if options == nil || table == "" {
+		return nil, fmt.Errorf("Invalid arguments passed to set options: table: %s, options:  %v", table, options)
+	}
+	optionsMap, err := libovsdb.NewOvsMap(options)
+	if err != nil {
+		return nil, err
+	}
+
+	uuid, err := func() (string, error) {
+		odbi.cachemutex.RLock()
+		defer odbi.cachemutex.RUnlock()
+		cacheGlobal, ok := odbi.cache[table]
+		if !ok {
+			return "", fmt.Errorf("Table %s not found in cache %v", table, odbi.cache)
+		}
+		for uuid, _ := range cacheGlobal {
+			return uuid, nil
+		}
+		return "", fmt.Errorf("No row found in %s table", table)
+	}()
+	if err != nil {
+		return nil, err
+	}
+	row := make(OVNRow)
+	row["options"] = optionsMap
+	condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(uuid))
+
+	// simple mutate operation
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: table,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) globalGetOptionsImp(table string) (map[string]string, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+	cacheGlobal, ok := odbi.cache[table]
+	if !ok {
+		return nil, ErrorSchema
+	}
+	for _, drows := range cacheGlobal {
+		if options, ok := drows.Fields["options"]; ok {
+			switch options.(type) {
+			case libovsdb.OvsMap:
+				optionsGoMap := options.(libovsdb.OvsMap).GoMap
+				optionsMap := make(map[string]string)
+				for k, v := range optionsGoMap {
+					key, keyOk := k.(string)
+					value, valueOk := v.(string)
+					if !keyOk || !valueOk {
+						continue
+					}
+					optionsMap[key] = value
+				}
+				return optionsMap, nil
+			default:
+				return nil, fmt.Errorf("Error getting options field of the %s table - unsupported type", table)
+			}
+		}
+	}
+	return nil, fmt.Errorf("No row found in %s table", table)
+}
+
+// globalRowUUID returns the UUID of the single row of the NB_Global or
+// SB_Global table
+func (odbi *ovndb) globalRowUUID(table string) (string, error) {
+	if !odbi.tableSupported(table) {
+		return "", ErrorSchema
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+	// the cache has no key for a table without rows
+	for uuid := range odbi.cache[table] {
+		return uuid, nil
+	}
+	return "", ErrorNotFound
+}
+
+// globalSetOptionImp sets a single option of the NB_Global or SB_Global row,
+// or clears it when value is empty, leaving the other options untouched
+func (odbi *ovndb) globalSetOptionImp(table, key, value string) (*OvnCommand, error) {
+	uuid, err := odbi.globalRowUUID(table)
+	if err != nil {
+		return nil, err
+	}
+	delSet, err := libovsdb.NewOvsSet([]string{key})
+	if err != nil {
+		return nil, err
+	}
+	mutations := []interface{}{libovsdb.NewMutation("options", opDelete, delSet)}
+	if len(value) > 0 {
+		insMap, err := libovsdb.NewOvsMap(map[string]string{key: value})
+		if err != nil {
+			return nil, err
+		}
+		mutations = append(mutations, libovsdb.NewMutation("options", opInsert, insMap))
+	}
+
+	condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(uuid))
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     table,
+		Mutations: mutations,
+		Where:     []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+// globalGetOptionImp returns a single option of the NB_Global or SB_Global
+// row, ErrorNotFound if it isn't set
+func (odbi *ovndb) globalGetOptionImp(table, key string) (string, error) {
+	uuid, err := odbi.globalRowUUID(table)
+	if err != nil {
+		return "", err
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+	value, ok := rowMap(odbi.cache[table][uuid], "options")[key].(string)
+	if !ok {
+		return "", ErrorNotFound
+	}
+	return value, nil
+}
+
+// globalIncrementImp increments an integer column of the NB_Global or
+// SB_Global row, e.g. nb_cfg, and returns the value it was incremented to. The
+// value is read back in the same transaction, so unlike the commands it runs
+// the transaction itself.
+func (odbi *ovndb) globalIncrementImp(table, column string) (int, error) {
+	uuid, err := odbi.globalRowUUID(table)
+	if err != nil {
+		return 0, err
+	}
+	condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(uuid))
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     table,
+		Mutations: []interface{}{libovsdb.NewMutation(column, "+=", 1)},
+		Where:     []interface{}{condition},
+	}
+	selectOp := libovsdb.Operation{
+		Op:      opSelect,
+		Table:   table,
+		Columns: []string{column},
+		Where:   []interface{}{condition},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), odbi.timeout)
+	defer cancel()
+	results, err := odbi.transact(ctx, odbi.db, mutateOp, selectOp)
+	if err != nil {
+		return 0, err
+	}
+	if len(results) < 2 || len(results[1].Rows) != 1 {
+		return 0, ErrorNotFound
+	}
+	// numbers are decoded from the JSON reply as float64
+	switch value := results[1].Rows[0][column].(type) {
+	case float64:
+		return int(value), nil
+	case int:
+		return value, nil
+	}
+	return 0, fmt.Errorf("unexpected %s value %v in the %s table", column, results[1].Rows[0][column], table)
+}
+
+// globalGetIntImp returns an integer column of the NB_Global or SB_Global row
+// from the cache
+func (odbi *ovndb) globalGetIntImp(table, column string) (int, error) {
+	uuid, err := odbi.globalRowUUID(table)
+	if err != nil {
+		return 0, err
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+	return rowInt(odbi.cache[table][uuid], column), nil
+}
@@ -0,0 +1,13 @@
+module github.com/ebay/go-ovn
+
+go 1.13
+
+require (
+	github.com/cenkalti/rpc2 v0.0.0-20210604223624-c1acbc6ec984
+	github.com/ebay/libovsdb v0.2.1-0.20200719163122-3332afaeb27c
+	github.com/google/uuid v1.2.0
+	github.com/stretchr/testify v1.6.1
+	k8s.io/klog/v2 v2.4.0
+)
+
+replace github.com/ebay/libovsdb => ../libovsdb
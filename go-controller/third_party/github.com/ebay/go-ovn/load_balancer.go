@@ -0,0 +1,434 @@
+/**
+ * Copyright (c) 2017 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/ebay/libovsdb"
+)
+
+// LoadBalancer ovnnb item
+type LoadBalancer struct {
+	UUID            string
+	Version         string
+	Name            string
+	VIPs            map[interface{}]interface{}
+	Protocol        string
+	SelectionFields string
+	Options         map[interface{}]interface{}
+	ExternalID      map[interface{}]interface{}
+	// Template is set for the LBs whose VIPs and backends reference
+	// Chassis_Template_Var variables, instantiated by each chassis
+	Template bool
+	// HairpinSNATIPs are the IPs of options:hairpin_snat_ip, at most one
+	// per IP family
+	HairpinSNATIPs []string
+}
+
+// LBOptionReject is the LB option making OVN reject, with a TCP reset or an
+// ICMP port unreachable, the traffic to a VIP without backends instead of
+// dropping it.
+const LBOptionReject = "reject"
+
+// LBOptionTemplate is the LB option making OVN expand the ^variables of the
+// VIPs and backends with the Chassis_Template_Var of each chassis
+const LBOptionTemplate = "template"
+
+// LBOptionHairpinSNATIP is the LB option holding the IPs, separated by a
+// space, that OVN SNATs to the traffic a backend sends to a VIP it backs
+const LBOptionHairpinSNATIP = "hairpin_snat_ip"
+
+func (odbi *ovndb) lbUpdateImp(name string, vipPort string, protocol string, addrs []string) (*OvnCommand, error) {
+	row := make(OVNRow)
+
+	// prepare vips map
+	vipMap := make(map[string]string)
+	vipMap[vipPort] = strings.Join(addrs, ",")
+
+	oMap, err := libovsdb.NewOvsMap(vipMap)
+	if err != nil {
+		return nil, err
+	}
+
+	row["vips"] = oMap
+	row["protocol"] = protocol
+
+	condition := libovsdb.NewCondition("name", "==", name)
+
+	insertOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableLoadBalancer,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{insertOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lbAddImp(name string, vipPort string, protocol string, addrs []string) (*OvnCommand, error) {
+	var operations []libovsdb.Operation
+	namedUUID, err := newRowUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	row := make(OVNRow)
+	row["name"] = name
+
+	if uuid := odbi.getRowUUID(TableLoadBalancer, row); len(uuid) > 0 {
+		return nil, ErrorExist
+	}
+
+	// prepare vips map
+	vipMap := make(map[string]string)
+	vipMap[vipPort] = strings.Join(addrs, ",")
+
+	oMap, err := libovsdb.NewOvsMap(vipMap)
+	if err != nil {
+		return nil, err
+	}
+	row["vips"] = oMap
+	row["protocol"] = protocol
+
+	insertOp := libovsdb.Operation{
+		Op:       opInsert,
+		Table:    TableLoadBalancer,
+		Row:      row,
+		UUIDName: namedUUID,
+	}
+	operations = append(operations, insertOp)
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lbAddTemplateImp(name string, vipTemplate string, protocol string, backendTemplate string, options map[string]string) (*OvnCommand, error) {
+	if len(vipTemplate) == 0 {
+		return nil, fmt.Errorf("VIP template cannot be empty while adding template LB %s", name)
+	}
+	// servers not knowing Chassis_Template_Var would take the variables
+	// for literal addresses
+	if !odbi.tableSupported(TableChassisTemplateVar) || !odbi.columnSupported(TableLoadBalancer, "options") {
+		return nil, ErrorSchema
+	}
+	namedUUID, err := newRowUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	row := make(OVNRow)
+	row["name"] = name
+	if uuid := odbi.getRowUUID(TableLoadBalancer, row); len(uuid) > 0 {
+		return nil, ErrorExist
+	}
+
+	vipMap, err := libovsdb.NewOvsMap(map[string]string{vipTemplate: backendTemplate})
+	if err != nil {
+		return nil, err
+	}
+	row["vips"] = vipMap
+	row["protocol"] = protocol
+
+	lbOptions := map[string]string{}
+	for key, value := range options {
+		lbOptions[key] = value
+	}
+	lbOptions[LBOptionTemplate] = "true"
+	optionsMap, err := libovsdb.NewOvsMap(lbOptions)
+	if err != nil {
+		return nil, err
+	}
+	row["options"] = optionsMap
+
+	insertOp := libovsdb.Operation{
+		Op:       opInsert,
+		Table:    TableLoadBalancer,
+		Row:      row,
+		UUIDName: namedUUID,
+	}
+	operations := []libovsdb.Operation{insertOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lbDelImp(name string) (*OvnCommand, error) {
+	var operations []libovsdb.Operation
+
+	condition := libovsdb.NewCondition("name", "==", name)
+	deleteOp := libovsdb.Operation{
+		Op:    opDelete,
+		Table: TableLoadBalancer,
+		Where: []interface{}{condition},
+	}
+	// Also delete references from Logical switches
+	row := make(OVNRow)
+	row["name"] = name
+	lbuuid := odbi.getRowUUID(TableLoadBalancer, row)
+	if len(lbuuid) == 0 {
+		return nil, ErrorNotFound
+	}
+	mutateUUID := []libovsdb.UUID{stringToGoUUID(lbuuid)}
+	mutateSet, err := libovsdb.NewOvsSet(mutateUUID)
+	if err != nil {
+		return nil, err
+	}
+	mutation := libovsdb.NewMutation("load_balancer", opDelete, mutateSet)
+	lswitches, err := odbi.getRowsMatchingUUID(TableLogicalSwitch, "load_balancer", lbuuid)
+	if err != nil && err != ErrorNotFound {
+		return nil, err
+	} else if err == nil {
+		// mutate all matching lswitches for the corresponding load_balancer
+		for _, lswitch := range lswitches {
+			mucondition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(lswitch))
+			mutateOp := libovsdb.Operation{
+				Op:        opMutate,
+				Table:     TableLogicalSwitch,
+				Mutations: []interface{}{mutation},
+				Where:     []interface{}{mucondition},
+			}
+			operations = append(operations, mutateOp)
+		}
+	}
+	operations = append(operations, deleteOp)
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lbGetImp(name string) ([]*LoadBalancer, error) {
+	var listLB []*LoadBalancer
+
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheLoadBalancer, ok := odbi.cache[TableLoadBalancer]
+	if !ok {
+		return nil, ErrorSchema
+	}
+
+	for uuid, drows := range cacheLoadBalancer {
+		if lbName, ok := drows.Fields["name"].(string); ok && lbName == name {
+			lb, err := odbi.rowToLB(uuid)
+			if err != nil {
+				return nil, err
+			}
+			listLB = append(listLB, lb)
+		}
+	}
+	return listLB, nil
+}
+
+func (odbi *ovndb) lbListImp() ([]*LoadBalancer, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheLoadBalancer, ok := odbi.cache[TableLoadBalancer]
+	if !ok {
+		return nil, ErrorSchema
+	}
+
+	listLB := make([]*LoadBalancer, 0, len(cacheLoadBalancer))
+	for uuid := range cacheLoadBalancer {
+		lb, err := odbi.rowToLB(uuid)
+		if err != nil {
+			return nil, err
+		}
+		listLB = append(listLB, lb)
+	}
+
+	return listLB, nil
+}
+
+func (odbi *ovndb) lbSetSelectionFieldsImp(name string, selectionFields string) (*OvnCommand, error) {
+	row := make(OVNRow)
+	row["selection_fields"] = selectionFields
+
+	condition := libovsdb.NewCondition("name", "==", name)
+
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableLoadBalancer,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) configureServiceRejectImp(name string, reject bool) (*OvnCommand, error) {
+	if len(name) == 0 {
+		return nil, fmt.Errorf("LB name cannot be empty while configuring reject")
+	}
+
+	delSet, err := libovsdb.NewOvsSet([]string{LBOptionReject})
+	if err != nil {
+		return nil, err
+	}
+	mutations := []interface{}{libovsdb.NewMutation("options", opDelete, delSet)}
+	// disabling only clears the option, which defaults to false
+	if reject {
+		insMap, err := libovsdb.NewOvsMap(map[string]string{LBOptionReject: "true"})
+		if err != nil {
+			return nil, err
+		}
+		mutations = append(mutations, libovsdb.NewMutation("options", opInsert, insMap))
+	}
+
+	condition := libovsdb.NewCondition("name", "==", name)
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     TableLoadBalancer,
+		Mutations: mutations,
+		Where:     []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+// lbSetHairpinSNATIPImp sets options:hairpin_snat_ip of the LB to the given
+// IPv4 and IPv6 addresses, either of which may be empty, and clears it when
+// both are
+func (odbi *ovndb) lbSetHairpinSNATIPImp(name string, v4, v6 string) (*OvnCommand, error) {
+	if len(name) == 0 {
+		return nil, fmt.Errorf("LB name cannot be empty while configuring the hairpin SNAT IP")
+	}
+	if !odbi.columnSupported(TableLoadBalancer, "options") {
+		return nil, ErrorSchema
+	}
+	var ips []string
+	if len(v4) > 0 {
+		if ip := net.ParseIP(v4); ip == nil || ip.To4() == nil {
+			return nil, fmt.Errorf("invalid IPv4 hairpin SNAT IP %q", v4)
+		}
+		ips = append(ips, v4)
+	}
+	if len(v6) > 0 {
+		if ip := net.ParseIP(v6); ip == nil || ip.To4() != nil {
+			return nil, fmt.Errorf("invalid IPv6 hairpin SNAT IP %q", v6)
+		}
+		ips = append(ips, v6)
+	}
+
+	delSet, err := libovsdb.NewOvsSet([]string{LBOptionHairpinSNATIP})
+	if err != nil {
+		return nil, err
+	}
+	mutations := []interface{}{libovsdb.NewMutation("options", opDelete, delSet)}
+	if len(ips) > 0 {
+		insMap, err := libovsdb.NewOvsMap(map[string]string{LBOptionHairpinSNATIP: strings.Join(ips, " ")})
+		if err != nil {
+			return nil, err
+		}
+		mutations = append(mutations, libovsdb.NewMutation("options", opInsert, insMap))
+	}
+
+	condition := libovsdb.NewCondition("name", "==", name)
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     TableLoadBalancer,
+		Mutations: mutations,
+		Where:     []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lbGetRejectImp(name string) (bool, error) {
+	lbs, err := odbi.lbGetImp(name)
+	if err != nil {
+		return false, err
+	}
+	if len(lbs) == 0 {
+		return false, ErrorNotFound
+	} else if len(lbs) > 1 {
+		return false, ErrorDuplicateName
+	}
+	reject, _ := lbs[0].Options[LBOptionReject].(string)
+	return reject == "true", nil
+}
+
+func (odbi *ovndb) lbGetAttachmentsImp(name string) ([]string, []string, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	var lbUUID string
+	for uuid, drows := range odbi.cache[TableLoadBalancer] {
+		if rname, ok := drows.Fields["name"].(string); ok && rname == name {
+			if len(lbUUID) > 0 {
+				return nil, nil, ErrorDuplicateName
+			}
+			lbUUID = uuid
+		}
+	}
+	if len(lbUUID) == 0 {
+		return nil, nil, ErrorNotFound
+	}
+
+	// the LB is also attached to the rows referencing a group that holds it
+	holders := map[string]bool{lbUUID: true}
+	for uuid, drows := range odbi.cache[TableLoadBalancerGroup] {
+		for _, lb := range rowReferences(drows.Fields["load_balancer"]) {
+			if lb == lbUUID {
+				holders[uuid] = true
+				break
+			}
+		}
+	}
+
+	// names of the rows of table whose load_balancer or load_balancer_group
+	// column holds the LB or one of its groups
+	attachedTo := func(table string) []string {
+		names := []string{}
+		for _, drows := range odbi.cache[table] {
+			refs := append(rowReferences(drows.Fields["load_balancer"]), rowReferences(drows.Fields["load_balancer_group"])...)
+			for _, uuid := range refs {
+				if holders[uuid] {
+					names = append(names, rowString(drows, "name"))
+					break
+				}
+			}
+		}
+		sort.Strings(names)
+		return names
+	}
+	return attachedTo(TableLogicalSwitch), attachedTo(TableLogicalRouter), nil
+}
+
+func (odbi *ovndb) rowToLB(uuid string) (*LoadBalancer, error) {
+	cacheLoadBalancer, ok := odbi.cache[TableLoadBalancer][uuid]
+	if !ok {
+		return nil, ErrorSchema
+	}
+
+	lb := &LoadBalancer{
+		UUID:       uuid,
+		Version:    rowVersion(&cacheLoadBalancer),
+		Protocol:   rowString(cacheLoadBalancer, "protocol"),
+		Name:       rowString(cacheLoadBalancer, "name"),
+		VIPs:       rowMap(cacheLoadBalancer, "vips"),
+		Options:    rowMap(cacheLoadBalancer, "options"),
+		ExternalID: rowMap(cacheLoadBalancer, "external_ids"),
+	}
+
+	if fields, ok := cacheLoadBalancer.Fields["selection_fields"].(string); ok {
+		lb.SelectionFields = fields
+	}
+	lb.Template = lb.Options[LBOptionTemplate] == "true"
+	if ips, ok := lb.Options[LBOptionHairpinSNATIP].(string); ok {
+		lb.HairpinSNATIPs = strings.Fields(ips)
+	}
+	return lb, nil
+}
@@ -0,0 +1,194 @@
+/**
+ * Copyright (c) 2017 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"fmt"
+
+	"github.com/ebay/libovsdb"
+)
+
+// LoadBalancerGroup ovnnb item, only present in newer OVN schemas. Switches and
+// routers referencing a group share its load balancers without each having to
+// list them in their own load_balancer column.
+type LoadBalancerGroup struct {
+	UUID         string
+	Name         string
+	LoadBalancer []string
+}
+
+func (odbi *ovndb) rowToLBGroup(uuid string) *LoadBalancerGroup {
+	cacheLBGroup, ok := odbi.cache[TableLoadBalancerGroup][uuid]
+	if !ok {
+		return nil
+	}
+
+	group := &LoadBalancerGroup{
+		UUID: uuid,
+		Name: rowString(cacheLBGroup, "name"),
+	}
+	switch lbs := cacheLBGroup.Fields["load_balancer"].(type) {
+	case libovsdb.UUID:
+		group.LoadBalancer = []string{lbs.GoUUID}
+	case libovsdb.OvsSet:
+		group.LoadBalancer = odbi.ConvertGoSetToStringArray(lbs)
+	}
+	return group
+}
+
+// lbGroupSupported tells whether the server schema has the Load_Balancer_Group table
+func (odbi *ovndb) lbGroupSupported() bool {
+	return odbi.tableSupported(TableLoadBalancerGroup)
+}
+
+func (odbi *ovndb) lbGroupAddImp(name string, lbs []string) (*OvnCommand, error) {
+	if len(name) == 0 {
+		return nil, fmt.Errorf("load balancer group name cannot be empty")
+	}
+	if !odbi.lbGroupSupported() {
+		return nil, ErrorSchema
+	}
+	if uuid := odbi.getRowUUID(TableLoadBalancerGroup, OVNRow{"name": name}); len(uuid) > 0 {
+		return nil, ErrorExist
+	}
+
+	namedUUID, err := newRowUUID()
+	if err != nil {
+		return nil, err
+	}
+	row := make(OVNRow)
+	row["name"] = name
+	if len(lbs) > 0 {
+		lbUUIDs := make([]libovsdb.UUID, 0, len(lbs))
+		for _, lb := range lbs {
+			lbUUIDs = append(lbUUIDs, stringToGoUUID(lb))
+		}
+		lbSet, err := libovsdb.NewOvsSet(lbUUIDs)
+		if err != nil {
+			return nil, err
+		}
+		row["load_balancer"] = lbSet
+	}
+
+	insertOp := libovsdb.Operation{
+		Op:       opInsert,
+		Table:    TableLoadBalancerGroup,
+		Row:      row,
+		UUIDName: namedUUID,
+	}
+	operations := []libovsdb.Operation{insertOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lbGroupDelImp(name string) (*OvnCommand, error) {
+	if !odbi.lbGroupSupported() {
+		return nil, ErrorSchema
+	}
+	if uuid := odbi.getRowUUID(TableLoadBalancerGroup, OVNRow{"name": name}); len(uuid) == 0 {
+		return nil, ErrorNotFound
+	}
+
+	condition := libovsdb.NewCondition("name", "==", name)
+	deleteOp := libovsdb.Operation{
+		Op:    opDelete,
+		Table: TableLoadBalancerGroup,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{deleteOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lbGroupListImp() ([]*LoadBalancerGroup, error) {
+	if !odbi.lbGroupSupported() {
+		return nil, ErrorSchema
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheLBGroup := odbi.cache[TableLoadBalancerGroup]
+
+	listGroup := make([]*LoadBalancerGroup, 0, len(cacheLBGroup))
+	for uuid := range cacheLBGroup {
+		listGroup = append(listGroup, odbi.rowToLBGroup(uuid))
+	}
+	return listGroup, nil
+}
+
+// setLBGroupImp replaces the load_balancer_group column of the named switch or
+// router with the given groups, an empty list detaches all of them
+func (odbi *ovndb) setLBGroupImp(table, name string, groups []string) (*OvnCommand, error) {
+	if !odbi.lbGroupSupported() {
+		return nil, ErrorSchema
+	}
+	if uuid := odbi.getRowUUID(table, OVNRow{"name": name}); len(uuid) == 0 {
+		return nil, ErrorNotFound
+	}
+
+	groupUUIDs := make([]libovsdb.UUID, 0, len(groups))
+	for _, group := range groups {
+		uuid := odbi.getRowUUID(TableLoadBalancerGroup, OVNRow{"name": group})
+		if len(uuid) == 0 {
+			return nil, ErrorNotFound
+		}
+		groupUUIDs = append(groupUUIDs, stringToGoUUID(uuid))
+	}
+	groupSet, err := libovsdb.NewOvsSet(groupUUIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	row := make(OVNRow)
+	row["load_balancer_group"] = groupSet
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: table,
+		Row:   row,
+		Where: []interface{}{libovsdb.NewCondition("name", "==", name)},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+// getLBGroupsImp returns the names of the groups the named switch or router references
+func (odbi *ovndb) getLBGroupsImp(table, name string) ([]string, error) {
+	if !odbi.lbGroupSupported() {
+		return nil, ErrorSchema
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	for _, drows := range odbi.cache[table] {
+		if rname, ok := drows.Fields["name"].(string); !ok || rname != name {
+			continue
+		}
+		var groupUUIDs []string
+		switch groups := drows.Fields["load_balancer_group"].(type) {
+		case libovsdb.UUID:
+			groupUUIDs = []string{groups.GoUUID}
+		case libovsdb.OvsSet:
+			groupUUIDs = odbi.ConvertGoSetToStringArray(groups)
+		}
+		names := make([]string, 0, len(groupUUIDs))
+		for _, uuid := range groupUUIDs {
+			if group := odbi.rowToLBGroup(uuid); group != nil {
+				names = append(names, group.Name)
+			}
+		}
+		return names, nil
+	}
+	return nil, ErrorNotFound
+}
@@ -0,0 +1,228 @@
+package goovn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func newLBGroupTestDB() *ovndb {
+	return newTestDB(DBNB, map[string][]string{
+		TableLoadBalancerGroup: {"name", "load_balancer"},
+		TableLogicalSwitch:     {"name", "load_balancer_group"},
+		TableLogicalRouter:     {"name", "load_balancer_group"},
+	}, map[string]map[string]libovsdb.Row{
+		TableLoadBalancerGroup: {
+			"group1": {Fields: map[string]interface{}{"name": "clusterLBGroup", "load_balancer": libovsdb.UUID{GoUUID: "lb1"}}},
+			"group2": {Fields: map[string]interface{}{"name": "nodeLBGroup", "load_balancer": libovsdb.OvsSet{}}},
+		},
+		TableLogicalSwitch: {
+			"ls1": {Fields: map[string]interface{}{"name": "node1", "load_balancer_group": libovsdb.OvsSet{GoSet: []interface{}{libovsdb.UUID{GoUUID: "group1"}}}}},
+		},
+		TableLogicalRouter: {
+			"lr1": {Fields: map[string]interface{}{"name": "GR_node1"}},
+		},
+	})
+}
+
+func TestLBGroupAdd(t *testing.T) {
+	tests := []struct {
+		desc     string
+		name     string
+		lbs      []string
+		noRows   bool
+		noSchema bool
+		expOp    string
+		expErr   error
+	}{
+		{
+			desc:   "inserts the first group of an empty table",
+			name:   "newLBGroup",
+			lbs:    []string{"lb1"},
+			noRows: true,
+			expOp:  "insert Load_Balancer_Group load_balancer=[lb1] name=newLBGroup",
+		},
+		{
+			desc:  "inserts a group with its load balancers",
+			name:  "newLBGroup",
+			lbs:   []string{"lb1", "lb2"},
+			expOp: "insert Load_Balancer_Group load_balancer=[lb1 lb2] name=newLBGroup",
+		},
+		{
+			desc:  "inserts an empty group",
+			name:  "newLBGroup",
+			expOp: "insert Load_Balancer_Group name=newLBGroup",
+		},
+		{
+			desc:   "rejects a duplicate name",
+			name:   "clusterLBGroup",
+			expErr: ErrorExist,
+		},
+		{
+			desc:   "rejects an empty name",
+			expErr: fmt.Errorf("load balancer group name cannot be empty"),
+		},
+		{
+			desc:     "fails without the table in the schema",
+			name:     "newLBGroup",
+			noSchema: true,
+			expErr:   ErrorSchema,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			odbi := newLBGroupTestDB()
+			if tc.noRows || tc.noSchema {
+				delete(odbi.cache, TableLoadBalancerGroup)
+			}
+			if tc.noSchema {
+				delete(odbi.client.Schema[DBNB].Tables, TableLoadBalancerGroup)
+			}
+			cmd, err := odbi.LBGroupAdd(tc.name, tc.lbs)
+			if tc.expErr != nil {
+				assert.Equal(t, tc.expErr, err)
+				assert.Nil(t, cmd)
+				return
+			}
+			assert.Nil(t, err)
+			ops := cmd.Describe()
+			assert.Len(t, ops, 1)
+			assert.Equal(t, tc.expOp, ops[0].String())
+			assert.NotEmpty(t, cmd.Operations[0].UUIDName)
+		})
+	}
+}
+
+func TestLBGroupDel(t *testing.T) {
+	tests := []struct {
+		desc   string
+		name   string
+		expOp  string
+		expErr error
+	}{
+		{
+			desc:  "deletes the group by name",
+			name:  "nodeLBGroup",
+			expOp: "delete Load_Balancer_Group where name == nodeLBGroup",
+		},
+		{
+			desc:   "fails for a missing group",
+			name:   "missingLBGroup",
+			expErr: ErrorNotFound,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			cmd, err := newLBGroupTestDB().LBGroupDel(tc.name)
+			if tc.expErr != nil {
+				assert.Equal(t, tc.expErr, err)
+				assert.Nil(t, cmd)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, fmt.Sprint([]string{tc.expOp}), fmt.Sprint(cmd.Describe()))
+		})
+	}
+}
+
+func TestLBGroupList(t *testing.T) {
+	odbi := newLBGroupTestDB()
+	odbi.sortListResults = true
+	groups, err := odbi.LBGroupList()
+	assert.Nil(t, err)
+	assert.Equal(t, []*LoadBalancerGroup{
+		{UUID: "group1", Name: "clusterLBGroup", LoadBalancer: []string{"lb1"}},
+		{UUID: "group2", Name: "nodeLBGroup", LoadBalancer: []string{}},
+	}, groups)
+
+	delete(odbi.cache, TableLoadBalancerGroup)
+	groups, err = odbi.LBGroupList()
+	assert.Nil(t, err)
+	assert.Empty(t, groups)
+
+	delete(odbi.client.Schema[DBNB].Tables, TableLoadBalancerGroup)
+	_, err = odbi.LBGroupList()
+	assert.Equal(t, ErrorSchema, err)
+}
+
+func TestSetLBGroup(t *testing.T) {
+	tests := []struct {
+		desc   string
+		set    func(odbi *ovndb) (*OvnCommand, error)
+		expOp  string
+		expErr error
+	}{
+		{
+			desc: "replaces the groups of a switch",
+			set: func(odbi *ovndb) (*OvnCommand, error) {
+				return odbi.LSSetLBGroup("node1", []string{"clusterLBGroup", "nodeLBGroup"})
+			},
+			expOp: "update Logical_Switch load_balancer_group=[group1 group2] where name == node1",
+		},
+		{
+			desc: "detaches all the groups of a switch",
+			set: func(odbi *ovndb) (*OvnCommand, error) {
+				return odbi.LSSetLBGroup("node1", nil)
+			},
+			expOp: "update Logical_Switch load_balancer_group=[] where name == node1",
+		},
+		{
+			desc: "replaces the groups of a router",
+			set: func(odbi *ovndb) (*OvnCommand, error) {
+				return odbi.LRSetLBGroup("GR_node1", []string{"nodeLBGroup"})
+			},
+			expOp: "update Logical_Router load_balancer_group=[group2] where name == GR_node1",
+		},
+		{
+			desc: "fails for a missing switch",
+			set: func(odbi *ovndb) (*OvnCommand, error) {
+				return odbi.LSSetLBGroup("node2", []string{"clusterLBGroup"})
+			},
+			expErr: ErrorNotFound,
+		},
+		{
+			desc: "fails for a missing group",
+			set: func(odbi *ovndb) (*OvnCommand, error) {
+				return odbi.LRSetLBGroup("GR_node1", []string{"missingLBGroup"})
+			},
+			expErr: ErrorNotFound,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			cmd, err := tc.set(newLBGroupTestDB())
+			if tc.expErr != nil {
+				assert.Equal(t, tc.expErr, err)
+				assert.Nil(t, cmd)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, fmt.Sprint([]string{tc.expOp}), fmt.Sprint(cmd.Describe()))
+		})
+	}
+}
+
+func TestGetLBGroups(t *testing.T) {
+	odbi := newLBGroupTestDB()
+	groups, err := odbi.LSGetLBGroups("node1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"clusterLBGroup"}, groups)
+
+	groups, err = odbi.LRGetLBGroups("GR_node1")
+	assert.Nil(t, err)
+	assert.Empty(t, groups)
+
+	_, err = odbi.LSGetLBGroups("node2")
+	assert.Equal(t, ErrorNotFound, err)
+
+	delete(odbi.cache, TableLoadBalancerGroup)
+	groups, err = odbi.LRGetLBGroups("GR_node1")
+	assert.Nil(t, err)
+	assert.Empty(t, groups)
+
+	delete(odbi.client.Schema[DBNB].Tables, TableLoadBalancerGroup)
+	_, err = odbi.LRGetLBGroups("GR_node1")
+	assert.Equal(t, ErrorSchema, err)
+}
@@ -0,0 +1,193 @@
+package goovn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigureServiceReject(t *testing.T) {
+	tests := []struct {
+		desc   string
+		name   string
+		reject bool
+		expOp  string
+		expErr error
+	}{
+		{
+			desc:   "enabling replaces the option with true",
+			name:   "lb1",
+			reject: true,
+			expOp:  "mutate Load_Balancer options delete [reject] options insert map[reject:true] where name == lb1",
+		},
+		{
+			desc:  "disabling clears the option",
+			name:  "lb1",
+			expOp: "mutate Load_Balancer options delete [reject] where name == lb1",
+		},
+		{
+			desc:   "empty name",
+			reject: true,
+			expErr: fmt.Errorf("LB name cannot be empty while configuring reject"),
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			odbi := &ovndb{db: DBNB}
+			cmd, err := odbi.ConfigureServiceReject(tc.name, tc.reject)
+			if tc.expErr != nil {
+				assert.Equal(t, tc.expErr, err)
+				assert.Nil(t, cmd)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, fmt.Sprint([]string{tc.expOp}), fmt.Sprint(cmd.Describe()))
+		})
+	}
+}
+
+func TestLBGetReject(t *testing.T) {
+	lbRow := func(name string, options map[interface{}]interface{}) libovsdb.Row {
+		return libovsdb.Row{Fields: map[string]interface{}{
+			"name":    name,
+			"options": libovsdb.OvsMap{GoMap: options},
+		}}
+	}
+	odbi := &ovndb{
+		db: DBNB,
+		cache: map[string]map[string]libovsdb.Row{
+			TableLoadBalancer: {
+				"lb1": lbRow("lb-reject", map[interface{}]interface{}{"reject": "true"}),
+				"lb2": lbRow("lb-no-reject", map[interface{}]interface{}{"reject": "false"}),
+				"lb3": lbRow("lb-default", map[interface{}]interface{}{"skip_snat": "true"}),
+				"lb4": {Fields: map[string]interface{}{"name": "lb-no-options"}},
+				"lb5": lbRow("lb-dup", nil),
+				"lb6": lbRow("lb-dup", nil),
+			},
+		},
+	}
+
+	tests := []struct {
+		desc      string
+		name      string
+		expReject bool
+		expErr    error
+	}{
+		{
+			desc:      "reject enabled",
+			name:      "lb-reject",
+			expReject: true,
+		},
+		{
+			desc: "reject disabled",
+			name: "lb-no-reject",
+		},
+		{
+			desc: "missing option reads as disabled",
+			name: "lb-default",
+		},
+		{
+			desc: "missing options column reads as disabled",
+			name: "lb-no-options",
+		},
+		{
+			desc:   "missing load balancer",
+			name:   "lb-missing",
+			expErr: ErrorNotFound,
+		},
+		{
+			desc:   "duplicate load balancer name",
+			name:   "lb-dup",
+			expErr: ErrorDuplicateName,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			reject, err := odbi.LBGetReject(tc.name)
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expReject, reject)
+		})
+	}
+}
+
+func TestLBGetAttachments(t *testing.T) {
+	odbi := &ovndb{
+		db: DBNB,
+		cache: map[string]map[string]libovsdb.Row{
+			TableLoadBalancer: {
+				"lb1": {Fields: map[string]interface{}{"name": "lb-direct"}},
+				"lb2": {Fields: map[string]interface{}{"name": "lb-grouped"}},
+				"lb3": {Fields: map[string]interface{}{"name": "lb-both"}},
+				"lb4": {Fields: map[string]interface{}{"name": "lb-unused"}},
+				"lb5": {Fields: map[string]interface{}{"name": "lb-dup"}},
+				"lb6": {Fields: map[string]interface{}{"name": "lb-dup"}},
+			},
+			TableLoadBalancerGroup: {
+				"group1": {Fields: map[string]interface{}{"name": "clusterLBGroup", "load_balancer": lbSet("lb2", "lb3")}},
+				"group2": {Fields: map[string]interface{}{"name": "nodeLBGroup", "load_balancer": libovsdb.UUID{GoUUID: "lb2"}}},
+			},
+			TableLogicalSwitch: {
+				"ls1": {Fields: map[string]interface{}{"name": "node1", "load_balancer": lbSet("lb1", "lb3"), "load_balancer_group": lbSet("group1")}},
+				"ls2": {Fields: map[string]interface{}{"name": "node2", "load_balancer": libovsdb.UUID{GoUUID: "lb1"}}},
+				"ls3": {Fields: map[string]interface{}{"name": "join"}},
+			},
+			TableLogicalRouter: {
+				"lr1": {Fields: map[string]interface{}{"name": "GR_node1", "load_balancer_group": libovsdb.UUID{GoUUID: "group2"}}},
+				"lr2": {Fields: map[string]interface{}{"name": "GR_node2", "load_balancer_group": lbSet("group1")}},
+			},
+		},
+	}
+
+	tests := []struct {
+		desc        string
+		name        string
+		expSwitches []string
+		expRouters  []string
+		expErr      error
+	}{
+		{
+			desc:        "attached through the load_balancer column",
+			name:        "lb-direct",
+			expSwitches: []string{"node1", "node2"},
+			expRouters:  []string{},
+		},
+		{
+			desc:        "attached through load balancer groups",
+			name:        "lb-grouped",
+			expSwitches: []string{"node1"},
+			expRouters:  []string{"GR_node1", "GR_node2"},
+		},
+		{
+			desc:        "attached both directly and through a group lists each row once",
+			name:        "lb-both",
+			expSwitches: []string{"node1"},
+			expRouters:  []string{"GR_node2"},
+		},
+		{
+			desc:        "not attached",
+			name:        "lb-unused",
+			expSwitches: []string{},
+			expRouters:  []string{},
+		},
+		{
+			desc:   "missing load balancer",
+			name:   "lb-missing",
+			expErr: ErrorNotFound,
+		},
+		{
+			desc:   "duplicate load balancer name",
+			name:   "lb-dup",
+			expErr: ErrorDuplicateName,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			switches, routers, err := odbi.LBGetAttachments(tc.name)
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expSwitches, switches)
+			assert.Equal(t, tc.expRouters, routers)
+		})
+	}
+}
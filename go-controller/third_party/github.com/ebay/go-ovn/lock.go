@@ -0,0 +1,165 @@
+/**
+ * Copyright (c) 2017 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"k8s.io/klog/v2"
+)
+
+func (odbi *ovndb) lockImp(id string) error {
+	if odbi.snapshot {
+		return ErrorSnapshot
+	}
+	client, err := odbi.getClient()
+	if err != nil {
+		return err
+	}
+
+	added := odbi.addLock(id)
+
+	acquired, err := client.Lock(id)
+	if err != nil {
+		if added {
+			odbi.removeLock(id)
+		}
+		return err
+	}
+	// otherwise the server sends a "locked" notification once it is granted
+	if acquired {
+		odbi.setLockState(id, true)
+	}
+	return nil
+}
+
+func (odbi *ovndb) stealImp(id string) error {
+	if odbi.snapshot {
+		return ErrorSnapshot
+	}
+	client, err := odbi.getClient()
+	if err != nil {
+		return err
+	}
+
+	added := odbi.addLock(id)
+
+	if err := client.Steal(id); err != nil {
+		if added {
+			odbi.removeLock(id)
+		}
+		return err
+	}
+	odbi.setLockState(id, true)
+	return nil
+}
+
+func (odbi *ovndb) unlockImp(id string) error {
+	if odbi.snapshot {
+		return ErrorSnapshot
+	}
+	if !odbi.removeLock(id) {
+		return ErrorNotFound
+	}
+
+	client, err := odbi.getClient()
+	if err != nil {
+		// the server releases all locks of a closed connection
+		return nil
+	}
+	return client.Unlock(id)
+}
+
+// addLock records a lock request and tells whether it was not requested yet.
+// Locks are not held until the server grants them.
+func (odbi *ovndb) addLock(id string) bool {
+	odbi.locksMutex.Lock()
+	defer odbi.locksMutex.Unlock()
+	if odbi.locks == nil {
+		odbi.locks = make(map[string]bool)
+	}
+	if _, ok := odbi.locks[id]; ok {
+		return false
+	}
+	odbi.locks[id] = false
+	return true
+}
+
+// removeLock forgets a lock request and tells whether it existed
+func (odbi *ovndb) removeLock(id string) bool {
+	odbi.locksMutex.Lock()
+	defer odbi.locksMutex.Unlock()
+	_, ok := odbi.locks[id]
+	delete(odbi.locks, id)
+	return ok
+}
+
+// setLockState records whether a requested lock is held and runs the lock
+// callback when that changed. Locks that were not requested are ignored.
+func (odbi *ovndb) setLockState(id string, held bool) {
+	odbi.locksMutex.Lock()
+	prev, ok := odbi.locks[id]
+	if !ok || prev == held {
+		odbi.locksMutex.Unlock()
+		return
+	}
+	odbi.locks[id] = held
+	odbi.locksMutex.Unlock()
+
+	if held {
+		klog.Infof("[%s] acquired lock %q", odbi.db, id)
+	} else {
+		klog.Warningf("[%s] lost lock %q", odbi.db, id)
+	}
+	if odbi.lockCB != nil {
+		odbi.lockCB(id, held)
+	}
+}
+
+// locksLost marks all held locks as lost, the server releases them when the
+// connection goes away.
+func (odbi *ovndb) locksLost() {
+	for _, id := range odbi.lockIDs() {
+		odbi.setLockState(id, false)
+	}
+}
+
+// relock requests again all the locks that were requested before a reconnect
+func (odbi *ovndb) relock() {
+	for _, id := range odbi.lockIDs() {
+		if err := odbi.lockImp(id); err != nil {
+			klog.Errorf("[%s] failed to request lock %q after reconnect: %v", odbi.db, id, err)
+		}
+	}
+}
+
+func (odbi *ovndb) lockIDs() []string {
+	odbi.locksMutex.Lock()
+	defer odbi.locksMutex.Unlock()
+	ids := make([]string, 0, len(odbi.locks))
+	for id := range odbi.locks {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// lockNotificationID returns the lock id carried by a locked or stolen notification
+func lockNotificationID(params []interface{}) (string, bool) {
+	if len(params) == 0 {
+		return "", false
+	}
+	id, ok := params[0].(string)
+	return id, ok
+}
@@ -0,0 +1,210 @@
+package goovn
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// lockEvents records the calls of the lock callback
+type lockEvents struct {
+	mutex  sync.Mutex
+	events []string
+}
+
+func (l *lockEvents) callback(id string, acquired bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	state := "lost"
+	if acquired {
+		state = "acquired"
+	}
+	l.events = append(l.events, id+" "+state)
+}
+
+// take returns the events recorded since the last call
+func (l *lockEvents) take() []string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	events := l.events
+	l.events = nil
+	return events
+}
+
+// lockServer returns a server granting the locks of granted right away, and
+// recording the ids of the lock requests
+func lockServer(t *testing.T, granted map[string]bool) (*fakeServer, func() []string) {
+	var mutex sync.Mutex
+	var requested []string
+	lockID := func(params []interface{}) string {
+		id, _ := lockNotificationID(params)
+		return id
+	}
+	server := newFakeServer(t, DBNB, `{"Logical_Switch": {"columns": {"name": {"type": "string"}}}}`,
+		map[string]func([]interface{}) (interface{}, error){
+			"lock": func(params []interface{}) (interface{}, error) {
+				id := lockID(params)
+				if id == "failing" {
+					return nil, fmt.Errorf("lock failed")
+				}
+				mutex.Lock()
+				defer mutex.Unlock()
+				requested = append(requested, id)
+				return map[string]bool{"locked": granted[id]}, nil
+			},
+			"steal": func(params []interface{}) (interface{}, error) {
+				return map[string]bool{"locked": true}, nil
+			},
+			"unlock": func(params []interface{}) (interface{}, error) {
+				return map[string]interface{}{}, nil
+			},
+		})
+	return server, func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		ids := requested
+		requested = nil
+		sort.Strings(ids)
+		return ids
+	}
+}
+
+func TestLockNotificationID(t *testing.T) {
+	tests := []struct {
+		desc   string
+		params []interface{}
+		expID  string
+		expOK  bool
+	}{
+		{
+			desc:   "lock id",
+			params: []interface{}{"ovn_northd"},
+			expID:  "ovn_northd",
+			expOK:  true,
+		},
+		{
+			desc: "no params",
+		},
+		{
+			desc:   "id is not a string",
+			params: []interface{}{float64(1)},
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			id, ok := lockNotificationID(tc.params)
+			assert.Equal(t, tc.expID, id)
+			assert.Equal(t, tc.expOK, ok)
+		})
+	}
+}
+
+func TestLockState(t *testing.T) {
+	events := &lockEvents{}
+	odbi := newOvndb(&Config{OnLockChange: events.callback}, DBNB)
+	notify := ovnNotifier{odbi}
+	assert.True(t, odbi.addLock("lock1"))
+	assert.False(t, odbi.addLock("lock1"))
+	assert.True(t, odbi.addLock("lock2"))
+
+	notify.Locked([]interface{}{"lock1"})
+	assert.Equal(t, []string{"lock1 acquired"}, events.take())
+	assert.Equal(t, map[string]bool{"lock1": true, "lock2": false}, odbi.locks)
+
+	// only the changes are reported
+	notify.Locked([]interface{}{"lock1"})
+	assert.Empty(t, events.take())
+
+	notify.Stolen([]interface{}{"lock1"})
+	assert.Equal(t, []string{"lock1 lost"}, events.take())
+	assert.Equal(t, map[string]bool{"lock1": false, "lock2": false}, odbi.locks)
+
+	// the locks not requested are ignored, as are invalid notifications
+	notify.Locked([]interface{}{"lock3"})
+	notify.Locked(nil)
+	assert.Empty(t, events.take())
+	assert.Equal(t, map[string]bool{"lock1": false, "lock2": false}, odbi.locks)
+
+	// only the locks held are lost with the connection, but all stay requested
+	notify.Locked([]interface{}{"lock2"})
+	assert.Equal(t, []string{"lock2 acquired"}, events.take())
+	odbi.locksLost()
+	assert.Equal(t, []string{"lock2 lost"}, events.take())
+	assert.Equal(t, map[string]bool{"lock1": false, "lock2": false}, odbi.locks)
+
+	assert.True(t, odbi.removeLock("lock1"))
+	assert.False(t, odbi.removeLock("lock1"))
+	assert.Equal(t, map[string]bool{"lock2": false}, odbi.locks)
+}
+
+func TestLockUnlock(t *testing.T) {
+	server, requested := lockServer(t, map[string]bool{"granted": true})
+	defer server.close()
+	events := &lockEvents{}
+	odbi := server.connect(t, &Config{OnLockChange: events.callback}, DBNB)
+	defer odbi.close()
+
+	// granted right away
+	assert.Nil(t, odbi.Lock("granted"))
+	assert.Equal(t, []string{"granted acquired"}, events.take())
+
+	// granted later by a locked notification
+	assert.Nil(t, odbi.Lock("pending"))
+	assert.Empty(t, events.take())
+	ovnNotifier{odbi}.Locked([]interface{}{"pending"})
+	assert.Equal(t, []string{"pending acquired"}, events.take())
+	assert.Equal(t, []string{"granted", "pending"}, requested())
+
+	assert.Nil(t, odbi.Steal("stolen"))
+	assert.Equal(t, []string{"stolen acquired"}, events.take())
+
+	// a failed request is forgotten
+	assert.EqualError(t, odbi.Lock("failing"), "lock failed")
+	assert.Equal(t, map[string]bool{"granted": true, "pending": true, "stolen": true}, odbi.locks)
+
+	assert.Nil(t, odbi.Unlock("granted"))
+	assert.Equal(t, 1, server.callCount("unlock"))
+	assert.Equal(t, map[string]bool{"pending": true, "stolen": true}, odbi.locks)
+	// releasing a lock does not report it as lost
+	assert.Empty(t, events.take())
+
+	// unknown locks are not sent to the server
+	assert.Equal(t, ErrorNotFound, odbi.Unlock("unknown"))
+	assert.Equal(t, ErrorNotFound, odbi.Unlock("granted"))
+	assert.Equal(t, 1, server.callCount("unlock"))
+}
+
+func TestRelock(t *testing.T) {
+	server, requested := lockServer(t, map[string]bool{"lock1": true, "lock3": true})
+	defer server.close()
+	events := &lockEvents{}
+	odbi := server.connect(t, &Config{OnLockChange: events.callback}, DBNB)
+	defer odbi.close()
+
+	assert.Nil(t, odbi.Lock("lock1"))
+	assert.Nil(t, odbi.Lock("lock2"))
+	assert.Nil(t, odbi.Steal("lock3"))
+	assert.Equal(t, []string{"lock1", "lock2"}, requested())
+	assert.Equal(t, []string{"lock1 acquired", "lock3 acquired"}, events.take())
+
+	// the server releases the locks with the connection
+	odbi.close()
+	ovnNotifier{odbi}.Disconnected(odbi.client)
+	lost := events.take()
+	sort.Strings(lost)
+	assert.Equal(t, []string{"lock1 lost", "lock3 lost"}, lost)
+	assert.Equal(t, map[string]bool{"lock1": false, "lock2": false, "lock3": false}, odbi.locks)
+
+	// every lock requested, held or not, is requested again once reconnected
+	reconnected := server.connect(t, &Config{}, DBNB)
+	odbi.client = reconnected.client
+	odbi.relock()
+	assert.Equal(t, []string{"lock1", "lock2", "lock3"}, requested())
+	acquired := events.take()
+	sort.Strings(acquired)
+	assert.Equal(t, []string{"lock1 acquired", "lock3 acquired"}, acquired)
+	assert.Equal(t, map[string]bool{"lock1": true, "lock2": false, "lock3": true}, odbi.locks)
+}
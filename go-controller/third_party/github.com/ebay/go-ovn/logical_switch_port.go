@@ -0,0 +1,1149 @@
+/**
+ * Copyright (c) 2017 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/ebay/libovsdb"
+)
+
+// LogicalSwitchPort ovnnb item
+type LogicalSwitchPort struct {
+	UUID             string
+	Version          string
+	Name             string
+	Type             string
+	Options          map[interface{}]interface{}
+	Addresses        []string
+	DynamicAddresses string
+	PortSecurity     []string
+	DHCPv4Options    string
+	DHCPv6Options    string
+	ExternalID       map[interface{}]interface{}
+	HAChassisGroup   string
+	// MAC and IPs are parsed from the dynamic addresses if set, otherwise
+	// from the first entry of Addresses. Both are nil when the port has no
+	// fixed address, i.e. "dynamic", "router" or "unknown", or a malformed one.
+	MAC net.HardwareAddr
+	IPs []net.IP
+	// NATAddresses are the entries of the nat-addresses option of a router
+	// type port, i.e. "router" or a MAC followed by IPs, nil if it is unset
+	NATAddresses []string
+}
+
+// LSPStatus is the binding status of a logical switch port
+type LSPStatus struct {
+	Name string
+	// Up is nil until the up column of the port has been set
+	Up *bool
+	// Chassis is the name of the chassis the port is bound to, from the
+	// Port_Binding of the port in the southbound client of Config.SBClient.
+	// It is empty while the port is unbound, and without such a client.
+	Chassis string
+}
+
+// LSPSpec describes the full configuration of a logical switch port so that it
+// can be written with a single update operation. Nil fields are left untouched.
+type LSPSpec struct {
+	Type             *string
+	Enabled          *bool
+	Addresses        []string
+	DynamicAddresses *string
+	PortSecurity     []string
+	Options          map[string]string
+	ExternalIDs      map[string]string
+}
+
+// LSPTypeExternal is the type of ports that are bound to an HA chassis group
+// instead of a VIF, e.g. for hardware offloaded VFs.
+const LSPTypeExternal = "external"
+
+// LSPOptionRequestedChassis is the LSP option that binds the port to a chassis.
+// It holds a comma-separated list of chassis names in order of preference.
+const LSPOptionRequestedChassis = "requested-chassis"
+
+// ParseRequestedChassis splits a requested-chassis option value in to the
+// ordered list of chassis names, dropping empty entries.
+func ParseRequestedChassis(value string) []string {
+	chassis := []string{}
+	for _, c := range strings.Split(value, ",") {
+		if c = strings.TrimSpace(c); len(c) > 0 {
+			chassis = append(chassis, c)
+		}
+	}
+	return chassis
+}
+
+// LSPOptionARPProxy is the option of a router type LSP listing, space-separated,
+// an optional MAC followed by the IPs and CIDRs the port answers ARP and ND
+// requests for.
+const LSPOptionARPProxy = "arp_proxy"
+
+// ParseARPProxy splits an arp_proxy option value in to its entries, dropping
+// empty ones.
+func ParseARPProxy(value string) []string {
+	return strings.Fields(value)
+}
+
+// validateARPProxy checks that every entry is an IP or a CIDR, except for the
+// first which may also be a MAC
+func validateARPProxy(addrs []string) error {
+	for i, addr := range addrs {
+		if net.ParseIP(addr) != nil {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(addr); err == nil {
+			continue
+		}
+		if _, err := net.ParseMAC(addr); err == nil && i == 0 {
+			continue
+		}
+		return fmt.Errorf("invalid arp_proxy entry %q", addr)
+	}
+	return nil
+}
+
+// LSPOptionNATAddresses is the option of a router type LSP listing the
+// addresses ovn-controller sends gratuitous ARPs for: "router", for the NAT
+// addresses of the gateway router the port peers with, or a MAC followed by
+// IPs, space-separated.
+const LSPOptionNATAddresses = "nat-addresses"
+
+// LSPNATAddressesRouter is the nat-addresses value advertising the NAT
+// addresses of the peer gateway router
+const LSPNATAddressesRouter = "router"
+
+// validateNATAddresses checks that the entries are either "router" alone or a
+// MAC followed by at least one IP
+func validateNATAddresses(addrs []string) error {
+	if len(addrs) == 1 && addrs[0] == LSPNATAddressesRouter {
+		return nil
+	}
+	if len(addrs) < 2 {
+		return fmt.Errorf("nat-addresses must be %q or a MAC followed by IPs, got %q",
+			LSPNATAddressesRouter, strings.Join(addrs, " "))
+	}
+	if _, err := net.ParseMAC(addrs[0]); err != nil {
+		return fmt.Errorf("invalid nat-addresses MAC %q", addrs[0])
+	}
+	for _, addr := range addrs[1:] {
+		if net.ParseIP(addr) == nil {
+			return fmt.Errorf("invalid nat-addresses IP %q", addr)
+		}
+	}
+	return nil
+}
+
+// parseLSPAddresses parses the MAC and IPs of an LSP addresses entry, e.g.
+// "0a:58:0a:80:00:05 10.128.0.5". It returns nils for the special values such
+// as "dynamic", "router" and "unknown", and for malformed entries.
+func parseLSPAddresses(addresses string) (net.HardwareAddr, []net.IP) {
+	fields := strings.Fields(addresses)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	mac, err := net.ParseMAC(fields[0])
+	if err != nil {
+		return nil, nil
+	}
+	var ips []net.IP
+	for _, field := range fields[1:] {
+		ip := net.ParseIP(field)
+		if ip == nil {
+			return nil, nil
+		}
+		ips = append(ips, ip)
+	}
+	return mac, ips
+}
+
+func (odbi *ovndb) lspAddImp(lsw, lswUUID, lsp string) (*OvnCommand, error) {
+	if uuid := odbi.getRowUUID(TableLogicalSwitchPort, OVNRow{"name": lsp}); len(uuid) > 0 {
+		return nil, ErrorExist
+	}
+	return odbi.lspInsertImp(lsw, lswUUID, lsp)
+}
+
+// lspInsertImp adds a port to a switch without checking whether a port with
+// the same name exists, for commands deleting it first
+func (odbi *ovndb) lspInsertImp(lsw, lswUUID, lsp string) (*OvnCommand, error) {
+	namedUUID, err := newRowUUID()
+	if err != nil {
+		return nil, err
+	}
+	row := make(OVNRow)
+	row["name"] = lsp
+
+	insertOp := libovsdb.Operation{
+		Op:       opInsert,
+		Table:    TableLogicalSwitchPort,
+		Row:      row,
+		UUIDName: namedUUID,
+	}
+
+	mutateUUID := []libovsdb.UUID{stringToGoUUID(namedUUID)}
+	mutateSet, err := libovsdb.NewOvsSet(mutateUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	mutation := libovsdb.NewMutation("ports", opInsert, mutateSet)
+	condition := libovsdb.NewCondition("name", "==", lsw)
+	if lswUUID != "" {
+		condition = libovsdb.NewCondition("_uuid", "==", stringToGoUUID(lswUUID))
+	}
+
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     TableLogicalSwitch,
+		Mutations: []interface{}{mutation},
+		Where:     []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{insertOp, mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lspAddExternalImp(lsw, lsp, haChassisGroup string) (*OvnCommand, error) {
+	if len(lsw) == 0 || len(lsp) == 0 || len(haChassisGroup) == 0 {
+		return nil, fmt.Errorf("LS, LSP and HA chassis group names cannot be empty while adding an external LSP")
+	}
+
+	groupUUID := odbi.getRowUUID(TableHAChassisGroup, OVNRow{"name": haChassisGroup})
+	if len(groupUUID) == 0 {
+		return nil, ErrorNotFound
+	}
+
+	cmd, err := odbi.lspAddImp(lsw, "", lsp)
+	if err != nil {
+		return nil, err
+	}
+	// the insert of the port is the first operation of the command
+	cmd.Operations[0].Row["type"] = LSPTypeExternal
+	cmd.Operations[0].Row["ha_chassis_group"] = stringToGoUUID(groupUUID)
+	return cmd, nil
+}
+
+func (odbi *ovndb) lspDelImp(lsp string) (*OvnCommand, error) {
+	row := make(OVNRow)
+	row["name"] = lsp
+
+	lspUUID := odbi.getRowUUID(TableLogicalSwitchPort, row)
+	if len(lspUUID) == 0 {
+		return nil, ErrorNotFound
+	}
+
+	mutateUUID := []libovsdb.UUID{stringToGoUUID(lspUUID)}
+	mutateSet, err := libovsdb.NewOvsSet(mutateUUID)
+	if err != nil {
+		return nil, err
+	}
+	mutation := libovsdb.NewMutation("ports", opDelete, mutateSet)
+	ucondition, err := odbi.getRowUUIDContainsUUID(TableLogicalSwitch, "ports", lspUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	mucondition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(ucondition))
+	// simple mutate operation
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     TableLogicalSwitch,
+		Mutations: []interface{}{mutation},
+		Where:     []interface{}{mucondition},
+	}
+	operations := []libovsdb.Operation{mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lspSetAddressImp(lsp string, addr ...string) (*OvnCommand, error) {
+	row := make(OVNRow)
+	addresses, err := libovsdb.NewOvsSet(addr)
+	if err != nil {
+		return nil, err
+	}
+	row["addresses"] = addresses
+	condition := libovsdb.NewCondition("name", "==", lsp)
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableLogicalSwitchPort,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lspSetPortSecurityImp(lsp string, security ...string) (*OvnCommand, error) {
+	row := make(OVNRow)
+	port_security, err := libovsdb.NewOvsSet(security)
+	if err != nil {
+		return nil, err
+	}
+	row["port_security"] = port_security
+	condition := libovsdb.NewCondition("name", "==", lsp)
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableLogicalSwitchPort,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lspSetTypeImp(lsp string, portType string) (*OvnCommand, error) {
+	row := make(OVNRow)
+	row["type"] = portType
+	condition := libovsdb.NewCondition("name", "==", lsp)
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableLogicalSwitchPort,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lspSetDHCPv4OptionsImp(lsp string, uuid string) (*OvnCommand, error) {
+	row := make(OVNRow)
+	row["dhcpv4_options"] = stringToGoUUID(uuid)
+	condition := libovsdb.NewCondition("name", "==", lsp)
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableLogicalSwitchPort,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lspGetDHCPv4OptionsImp(lsp string) (*DHCPOptions, error) {
+	lp, err := odbi.lspGetImp(lsp)
+	if err != nil {
+		return nil, err
+	}
+	return odbi.rowToDHCPOptions(lp.DHCPv4Options), nil
+}
+
+func (odbi *ovndb) lspSetDHCPv6OptionsImp(lsp string, options string) (*OvnCommand, error) {
+	mutation := libovsdb.NewMutation("dhcpv6_options", opInsert, options)
+	condition := libovsdb.NewCondition("name", "==", lsp)
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     TableLogicalSwitchPort,
+		Mutations: []interface{}{mutation},
+		Where:     []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lspGetDHCPv6OptionsImp(lsp string) (*DHCPOptions, error) {
+	lp, err := odbi.lspGetImp(lsp)
+	if err != nil {
+		return nil, err
+	}
+	return odbi.rowToDHCPOptions(lp.DHCPv6Options), nil
+}
+
+func (odbi *ovndb) lspSetOptionsImp(lsp string, options map[string]string) (*OvnCommand, error) {
+	if options == nil {
+		return nil, ErrorOption
+	}
+
+	if len(lsp) == 0 {
+		return nil, fmt.Errorf("LSP name cannot be empty while setting options")
+	}
+
+	optionsMap, err := libovsdb.NewOvsMap(options)
+	if err != nil {
+		return nil, err
+	}
+
+	row := make(OVNRow)
+	row["options"] = optionsMap
+
+	condition := libovsdb.NewCondition("name", "==", lsp)
+
+	// simple mutate operation
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableLogicalSwitchPort,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lspGetOptionsImp(lsp string) (map[string]string, error) {
+	lp, err := odbi.lspGetImp(lsp)
+	if err != nil {
+		return nil, err
+	}
+	options := make(map[string]string)
+	for k, v := range lp.Options {
+		key, keyOk := k.(string)
+		value, valueOk := v.(string)
+		if !keyOk || !valueOk {
+			continue
+		}
+		switch key {
+		case LSPOptionRequestedChassis:
+			value = strings.Join(ParseRequestedChassis(value), ",")
+		case LSPOptionARPProxy:
+			value = strings.Join(ParseARPProxy(value), " ")
+		case LSPOptionNATAddresses:
+			value = strings.Join(strings.Fields(value), " ")
+		}
+		options[key] = value
+	}
+	return options, nil
+}
+
+func (odbi *ovndb) lspSetRequestedChassisImp(lsp string, chassis []string) (*OvnCommand, error) {
+	if len(lsp) == 0 {
+		return nil, fmt.Errorf("LSP name cannot be empty while setting requested chassis")
+	}
+
+	names := []string{}
+	for _, c := range chassis {
+		if strings.Contains(c, ",") {
+			return nil, fmt.Errorf("invalid chassis name %q for LSP %s", c, lsp)
+		}
+		if c = strings.TrimSpace(c); len(c) > 0 {
+			names = append(names, c)
+		}
+	}
+
+	delSet, err := libovsdb.NewOvsSet([]string{LSPOptionRequestedChassis})
+	if err != nil {
+		return nil, err
+	}
+	mutations := []interface{}{libovsdb.NewMutation("options", opDelete, delSet)}
+	// an empty list only clears the option
+	if len(names) > 0 {
+		insMap, err := libovsdb.NewOvsMap(map[string]string{LSPOptionRequestedChassis: strings.Join(names, ",")})
+		if err != nil {
+			return nil, err
+		}
+		mutations = append(mutations, libovsdb.NewMutation("options", opInsert, insMap))
+	}
+
+	condition := libovsdb.NewCondition("name", "==", lsp)
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     TableLogicalSwitchPort,
+		Mutations: mutations,
+		Where:     []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lspSetARPProxyImp(lsp string, addrs []string) (*OvnCommand, error) {
+	if len(lsp) == 0 {
+		return nil, fmt.Errorf("LSP name cannot be empty while setting arp_proxy")
+	}
+
+	entries := []string{}
+	for _, addr := range addrs {
+		entries = append(entries, ParseARPProxy(addr)...)
+	}
+	if err := validateARPProxy(entries); err != nil {
+		return nil, fmt.Errorf("cannot set arp_proxy of LSP %s: %v", lsp, err)
+	}
+
+	delSet, err := libovsdb.NewOvsSet([]string{LSPOptionARPProxy})
+	if err != nil {
+		return nil, err
+	}
+	mutations := []interface{}{libovsdb.NewMutation("options", opDelete, delSet)}
+	// an empty list only clears the option
+	if len(entries) > 0 {
+		insMap, err := libovsdb.NewOvsMap(map[string]string{LSPOptionARPProxy: strings.Join(entries, " ")})
+		if err != nil {
+			return nil, err
+		}
+		mutations = append(mutations, libovsdb.NewMutation("options", opInsert, insMap))
+	}
+
+	condition := libovsdb.NewCondition("name", "==", lsp)
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     TableLogicalSwitchPort,
+		Mutations: mutations,
+		Where:     []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lspSetNATAddressesImp(lsp string, addrs []string) (*OvnCommand, error) {
+	if len(lsp) == 0 {
+		return nil, fmt.Errorf("LSP name cannot be empty while setting nat-addresses")
+	}
+
+	entries := []string{}
+	for _, addr := range addrs {
+		entries = append(entries, strings.Fields(addr)...)
+	}
+	if len(entries) > 0 {
+		if err := validateNATAddresses(entries); err != nil {
+			return nil, fmt.Errorf("cannot set nat-addresses of LSP %s: %v", lsp, err)
+		}
+	}
+
+	delSet, err := libovsdb.NewOvsSet([]string{LSPOptionNATAddresses})
+	if err != nil {
+		return nil, err
+	}
+	mutations := []interface{}{libovsdb.NewMutation("options", opDelete, delSet)}
+	// an empty list only clears the option
+	if len(entries) > 0 {
+		insMap, err := libovsdb.NewOvsMap(map[string]string{LSPOptionNATAddresses: strings.Join(entries, " ")})
+		if err != nil {
+			return nil, err
+		}
+		mutations = append(mutations, libovsdb.NewMutation("options", opInsert, insMap))
+	}
+
+	condition := libovsdb.NewCondition("name", "==", lsp)
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     TableLogicalSwitchPort,
+		Mutations: mutations,
+		Where:     []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lspUpdateAddressesImp(lsp string, mac net.HardwareAddr, ips []*net.IPNet, portSecurity []string) (*OvnCommand, error) {
+	if len(lsp) == 0 {
+		return nil, fmt.Errorf("LSP name cannot be empty while updating addresses")
+	}
+	if len(mac) == 0 {
+		return nil, fmt.Errorf("MAC address cannot be empty while updating addresses of LSP %s", lsp)
+	}
+	// the port must be updated in place, never created, so that its UUID
+	// and therefore all references to it are preserved
+	if uuid := odbi.getRowUUID(TableLogicalSwitchPort, OVNRow{"name": lsp}); len(uuid) == 0 {
+		return nil, ErrorNotFound
+	}
+
+	// the addresses are a single space-separated value
+	addresses := []string{mac.String()}
+	for _, ip := range ips {
+		addresses = append(addresses, ip.IP.String())
+	}
+	addrSet, err := libovsdb.NewOvsSet([]string{strings.Join(addresses, " ")})
+	if err != nil {
+		return nil, err
+	}
+	// port security is written as given, as it holds more than the
+	// addresses, e.g. the IPv6 link-local address ND depends on
+	if portSecurity == nil {
+		portSecurity = []string{}
+	}
+	psSet, err := libovsdb.NewOvsSet(portSecurity)
+	if err != nil {
+		return nil, err
+	}
+
+	row := make(OVNRow)
+	row["addresses"] = addrSet
+	row["port_security"] = psSet
+	condition := libovsdb.NewCondition("name", "==", lsp)
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableLogicalSwitchPort,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lspSetDynamicAddressesImp(lsp string, address string) (*OvnCommand, error) {
+	if len(lsp) == 0 {
+		return nil, fmt.Errorf("LSP name cannot be empty while setting dynamic addresses")
+	}
+
+	row := make(OVNRow)
+	row["dynamic_addresses"] = address
+	condition := libovsdb.NewCondition("name", "==", lsp)
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableLogicalSwitchPort,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lspGetDynamicAddressesImp(lsp string) (string, error) {
+	lp, err := odbi.lspGetImp(lsp)
+	if err != nil {
+		return "", err
+	}
+	return lp.DynamicAddresses, nil
+}
+
+func (odbi *ovndb) lspSetExternalIdsImp(lsp string, external_ids map[string]string) (*OvnCommand, error) {
+	if external_ids == nil {
+		return nil, ErrorOption
+	}
+
+	if len(lsp) == 0 {
+		return nil, fmt.Errorf("LSP name cannot be empty while setting external_ids")
+	}
+
+	externalIdsMap, err := libovsdb.NewOvsMap(external_ids)
+	if err != nil {
+		return nil, err
+	}
+
+	row := make(OVNRow)
+	row["external_ids"] = externalIdsMap
+
+	condition := libovsdb.NewCondition("name", "==", lsp)
+
+	// simple mutate operation
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableLogicalSwitchPort,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lspGetExternalIdsImp(lsp string) (map[string]string, error) {
+	lp, err := odbi.lspGetImp(lsp)
+	if err != nil {
+		return nil, err
+	}
+	extIds := make(map[string]string)
+	for k, v := range lp.ExternalID {
+		key, keyOk := k.(string)
+		value, valueOk := v.(string)
+		if !keyOk || !valueOk {
+			continue
+		}
+		extIds[key] = value
+	}
+	return extIds, nil
+}
+
+func (odbi *ovndb) lspSetImp(lsp string, spec LSPSpec) (*OvnCommand, error) {
+	if len(lsp) == 0 {
+		return nil, fmt.Errorf("LSP name cannot be empty while setting port configuration")
+	}
+
+	row, err := lspSpecToRow(spec)
+	if err != nil {
+		return nil, err
+	}
+	if len(row) == 0 {
+		return nil, ErrorNoChanges
+	}
+
+	condition := libovsdb.NewCondition("name", "==", lsp)
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableLogicalSwitchPort,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lspAddFullImp(lsw, lswUUID, lsp string, spec LSPSpec) (*OvnCommand, error) {
+	specRow, err := lspSpecToRow(spec)
+	if err != nil {
+		return nil, err
+	}
+	cmd, err := odbi.lspAddImp(lsw, lswUUID, lsp)
+	if err != nil {
+		return nil, err
+	}
+	// the insert of the port is the first operation of the command
+	for column, value := range specRow {
+		cmd.Operations[0].Row[column] = value
+	}
+	return cmd, nil
+}
+
+// lspReattachColumnsSkipped are the columns of a port that are not copied
+// when it is reattached: its identity, and its status owned by ovn-northd
+var lspReattachColumnsSkipped = map[string]bool{
+	"_uuid":    true,
+	"_version": true,
+	"up":       true,
+	"tag":      true,
+}
+
+// lspReattachImp moves a port to another switch in one transaction: the port
+// is removed from its switch and deleted, and a copy with all its
+// configuration is inserted in the new switch. The copy is a new row, so the
+// UUID of the port changes and the references to the old UUID, e.g. from port
+// groups, are not carried over.
+func (odbi *ovndb) lspReattachImp(lsp, newSwitch string) (*OvnCommand, error) {
+	if len(lsp) == 0 || len(newSwitch) == 0 {
+		return nil, fmt.Errorf("LSP and LS names cannot be empty while reattaching a LSP")
+	}
+	lspUUID := odbi.getRowUUID(TableLogicalSwitchPort, OVNRow{"name": lsp})
+	if len(lspUUID) == 0 {
+		return nil, ErrorNotFound
+	}
+	newSwitchUUID := odbi.getRowUUID(TableLogicalSwitch, OVNRow{"name": newSwitch})
+	if len(newSwitchUUID) == 0 {
+		return nil, ErrorNotFound
+	}
+	oldSwitchUUID, err := odbi.getRowUUIDContainsUUID(TableLogicalSwitch, "ports", lspUUID)
+	if err != nil {
+		return nil, err
+	}
+	if oldSwitchUUID == newSwitchUUID {
+		return nil, ErrorNoChanges
+	}
+
+	odbi.cachemutex.RLock()
+	lspRow, ok := odbi.cache[TableLogicalSwitchPort][lspUUID]
+	if ok {
+		lspRow = copyRow(lspRow)
+	}
+	odbi.cachemutex.RUnlock()
+	if !ok {
+		return nil, ErrorNotFound
+	}
+
+	delCmd, err := odbi.lspDelImp(lsp)
+	if err != nil {
+		return nil, err
+	}
+	// the port must be gone before its copy is inserted with the same name
+	deleteOp := libovsdb.Operation{
+		Op:    opDelete,
+		Table: TableLogicalSwitchPort,
+		Where: []interface{}{libovsdb.NewCondition("_uuid", "==", stringToGoUUID(lspUUID))},
+	}
+	addCmd, err := odbi.lspInsertImp(newSwitch, newSwitchUUID, lsp)
+	if err != nil {
+		return nil, err
+	}
+	// the insert of the port is the first operation of the command
+	for column, value := range lspRow.Fields {
+		if !lspReattachColumnsSkipped[column] {
+			addCmd.Operations[0].Row[column] = value
+		}
+	}
+
+	operations := append(delCmd.Operations, deleteOp)
+	operations = append(operations, addCmd.Operations...)
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+// lspSpecToRow returns the columns of the set fields of spec
+func lspSpecToRow(spec LSPSpec) (OVNRow, error) {
+	row := make(OVNRow)
+	if spec.Type != nil {
+		row["type"] = *spec.Type
+	}
+	if spec.Enabled != nil {
+		row["enabled"] = *spec.Enabled
+	}
+	if spec.Addresses != nil {
+		addresses, err := libovsdb.NewOvsSet(spec.Addresses)
+		if err != nil {
+			return nil, err
+		}
+		row["addresses"] = addresses
+	}
+	if spec.DynamicAddresses != nil {
+		row["dynamic_addresses"] = *spec.DynamicAddresses
+	}
+	if spec.PortSecurity != nil {
+		portSecurity, err := libovsdb.NewOvsSet(spec.PortSecurity)
+		if err != nil {
+			return nil, err
+		}
+		row["port_security"] = portSecurity
+	}
+	if spec.Options != nil {
+		options, err := libovsdb.NewOvsMap(spec.Options)
+		if err != nil {
+			return nil, err
+		}
+		row["options"] = options
+	}
+	if spec.ExternalIDs != nil {
+		externalIDs, err := libovsdb.NewOvsMap(spec.ExternalIDs)
+		if err != nil {
+			return nil, err
+		}
+		row["external_ids"] = externalIDs
+	}
+	return row, nil
+}
+
+func (odbi *ovndb) uuidToLogicalPort(uuid string) (*LogicalSwitchPort, error) {
+	row := odbi.cache[TableLogicalSwitchPort][uuid]
+	return odbi.rowToLogicalPort(uuid, &row)
+}
+
+func (odbi *ovndb) rowToLogicalPort(uuid string, row *libovsdb.Row) (*LogicalSwitchPort, error) {
+	lp := &LogicalSwitchPort{
+		UUID:       uuid,
+		Version:    rowVersion(row),
+		Name:       rowString(*row, "name"),
+		Type:       rowString(*row, "type"),
+		ExternalID: rowMap(*row, "external_ids"),
+	}
+
+	if dhcpv4, ok := row.Fields["dhcpv4_options"]; ok {
+		switch dhcpv4.(type) {
+		case libovsdb.UUID:
+			lp.DHCPv4Options = dhcpv4.(libovsdb.UUID).GoUUID
+		case libovsdb.OvsSet:
+		default:
+		}
+	}
+	if dhcpv6, ok := row.Fields["dhcpv6_options"]; ok {
+		switch dhcpv6.(type) {
+		case libovsdb.UUID:
+			lp.DHCPv6Options = dhcpv6.(libovsdb.UUID).GoUUID
+		case libovsdb.OvsSet:
+		default:
+		}
+	}
+
+	if addr, ok := row.Fields["addresses"]; ok {
+		switch addr.(type) {
+		case string:
+			lp.Addresses = []string{addr.(string)}
+		case libovsdb.OvsSet:
+			lp.Addresses = odbi.ConvertGoSetToStringArray(addr.(libovsdb.OvsSet))
+		default:
+			return nil, fmt.Errorf("Unsupported type found in lport address.")
+		}
+	}
+
+	if portsecurity, ok := row.Fields["port_security"]; ok {
+		switch portsecurity.(type) {
+		case string:
+			lp.PortSecurity = []string{portsecurity.(string)}
+		case libovsdb.OvsSet:
+			lp.PortSecurity = odbi.ConvertGoSetToStringArray(portsecurity.(libovsdb.OvsSet))
+		default:
+			return nil, fmt.Errorf("Unsupported type found in port security.")
+		}
+	}
+
+	if options, ok := row.Fields["options"].(libovsdb.OvsMap); ok {
+		lp.Options = options.GoMap
+		if natAddresses, ok := lp.Options[LSPOptionNATAddresses].(string); ok {
+			lp.NATAddresses = strings.Fields(natAddresses)
+		}
+	}
+
+	if group, ok := row.Fields["ha_chassis_group"]; ok {
+		if uuid, ok := group.(libovsdb.UUID); ok {
+			lp.HAChassisGroup = uuid.GoUUID
+		}
+	}
+
+	if dynamicAddresses, ok := row.Fields["dynamic_addresses"]; ok {
+		switch dynamicAddresses.(type) {
+		case string:
+			lp.DynamicAddresses = dynamicAddresses.(string)
+		case libovsdb.OvsSet:
+			lp.DynamicAddresses = strings.Join(odbi.ConvertGoSetToStringArray(dynamicAddresses.(libovsdb.OvsSet)), " ")
+		default:
+			return nil, fmt.Errorf("Unsupport type found in lport dynamic address.")
+		}
+	}
+
+	if len(lp.DynamicAddresses) > 0 {
+		lp.MAC, lp.IPs = parseLSPAddresses(lp.DynamicAddresses)
+	} else if len(lp.Addresses) > 0 {
+		lp.MAC, lp.IPs = parseLSPAddresses(lp.Addresses[0])
+	}
+
+	return lp, nil
+}
+
+// Get lsp by name
+func (odbi *ovndb) lspGetImp(lsp string) (*LogicalSwitchPort, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheLogicalSwitchPort, ok := odbi.cache[TableLogicalSwitchPort]
+	if !ok {
+		return nil, ErrorSchema
+	}
+
+	for uuid, drows := range cacheLogicalSwitchPort {
+		if rlsp, ok := drows.Fields["name"].(string); ok && rlsp == lsp {
+			return odbi.rowToLogicalPort(uuid, &drows)
+		}
+	}
+	return nil, ErrorNotFound
+}
+
+// lspUp returns the value of the optional up column of an LSP row, nil if it
+// is not set
+func lspUp(row libovsdb.Row) *bool {
+	switch up := row.Fields["up"].(type) {
+	case bool:
+		return &up
+	case libovsdb.OvsSet:
+		if len(up.GoSet) == 1 {
+			if b, ok := up.GoSet[0].(bool); ok {
+				return &b
+			}
+		}
+	}
+	return nil
+}
+
+// lspIsUp reports whether ovn-controller has set the up column of the LSP.
+// The caller must hold cachemutex.
+func (odbi *ovndb) lspIsUp(lsp string) (bool, error) {
+	cacheLogicalSwitchPort, ok := odbi.cache[TableLogicalSwitchPort]
+	if !ok {
+		return false, ErrorSchema
+	}
+	for _, drows := range cacheLogicalSwitchPort {
+		if rlsp, ok := drows.Fields["name"].(string); !ok || rlsp != lsp {
+			continue
+		}
+		up := lspUp(drows)
+		return up != nil && *up, nil
+	}
+	return false, ErrorNotFound
+}
+
+func (odbi *ovndb) lspStatusImp(lsp string) (*LSPStatus, error) {
+	odbi.cachemutex.RLock()
+	cacheLogicalSwitchPort, ok := odbi.cache[TableLogicalSwitchPort]
+	if !ok {
+		odbi.cachemutex.RUnlock()
+		return nil, ErrorSchema
+	}
+	var status *LSPStatus
+	for _, drows := range cacheLogicalSwitchPort {
+		if rlsp, ok := drows.Fields["name"].(string); ok && rlsp == lsp {
+			status = &LSPStatus{Name: lsp, Up: lspUp(drows)}
+			break
+		}
+	}
+	odbi.cachemutex.RUnlock()
+	if status == nil {
+		return nil, ErrorNotFound
+	}
+
+	if odbi.sbClient != nil {
+		chassis, err := odbi.sbClient.PortBindingChassis(lsp)
+		switch err {
+		case nil:
+			status.Chassis = chassis
+		case ErrorSchema, ErrorNotFound:
+			// northd hasn't created the binding yet, or the southbound
+			// client doesn't monitor Port_Binding
+		default:
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+func (odbi *ovndb) lspWaitForUpImp(ctx context.Context, lsp string) error {
+	// the table is only cached once it has a row, so with no port at all
+	// lspIsUp fails with ErrorSchema even though the schema has the table
+	tableSupported := odbi.tableSupported(TableLogicalSwitchPort)
+	for {
+		odbi.cachemutex.RLock()
+		up, err := odbi.lspIsUp(lsp)
+		var updated <-chan struct{}
+		if (err == nil && !up) || err == ErrorNotFound || (err == ErrorSchema && tableSupported) {
+			// the port may not have been created yet, wait for it as well
+			updated = odbi.waitForCacheUpdate(TableLogicalSwitchPort)
+		}
+		odbi.cachemutex.RUnlock()
+
+		if updated == nil {
+			return err
+		}
+		select {
+		case <-updated:
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for LSP %s to be up: %v", lsp, ctx.Err())
+		}
+	}
+}
+
+func (odbi *ovndb) lspGetByUUIDImp(uuid string) (*LogicalSwitchPort, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheLogicalSwitchPort, ok := odbi.cache[TableLogicalSwitchPort]
+	if !ok {
+		return nil, ErrorSchema
+	}
+	if row, ok := cacheLogicalSwitchPort[uuid]; ok {
+		return odbi.rowToLogicalPort(uuid, &row)
+	}
+	return nil, ErrorNotFound
+}
+
+// orphanLSPListImp returns the untyped ports of all switches whose name is not
+// a key of validNames, the ports the caller still expects to exist. The
+// external_ids of the ports are not looked at, so that ports created by an
+// older version, or by hand, are found as well.
+func (odbi *ovndb) orphanLSPListImp(validNames map[string]bool) ([]*LogicalSwitchPort, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheLogicalSwitchPort, ok := odbi.cache[TableLogicalSwitchPort]
+	if !ok {
+		return nil, ErrorSchema
+	}
+	listLSP := []*LogicalSwitchPort{}
+	for uuid, drows := range cacheLogicalSwitchPort {
+		name, ok := drows.Fields["name"].(string)
+		if !ok || validNames[name] {
+			continue
+		}
+		// ports with a type (router, localnet, ...) are infrastructure
+		// ports, never consider them orphans
+		if ptype, ok := drows.Fields["type"].(string); ok && len(ptype) > 0 {
+			continue
+		}
+		lp, err := odbi.rowToLogicalPort(uuid, &drows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get logical port %s: %v", name, err)
+		}
+		listLSP = append(listLSP, lp)
+	}
+	return listLSP, nil
+}
+
+// lspListByTypeImp returns the ports of a switch with the given type, "" for
+// the VIF ports. The type is checked on the cached rows before they are
+// converted, so that the other ports are skipped at no cost.
+func (odbi *ovndb) lspListByTypeImp(lsw, portType string) ([]*LogicalSwitchPort, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheLogicalSwitch, ok := odbi.cache[TableLogicalSwitch]
+	if !ok {
+		return nil, ErrorSchema
+	}
+	for _, drows := range cacheLogicalSwitch {
+		if rlsw, ok := drows.Fields["name"].(string); !ok || rlsw != lsw {
+			continue
+		}
+		var portUUIDs []string
+		switch ports := drows.Fields["ports"].(type) {
+		case libovsdb.UUID:
+			portUUIDs = []string{ports.GoUUID}
+		case libovsdb.OvsSet:
+			portUUIDs = odbi.ConvertGoSetToStringArray(ports)
+		}
+		listLSP := []*LogicalSwitchPort{}
+		for _, uuid := range portUUIDs {
+			row, ok := odbi.cache[TableLogicalSwitchPort][uuid]
+			if !ok || rowString(row, "type") != portType {
+				continue
+			}
+			lp, err := odbi.rowToLogicalPort(uuid, &row)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get logical port: %s", err)
+			}
+			listLSP = append(listLSP, lp)
+		}
+		return listLSP, nil
+	}
+	return nil, ErrorNotFound
+}
+
+// Get all lport by lswitch
+func (odbi *ovndb) lspListImp(lsw string) ([]*LogicalSwitchPort, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheLogicalSwitch, ok := odbi.cache[TableLogicalSwitch]
+	if !ok {
+		return nil, ErrorSchema
+	}
+	for _, drows := range cacheLogicalSwitch {
+		if rlsw, ok := drows.Fields["name"].(string); ok && rlsw == lsw {
+			ports := drows.Fields["ports"]
+			if ports != nil {
+				switch ports.(type) {
+				case libovsdb.OvsSet:
+					if ps, ok := ports.(libovsdb.OvsSet); ok {
+						listLSP := make([]*LogicalSwitchPort, 0, len(ps.GoSet))
+						for _, p := range ps.GoSet {
+							if vp, ok := p.(libovsdb.UUID); ok {
+								if !odbi.rowCached(TableLogicalSwitchPort, vp.GoUUID) {
+									continue
+								}
+								tp, err := odbi.uuidToLogicalPort(vp.GoUUID)
+								if err != nil {
+									return nil, fmt.Errorf("Failed to get logical port: %s", err)
+								}
+								listLSP = append(listLSP, tp)
+							}
+						}
+						return listLSP, nil
+					} else {
+						return nil, fmt.Errorf("type libovsdb.OvsSet casting failed")
+					}
+				case libovsdb.UUID:
+					if vp, ok := ports.(libovsdb.UUID); ok {
+						if !odbi.rowCached(TableLogicalSwitchPort, vp.GoUUID) {
+							return []*LogicalSwitchPort{}, nil
+						}
+						tp, err := odbi.uuidToLogicalPort(vp.GoUUID)
+						if err != nil {
+							return nil, fmt.Errorf("Failed to get logical port: %s", err)
+						}
+						return []*LogicalSwitchPort{tp}, nil
+					} else {
+						return nil, fmt.Errorf("type libovsdb.UUID casting failed")
+					}
+				default:
+					return nil, fmt.Errorf("Unsupported type found in ovsdb rows")
+				}
+			}
+			return []*LogicalSwitchPort{}, nil
+		}
+	}
+	return nil, ErrorNotFound
+}
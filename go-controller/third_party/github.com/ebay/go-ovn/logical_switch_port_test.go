@@ -0,0 +1,313 @@
+package goovn
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLSPListByType(t *testing.T) {
+	portSet := func(uuids ...string) libovsdb.OvsSet {
+		set := libovsdb.OvsSet{}
+		for _, uuid := range uuids {
+			set.GoSet = append(set.GoSet, libovsdb.UUID{GoUUID: uuid})
+		}
+		return set
+	}
+	odbi := &ovndb{
+		db:              DBNB,
+		sortListResults: true,
+		cache: map[string]map[string]libovsdb.Row{
+			TableLogicalSwitch: {
+				"ls1": {Fields: map[string]interface{}{"name": "node1", "ports": portSet("lsp1", "lsp2", "lsp3", "lsp4", "lsp5")}},
+				"ls2": {Fields: map[string]interface{}{"name": "node2", "ports": libovsdb.UUID{GoUUID: "lsp6"}}},
+				"ls3": {Fields: map[string]interface{}{"name": "node3", "ports": portSet()}},
+			},
+			TableLogicalSwitchPort: {
+				"lsp1": {Fields: map[string]interface{}{"name": "ns_pod1", "type": ""}},
+				"lsp2": {Fields: map[string]interface{}{"name": "stor-node1", "type": "router"}},
+				"lsp3": {Fields: map[string]interface{}{"name": "k8s-node1"}},
+				"lsp4": {Fields: map[string]interface{}{"name": "ns_pod2", "type": "", "addresses": "0a:58:0a:80:01:04 10.128.1.4"}},
+				"lsp5": {Fields: map[string]interface{}{"name": "br-ex_node1", "type": "localnet"}},
+				"lsp6": {Fields: map[string]interface{}{"name": "stor-node2", "type": "router"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		desc     string
+		ls       string
+		portType string
+		expPorts []string
+		expErr   error
+	}{
+		{
+			desc:     "the empty type lists the VIF ports, with or without the type column",
+			ls:       "node1",
+			expPorts: []string{"k8s-node1", "ns_pod1", "ns_pod2"},
+		},
+		{
+			desc:     "lists the router ports",
+			ls:       "node1",
+			portType: "router",
+			expPorts: []string{"stor-node1"},
+		},
+		{
+			desc:     "lists a switch with a single port",
+			ls:       "node2",
+			portType: "router",
+			expPorts: []string{"stor-node2"},
+		},
+		{
+			desc:     "no port of the type",
+			ls:       "node2",
+			portType: "localnet",
+			expPorts: []string{},
+		},
+		{
+			desc:     "switch without ports",
+			ls:       "node3",
+			expPorts: []string{},
+		},
+		{
+			desc:   "missing switch",
+			ls:     "node4",
+			expErr: ErrorNotFound,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			ports, err := odbi.LSPListByType(tc.ls, tc.portType)
+			if tc.expErr != nil {
+				assert.Equal(t, tc.expErr, err)
+				assert.Nil(t, ports)
+				return
+			}
+			assert.Nil(t, err)
+			names := []string{}
+			for _, port := range ports {
+				assert.Equal(t, tc.portType, port.Type)
+				names = append(names, port.Name)
+			}
+			assert.Equal(t, tc.expPorts, names)
+		})
+	}
+}
+
+func TestLSPUpdateAddresses(t *testing.T) {
+	odbi := &ovndb{
+		db: DBNB,
+		cache: map[string]map[string]libovsdb.Row{
+			TableLogicalSwitchPort: {
+				"lsp1": {Fields: map[string]interface{}{"name": "ns_pod1"}},
+			},
+		},
+	}
+	mac, _ := net.ParseMAC("0a:58:0a:80:01:05")
+	ips := []*net.IPNet{
+		{IP: net.ParseIP("10.128.1.5"), Mask: net.CIDRMask(24, 32)},
+		{IP: net.ParseIP("fd00:10:244:1::5"), Mask: net.CIDRMask(64, 128)},
+	}
+	portSecurity := []string{"0a:58:0a:80:01:05 10.128.1.5 fd00:10:244:1::5 fe80::858:aff:fe80:105"}
+
+	t.Run("writes the given port security", func(t *testing.T) {
+		cmd, err := odbi.LSPUpdateAddresses("ns_pod1", mac, ips, portSecurity)
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprint([]string{
+			"update Logical_Switch_Port addresses=[0a:58:0a:80:01:05 10.128.1.5 fd00:10:244:1::5] " +
+				"port_security=[0a:58:0a:80:01:05 10.128.1.5 fd00:10:244:1::5 fe80::858:aff:fe80:105] where name == ns_pod1",
+		}), fmt.Sprint(cmd.Describe()))
+	})
+
+	t.Run("clears the port security when none is given", func(t *testing.T) {
+		cmd, err := odbi.LSPUpdateAddresses("ns_pod1", mac, ips[:1], nil)
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprint([]string{
+			"update Logical_Switch_Port addresses=[0a:58:0a:80:01:05 10.128.1.5] port_security=[] where name == ns_pod1",
+		}), fmt.Sprint(cmd.Describe()))
+	})
+
+	t.Run("fails for a missing port", func(t *testing.T) {
+		_, err := odbi.LSPUpdateAddresses("ns_pod2", mac, ips, portSecurity)
+		assert.Equal(t, ErrorNotFound, err)
+	})
+
+	t.Run("fails without a MAC", func(t *testing.T) {
+		_, err := odbi.LSPUpdateAddresses("ns_pod1", nil, ips, portSecurity)
+		assert.Error(t, err)
+	})
+}
+
+func TestLSPWaitForUp(t *testing.T) {
+	lspRow := func(name string, up bool) libovsdb.Row {
+		return libovsdb.Row{Fields: map[string]interface{}{"name": name, "up": up}}
+	}
+	tests := []struct {
+		desc     string
+		rows     map[string]libovsdb.Row
+		noSchema bool
+		updates  []map[string]libovsdb.RowUpdate2
+		errMatch string
+	}{
+		{
+			desc: "returns at once for a port that is up",
+			rows: map[string]libovsdb.Row{"lsp1": lspRow("ns_pod1", true)},
+		},
+		{
+			desc: "returns once the port is up",
+			rows: map[string]libovsdb.Row{"lsp1": lspRow("ns_pod1", false), "lsp2": lspRow("ns_pod2", false)},
+			updates: []map[string]libovsdb.RowUpdate2{
+				{"lsp2": {Modify: libovsdb.Row{Fields: map[string]interface{}{"up": true}}}},
+				{"lsp1": {Modify: libovsdb.Row{Fields: map[string]interface{}{"up": true}}}},
+			},
+		},
+		{
+			desc: "returns once a port that does not exist yet is created up",
+			rows: map[string]libovsdb.Row{"lsp2": lspRow("ns_pod2", true)},
+			updates: []map[string]libovsdb.RowUpdate2{
+				{"lsp1": {Insert: lspRow("ns_pod1", false)}},
+				{"lsp1": {Modify: libovsdb.Row{Fields: map[string]interface{}{"up": true}}}},
+			},
+		},
+		{
+			desc: "returns once the first port is created up",
+			updates: []map[string]libovsdb.RowUpdate2{
+				{"lsp1": {Insert: lspRow("ns_pod1", true)}},
+			},
+		},
+		{
+			desc:     "fails when the context is done before the port is up",
+			rows:     map[string]libovsdb.Row{"lsp1": lspRow("ns_pod1", false)},
+			errMatch: "timed out waiting for LSP ns_pod1 to be up",
+		},
+		{
+			desc:     "fails without the table in the schema",
+			noSchema: true,
+			errMatch: ErrorSchema.Error(),
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			tables := map[string][]string{TableLogicalSwitchPort: {"name", "up"}}
+			if tc.noSchema {
+				tables = map[string][]string{}
+			}
+			odbi := newTestDB(DBNB, tables, map[string]map[string]libovsdb.Row{TableLogicalSwitchPort: tc.rows})
+			odbi.tableCols = map[string][]string{TableLogicalSwitchPort: {}}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if tc.errMatch != "" && !tc.noSchema {
+				ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+			}
+			defer cancel()
+
+			go func(updates []map[string]libovsdb.RowUpdate2) {
+				for _, rows := range updates {
+					time.Sleep(20 * time.Millisecond)
+					odbi.cachemutex.Lock()
+					odbi.populateCache2(DBNB, libovsdb.TableUpdates2{Updates: map[string]libovsdb.TableUpdate2{
+						TableLogicalSwitchPort: {Rows: rows},
+					}}, false)
+					odbi.cachemutex.Unlock()
+				}
+			}(tc.updates)
+
+			err := odbi.WaitForLSPUp(ctx, "ns_pod1")
+			if tc.errMatch != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMatch)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Nil(t, ctx.Err())
+			up, err := odbi.lspIsUp("ns_pod1")
+			assert.Nil(t, err)
+			assert.True(t, up)
+		})
+	}
+}
+
+func TestLSPReattach(t *testing.T) {
+	odbi := newTestDB(DBNB, map[string][]string{
+		TableLogicalSwitch:     {"name", "ports"},
+		TableLogicalSwitchPort: {"name", "type", "addresses", "options", "tag_request", "tag", "up", "external_ids"},
+	}, map[string]map[string]libovsdb.Row{
+		TableLogicalSwitch: {
+			"ls1": {Fields: map[string]interface{}{"name": "node1", "ports": libovsdb.OvsSet{GoSet: []interface{}{libovsdb.UUID{GoUUID: "lsp1"}, libovsdb.UUID{GoUUID: "lsp2"}}}}},
+			"ls2": {Fields: map[string]interface{}{"name": "node2", "ports": libovsdb.OvsSet{}}},
+		},
+		TableLogicalSwitchPort: {
+			"lsp1": {Fields: map[string]interface{}{
+				"_uuid":        libovsdb.UUID{GoUUID: "lsp1"},
+				"_version":     libovsdb.UUID{GoUUID: "version1"},
+				"name":         "ns_pod1",
+				"type":         "",
+				"addresses":    "0a:58:0a:80:01:05 10.128.1.5",
+				"options":      libovsdb.OvsMap{GoMap: map[interface{}]interface{}{"requested-chassis": "node1"}},
+				"tag_request":  0,
+				"tag":          1,
+				"up":           true,
+				"external_ids": libovsdb.OvsMap{GoMap: map[interface{}]interface{}{"pod": "true"}},
+			}},
+			"lsp2": {Fields: map[string]interface{}{"name": "ns_pod2"}},
+		},
+	})
+
+	cmd, err := odbi.LSPReattach("ns_pod1", "node2")
+	assert.Nil(t, err)
+	assert.Len(t, cmd.Operations, 4)
+	insert := cmd.Operations[2]
+	// the port is removed from its switch and deleted before its copy is
+	// inserted in the new switch
+	assert.Equal(t, fmt.Sprint([]string{
+		"mutate Logical_Switch ports delete [lsp1] where _uuid == ls1",
+		"delete Logical_Switch_Port where _uuid == lsp1",
+		"insert Logical_Switch_Port addresses=0a:58:0a:80:01:05 10.128.1.5 external_ids=map[pod:true] name=ns_pod1 " +
+			"options=map[requested-chassis:node1] tag_request=0 type=",
+		"mutate Logical_Switch ports insert [" + insert.UUIDName + "] where _uuid == ls2",
+	}), fmt.Sprint(cmd.Describe()))
+	for _, column := range []string{"_uuid", "_version", "up", "tag"} {
+		assert.NotContains(t, insert.Row, column)
+	}
+	// the cached row is left alone
+	assert.Equal(t, true, odbi.cache[TableLogicalSwitchPort]["lsp1"].Fields["up"])
+
+	errTests := []struct {
+		desc      string
+		lsp       string
+		newSwitch string
+		expErr    error
+	}{
+		{
+			desc:      "the port is already on the switch",
+			lsp:       "ns_pod1",
+			newSwitch: "node1",
+			expErr:    ErrorNoChanges,
+		},
+		{
+			desc:      "unknown port",
+			lsp:       "ns_pod3",
+			newSwitch: "node2",
+			expErr:    ErrorNotFound,
+		},
+		{
+			desc:      "unknown switch",
+			lsp:       "ns_pod1",
+			newSwitch: "node3",
+			expErr:    ErrorNotFound,
+		},
+	}
+	for i, tc := range errTests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			cmd, err := odbi.LSPReattach(tc.lsp, tc.newSwitch)
+			assert.Equal(t, tc.expErr, err)
+			assert.Nil(t, cmd)
+		})
+	}
+	_, err = odbi.LSPReattach("", "node2")
+	assert.Error(t, err)
+}
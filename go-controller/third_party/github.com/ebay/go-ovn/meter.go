@@ -0,0 +1,299 @@
+package goovn
+
+import (
+	"math"
+	"strings"
+
+	"github.com/ebay/libovsdb"
+)
+
+type Meter struct {
+	UUID        string
+	Name        string                      `json:"name"`
+	Unit        string                      `json:"unit"`
+	Bands       []string                    `json:"bands"`
+	Fair        bool                        `json:"fair"`
+	ExternalIds map[interface{}]interface{} `json:"external_ids"`
+}
+
+type MeterBand struct {
+	UUID        string
+	Action      string                      `json:"action"`
+	Rate        int                         `json:"rate"`
+	BurstSize   int                         `json:"burst_size"`
+	ExternalIds map[interface{}]interface{} `json:"external_ids"`
+}
+
+// MeterBandSpec describes a band of a meter to be added
+type MeterBandSpec struct {
+	Action    string
+	Rate      int
+	BurstSize int
+}
+
+func (odbi *ovndb) rowToMeter(uuid string) *Meter {
+	cacheMeter, ok := odbi.cache[TableMeter][uuid]
+	if !ok {
+		return nil
+	}
+	meter := &Meter{
+		UUID:        uuid,
+		Name:        rowString(cacheMeter, "name"),
+		Unit:        rowString(cacheMeter, "unit"),
+		Bands:       meterBandUUIDs(cacheMeter),
+		ExternalIds: rowMap(cacheMeter, "external_ids"),
+	}
+	// fair is an optional column, absent from older schemas
+	if fair, ok := cacheMeter.Fields["fair"].(bool); ok {
+		meter.Fair = fair
+	}
+	return meter
+}
+
+// meterBandUUIDs returns the UUIDs of the bands of a meter row
+func meterBandUUIDs(row libovsdb.Row) []string {
+	switch bands := row.Fields["bands"].(type) {
+	case libovsdb.UUID:
+		return []string{bands.GoUUID}
+	case libovsdb.OvsSet:
+		uuids := make([]string, 0, len(bands.GoSet))
+		for _, band := range bands.GoSet {
+			if uuid, ok := band.(libovsdb.UUID); ok {
+				uuids = append(uuids, uuid.GoUUID)
+			}
+		}
+		return uuids
+	}
+	return nil
+}
+
+func (odbi *ovndb) rowToMeterBand(uuid string) (*MeterBand, error) {
+	cacheMeterBand, ok := odbi.cache[TableMeterBand][uuid]
+	if !ok {
+		return nil, ErrorNotFound
+	}
+	meterBand := &MeterBand{
+		UUID:        uuid,
+		Action:      rowString(cacheMeterBand, "action"),
+		Rate:        rowInt(cacheMeterBand, "rate"),
+		BurstSize:   rowInt(cacheMeterBand, "burst_size"),
+		ExternalIds: rowMap(cacheMeterBand, "external_ids"),
+	}
+	return meterBand, nil
+}
+
+func (odbi *ovndb) meterAddImp(name, action string, rate int, unit string, external_ids map[string]string, burst int) (*OvnCommand, error) {
+	band := MeterBandSpec{Action: action, Rate: rate, BurstSize: burst}
+	return odbi.meterAddBandsImp(name, unit, false, []MeterBandSpec{band}, external_ids)
+}
+
+func (odbi *ovndb) meterAddBandsImp(name, unit string, fair bool, bands []MeterBandSpec, external_ids map[string]string) (*OvnCommand, error) {
+
+	//Names  that  start  with "__" (two underscores) are reserved for
+	//internal use by OVN.
+	if strings.HasPrefix(name, "__") {
+		return nil, ErrorOption
+	}
+
+	if len(bands) == 0 {
+		return nil, ErrorOption
+	}
+
+	MeterUUID, err := newRowUUID()
+	if err != nil {
+		return nil, err
+	}
+	//meter row
+	mRow := make(OVNRow)
+
+	mRow["name"] = name
+	if uuid := odbi.getRowUUID(TableMeter, mRow); len(uuid) > 0 {
+		return nil, ErrorExist
+	}
+
+	switch unit {
+	case "kbps", "pktps":
+		mRow["unit"] = unit
+	default:
+		return nil, ErrorOption
+	}
+
+	// fair is only written when set, so that meters can still be added
+	// with schemas that predate the column
+	if fair {
+		mRow["fair"] = true
+	}
+
+	if external_ids != nil {
+		oMap, err := libovsdb.NewOvsMap(external_ids)
+		if err != nil {
+			return nil, err
+		}
+		mRow["external_ids"] = oMap
+		//mbRow["external_ids"] = oMap
+	}
+
+	var operations []libovsdb.Operation
+	bandUUIDs := make([]libovsdb.UUID, 0, len(bands))
+	for _, band := range bands {
+		// The only supported action is drop.
+		if band.Action != "drop" {
+			return nil, ErrorOption
+		}
+
+		//Meter Band row
+		mbRow := make(OVNRow)
+
+		mbRow["action"] = band.Action
+
+		//rate must be in the range 1...4294967295
+		if band.Rate < 1 || band.Rate > math.MaxInt32 {
+			return nil, ErrorOption
+		}
+		mbRow["rate"] = band.Rate
+
+		//burst must be in the range 0...4294967295
+		if band.BurstSize >= 0 && band.BurstSize <= math.MaxInt32 {
+			mbRow["burst_size"] = band.BurstSize
+		}
+
+		MeterBandUUID, err := newRowUUID()
+		if err != nil {
+			return nil, err
+		}
+		mbInsterOp := libovsdb.Operation{
+			Op:       opInsert,
+			Table:    TableMeterBand,
+			Row:      mbRow,
+			UUIDName: MeterBandUUID,
+		}
+		operations = append(operations, mbInsterOp)
+		bandUUIDs = append(bandUUIDs, libovsdb.UUID{GoUUID: MeterBandUUID})
+	}
+
+	if len(bandUUIDs) == 1 {
+		mRow["bands"] = bandUUIDs[0]
+	} else {
+		bandSet, err := libovsdb.NewOvsSet(bandUUIDs)
+		if err != nil {
+			return nil, err
+		}
+		mRow["bands"] = bandSet
+	}
+
+	mInsertOp := libovsdb.Operation{
+		Op:       opInsert,
+		Table:    TableMeter,
+		Row:      mRow,
+		UUIDName: MeterUUID,
+	}
+	operations = append(operations, mInsertOp)
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+/*
+meter-del [name]
+Deletes meters. By default, all meters are deleted. If  name  is
+supplied, only the meter with that name will be deleted.
+*/
+func (odbi *ovndb) meterDelImp(name ...string) (*OvnCommand, error) {
+	var operations []libovsdb.Operation
+	var err error
+
+	switch len(name) {
+	case 0:
+		for uuid := range odbi.cache[TableMeter] {
+			name := rowString(odbi.cache[TableMeter][uuid], "name")
+			operations, err = odbi.singleMeterDel(name, operations)
+			if err != nil {
+				return nil, err
+			}
+		}
+	default:
+		for i := 0; i < len(name); i++ {
+			operations, err = odbi.singleMeterDel(name[i], operations)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+
+}
+
+//meter-list
+//Lists all meters.
+//but not like ovn-nbctl , it can't show meter bands information
+func (odbi *ovndb) meterListImp() ([]*Meter, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+	cacheMeter, ok := odbi.cache[TableMeter]
+	if !ok {
+		return nil, ErrorNotFound
+	}
+	ListMeter := make([]*Meter, 0, len(cacheMeter))
+	for uuid := range cacheMeter {
+		ListMeter = append(ListMeter, odbi.rowToMeter(uuid))
+	}
+	return ListMeter, nil
+}
+
+//Because meterList can't show meter bands , add this method as a solution
+func (odbi *ovndb) meterBandsListImp() ([]*MeterBand, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+	cacheMeterBands, ok := odbi.cache[TableMeterBand]
+	if !ok {
+		return nil, ErrorNotFound
+	}
+	ListMeterBands := make([]*MeterBand, 0, len(cacheMeterBands))
+	for uuid := range cacheMeterBands {
+		meterBand, err := odbi.rowToMeterBand(uuid)
+		if err != nil {
+			return nil, ErrorNotFound
+		}
+		ListMeterBands = append(ListMeterBands, meterBand)
+	}
+	return ListMeterBands, nil
+}
+
+func (odbi *ovndb) meterFind(name string) bool {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+	row := make(OVNRow)
+	row["name"] = name
+	meterUUID := odbi.getRowUUID(TableMeter, row)
+	if len(meterUUID) == 0 {
+		return false
+	}
+	return true
+}
+
+func (odbi *ovndb) singleMeterDel(name string, operations []libovsdb.Operation) ([]libovsdb.Operation, error) {
+	meterName := name
+	row := make(OVNRow)
+	row["name"] = meterName
+	meterUUID := odbi.getRowUUID(TableMeter, row)
+	if len(meterUUID) == 0 {
+		return nil, ErrorNotFound
+	}
+	bands := meterBandUUIDs(odbi.cache[TableMeter][meterUUID])
+	mCondition := libovsdb.NewCondition("name", "==", meterName)
+	mDeleteOp := libovsdb.Operation{
+		Op:    opDelete,
+		Table: TableMeter,
+		Where: []interface{}{mCondition},
+	}
+
+	for _, band := range bands {
+		bCondition := libovsdb.NewCondition("_uuid", "==", libovsdb.UUID{GoUUID: band})
+		bDeleteOp := libovsdb.Operation{
+			Op:    opDelete,
+			Table: TableMeterBand,
+			Where: []interface{}{bCondition},
+		}
+		operations = append(operations, bDeleteOp)
+	}
+	operations = append(operations, mDeleteOp)
+	return operations, nil
+}
@@ -0,0 +1,148 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// The well-known options of the NB_Global row
+const (
+	// NBGlobalOptionMACPrefix is the first three octets of the MACs
+	// ovn-northd allocates to dynamic addresses, e.g. 0a:58:0a
+	NBGlobalOptionMACPrefix = "mac_prefix"
+	// NBGlobalOptionSvcMonitorMAC is the source MAC of the service monitor
+	// health checks
+	NBGlobalOptionSvcMonitorMAC = "svc_monitor_mac"
+	// NBGlobalOptionMaxTunID is the maximum tunnel key, set by ovn-northd
+	NBGlobalOptionMaxTunID = "max_tunid"
+	// NBGlobalOptionNorthdProbeInterval is the inactivity probe interval of
+	// ovn-northd's database connections, in milliseconds, 0 disabling it
+	NBGlobalOptionNorthdProbeInterval = "northd_probe_interval"
+	// NBGlobalOptionUseLogicalDPGroups enables the logical datapath groups
+	NBGlobalOptionUseLogicalDPGroups = "use_logical_dp_groups"
+	// NBGlobalOptionControllerEvent enables the controller events, e.g. for
+	// the load balancers without backends
+	NBGlobalOptionControllerEvent = "controller_event"
+	// GlobalOptionE2ETimestamp is the timestamp written to NB_Global and
+	// copied by ovn-northd to SB_Global
+	GlobalOptionE2ETimestamp = "e2e_timestamp"
+)
+
+type NBGlobalTableRow struct {
+	UUID        string
+	Options     map[interface{}]interface{}
+	ExternalID  map[interface{}]interface{}
+	Connections []string
+	SSL         string
+	IPSec       bool
+}
+
+func (odbi *ovndb) nbGlobalAddImp(options map[string]string) (*OvnCommand, error) {
+	return odbi.addGlobalTableRowImp(options, TableNBGlobal)
+}
+
+func (odbi *ovndb) nbGlobalDelImp() (*OvnCommand, error) {
+	return odbi.delGlobalTableRowImp(TableNBGlobal)
+}
+
+// ovsdb-client -v transact '["Open_vSwitch", {"op" : "update", "table" : "NB_Global", "where": [["_uuid", "==", ["uuid", "587c6ee2-93f9-4bd8-9794-f4a983d139a4"]]],
+// "row":{ "options" : [ "map", [[ "bar", "baz"],["engine_test", "engine-foo"]]],}}]'
+
+func (odbi *ovndb) nbGlobalSetOptionsImp(options map[string]string) (*OvnCommand, error) {
+	return odbi.globalSetOptionsImp(options, TableNBGlobal)
+}
+
+func (odbi *ovndb) nbGlobalGetOptionsImp() (map[string]string, error) {
+	return odbi.globalGetOptionsImp(TableNBGlobal)
+}
+
+func (odbi *ovndb) nbGlobalGetMACPrefixImp() (string, error) {
+	return odbi.globalGetOptionImp(TableNBGlobal, NBGlobalOptionMACPrefix)
+}
+
+// nbGlobalSetMACPrefixImp sets the MAC prefix, which must be the first three
+// octets of a unicast MAC
+func (odbi *ovndb) nbGlobalSetMACPrefixImp(prefix string) (*OvnCommand, error) {
+	mac, err := net.ParseMAC(prefix + ":00:00:00")
+	if err != nil || len(prefix) != len("00:00:00") || mac[0]&1 != 0 {
+		return nil, fmt.Errorf("invalid MAC prefix %q", prefix)
+	}
+	return odbi.globalSetOptionImp(TableNBGlobal, NBGlobalOptionMACPrefix, prefix)
+}
+
+func (odbi *ovndb) nbGlobalGetSvcMonitorMACImp() (net.HardwareAddr, error) {
+	value, err := odbi.globalGetOptionImp(TableNBGlobal, NBGlobalOptionSvcMonitorMAC)
+	if err != nil {
+		return nil, err
+	}
+	mac, err := net.ParseMAC(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s option %q: %v", NBGlobalOptionSvcMonitorMAC, value, err)
+	}
+	return mac, nil
+}
+
+// nbGlobalSetSvcMonitorMACImp sets the service monitor MAC, which must be a
+// unicast MAC
+func (odbi *ovndb) nbGlobalSetSvcMonitorMACImp(mac net.HardwareAddr) (*OvnCommand, error) {
+	if len(mac) != 6 || mac[0]&1 != 0 {
+		return nil, fmt.Errorf("invalid service monitor MAC %q", mac.String())
+	}
+	return odbi.globalSetOptionImp(TableNBGlobal, NBGlobalOptionSvcMonitorMAC, mac.String())
+}
+
+func (odbi *ovndb) nbGlobalGetMaxTunIDImp() (int, error) {
+	value, err := odbi.globalGetOptionImp(TableNBGlobal, NBGlobalOptionMaxTunID)
+	if err != nil {
+		return 0, err
+	}
+	maxTunID, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s option %q: %v", NBGlobalOptionMaxTunID, value, err)
+	}
+	return maxTunID, nil
+}
+
+func (odbi *ovndb) nbGlobalGetNorthdProbeIntervalImp() (int, error) {
+	value, err := odbi.globalGetOptionImp(TableNBGlobal, NBGlobalOptionNorthdProbeInterval)
+	if err != nil {
+		return 0, err
+	}
+	interval, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s option %q: %v", NBGlobalOptionNorthdProbeInterval, value, err)
+	}
+	return interval, nil
+}
+
+// nbGlobalSetNorthdProbeIntervalImp sets the ovn-northd probe interval in
+// milliseconds, 0 disabling the probes
+func (odbi *ovndb) nbGlobalSetNorthdProbeIntervalImp(interval int) (*OvnCommand, error) {
+	if interval < 0 {
+		return nil, fmt.Errorf("invalid northd probe interval %d", interval)
+	}
+	return odbi.globalSetOptionImp(TableNBGlobal, NBGlobalOptionNorthdProbeInterval, strconv.Itoa(interval))
+}
+
+// nbGlobalIncrementNbCfgImp bumps nb_cfg, which ovn-northd copies to the
+// nb_cfg of SB_Global once the southbound database reflects the change
+func (odbi *ovndb) nbGlobalIncrementNbCfgImp() (int, error) {
+	return odbi.globalIncrementImp(TableNBGlobal, "nb_cfg")
+}
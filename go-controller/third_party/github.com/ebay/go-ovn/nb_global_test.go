@@ -0,0 +1,156 @@
+package goovn
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func newNBGlobalTestDB(options map[interface{}]interface{}) *ovndb {
+	var rows map[string]libovsdb.Row
+	if options != nil {
+		rows = map[string]libovsdb.Row{"nbg1": {Fields: map[string]interface{}{"options": libovsdb.OvsMap{GoMap: options}}}}
+	}
+	return newTestDB(DBNB, map[string][]string{TableNBGlobal: {"options", "nb_cfg"}},
+		map[string]map[string]libovsdb.Row{TableNBGlobal: rows})
+}
+
+func TestNBGlobalGetters(t *testing.T) {
+	odbi := newNBGlobalTestDB(map[interface{}]interface{}{
+		NBGlobalOptionMACPrefix:           "0a:58:0a",
+		NBGlobalOptionSvcMonitorMAC:       "fe:16:3e:27:3c:a8",
+		NBGlobalOptionMaxTunID:            "16711680",
+		NBGlobalOptionNorthdProbeInterval: "5000",
+	})
+	prefix, err := odbi.NBGlobalGetMACPrefix()
+	assert.Nil(t, err)
+	assert.Equal(t, "0a:58:0a", prefix)
+	mac, err := odbi.NBGlobalGetSvcMonitorMAC()
+	assert.Nil(t, err)
+	assert.Equal(t, "fe:16:3e:27:3c:a8", mac.String())
+	maxTunID, err := odbi.NBGlobalGetMaxTunID()
+	assert.Nil(t, err)
+	assert.Equal(t, 16711680, maxTunID)
+	interval, err := odbi.NBGlobalGetNorthdProbeInterval()
+	assert.Nil(t, err)
+	assert.Equal(t, 5000, interval)
+
+	t.Run("invalid values", func(t *testing.T) {
+		odbi := newNBGlobalTestDB(map[interface{}]interface{}{
+			NBGlobalOptionSvcMonitorMAC:       "fe:16:3e",
+			NBGlobalOptionMaxTunID:            "many",
+			NBGlobalOptionNorthdProbeInterval: "5s",
+		})
+		_, err := odbi.NBGlobalGetSvcMonitorMAC()
+		assert.Error(t, err)
+		_, err = odbi.NBGlobalGetMaxTunID()
+		assert.Error(t, err)
+		_, err = odbi.NBGlobalGetNorthdProbeInterval()
+		assert.Error(t, err)
+	})
+
+	t.Run("unset options", func(t *testing.T) {
+		odbi := newNBGlobalTestDB(map[interface{}]interface{}{})
+		_, err := odbi.NBGlobalGetMACPrefix()
+		assert.Equal(t, ErrorNotFound, err)
+		_, err = odbi.NBGlobalGetSvcMonitorMAC()
+		assert.Equal(t, ErrorNotFound, err)
+		_, err = odbi.NBGlobalGetMaxTunID()
+		assert.Equal(t, ErrorNotFound, err)
+		_, err = odbi.NBGlobalGetNorthdProbeInterval()
+		assert.Equal(t, ErrorNotFound, err)
+	})
+
+	t.Run("missing NB_Global row", func(t *testing.T) {
+		_, err := newNBGlobalTestDB(nil).NBGlobalGetMACPrefix()
+		assert.Equal(t, ErrorNotFound, err)
+	})
+
+	t.Run("NB_Global not in the schema", func(t *testing.T) {
+		odbi := &ovndb{db: DBNB, cache: map[string]map[string]libovsdb.Row{}}
+		_, err := odbi.NBGlobalGetMACPrefix()
+		assert.Equal(t, ErrorSchema, err)
+	})
+}
+
+func TestNBGlobalSetters(t *testing.T) {
+	mutate := func(key, value string) string {
+		op := fmt.Sprintf("mutate NB_Global options delete [%s]", key)
+		if value != "" {
+			op += fmt.Sprintf(" options insert map[%s:%s]", key, value)
+		}
+		return op + " where _uuid == nbg1"
+	}
+	tests := []struct {
+		desc   string
+		set    func(odbi *ovndb) (*OvnCommand, error)
+		noRow  bool
+		expOp  string
+		expErr bool
+	}{
+		{
+			desc:  "sets the MAC prefix",
+			set:   func(odbi *ovndb) (*OvnCommand, error) { return odbi.NBGlobalSetMACPrefix("0a:58:0a") },
+			expOp: mutate(NBGlobalOptionMACPrefix, "0a:58:0a"),
+		},
+		{
+			desc:   "rejects a multicast MAC prefix",
+			set:    func(odbi *ovndb) (*OvnCommand, error) { return odbi.NBGlobalSetMACPrefix("01:00:5e") },
+			expErr: true,
+		},
+		{
+			desc:   "rejects a MAC prefix of the wrong length",
+			set:    func(odbi *ovndb) (*OvnCommand, error) { return odbi.NBGlobalSetMACPrefix("0a:58") },
+			expErr: true,
+		},
+		{
+			desc: "sets the service monitor MAC",
+			set: func(odbi *ovndb) (*OvnCommand, error) {
+				return odbi.NBGlobalSetSvcMonitorMAC(net.HardwareAddr{0xfe, 0x16, 0x3e, 0x27, 0x3c, 0xa8})
+			},
+			expOp: mutate(NBGlobalOptionSvcMonitorMAC, "fe:16:3e:27:3c:a8"),
+		},
+		{
+			desc: "rejects a multicast service monitor MAC",
+			set: func(odbi *ovndb) (*OvnCommand, error) {
+				return odbi.NBGlobalSetSvcMonitorMAC(net.HardwareAddr{0x01, 0x00, 0x5e, 0x00, 0x00, 0x01})
+			},
+			expErr: true,
+		},
+		{
+			desc:  "sets a probe interval of 0",
+			set:   func(odbi *ovndb) (*OvnCommand, error) { return odbi.NBGlobalSetNorthdProbeInterval(0) },
+			expOp: mutate(NBGlobalOptionNorthdProbeInterval, "0"),
+		},
+		{
+			desc:   "rejects a negative probe interval",
+			set:    func(odbi *ovndb) (*OvnCommand, error) { return odbi.NBGlobalSetNorthdProbeInterval(-1) },
+			expErr: true,
+		},
+		{
+			desc:   "fails without the NB_Global row",
+			set:    func(odbi *ovndb) (*OvnCommand, error) { return odbi.NBGlobalSetNorthdProbeInterval(5000) },
+			noRow:  true,
+			expErr: true,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			options := map[interface{}]interface{}{NBGlobalOptionUseLogicalDPGroups: "true"}
+			if tc.noRow {
+				options = nil
+			}
+			cmd, err := tc.set(newNBGlobalTestDB(options))
+			if tc.expErr {
+				assert.Error(t, err)
+				assert.Nil(t, cmd)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, fmt.Sprint([]string{tc.expOp}), fmt.Sprint(cmd.Describe()))
+		})
+	}
+}
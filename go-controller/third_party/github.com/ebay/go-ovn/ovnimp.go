@@ -0,0 +1,1374 @@
+/**
+ * Copyright (c) 2017 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/rpc2"
+	"github.com/ebay/libovsdb"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	commitTransactionText = "committing transaction"
+)
+
+var (
+	// ErrorOption used when invalid args specified
+	ErrorOption = errors.New("invalid option specified")
+	// ErrorSchema used when something wrong in ovnnb
+	ErrorSchema = errors.New("table schema error")
+	// ErrorNotFound used when object not found in ovnnb
+	ErrorNotFound = errors.New("object not found")
+	// ErrorExist used when object already exists in ovnnb
+	ErrorExist = errors.New("object exist")
+	// ErrorNoChanges used when function called, but no changes
+	ErrorNoChanges = errors.New("no changes requested")
+	// ErrorDuplicateName used when multiple rows are found when searching by name
+	ErrorDuplicateName = errors.New("duplicate name")
+	// ErrorVersionMismatch used when a row changed since its version was read
+	ErrorVersionMismatch = errors.New("row version changed")
+	// ErrorSnapshot used when commands are executed on a cache snapshot
+	ErrorSnapshot = errors.New("cache snapshot is read-only")
+)
+
+// OVNRow ovn nb/sb row
+type OVNRow map[string]interface{}
+
+func (odbi *ovndb) getRowUUIDs(table string, row OVNRow) []string {
+	var uuids []string
+	var wildcard bool
+
+	if reflect.DeepEqual(row, make(OVNRow)) {
+		wildcard = true
+	}
+
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheTable, ok := odbi.cache[table]
+	if !ok {
+		return nil
+	}
+
+	for uuid, drows := range cacheTable {
+		if wildcard {
+			uuids = append(uuids, uuid)
+			continue
+		}
+
+		isEqual := true
+		for field, value := range row {
+			if v, ok := drows.Fields[field]; ok {
+				// sets and maps hold slices and maps, which can't be
+				// compared with !=
+				if !reflect.DeepEqual(v, value) {
+					isEqual = false
+					break
+				}
+			}
+		}
+		if isEqual {
+			uuids = append(uuids, uuid)
+		}
+	}
+
+	return uuids
+}
+
+func (odbi *ovndb) getRowUUID(table string, row OVNRow) string {
+	uuids := odbi.getRowUUIDs(table, row)
+	if len(uuids) > 0 {
+		return uuids[0]
+	}
+	return ""
+}
+
+// rowCached reports whether the cache holds the row, the rows referenced by
+// cached rows being left out when Config.MonitorScope narrows their table.
+// Must be called with the cachemutex held.
+func (odbi *ovndb) rowCached(table, uuid string) bool {
+	_, ok := odbi.cache[table][uuid]
+	return ok
+}
+
+//test if map s contains t
+//This function is not both s and t are nil at same time
+func (odbi *ovndb) oMapContians(s, t map[interface{}]interface{}) bool {
+	if s == nil || t == nil {
+		return false
+	}
+
+	for tk, tv := range t {
+		if sv, ok := s[tk]; !ok {
+			return false
+		} else if tv != sv {
+			return false
+		}
+	}
+	return true
+}
+
+func (odbi *ovndb) getRowUUIDContainsUUID(table, field, uuid string) (string, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheTable, ok := odbi.cache[table]
+	if !ok {
+		return "", ErrorSchema
+	}
+
+	for id, drows := range cacheTable {
+		v := fmt.Sprintf("%s", drows.Fields[field])
+		if strings.Contains(v, uuid) {
+			return id, nil
+		}
+	}
+	return "", ErrorNotFound
+}
+
+func (odbi *ovndb) getRowsMatchingUUID(table, field, uuid string) ([]string, error) {
+	odbi.cachemutex.Lock()
+	defer odbi.cachemutex.Unlock()
+	var uuids []string
+	for id, drows := range odbi.cache[table] {
+		v := fmt.Sprintf("%s", drows.Fields[field])
+		if strings.Contains(v, uuid) {
+			uuids = append(uuids, id)
+		}
+	}
+	if len(uuids) == 0 {
+		return uuids, ErrorNotFound
+	}
+	return uuids, nil
+}
+
+// connectionError is returned by transact when the transaction failed along
+// with the connection it was sent on. The errors the server returns for the
+// operations are not connection errors, the transaction was rejected.
+type connectionError struct {
+	error
+}
+
+// isConnectionError tells whether err comes from a transaction lost with its
+// connection, in which case it may be resubmitted once the client reconnects
+func isConnectionError(err error) bool {
+	_, ok := err.(connectionError)
+	return ok
+}
+
+func (odbi *ovndb) transact(ctx context.Context, db string, ops ...libovsdb.Operation) ([]libovsdb.OperationResult, error) {
+	odbi.tranmutex.RLock()
+	defer odbi.tranmutex.RUnlock()
+	client, err := odbi.getClient()
+	if err != nil {
+		return nil, connectionError{err}
+	}
+
+	reply, err := client.TransactWithContext(ctx, db, ops...)
+	if err != nil {
+		switch err {
+		case rpc2.ErrShutdown, io.EOF, io.ErrUnexpectedEOF:
+			return reply, connectionError{err}
+		}
+		if _, ok := err.(net.Error); ok && ctx.Err() == nil {
+			return reply, connectionError{err}
+		}
+		return reply, err
+	}
+
+	// Per RFC 7047 Section 4.1.3, the operation result array in the transact response object
+	// maps one-to-one with operations array in the transact request object. We need to check
+	// each of the operation result for null error to ensure that the transaction has succeeded.
+	for i, o := range reply {
+		if o.Error != "" {
+			if i < len(ops) && ops[i].Op == opWait && o.Error == "timed out" {
+				// a WithRowVersion check failed, the connection is fine
+				return nil, ErrorVersionMismatch
+			}
+			// Per RFC 7047 Section 4.1.3, if all of the operations succeed, but the results
+			// cannot be committed, then "result" will have one more element than "params",
+			// with the additional element being an <error>.
+			opsInfo := commitTransactionText
+			if i < len(ops) {
+				opsInfo = fmt.Sprintf("%v", ops[i])
+			}
+			odbi.close()
+			return nil, fmt.Errorf("Reconnecting...Transaction Failed due to an error: %v details: %v in %s",
+				o.Error, o.Details, opsInfo)
+		}
+	}
+	if len(reply) < len(ops) {
+		return reply, fmt.Errorf("Number of Replies should be atleast equal to number of operations")
+	}
+	return reply, nil
+}
+
+func (odbi *ovndb) execute(cmds ...*OvnCommand) error {
+	_, err := odbi.ExecuteR(cmds...)
+	return err
+}
+
+func (odbi *ovndb) executeR(cmds ...*OvnCommand) ([]string, error) {
+	return odbi.executeROptions(nil, cmds...)
+}
+
+func (odbi *ovndb) executeROptions(opts []ExecuteOption, cmds ...*OvnCommand) ([]string, error) {
+	if cmds == nil {
+		return nil, nil
+	}
+	if odbi.snapshot {
+		return nil, ErrorSnapshot
+	}
+	options := executeOptions{timeout: odbi.timeout}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	// the version checks come first, so nothing else runs if one fails
+	ops := options.waits
+	for _, cmd := range cmds {
+		if cmd != nil {
+			ops = append(ops, cmd.Operations...)
+		}
+	}
+	if len(odbi.txnOrigin) > 0 && len(ops) > 0 {
+		ops = append(ops, libovsdb.Operation{Op: libovsdb.OperationComment, Comment: odbi.txnOrigin})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), options.timeout)
+	defer cancel()
+
+	results, err := odbi.transact(ctx, odbi.db, ops...)
+	odbi.countTxn(cmds, func(c *TxnCounts) {
+		if err != nil {
+			c.Failed++
+		} else {
+			c.Succeeded++
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The total number of UUIDs will be <= number of results returned.
+	UUIDs := make([]string, 0, len(results))
+	for _, r := range results {
+		if len(r.UUID.GoUUID) > 0 {
+			UUIDs = append(UUIDs, r.UUID.GoUUID)
+		}
+	}
+
+	if len(UUIDs) > 0 {
+		return UUIDs, nil
+	}
+
+	return nil, nil
+}
+
+// executeRRetryImp executes the commands and, if the transaction was lost with
+// the connection, e.g. because the server stepped down as the cluster leader,
+// waits for the client to reconnect and resubmits it once. The transaction
+// may have been committed before the connection was lost, so the commands
+// must be idempotent.
+func (odbi *ovndb) executeRRetryImp(opts RetryOpts, cmds ...*OvnCommand) ([]string, error) {
+	client, _ := odbi.getClient()
+	uuids, err := odbi.executeROptions(opts.Options, cmds...)
+	if err == nil || !isConnectionError(err) || !odbi.reconn {
+		return uuids, err
+	}
+
+	timeout := opts.ReconnectTimeout
+	if timeout == 0 {
+		timeout = odbi.timeout
+	}
+	klog.Infof("[%s] transaction failed with the connection (%v), retrying once reconnected", odbi.db, err)
+	odbi.countTxn(cmds, func(c *TxnCounts) { c.Retried++ })
+	if werr := odbi.waitForReconnect(client, timeout); werr != nil {
+		return nil, fmt.Errorf("%v: %v", err, werr)
+	}
+	return odbi.executeROptions(opts.Options, cmds...)
+}
+
+// countTxn applies count to the counters of every table the commands operate
+// on, once per table
+func (odbi *ovndb) countTxn(cmds []*OvnCommand, count func(*TxnCounts)) {
+	odbi.txnCountsMutex.Lock()
+	defer odbi.txnCountsMutex.Unlock()
+	seen := make(map[string]bool)
+	for _, cmd := range cmds {
+		if cmd == nil {
+			continue
+		}
+		for _, op := range cmd.Operations {
+			if op.Table == "" || seen[op.Table] {
+				continue
+			}
+			seen[op.Table] = true
+			counts, ok := odbi.txnCounts[op.Table]
+			if !ok {
+				counts = &TxnCounts{}
+				odbi.txnCounts[op.Table] = counts
+			}
+			count(counts)
+		}
+	}
+}
+
+func (odbi *ovndb) txnCountsImp() map[string]TxnCounts {
+	odbi.txnCountsMutex.Lock()
+	defer odbi.txnCountsMutex.Unlock()
+	counts := make(map[string]TxnCounts, len(odbi.txnCounts))
+	for table, c := range odbi.txnCounts {
+		counts[table] = *c
+	}
+	return counts
+}
+
+// waitForReconnect waits for the client to replace the connection old with a
+// new one, until the timeout expires
+func (odbi *ovndb) waitForReconnect(old *libovsdb.OvsdbClient, timeout time.Duration) error {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	deadline := time.After(timeout)
+	for {
+		if client, err := odbi.getClient(); err == nil && client != old {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-deadline:
+			return fmt.Errorf("client did not reconnect within %v", timeout)
+		}
+	}
+}
+
+func (odbi *ovndb) float64_to_int(row libovsdb.Row) {
+	for field, value := range row.Fields {
+		if v, ok := value.(float64); ok {
+			n := int(v)
+			if float64(n) == v {
+				row.Fields[field] = n
+			}
+		}
+	}
+}
+
+func (odbi *ovndb) signalCreate(table, uuid string) {
+	switch table {
+	case TableLogicalRouter:
+		lr := odbi.rowToLogicalRouter(uuid)
+		odbi.signalCB.OnLogicalRouterCreate(lr)
+	case TableLogicalRouterPort:
+		lrp := odbi.rowToLogicalRouterPort(uuid)
+		odbi.signalCB.OnLogicalRouterPortCreate(lrp)
+	case TableLogicalRouterStaticRoute:
+		lrsr := odbi.rowToLogicalRouterStaticRoute(uuid)
+		odbi.signalCB.OnLogicalRouterStaticRouteCreate(lrsr)
+	case TableLogicalSwitch:
+		ls := odbi.rowToLogicalSwitch(uuid)
+		odbi.signalCB.OnLogicalSwitchCreate(ls)
+	case TableLogicalSwitchPort:
+		lp, err := odbi.uuidToLogicalPort(uuid)
+		if err == nil {
+			odbi.signalCB.OnLogicalPortCreate(lp)
+		}
+	case TableACL:
+		acl := odbi.rowToACL(uuid)
+		odbi.signalCB.OnACLCreate(acl)
+	case TableDHCPOptions:
+		dhcp := odbi.rowToDHCPOptions(uuid)
+		odbi.signalCB.OnDHCPOptionsCreate(dhcp)
+	case TableQoS:
+		qos := odbi.rowToQoS(uuid)
+		odbi.signalCB.OnQoSCreate(qos)
+	case TableLoadBalancer:
+		lb, _ := odbi.rowToLB(uuid)
+		odbi.signalCB.OnLoadBalancerCreate(lb)
+	case TableMeter:
+		meter := odbi.rowToMeter(uuid)
+		odbi.signalCB.OnMeterCreate(meter)
+	case TableMeterBand:
+		band, _ := odbi.rowToMeterBand(uuid)
+		odbi.signalCB.OnMeterBandCreate(band)
+	case TableChassis:
+		chassis, _ := odbi.rowToChassis(uuid)
+		odbi.signalCB.OnChassisCreate(chassis)
+	case TableEncap:
+		encap, _ := odbi.rowToEncap(uuid)
+		odbi.signalCB.OnEncapCreate(encap)
+	}
+}
+
+func (odbi *ovndb) signalDelete(table, uuid string) {
+	switch table {
+	case TableLogicalRouter:
+		lr := odbi.rowToLogicalRouter(uuid)
+		odbi.signalCB.OnLogicalRouterDelete(lr)
+	case TableLogicalRouterPort:
+		lrp := odbi.rowToLogicalRouterPort(uuid)
+		odbi.signalCB.OnLogicalRouterPortDelete(lrp)
+	case TableLogicalRouterStaticRoute:
+		lrsr := odbi.rowToLogicalRouterStaticRoute(uuid)
+		odbi.signalCB.OnLogicalRouterStaticRouteDelete(lrsr)
+	case TableLogicalSwitch:
+		ls := odbi.rowToLogicalSwitch(uuid)
+		odbi.signalCB.OnLogicalSwitchDelete(ls)
+	case TableLogicalSwitchPort:
+		lp, err := odbi.uuidToLogicalPort(uuid)
+		if err == nil {
+			odbi.signalCB.OnLogicalPortDelete(lp)
+		}
+	case TableACL:
+		acl := odbi.rowToACL(uuid)
+		odbi.signalCB.OnACLDelete(acl)
+	case TableDHCPOptions:
+		dhcp := odbi.rowToDHCPOptions(uuid)
+		odbi.signalCB.OnDHCPOptionsDelete(dhcp)
+	case TableQoS:
+		qos := odbi.rowToQoS(uuid)
+		odbi.signalCB.OnQoSDelete(qos)
+	case TableLoadBalancer:
+		lb, _ := odbi.rowToLB(uuid)
+		odbi.signalCB.OnLoadBalancerDelete(lb)
+	case TableMeter:
+		meter := odbi.rowToMeter(uuid)
+		odbi.signalCB.OnMeterDelete(meter)
+	case TableMeterBand:
+		band, _ := odbi.rowToMeterBand(uuid)
+		odbi.signalCB.OnMeterBandDelete(band)
+	case TableChassis:
+		chassis, _ := odbi.rowToChassis(uuid)
+		odbi.signalCB.OnChassisDelete(chassis)
+	case TableEncap:
+		encap, _ := odbi.rowToEncap(uuid)
+		odbi.signalCB.OnEncapDelete(encap)
+	}
+}
+
+func (odbi *ovndb) requestDisconnect() {
+	select {
+	case odbi.disconnSig <- struct{}{}:
+		klog.V(5).Infof("Requested disconnect from follower")
+	default:
+		klog.V(5).Infof("Disconnect from follower already requested")
+	}
+}
+
+func (odbi *ovndb) disconnectIfFollower(table, uuid string) {
+	if table == TableDatabase && odbi.leaderOnly && !odbi.serverIsLeader() {
+		klog.Infof("Leader-only requested; disconnecting from follower %s...", odbi.endpoints[odbi.curEndpoint])
+		// Disconnect client and let the disconnect notification
+		// from libovsdb trigger our reconnect handler
+		odbi.nextEndpoint()
+		odbi.requestDisconnect()
+	}
+}
+
+// checkLeaderChange reports the leader state of the server through the
+// OnLeaderChange callback, the first time it is known and then only when it
+// changes. It must be called once the server cache has been updated.
+// The callback runs in its own goroutine, as the cache locks, and on connect
+// the client and transaction locks, are held here, but after the callbacks
+// of the previous changes so that it sees the changes in order.
+func (odbi *ovndb) checkLeaderChange(dbName string) {
+	if dbName != DBServer || odbi.leaderCB == nil {
+		return
+	}
+	isLeader := odbi.serverIsLeader()
+	if odbi.leaderKnown && odbi.isLeader == isLeader {
+		return
+	}
+	odbi.leaderKnown, odbi.isLeader = true, isLeader
+
+	prev, done := odbi.leaderCBDone, make(chan struct{})
+	odbi.leaderCBDone = done
+	go func() {
+		defer close(done)
+		if prev != nil {
+			<-prev
+		}
+		odbi.leaderCB(isLeader)
+	}()
+}
+
+func (odbi *ovndb) getContext(dbName string) (*map[string][]string, *map[string]map[string]libovsdb.Row, func(string, string), func(string, string)) {
+	if dbName == DBServer {
+		return &odbi.serverTableCols, &odbi.serverCache, odbi.disconnectIfFollower, odbi.disconnectIfFollower
+	}
+	if odbi.signalCB == nil {
+		return &odbi.tableCols, &odbi.cache, nil, nil
+	}
+	return &odbi.tableCols, &odbi.cache, odbi.signalCreate, odbi.signalDelete
+}
+
+// checkCacheLimits compares the number of cached rows of the updated tables,
+// and of the whole cache, against the configured limits. It must be called
+// with cachemutex held once the updates have been applied. The alarm is only
+// raised when a limit is crossed, not on every update while it stays exceeded.
+func (odbi *ovndb) checkCacheLimits(dbName string, tables []string) {
+	if dbName == DBServer || (odbi.maxCachedRows <= 0 && len(odbi.maxCachedTableRows) == 0) {
+		return
+	}
+	for _, table := range tables {
+		if limit, ok := odbi.maxCachedTableRows[table]; ok && limit > 0 {
+			odbi.checkCacheLimit(table, len(odbi.cache[table]), limit)
+		}
+	}
+	if odbi.maxCachedRows > 0 {
+		total := 0
+		for _, rows := range odbi.cache {
+			total += len(rows)
+		}
+		odbi.checkCacheLimit("", total, odbi.maxCachedRows)
+	}
+}
+
+func (odbi *ovndb) checkCacheLimit(table string, rows, limit int) {
+	exceeded := rows > limit
+	if exceeded == odbi.cacheLimitExceeded[table] {
+		return
+	}
+	if odbi.cacheLimitExceeded == nil {
+		odbi.cacheLimitExceeded = make(map[string]bool)
+	}
+	odbi.cacheLimitExceeded[table] = exceeded
+
+	scope := "table " + table
+	if table == "" {
+		scope = "all tables"
+	}
+	if !exceeded {
+		klog.Infof("[%s] cache is back under its limit: %d rows in %s, limit %d", odbi.db, rows, scope, limit)
+		return
+	}
+	klog.Errorf("[%s] CACHE LIMIT EXCEEDED: %d rows in %s, limit %d; consider restricting the monitored "+
+		"tables, the process may run out of memory", odbi.db, rows, scope, limit)
+	if odbi.cacheLimitCB != nil {
+		// the callback must not run with cachemutex held
+		go odbi.cacheLimitCB(table, rows, limit)
+	}
+}
+
+// waitForCacheUpdate returns a channel that is closed once the next update of
+// the given table has been applied to the cache. The caller must hold
+// cachemutex while checking the cache and registering, so no update is missed.
+func (odbi *ovndb) waitForCacheUpdate(table string) <-chan struct{} {
+	ch := make(chan struct{})
+	odbi.cacheWaitersMutex.Lock()
+	defer odbi.cacheWaitersMutex.Unlock()
+	if odbi.cacheWaiters == nil {
+		odbi.cacheWaiters = make(map[string][]chan struct{})
+	}
+	odbi.cacheWaiters[table] = append(odbi.cacheWaiters[table], ch)
+	return ch
+}
+
+// notifyCacheWaiters wakes up everyone waiting for an update of the given tables
+func (odbi *ovndb) notifyCacheWaiters(dbName string, tables []string) {
+	if dbName != odbi.db {
+		return
+	}
+	odbi.cacheWaitersMutex.Lock()
+	defer odbi.cacheWaitersMutex.Unlock()
+	for _, table := range tables {
+		for _, ch := range odbi.cacheWaiters[table] {
+			close(ch)
+		}
+		delete(odbi.cacheWaiters, table)
+	}
+}
+
+// markUpdated records that the monitor delivered an update of the database,
+// must be called with cachemutex held
+func (odbi *ovndb) markUpdated(dbName string) {
+	if dbName == odbi.db {
+		odbi.lastUpdate = time.Now()
+	}
+}
+
+func (odbi *ovndb) populateCache(dbName string, updates libovsdb.TableUpdates, signal bool) {
+	tableCols, cache, signalCreate, signalDelete := odbi.getContext(dbName)
+	odbi.markUpdated(dbName)
+
+	updatedTables := make([]string, 0, len(updates.Updates))
+	for table := range updates.Updates {
+		updatedTables = append(updatedTables, table)
+	}
+	// deferred first so waiters are notified after the deferred row deletions
+	defer odbi.notifyCacheWaiters(dbName, updatedTables)
+	defer odbi.checkCacheLimits(dbName, updatedTables)
+	// the batch ends after the deferred row deletions
+	defer odbi.startSignalBatch(dbName, signal)()
+
+	empty := libovsdb.Row{}
+
+	for table := range *tableCols {
+		tableUpdate, ok := updates.Updates[table]
+		if !ok {
+			continue
+		}
+
+		if _, ok := (*cache)[table]; !ok {
+			(*cache)[table] = make(map[string]libovsdb.Row)
+		}
+		for uuid, row := range tableUpdate.Rows {
+			// TODO: this is a workaround for the problem of
+			// missing json number conversion in libovsdb
+			odbi.float64_to_int(row.New)
+
+			if !reflect.DeepEqual(row.New, empty) {
+				// the rows are sent whole, so a row whose changes were all
+				// to ignored columns compares equal to the cached one
+				odbi.dropIgnoredColumns(table, &row.New)
+				if reflect.DeepEqual(row.New, (*cache)[table][uuid]) {
+					// Already existed and unchanged, ignore (this can happen when auto-reconnect)
+					continue
+				}
+				(*cache)[table][uuid] = row.New
+				if signal && signalCreate != nil {
+					signalCreate(table, uuid)
+				}
+			} else {
+				defer delete((*cache)[table], uuid)
+				if signal && signalDelete != nil {
+					defer signalDelete(table, uuid)
+				}
+			}
+		}
+	}
+}
+
+func (odbi *ovndb) initMissingColumnsWithDefaults(db, table string, row *libovsdb.Row) {
+	if odbi.skipColumnDefaults {
+		return
+	}
+	schema := odbi.getSchema(db)
+	tableSchema := schema.Tables[table]
+
+	for column, columnSchema := range tableSchema.Columns {
+		_, ok := row.Fields[column]
+		if !ok {
+			switch columnSchema.Type {
+			case "integer", "real":
+				row.Fields[column] = columnSchema.TypeObj.Min()
+			case "boolean":
+				if (columnSchema.TypeObj.Min() == 0) && (columnSchema.TypeObj.Max() == 1) {
+					row.Fields[column] = interface{}(nil)
+				} else {
+					row.Fields[column] = false
+				}
+			case "map":
+				row.Fields[column] = libovsdb.OvsMap{GoMap: make(map[interface{}]interface{})}
+			case "set":
+				row.Fields[column] = libovsdb.OvsSet{GoSet: make([]interface{}, 0)}
+			case "string":
+				row.Fields[column] = ""
+			default:
+				row.Fields[column] = interface{}(nil)
+			}
+		}
+	}
+}
+
+func updateSetWithElem(ovsSet *libovsdb.OvsSet, elem interface{}) {
+	bv := reflect.ValueOf(ovsSet.GoSet)
+	nv := reflect.ValueOf(elem)
+	var found bool
+	for i := 0; i < bv.Len(); i++ {
+		if bv.Index(i).Interface() == nv.Interface() {
+			// found a match, delete from slice
+			found = true
+			ovsSet.GoSet[i] = ovsSet.GoSet[bv.Len()-1]
+			ovsSet.GoSet = ovsSet.GoSet[0 : bv.Len()-1]
+			break
+		}
+	}
+
+	if !found {
+		ovsSet.GoSet = append(ovsSet.GoSet, elem)
+	}
+}
+
+func (odbi *ovndb) modifySet(orig interface{}, elem interface{}) *libovsdb.OvsSet {
+	var cv *libovsdb.OvsSet
+	o := reflect.ValueOf(orig)
+	switch o.Elem().Interface().(type) {
+	case libovsdb.OvsSet:
+		t := o.Elem().Interface().(libovsdb.OvsSet)
+		cv = &t
+	default:
+		temp := o.Elem().Interface()
+		origC := make([]interface{}, 1)
+		origC[0] = temp
+		cv, _ = libovsdb.NewOvsSet(origC)
+	}
+
+	switch elem.(type) {
+	case libovsdb.OvsSet:
+		t := elem.(libovsdb.OvsSet)
+		tv := reflect.ValueOf(t.GoSet)
+		for i := 0; i < tv.Len(); i++ {
+			updateSetWithElem(cv, tv.Index(i).Interface())
+		}
+	default:
+		updateSetWithElem(cv, elem)
+	}
+
+	return cv
+}
+
+func (odbi *ovndb) applyUpdatesToRow(db, table string, uuid string, rowdiff *libovsdb.Row, cache *map[string]map[string]libovsdb.Row) {
+	row := (*cache)[table][uuid]
+
+	for column, value := range rowdiff.Fields {
+		columnSchema, ok := odbi.getSchema(db).Tables[table].Columns[column]
+		if !ok {
+			if column == "_version" {
+				// not in the schema, but replaced by every update
+				row.Fields[column] = value
+			}
+			continue
+		}
+
+		switch columnSchema.Type {
+		case "map":
+			if _, ok := row.Fields[column].(libovsdb.OvsMap); !ok {
+				row.Fields[column] = libovsdb.OvsMap{GoMap: make(map[interface{}]interface{})}
+			}
+			for k, v := range value.(libovsdb.OvsMap).GoMap {
+				pv, ok := row.Fields[column].(libovsdb.OvsMap).GoMap[k]
+				if !ok {
+					/* New key */
+					row.Fields[column].(libovsdb.OvsMap).GoMap[k] = v
+				} else {
+					if pv != v {
+						/* Value changed.  Update it. */
+						row.Fields[column].(libovsdb.OvsMap).GoMap[k] = v
+					} else {
+						/* Delete the key. */
+						delete(row.Fields[column].(libovsdb.OvsMap).GoMap, k)
+					}
+				}
+			}
+		case "set":
+			if columnSchema.TypeObj.Max() == 1 {
+				row.Fields[column] = value
+			} else {
+				cv, ok := row.Fields[column]
+				if !ok {
+					cv = libovsdb.OvsSet{GoSet: make([]interface{}, 0)}
+				}
+				nv := odbi.modifySet(&cv, value)
+				bv := reflect.ValueOf(nv.GoSet)
+				if bv.Len() == 1 {
+					row.Fields[column] = bv.Index(0).Interface()
+				} else {
+					row.Fields[column] = *nv
+				}
+			}
+		default:
+			row.Fields[column] = value
+		}
+	}
+
+	(*cache)[table][uuid] = row
+}
+
+func (odbi *ovndb) populateCache2(dbName string, updates libovsdb.TableUpdates2, signal bool) {
+	tableCols, cache, signalCreate, signalDelete := odbi.getContext(dbName)
+	odbi.markUpdated(dbName)
+
+	updatedTables := make([]string, 0, len(updates.Updates))
+	for table := range updates.Updates {
+		updatedTables = append(updatedTables, table)
+	}
+	// deferred first so waiters are notified after the deferred row deletions
+	defer odbi.notifyCacheWaiters(dbName, updatedTables)
+	defer odbi.checkCacheLimits(dbName, updatedTables)
+	defer odbi.checkLeaderChange(dbName)
+	// the batch ends after the deferred row deletions
+	defer odbi.startSignalBatch(dbName, signal)()
+
+	for table := range *tableCols {
+		tableUpdate, ok := updates.Updates[table]
+		if !ok {
+			continue
+		}
+
+		if _, ok := (*cache)[table]; !ok {
+			(*cache)[table] = make(map[string]libovsdb.Row)
+		}
+
+		for uuid, row := range tableUpdate.Rows {
+			switch {
+			case row.Initial.Fields != nil:
+				// TODO: this is a workaround for the problem of
+				// missing json number conversion in libovsdb
+				odbi.float64_to_int(row.Initial)
+				odbi.dropIgnoredColumns(table, &row.Initial)
+				if reflect.DeepEqual(row.Initial, (*cache)[table][uuid]) {
+					// Already existed and unchanged, ignore (this can happen when auto-reconnect)
+					continue
+				}
+				odbi.initMissingColumnsWithDefaults(dbName, table, &row.Initial)
+				(*cache)[table][uuid] = row.Initial
+				if signal && signalCreate != nil {
+					signalCreate(table, uuid)
+				}
+			case row.Insert.Fields != nil:
+				odbi.dropIgnoredColumns(table, &row.Insert)
+				odbi.initMissingColumnsWithDefaults(dbName, table, &row.Insert)
+				// TODO: this is a workaround for the problem of
+				// missing json number conversion in libovsdb
+				odbi.float64_to_int(row.Insert)
+				(*cache)[table][uuid] = row.Insert
+				if signal && signalCreate != nil {
+					signalCreate(table, uuid)
+				}
+			case row.Modify.Fields != nil:
+				// TODO: this is a workaround for the problem of
+				// missing json number conversion in libovsdb
+				odbi.float64_to_int(row.Modify)
+				changed := odbi.dropIgnoredColumns(table, &row.Modify)
+				// the _version is applied regardless, for WithRowVersion
+				odbi.applyUpdatesToRow(dbName, table, uuid, &row.Modify, cache)
+				if changed && signal && signalCreate != nil {
+					signalCreate(table, uuid)
+				}
+			case row.Delete.Fields != nil:
+				defer delete((*cache)[table], uuid)
+				if signal && signalDelete != nil {
+					signalDelete(table, uuid)
+				}
+			}
+		}
+	}
+}
+
+// startSignalBatch tells the signal callback, if it implements OVNBatchSignal,
+// that the row callbacks of an update of dbName start, and returns the
+// function telling it that they ended
+func (odbi *ovndb) startSignalBatch(dbName string, signal bool) func() {
+	batch, ok := odbi.signalCB.(OVNBatchSignal)
+	if !ok || !signal || dbName == DBServer {
+		return func() {}
+	}
+	batch.OnBatchStart()
+	return batch.OnBatchEnd
+}
+
+// dropIgnoredColumns removes the columns of Config.IgnoreColumns from a row
+// update and tells whether it still changes a column other than _version
+func (odbi *ovndb) dropIgnoredColumns(table string, row *libovsdb.Row) bool {
+	changed := false
+	for column := range row.Fields {
+		if odbi.ignoreColumns[table][column] {
+			delete(row.Fields, column)
+		} else if column != "_version" {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// sortList sorts a slice of pointers to table items by their Name field, if
+// they have one, and then by UUID when Config.SortListResults is set.
+func (odbi *ovndb) sortList(list interface{}) {
+	if !odbi.sortListResults {
+		return
+	}
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice || v.Len() < 2 {
+		return
+	}
+	field := func(i int, name string) string {
+		item := v.Index(i)
+		if item.IsNil() {
+			return ""
+		}
+		f := item.Elem().FieldByName(name)
+		if !f.IsValid() || f.Kind() != reflect.String {
+			return ""
+		}
+		return f.String()
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if ni, nj := field(i, "Name"), field(j, "Name"); ni != nj {
+			return ni < nj
+		}
+		return field(i, "UUID") < field(j, "UUID")
+	})
+}
+
+func (odbi *ovndb) ConvertGoSetToStringArray(oset libovsdb.OvsSet) []string {
+	var ret = []string{}
+	for _, s := range oset.GoSet {
+		switch s.(type) {
+		case string:
+			value := s.(string)
+			ret = append(ret, value)
+		case libovsdb.UUID:
+			uuid := s.(libovsdb.UUID)
+			ret = append(ret, uuid.GoUUID)
+		}
+	}
+	return ret
+}
+
+func (odbi *ovndb) optionalStringFieldToPointer(fieldValue interface{}) *string {
+	switch fieldValue.(type) {
+	case string:
+		temp := fieldValue.(string)
+		return &temp
+	case libovsdb.OvsSet:
+		temp := odbi.ConvertGoSetToStringArray(fieldValue.(libovsdb.OvsSet))
+		if len(temp) > 0 {
+			return &temp[0]
+		}
+		return nil
+	}
+	return nil
+}
+
+// rowVersion returns the _version of a cached row, empty if it is not known
+func rowVersion(row *libovsdb.Row) string {
+	if version, ok := row.Fields["_version"].(libovsdb.UUID); ok {
+		return version.GoUUID
+	}
+	return ""
+}
+
+// rowString, rowInt, rowBool and rowMap return the value of a column of a
+// cached row, or its zero value when the column is missing, as it is when
+// Config.SkipColumnDefaults is set and the server didn't send it
+func rowString(row libovsdb.Row, column string) string {
+	value, _ := row.Fields[column].(string)
+	return value
+}
+
+func rowInt(row libovsdb.Row, column string) int {
+	value, _ := row.Fields[column].(int)
+	return value
+}
+
+func rowBool(row libovsdb.Row, column string) bool {
+	value, _ := row.Fields[column].(bool)
+	return value
+}
+
+func rowMap(row libovsdb.Row, column string) map[interface{}]interface{} {
+	if value, ok := row.Fields[column].(libovsdb.OvsMap); ok {
+		return value.GoMap
+	}
+	return make(map[interface{}]interface{})
+}
+
+// rowUUID returns the UUID an optional reference column of a cached row points
+// to, or an empty string when it is unset
+func rowUUID(row libovsdb.Row, column string) string {
+	switch value := row.Fields[column].(type) {
+	case libovsdb.UUID:
+		return value.GoUUID
+	case libovsdb.OvsSet:
+		if len(value.GoSet) > 0 {
+			if uuid, ok := value.GoSet[0].(libovsdb.UUID); ok {
+				return uuid.GoUUID
+			}
+		}
+	}
+	return ""
+}
+
+func stringToGoUUID(uuid string) libovsdb.UUID {
+	return libovsdb.UUID{GoUUID: uuid}
+}
+
+// optionalUUID returns the value to write to an optional reference column:
+// the referenced row, or an empty set clearing the column when uuid is nil
+func optionalUUID(uuid *string) (interface{}, error) {
+	if uuid == nil {
+		return libovsdb.OvsSet{GoSet: []interface{}{}}, nil
+	}
+	if len(*uuid) == 0 {
+		return nil, fmt.Errorf("referenced row UUID cannot be empty, use nil to clear the reference")
+	}
+	return stringToGoUUID(*uuid), nil
+}
+
+// columnSupported reports whether the schema of the server has the column, so
+// that columns added by newer OVN releases are not written to older ones
+func (odbi *ovndb) columnSupported(table, column string) bool {
+	_, ok := odbi.GetSchema().Tables[table].Columns[column]
+	return ok
+}
+
+// tableSupported reports whether the schema of the server has the table,
+// whether or not it is monitored
+func (odbi *ovndb) tableSupported(table string) bool {
+	_, ok := odbi.GetSchema().Tables[table]
+	return ok
+}
+
+func (odbi *ovndb) auxKeyValSet(table string, rowName string, auxCol string, kv map[string]string) (*OvnCommand, error) {
+	if len(kv) == 0 {
+		return nil, fmt.Errorf("key-value map is nil or empty")
+	}
+
+	ovnRow := make(OVNRow)
+	ovnRow["name"] = rowName
+
+	uuid := odbi.getRowUUID(table, ovnRow)
+	col := odbi.cache[table][uuid].Fields[auxCol]
+	if col == nil {
+		return nil, fmt.Errorf("table %s, row %s, column %s not present in cache", table, rowName, auxCol)
+	}
+
+	switch col.(type) {
+	case libovsdb.OvsMap:
+	default:
+		return nil, fmt.Errorf("table %s, row %s, column %s: value is not a map", table, rowName, auxCol)
+	}
+
+	cachedMap := col.(libovsdb.OvsMap).GoMap
+
+	// prepare new map for the update by copying keys/values from the kv map,
+	// followed by copying all other keys/values from the cache. NB: this is to implement functionality
+	// not explicitly provided by RFC7047 - change values for individual keys that already exist
+	mergedMap := make(map[interface{}]interface{}, len(kv)+len(cachedMap))
+	for k, v := range kv {
+		mergedMap[k] = v
+	}
+	for k, v := range cachedMap {
+		ck := k.(string)
+		if _, ok := kv[ck]; !ok {
+			mergedMap[ck] = v.(string)
+		}
+	}
+
+	auxMap, err := libovsdb.NewOvsMap(mergedMap)
+	if err != nil {
+		return nil, err
+	}
+	ovnRow[auxCol] = auxMap
+
+	condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(uuid))
+	operation := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: table,
+		Where: []interface{}{condition},
+		Row:   ovnRow,
+	}
+
+	operations := []libovsdb.Operation{operation}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+// bulkExternalIDSetImp merges kv into the external_ids of every row of table
+// named one of rowNames, skipping the names no row has. Each row is mutated
+// rather than updated from the cache, so the keys other clients set meanwhile
+// are kept.
+func (odbi *ovndb) bulkExternalIDSetImp(table string, rowNames []string, kv map[string]string) (*OvnCommand, error) {
+	if len(kv) == 0 {
+		return nil, fmt.Errorf("key-value map is nil or empty")
+	}
+	keys := make([]string, 0, len(kv))
+	for key := range kv {
+		keys = append(keys, key)
+	}
+	delSet, err := libovsdb.NewOvsSet(keys)
+	if err != nil {
+		return nil, err
+	}
+	insMap, err := libovsdb.NewOvsMap(kv)
+	if err != nil {
+		return nil, err
+	}
+
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheTable, ok := odbi.cache[table]
+	if !ok {
+		return nil, ErrorSchema
+	}
+	wanted := make(map[string]bool, len(rowNames))
+	for _, name := range rowNames {
+		wanted[name] = true
+	}
+	var uuids []string
+	for uuid, drows := range cacheTable {
+		if name, ok := drows.Fields["name"].(string); ok && wanted[name] {
+			uuids = append(uuids, uuid)
+		}
+	}
+	if len(uuids) == 0 {
+		return nil, ErrorNotFound
+	}
+	sort.Strings(uuids)
+
+	operations := make([]libovsdb.Operation, 0, len(uuids))
+	for _, uuid := range uuids {
+		operations = append(operations, libovsdb.Operation{
+			Op:    opMutate,
+			Table: table,
+			Mutations: []interface{}{
+				libovsdb.NewMutation("external_ids", opDelete, delSet),
+				libovsdb.NewMutation("external_ids", opInsert, insMap),
+			},
+			Where: []interface{}{libovsdb.NewCondition("_uuid", "==", stringToGoUUID(uuid))},
+		})
+	}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+// delByExternalIDImp deletes the rows of table whose external_ids have key set
+// to value, after removing the references other rows hold to them, e.g. the
+// nat column of the routers for NAT rows. Weak references are removed by the
+// server, references from map columns and mandatory single references cannot
+// be removed.
+func (odbi *ovndb) delByExternalIDImp(table, key, value string) (*OvnCommand, error) {
+	if len(key) == 0 {
+		return nil, fmt.Errorf("external_ids key cannot be empty")
+	}
+	if !odbi.tableSupported(table) {
+		return nil, ErrorSchema
+	}
+	schema := odbi.GetSchema()
+
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheTable := odbi.cache[table]
+	owned := make(map[string]bool)
+	var uuids []string
+	for uuid, drows := range cacheTable {
+		if rowMap(drows, "external_ids")[key] == value {
+			owned[uuid] = true
+			uuids = append(uuids, uuid)
+		}
+	}
+	if len(uuids) == 0 {
+		return nil, ErrorNotFound
+	}
+	sort.Strings(uuids)
+
+	detached := make(map[rowHolder][]libovsdb.UUID)
+	var holders []rowHolder
+	for _, ref := range odbi.referencesTo(schema, table, owned) {
+		// the rows deleted together need not be detached from each other
+		if ref.weak || (ref.table == table && owned[ref.uuid]) {
+			continue
+		}
+		if ref.inMap || (ref.single && !ref.optional) {
+			return nil, fmt.Errorf("cannot remove the reference to %s row %s held by the %s column of %s row %s",
+				table, ref.held, ref.column, ref.table, ref.uuid)
+		}
+		if _, ok := detached[ref.rowHolder]; !ok {
+			holders = append(holders, ref.rowHolder)
+		}
+		detached[ref.rowHolder] = append(detached[ref.rowHolder], stringToGoUUID(ref.held))
+	}
+	sort.Slice(holders, func(i, j int) bool {
+		if holders[i].table != holders[j].table {
+			return holders[i].table < holders[j].table
+		}
+		if holders[i].uuid != holders[j].uuid {
+			return holders[i].uuid < holders[j].uuid
+		}
+		return holders[i].column < holders[j].column
+	})
+
+	var operations []libovsdb.Operation
+	for _, holder := range holders {
+		delSet, err := libovsdb.NewOvsSet(detached[holder])
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, libovsdb.Operation{
+			Op:        opMutate,
+			Table:     holder.table,
+			Mutations: []interface{}{libovsdb.NewMutation(holder.column, opDelete, delSet)},
+			Where:     []interface{}{libovsdb.NewCondition("_uuid", "==", stringToGoUUID(holder.uuid))},
+		})
+	}
+	for _, uuid := range uuids {
+		operations = append(operations, libovsdb.Operation{
+			Op:    opDelete,
+			Table: table,
+			Where: []interface{}{libovsdb.NewCondition("_uuid", "==", stringToGoUUID(uuid))},
+		})
+	}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) auxKeyValDel(table string, rowName string, auxCol string, kv map[string]*string) (*OvnCommand, error) {
+	if len(kv) == 0 {
+		return nil, fmt.Errorf("KV map is empty")
+	}
+
+	ovnRow := make(OVNRow)
+	ovnRow["name"] = rowName
+	uuid := odbi.getRowUUID(TableLogicalSwitch, ovnRow)
+	if len(uuid) == 0 {
+		return nil, ErrorNotFound
+	}
+
+	delKeys := []string{}
+	delKeyVals := make(map[string]string, len(kv))
+
+	for k, v := range kv {
+		if v == nil {
+			delKeys = append(delKeys, k)
+		} else {
+			delKeyVals[k] = *v
+		}
+	}
+
+	var mutateSet *libovsdb.OvsSet
+	var mutateMap *libovsdb.OvsMap
+	var err error
+
+	condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(uuid))
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     table,
+		Mutations: []interface{}{},
+		Where:     []interface{}{condition},
+	}
+
+	if len(delKeys) != 0 {
+		mutateSet, err = libovsdb.NewOvsSet(delKeys)
+		if err != nil {
+			return nil, err
+		}
+		m := libovsdb.NewMutation(auxCol, opDelete, mutateSet)
+		mutateOp.Mutations = append(mutateOp.Mutations, m)
+	}
+	if len(delKeyVals) != 0 {
+		mutateMap, err = libovsdb.NewOvsMap(delKeyVals)
+		if err != nil {
+			return nil, err
+		}
+		m := libovsdb.NewMutation(auxCol, opDelete, mutateMap)
+		mutateOp.Mutations = append(mutateOp.Mutations, m)
+	}
+
+	operations := []libovsdb.Operation{mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+// mutateColumnImp mutates column of the row named rowName in table. value may
+// be a Go slice, which is mutated as a set, a map[string]string, or a value
+// that is already in OVSDB notation
+func (odbi *ovndb) mutateColumnImp(table, rowName, column, mutator string, value interface{}) (*OvnCommand, error) {
+	if len(column) == 0 || value == nil {
+		return nil, ErrorOption
+	}
+	switch mutator {
+	case opInsert, opDelete, "+=", "-=", "*=", "/=", "%=":
+	default:
+		return nil, ErrorOption
+	}
+
+	if !odbi.columnSupported(table, "name") || !odbi.columnSupported(table, column) {
+		return nil, ErrorSchema
+	}
+
+	row := make(OVNRow)
+	row["name"] = rowName
+	uuids := odbi.getRowUUIDs(table, row)
+	switch len(uuids) {
+	case 0:
+		return nil, ErrorNotFound
+	case 1:
+	default:
+		return nil, ErrorDuplicateName
+	}
+
+	var err error
+	switch v := value.(type) {
+	case map[string]string:
+		value, err = libovsdb.NewOvsMap(v)
+	default:
+		if reflect.ValueOf(value).Kind() == reflect.Slice {
+			value, err = libovsdb.NewOvsSet(value)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(uuids[0]))
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     table,
+		Mutations: []interface{}{libovsdb.NewMutation(column, mutator, value)},
+		Where:     []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) getExternalIDsImp(table string, rowName string) (map[string]string, error) {
+	if !odbi.columnSupported(table, "name") || !odbi.columnSupported(table, "external_ids") {
+		return nil, ErrorSchema
+	}
+
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	var found *libovsdb.Row
+	for uuid := range odbi.cache[table] {
+		row := odbi.cache[table][uuid]
+		// a column left at its default may be missing from the cached row
+		name, _ := row.Fields["name"].(string)
+		if name != rowName {
+			continue
+		}
+		if found != nil {
+			return nil, ErrorDuplicateName
+		}
+		found = &row
+	}
+	if found == nil {
+		return nil, ErrorNotFound
+	}
+
+	externalIDs := make(map[string]string)
+	col, ok := found.Fields["external_ids"].(libovsdb.OvsMap)
+	if !ok {
+		return externalIDs, nil
+	}
+	for k, v := range col.GoMap {
+		key, keyOk := k.(string)
+		value, valueOk := v.(string)
+		if keyOk && valueOk {
+			externalIDs[key] = value
+		}
+	}
+	return externalIDs, nil
+}
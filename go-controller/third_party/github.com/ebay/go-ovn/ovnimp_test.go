@@ -0,0 +1,1154 @@
+package goovn
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortListResults(t *testing.T) {
+	switchRow := func(name string) libovsdb.Row {
+		return libovsdb.Row{Fields: map[string]interface{}{"name": name}}
+	}
+	cache := map[string]map[string]libovsdb.Row{
+		TableLogicalSwitch: {
+			"uuid3": switchRow("ls-b"),
+			"uuid1": switchRow("ls-c"),
+			"uuid2": switchRow("ls-a"),
+			"uuid0": switchRow("ls-b"),
+			"uuid4": switchRow(""),
+		},
+	}
+	names := func(list []*LogicalSwitch) []string {
+		var ret []string
+		for _, ls := range list {
+			ret = append(ret, ls.Name+"/"+ls.UUID)
+		}
+		return ret
+	}
+	sorted := []string{"/uuid4", "ls-a/uuid2", "ls-b/uuid0", "ls-b/uuid3", "ls-c/uuid1"}
+
+	t.Run("sorted by name then UUID when enabled", func(t *testing.T) {
+		odbi := &ovndb{db: DBNB, cache: cache, sortListResults: true}
+		list, err := odbi.LSList()
+		assert.Nil(t, err)
+		assert.Equal(t, sorted, names(list))
+	})
+
+	t.Run("left in cache order when disabled", func(t *testing.T) {
+		odbi := &ovndb{db: DBNB, cache: cache}
+		list, err := odbi.LSList()
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, sorted, names(list))
+
+		// the cache order is random, so check that an unsorted list is
+		// left alone
+		unsorted := []*LogicalSwitch{{Name: "ls-c", UUID: "uuid1"}, {Name: "ls-a", UUID: "uuid2"}}
+		odbi.sortList(unsorted)
+		assert.Equal(t, []string{"ls-c/uuid1", "ls-a/uuid2"}, names(unsorted))
+	})
+
+	t.Run("nil entries and unnamed rows sort first", func(t *testing.T) {
+		odbi := &ovndb{db: DBNB, sortListResults: true}
+		list := []*LogicalSwitch{{Name: "ls-a", UUID: "uuid2"}, nil, {UUID: "uuid1"}}
+		odbi.sortList(list)
+		assert.Nil(t, list[0])
+		assert.Equal(t, "uuid1", list[1].UUID)
+		assert.Equal(t, "uuid2", list[2].UUID)
+
+		odbi.sortList(nil)
+	})
+}
+
+// newTestClient returns a client whose schema of db has the given tables, with
+// the given columns, for the commands that check the schema of the server
+func newTestClient(db string, tables map[string][]string) *libovsdb.OvsdbClient {
+	schema := libovsdb.DatabaseSchema{Tables: make(map[string]libovsdb.TableSchema, len(tables))}
+	for table, columns := range tables {
+		tableSchema := libovsdb.TableSchema{Columns: make(map[string]*libovsdb.ColumnSchema, len(columns))}
+		for _, column := range columns {
+			tableSchema.Columns[column] = &libovsdb.ColumnSchema{Type: libovsdb.TypeString}
+		}
+		schema.Tables[table] = tableSchema
+	}
+	return &libovsdb.OvsdbClient{Schema: map[string]libovsdb.DatabaseSchema{db: schema}}
+}
+
+// newTestSchemaClient returns a client whose schema of db has the tables given
+// in the JSON notation of RFC 7047, for the commands that follow references
+func newTestSchemaClient(t testing.TB, db, tables string) *libovsdb.OvsdbClient {
+	schema := libovsdb.DatabaseSchema{}
+	if err := json.Unmarshal([]byte(tables), &schema.Tables); err != nil {
+		t.Fatalf("invalid test schema: %v", err)
+	}
+	return &libovsdb.OvsdbClient{Schema: map[string]libovsdb.DatabaseSchema{db: schema}}
+}
+
+// newTestDB returns a client of db whose schema has the given columns of the
+// tables and whose cache has the given rows. As populateCache only adds the
+// tables that have rows, the tables without rows are left out of the cache.
+func newTestDB(db string, tables map[string][]string, rows map[string]map[string]libovsdb.Row) *ovndb {
+	cache := make(map[string]map[string]libovsdb.Row, len(rows))
+	for table, tableRows := range rows {
+		if len(tableRows) > 0 {
+			cache[table] = tableRows
+		}
+	}
+	return &ovndb{db: db, client: newTestClient(db, tables), cache: cache}
+}
+
+func TestGetExternalIDs(t *testing.T) {
+	const schema = `{
+		"Logical_Switch": {"columns": {
+			"name": {"type": "string"},
+			"external_ids": {"type": {"key": "string", "value": "string", "min": 0, "max": "unlimited"}}}},
+		"Load_Balancer": {"columns": {
+			"name": {"type": "string"},
+			"external_ids": {"type": {"key": "string", "value": "string", "min": 0, "max": "unlimited"}}}},
+		"NAT": {"columns": {
+			"external_ids": {"type": {"key": "string", "value": "string", "min": 0, "max": "unlimited"}}}}
+	}`
+	switchRow := func(fields map[string]interface{}) libovsdb.Row {
+		return libovsdb.Row{Fields: fields}
+	}
+	owner := libovsdb.OvsMap{GoMap: map[interface{}]interface{}{"owner": "node1"}}
+	db := &ovndb{
+		db:     DBNB,
+		client: newTestSchemaClient(t, DBNB, schema),
+		cache: map[string]map[string]libovsdb.Row{
+			TableLogicalSwitch: {
+				// a column left at its default may be missing from the cached row
+				"ls1": switchRow(map[string]interface{}{"name": "node1", "external_ids": owner}),
+				"ls2": switchRow(map[string]interface{}{"name": "node2"}),
+				"ls3": switchRow(map[string]interface{}{"external_ids": owner}),
+				"ls4": switchRow(map[string]interface{}{"name": "join"}),
+				"ls5": switchRow(map[string]interface{}{"name": "join"}),
+			},
+		},
+	}
+
+	tests := []struct {
+		desc   string
+		table  string
+		name   string
+		expIDs map[string]string
+		expErr error
+	}{
+		{
+			desc:   "returns the external_ids of the named row",
+			table:  TableLogicalSwitch,
+			name:   "node1",
+			expIDs: map[string]string{"owner": "node1"},
+		},
+		{
+			desc:   "row without external_ids",
+			table:  TableLogicalSwitch,
+			name:   "node2",
+			expIDs: map[string]string{},
+		},
+		{
+			desc:   "no row has the name",
+			table:  TableLogicalSwitch,
+			name:   "node3",
+			expErr: ErrorNotFound,
+		},
+		{
+			desc:   "more than one row has the name",
+			table:  TableLogicalSwitch,
+			name:   "join",
+			expErr: ErrorDuplicateName,
+		},
+		{
+			desc:   "supported table without rows",
+			table:  TableLoadBalancer,
+			name:   "lb1",
+			expErr: ErrorNotFound,
+		},
+		{
+			desc:   "table without a name column",
+			table:  TableNAT,
+			name:   "nat1",
+			expErr: ErrorSchema,
+		},
+		{
+			desc:   "table not in the schema",
+			table:  TableACL,
+			name:   "acl1",
+			expErr: ErrorSchema,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			ids, err := db.GetExternalIDs(tc.table, tc.name)
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expIDs, ids)
+		})
+	}
+}
+
+func TestGetRowUUIDs(t *testing.T) {
+	networks := func(cidrs ...interface{}) libovsdb.OvsSet {
+		return libovsdb.OvsSet{GoSet: cidrs}
+	}
+	db := newTestDB(DBNB, nil, map[string]map[string]libovsdb.Row{
+		TableLogicalRouterPort: {
+			"lrp1": {Fields: map[string]interface{}{"name": "rtoj-GR_node1", "networks": networks("100.64.0.2/16", "fd98::2/64")}},
+			"lrp2": {Fields: map[string]interface{}{"name": "rtoj-GR_node2", "networks": "100.64.0.3/16"}},
+		},
+	})
+
+	tests := []struct {
+		desc     string
+		row      OVNRow
+		expUUIDs []string
+	}{
+		{
+			desc:     "matches a set column",
+			row:      OVNRow{"networks": networks("100.64.0.2/16", "fd98::2/64")},
+			expUUIDs: []string{"lrp1"},
+		},
+		{
+			desc:     "tells a set from a different set",
+			row:      OVNRow{"name": "rtoj-GR_node1", "networks": networks("100.64.0.2/16")},
+			expUUIDs: nil,
+		},
+		{
+			desc:     "matches a scalar column",
+			row:      OVNRow{"name": "rtoj-GR_node2"},
+			expUUIDs: []string{"lrp2"},
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			assert.Equal(t, tc.expUUIDs, db.getRowUUIDs(TableLogicalRouterPort, tc.row))
+		})
+	}
+}
+
+func TestMutateColumn(t *testing.T) {
+	const schema = `{
+		"Logical_Switch": {"columns": {
+			"name": {"type": "string"},
+			"ports": {"type": {"key": {"type": "uuid", "refTable": "Logical_Switch_Port"}, "min": 0, "max": "unlimited"}},
+			"other_config": {"type": {"key": "string", "value": "string", "min": 0, "max": "unlimited"}}}},
+		"Load_Balancer": {"columns": {
+			"name": {"type": "string"},
+			"vips": {"type": {"key": "string", "value": "string", "min": 0, "max": "unlimited"}}}},
+		"NAT": {"columns": {
+			"external_ids": {"type": {"key": "string", "value": "string", "min": 0, "max": "unlimited"}}}}
+	}`
+	switchRow := func(name string) libovsdb.Row {
+		return libovsdb.Row{Fields: map[string]interface{}{"name": name}}
+	}
+	db := &ovndb{
+		db:     DBNB,
+		client: newTestSchemaClient(t, DBNB, schema),
+		cache: map[string]map[string]libovsdb.Row{
+			TableLogicalSwitch: {
+				"ls1": switchRow("node1"),
+				"ls2": switchRow("join"),
+				"ls3": switchRow("join"),
+			},
+		},
+	}
+
+	tests := []struct {
+		desc     string
+		table    string
+		name     string
+		column   string
+		mutator  string
+		value    interface{}
+		expValue interface{}
+		expErr   error
+	}{
+		{
+			desc:     "inserts into a map column of the named row",
+			table:    TableLogicalSwitch,
+			name:     "node1",
+			column:   "other_config",
+			mutator:  opInsert,
+			value:    map[string]string{"subnet": "10.128.1.0/24"},
+			expValue: &libovsdb.OvsMap{GoMap: map[interface{}]interface{}{"subnet": "10.128.1.0/24"}},
+		},
+		{
+			desc:     "deletes from a set column of the named row",
+			table:    TableLogicalSwitch,
+			name:     "node1",
+			column:   "ports",
+			mutator:  opDelete,
+			value:    []libovsdb.UUID{{GoUUID: "lsp1"}},
+			expValue: &libovsdb.OvsSet{GoSet: []interface{}{libovsdb.UUID{GoUUID: "lsp1"}}},
+		},
+		{
+			desc:    "unknown mutator",
+			table:   TableLogicalSwitch,
+			name:    "node1",
+			column:  "other_config",
+			mutator: "^=",
+			value:   map[string]string{"subnet": "10.128.1.0/24"},
+			expErr:  ErrorOption,
+		},
+		{
+			desc:    "no row has the name",
+			table:   TableLogicalSwitch,
+			name:    "node2",
+			column:  "other_config",
+			mutator: opInsert,
+			value:   map[string]string{"subnet": "10.128.1.0/24"},
+			expErr:  ErrorNotFound,
+		},
+		{
+			desc:    "more than one row has the name",
+			table:   TableLogicalSwitch,
+			name:    "join",
+			column:  "other_config",
+			mutator: opInsert,
+			value:   map[string]string{"subnet": "100.64.0.0/16"},
+			expErr:  ErrorDuplicateName,
+		},
+		{
+			desc:    "supported table without rows",
+			table:   TableLoadBalancer,
+			name:    "lb1",
+			column:  "vips",
+			mutator: opInsert,
+			value:   map[string]string{"10.96.0.1:443": "172.18.0.2:6443"},
+			expErr:  ErrorNotFound,
+		},
+		{
+			desc:    "column not in the schema",
+			table:   TableLogicalSwitch,
+			name:    "node1",
+			column:  "load_balancer",
+			mutator: opInsert,
+			value:   []libovsdb.UUID{{GoUUID: "lb1"}},
+			expErr:  ErrorSchema,
+		},
+		{
+			desc:    "table without a name column",
+			table:   TableNAT,
+			name:    "nat1",
+			column:  "external_ids",
+			mutator: opInsert,
+			value:   map[string]string{"owner": "node1"},
+			expErr:  ErrorSchema,
+		},
+		{
+			desc:    "table not in the schema",
+			table:   TableACL,
+			name:    "acl1",
+			column:  "external_ids",
+			mutator: opInsert,
+			value:   map[string]string{"owner": "node1"},
+			expErr:  ErrorSchema,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			cmd, err := db.MutateColumn(tc.table, tc.name, tc.column, tc.mutator, tc.value)
+			assert.Equal(t, tc.expErr, err)
+			if tc.expErr != nil {
+				assert.Nil(t, cmd)
+				return
+			}
+			if assert.Len(t, cmd.Operations, 1) {
+				op := cmd.Operations[0]
+				assert.Equal(t, opMutate, op.Op)
+				assert.Equal(t, tc.table, op.Table)
+				assert.Equal(t, []interface{}{libovsdb.NewMutation(tc.column, tc.mutator, tc.expValue)}, op.Mutations)
+				assert.Equal(t, []interface{}{libovsdb.NewCondition("_uuid", "==", stringToGoUUID("ls1"))}, op.Where)
+			}
+		})
+	}
+}
+
+func TestDelByExternalID(t *testing.T) {
+	const schema = `{
+		"NAT": {"columns": {
+			"external_ip": {"type": "string"},
+			"external_ids": {"type": {"key": "string", "value": "string", "min": 0, "max": "unlimited"}}}},
+		"Logical_Router": {"columns": {
+			"name": {"type": "string"},
+			"nat": {"type": {"key": {"type": "uuid", "refTable": "NAT"}, "min": 0, "max": "unlimited"}},
+			"weak_nat": {"type": {"key": {"type": "uuid", "refTable": "NAT", "refType": "weak"}, "min": 0, "max": "unlimited"}},
+			"nat_by_ip": {"type": {"key": "string", "value": {"type": "uuid", "refTable": "NAT"}, "min": 0, "max": "unlimited"}},
+			"single_nat": {"type": {"key": {"type": "uuid", "refTable": "NAT"}}}}},
+		"Logical_Switch": {"columns": {"name": {"type": "string"}}}
+	}`
+	natRow := func(owner string) libovsdb.Row {
+		return libovsdb.Row{Fields: map[string]interface{}{
+			"external_ids": libovsdb.OvsMap{GoMap: map[interface{}]interface{}{"owner": owner}},
+		}}
+	}
+	natSet := func(uuids ...string) libovsdb.OvsSet {
+		set := libovsdb.OvsSet{}
+		for _, uuid := range uuids {
+			set.GoSet = append(set.GoSet, libovsdb.UUID{GoUUID: uuid})
+		}
+		return set
+	}
+	newDB := func(routers map[string]libovsdb.Row) *ovndb {
+		return &ovndb{
+			db:     DBNB,
+			client: newTestSchemaClient(t, DBNB, schema),
+			cache: map[string]map[string]libovsdb.Row{
+				TableNAT: {
+					"nat1": natRow("egressip1"),
+					"nat2": natRow("egressip1"),
+					"nat3": natRow("egressip2"),
+				},
+				TableLogicalRouter: routers,
+			},
+		}
+	}
+
+	tests := []struct {
+		desc    string
+		routers map[string]libovsdb.Row
+		table   string
+		key     string
+		value   string
+		expOps  []string
+		expErr  string
+	}{
+		{
+			desc: "detaches the rows from their holders before deleting them",
+			routers: map[string]libovsdb.Row{
+				"lr2": {Fields: map[string]interface{}{"nat": natSet("nat2", "nat3")}},
+				"lr1": {Fields: map[string]interface{}{"nat": natSet("nat1")}},
+			},
+			table: TableNAT,
+			key:   "owner",
+			value: "egressip1",
+			expOps: []string{
+				"mutate Logical_Router nat delete [nat1] where _uuid == lr1",
+				"mutate Logical_Router nat delete [nat2] where _uuid == lr2",
+				"delete NAT where _uuid == nat1",
+				"delete NAT where _uuid == nat2",
+			},
+		},
+		{
+			desc: "leaves weak references to the server",
+			routers: map[string]libovsdb.Row{
+				"lr1": {Fields: map[string]interface{}{"weak_nat": natSet("nat3")}},
+			},
+			table:  TableNAT,
+			key:    "owner",
+			value:  "egressip2",
+			expOps: []string{"delete NAT where _uuid == nat3"},
+		},
+		{
+			desc: "cannot remove a reference held by a map",
+			routers: map[string]libovsdb.Row{
+				"lr1": {Fields: map[string]interface{}{
+					"nat_by_ip": libovsdb.OvsMap{GoMap: map[interface{}]interface{}{"172.18.0.2": libovsdb.UUID{GoUUID: "nat3"}}},
+				}},
+			},
+			table:  TableNAT,
+			key:    "owner",
+			value:  "egressip2",
+			expErr: "cannot remove the reference to NAT row nat3 held by the nat_by_ip column of Logical_Router row lr1",
+		},
+		{
+			desc: "cannot remove a mandatory single reference",
+			routers: map[string]libovsdb.Row{
+				"lr1": {Fields: map[string]interface{}{"single_nat": libovsdb.UUID{GoUUID: "nat3"}}},
+			},
+			table:  TableNAT,
+			key:    "owner",
+			value:  "egressip2",
+			expErr: "cannot remove the reference to NAT row nat3 held by the single_nat column of Logical_Router row lr1",
+		},
+		{
+			desc:   "no row has the marker",
+			table:  TableNAT,
+			key:    "owner",
+			value:  "egressip3",
+			expErr: ErrorNotFound.Error(),
+		},
+		{
+			desc:   "supported table without rows",
+			table:  TableLogicalSwitch,
+			key:    "owner",
+			value:  "egressip1",
+			expErr: ErrorNotFound.Error(),
+		},
+		{
+			desc:   "table not in the schema",
+			table:  TableACL,
+			key:    "owner",
+			value:  "egressip1",
+			expErr: ErrorSchema.Error(),
+		},
+		{
+			desc:   "empty key",
+			table:  TableNAT,
+			value:  "egressip1",
+			expErr: "external_ids key cannot be empty",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			cmd, err := newDB(tc.routers).DelByExternalID(tc.table, tc.key, tc.value)
+			if tc.expErr != "" {
+				assert.EqualError(t, err, tc.expErr)
+				assert.Nil(t, cmd)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, fmt.Sprint(tc.expOps), fmt.Sprint(cmd.Describe()))
+		})
+	}
+}
+
+// switchCreateCounter counts the logical switch create callbacks, the only
+// ones the cache updates of its tests signal
+type switchCreateCounter struct {
+	OVNSignal
+	creates int
+}
+
+func (s *switchCreateCounter) OnLogicalSwitchCreate(ls *LogicalSwitch) {
+	s.creates++
+}
+
+func TestPopulateCacheIgnoreColumns(t *testing.T) {
+	row := func(name, nbCfg string) libovsdb.Row {
+		return libovsdb.Row{Fields: map[string]interface{}{
+			"name":         name,
+			"other_config": libovsdb.OvsMap{GoMap: map[interface{}]interface{}{"nb_cfg": nbCfg}},
+		}}
+	}
+	update := func(rows ...libovsdb.Row) libovsdb.TableUpdates {
+		tableUpdate := libovsdb.TableUpdate{Rows: make(map[string]libovsdb.RowUpdate, len(rows))}
+		for i, r := range rows {
+			tableUpdate.Rows[fmt.Sprintf("ls%d", i+1)] = libovsdb.RowUpdate{New: r}
+		}
+		return libovsdb.TableUpdates{Updates: map[string]libovsdb.TableUpdate{TableLogicalSwitch: tableUpdate}}
+	}
+
+	signal := &switchCreateCounter{}
+	odbi := newOvndb(&Config{IgnoreColumns: map[string][]string{TableLogicalSwitch: {"other_config"}}}, DBNB)
+	odbi.tableCols = map[string][]string{TableLogicalSwitch: {"name", "other_config"}}
+	odbi.cache = make(map[string]map[string]libovsdb.Row)
+	odbi.signalCB = signal
+
+	odbi.populateCache(DBNB, update(row("node1", "1")), true)
+	assert.Equal(t, map[string]interface{}{"name": "node1"}, odbi.cache[TableLogicalSwitch]["ls1"].Fields)
+	assert.Equal(t, 1, signal.creates)
+
+	// a change to the ignored column alone is not signaled
+	odbi.populateCache(DBNB, update(row("node1", "2")), true)
+	assert.Equal(t, map[string]interface{}{"name": "node1"}, odbi.cache[TableLogicalSwitch]["ls1"].Fields)
+	assert.Equal(t, 1, signal.creates)
+
+	odbi.populateCache(DBNB, update(row("node2", "2")), true)
+	assert.Equal(t, map[string]interface{}{"name": "node2"}, odbi.cache[TableLogicalSwitch]["ls1"].Fields)
+	assert.Equal(t, 2, signal.creates)
+}
+
+func TestLeaderChangeCallbackUsesClient(t *testing.T) {
+	dbRow := func(leader bool) libovsdb.Row {
+		return libovsdb.Row{Fields: map[string]interface{}{
+			"name":   DBNB,
+			"model":  "clustered",
+			"leader": leader,
+		}}
+	}
+	update := func(rowUpdate libovsdb.RowUpdate2) libovsdb.TableUpdates2 {
+		return libovsdb.TableUpdates2{Updates: map[string]libovsdb.TableUpdate2{
+			TableDatabase: {Rows: map[string]libovsdb.RowUpdate2{"db1": rowUpdate}},
+		}}
+	}
+
+	type report struct {
+		isLeader bool
+		switches int
+	}
+	reports := make(chan report, 2)
+	var odbi *ovndb
+	odbi = newOvndb(&Config{OnLeaderChange: func(isLeader bool) {
+		// reading the cache from the callback must not deadlock
+		list, err := odbi.LSList()
+		assert.Nil(t, err)
+		reports <- report{isLeader, len(list)}
+	}}, DBNB)
+	odbi.client = newTestClient(DBServer, map[string][]string{TableDatabase: {"name", "model", "leader"}})
+	odbi.serverTableCols = map[string][]string{TableDatabase: {"name", "model", "leader"}}
+	odbi.serverCache = make(map[string]map[string]libovsdb.Row)
+	odbi.cache = map[string]map[string]libovsdb.Row{
+		TableLogicalSwitch: {"ls1": {Fields: map[string]interface{}{"name": "node1"}}},
+	}
+	notify := ovnNotifier{odbi}
+
+	updated := make(chan struct{})
+	go func() {
+		defer close(updated)
+		// on connect the initial server rows are populated with the cache
+		// locked as well
+		odbi.cachemutex.Lock()
+		notify.Update2(DBServer, update(libovsdb.RowUpdate2{Initial: dbRow(true)}))
+		odbi.cachemutex.Unlock()
+
+		notify.Update2(DBServer, update(libovsdb.RowUpdate2{Modify: libovsdb.Row{
+			Fields: map[string]interface{}{"leader": false},
+		}}))
+	}()
+
+	for _, expected := range []report{{true, 1}, {false, 1}} {
+		select {
+		case r := <-reports:
+			assert.Equal(t, expected, r)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for the leader change to %v", expected.isLeader)
+		}
+	}
+	<-updated
+}
+
+// fakeServer answers the JSON-RPC requests of a libovsdb client over a unix
+// socket, with the given handlers, for the tests of the commands that talk to
+// the server. It serves list_dbs and get_schema for a single database.
+type fakeServer struct {
+	addr     string
+	dir      string
+	listener net.Listener
+	handlers map[string]func(params []interface{}) (interface{}, error)
+
+	mutex sync.Mutex
+	calls map[string]int
+}
+
+func newFakeServer(t *testing.T, db, tables string, handlers map[string]func(params []interface{}) (interface{}, error)) *fakeServer {
+	dir, err := ioutil.TempDir("", "goovn")
+	if err != nil {
+		t.Fatalf("failed to create the socket directory: %v", err)
+	}
+	path := filepath.Join(dir, "db.sock")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("failed to listen on %s: %v", path, err)
+	}
+	schema := json.RawMessage(fmt.Sprintf(`{"name": %q, "version": "1.0.0", "tables": %s}`, db, tables))
+	s := &fakeServer{
+		addr:     "unix:" + path,
+		dir:      dir,
+		listener: listener,
+		handlers: map[string]func(params []interface{}) (interface{}, error){
+			"list_dbs":   func([]interface{}) (interface{}, error) { return []string{db}, nil },
+			"get_schema": func([]interface{}) (interface{}, error) { return schema, nil },
+		},
+		calls: make(map[string]int),
+	}
+	for method, handler := range handlers {
+		s.handlers[method] = handler
+	}
+	go s.serve()
+	return s
+}
+
+func (s *fakeServer) close() {
+	s.listener.Close()
+	os.RemoveAll(s.dir)
+}
+
+func (s *fakeServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.serveConn(conn)
+	}
+}
+
+func (s *fakeServer) serveConn(conn net.Conn) {
+	defer conn.Close()
+	decoder, encoder := json.NewDecoder(conn), json.NewEncoder(conn)
+	for {
+		var request struct {
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+			ID     interface{}   `json:"id"`
+		}
+		if err := decoder.Decode(&request); err != nil {
+			return
+		}
+		if request.Method == "" || request.ID == nil {
+			// a reply to an echo or a notification
+			continue
+		}
+		s.mutex.Lock()
+		s.calls[request.Method]++
+		s.mutex.Unlock()
+
+		reply := map[string]interface{}{"id": request.ID, "result": nil, "error": nil}
+		if handler, ok := s.handlers[request.Method]; ok {
+			result, err := handler(request.Params)
+			if err != nil {
+				reply["error"] = err.Error()
+			} else {
+				reply["result"] = result
+			}
+		} else {
+			reply["error"] = "unknown method"
+		}
+		if err := encoder.Encode(reply); err != nil {
+			return
+		}
+	}
+}
+
+// callCount returns the number of requests of method served
+func (s *fakeServer) callCount(method string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.calls[method]
+}
+
+// connect returns a client of db connected to the server, without monitoring
+func (s *fakeServer) connect(t *testing.T, cfg *Config, db string) *ovndb {
+	client, err := libovsdb.Connect(time.Second, s.addr, nil)
+	if err != nil {
+		t.Fatalf("failed to connect to %s: %v", s.addr, err)
+	}
+	odbi := newOvndb(cfg, db)
+	odbi.client = client
+	return odbi
+}
+
+func TestExecuteRRetry(t *testing.T) {
+	const tables = `{"Logical_Switch": {"columns": {"name": {"type": "string"}}}}`
+	insertSwitch := func(odbi *ovndb) *OvnCommand {
+		operations := []libovsdb.Operation{{
+			Op:       opInsert,
+			Table:    TableLogicalSwitch,
+			Row:      OVNRow{"name": "node1"},
+			UUIDName: "ls_node1",
+		}}
+		return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}
+	}
+
+	t.Run("does not retry a transaction rejected by the server", func(t *testing.T) {
+		server := newFakeServer(t, DBNB, tables, map[string]func([]interface{}) (interface{}, error){
+			"transact": func([]interface{}) (interface{}, error) {
+				return []interface{}{map[string]interface{}{
+					"error":   "constraint violation",
+					"details": "Transaction causes multiple rows in \"Logical_Switch\" table to have identical values",
+				}}, nil
+			},
+		})
+		defer server.close()
+		odbi := server.connect(t, &Config{Reconnect: true}, DBNB)
+		defer odbi.close()
+
+		_, err := odbi.ExecuteRRetry(RetryOpts{ReconnectTimeout: time.Second}, insertSwitch(odbi))
+		assert.Error(t, err)
+		assert.False(t, isConnectionError(err))
+		assert.Contains(t, err.Error(), "constraint violation")
+		assert.Equal(t, 1, server.callCount("transact"))
+		assert.Equal(t, TxnCounts{Failed: 1}, *odbi.txnCounts[TableLogicalSwitch])
+	})
+
+	t.Run("retries a transaction lost with the connection", func(t *testing.T) {
+		server := newFakeServer(t, DBNB, tables, map[string]func([]interface{}) (interface{}, error){
+			"transact": func([]interface{}) (interface{}, error) {
+				return []interface{}{map[string]interface{}{"uuid": []interface{}{"uuid", "ls1"}}}, nil
+			},
+		})
+		defer server.close()
+		odbi := server.connect(t, &Config{Reconnect: true}, DBNB)
+		defer odbi.close()
+		connected := odbi.client
+		odbi.client = nil
+		go func() {
+			// the client reconnects while the transaction waits
+			time.Sleep(200 * time.Millisecond)
+			odbi.clientLock.Lock()
+			odbi.client = connected
+			odbi.clientLock.Unlock()
+		}()
+
+		uuids, err := odbi.ExecuteRRetry(RetryOpts{ReconnectTimeout: 5 * time.Second}, insertSwitch(odbi))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"ls1"}, uuids)
+		assert.Equal(t, 1, server.callCount("transact"))
+		assert.Equal(t, TxnCounts{Succeeded: 1, Failed: 1, Retried: 1}, *odbi.txnCounts[TableLogicalSwitch])
+	})
+}
+
+func TestWithRowVersion(t *testing.T) {
+	const (
+		lspUUID = "6e6b5d8a-3a6f-4f5e-9d41-7a0c5b1f2e3d"
+		version = "0d4f1a3b-8c2e-4b7d-a5f6-1e9c3d7b2a40"
+	)
+	var options executeOptions
+	WithRowVersion(TableLogicalSwitchPort, lspUUID, version)(&options)
+	assert.Len(t, options.waits, 1)
+
+	t.Run("marshals the wait op with a zero timeout", func(t *testing.T) {
+		data, err := json.Marshal(options.waits[0])
+		assert.Nil(t, err)
+		assert.Equal(t, `{"timeout":0,"op":"wait","table":"Logical_Switch_Port",`+
+			`"rows":[{"_version":["uuid","`+version+`"]}],"columns":["_version"],`+
+			`"where":[["_uuid","==",["uuid","`+lspUUID+`"]]],"until":"=="}`, string(data))
+	})
+
+	updatePort := func(odbi *ovndb) *OvnCommand {
+		operations := []libovsdb.Operation{{
+			Op:    opUpdate,
+			Table: TableLogicalSwitchPort,
+			Row:   OVNRow{"addresses": "0a:58:0a:80:00:05 10.128.0.5"},
+			Where: []interface{}{libovsdb.NewCondition("_uuid", "==", stringToGoUUID(lspUUID))},
+		}}
+		return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}
+	}
+	tests := []struct {
+		desc    string
+		waitErr string
+		expErr  error
+	}{
+		{
+			desc: "applies the transaction while the row has the version",
+		},
+		{
+			desc:    "fails the transaction once the row version changed",
+			waitErr: "timed out",
+			expErr:  ErrorVersionMismatch,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			var ops []interface{}
+			server := newFakeServer(t, DBNB, lspTestSchema, map[string]func([]interface{}) (interface{}, error){
+				"transact": func(params []interface{}) (interface{}, error) {
+					ops = params[1:]
+					if tc.waitErr != "" {
+						// the server stops at the failed wait, nothing is written
+						return []interface{}{map[string]interface{}{"error": tc.waitErr}}, nil
+					}
+					return []interface{}{map[string]interface{}{}, map[string]interface{}{"count": 1}}, nil
+				},
+			})
+			defer server.close()
+			odbi := server.connect(t, &Config{}, DBNB)
+			defer odbi.close()
+
+			_, err := odbi.ExecuteROptions([]ExecuteOption{WithRowVersion(TableLogicalSwitchPort, lspUUID, version)}, updatePort(odbi))
+			assert.Equal(t, tc.expErr, err)
+			// the wait op comes first, so nothing else applies when it fails
+			assert.Len(t, ops, 2)
+			assert.Equal(t, map[string]interface{}{
+				"op":      opWait,
+				"table":   TableLogicalSwitchPort,
+				"timeout": float64(0),
+				"where":   []interface{}{[]interface{}{"_uuid", "==", []interface{}{"uuid", lspUUID}}},
+				"columns": []interface{}{"_version"},
+				"until":   "==",
+				"rows":    []interface{}{map[string]interface{}{"_version": []interface{}{"uuid", version}}},
+			}, ops[0])
+			assert.Equal(t, opUpdate, ops[1].(map[string]interface{})["op"])
+			assert.Equal(t, 1, server.callCount("transact"))
+		})
+	}
+}
+
+func TestTransactionOrigin(t *testing.T) {
+	const tables = `{"Logical_Switch": {"columns": {"name": {"type": "string"}}}}`
+	insertSwitch := func(odbi *ovndb, name string) *OvnCommand {
+		operations := []libovsdb.Operation{{
+			Op:       opInsert,
+			Table:    TableLogicalSwitch,
+			Row:      OVNRow{"name": name},
+			UUIDName: "ls_" + name,
+		}}
+		return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}
+	}
+
+	t.Run("marshals the comment op without a table", func(t *testing.T) {
+		data, err := json.Marshal(libovsdb.Operation{Op: libovsdb.OperationComment, Table: TableLogicalSwitch, Comment: "ovnkube-master"})
+		assert.Nil(t, err)
+		assert.Equal(t, `{"op":"comment","comment":"ovnkube-master"}`, string(data))
+	})
+
+	tests := []struct {
+		desc   string
+		origin string
+		expOps []string
+	}{
+		{
+			desc:   "no comment without a transaction origin",
+			expOps: []string{opInsert, opInsert},
+		},
+		{
+			desc:   "the comment comes after the operations of the commands",
+			origin: "ovnkube-master node1",
+			expOps: []string{opInsert, opInsert, libovsdb.OperationComment},
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			var ops []map[string]interface{}
+			server := newFakeServer(t, DBNB, tables, map[string]func([]interface{}) (interface{}, error){
+				"transact": func(params []interface{}) (interface{}, error) {
+					results := []interface{}{}
+					for i, param := range params[1:] {
+						op := param.(map[string]interface{})
+						ops = append(ops, op)
+						if op["op"] == opInsert {
+							results = append(results, map[string]interface{}{"uuid": []interface{}{"uuid", fmt.Sprintf("ls%d", i+1)}})
+						} else {
+							results = append(results, map[string]interface{}{})
+						}
+					}
+					return results, nil
+				},
+			})
+			defer server.close()
+			odbi := server.connect(t, &Config{TransactionOrigin: tc.origin}, DBNB)
+			defer odbi.close()
+
+			uuids, err := odbi.ExecuteR(insertSwitch(odbi, "node1"), insertSwitch(odbi, "node2"))
+			assert.Nil(t, err)
+			// the UUIDs of the inserted rows are in the order of the commands
+			assert.Equal(t, []string{"ls1", "ls2"}, uuids)
+
+			opNames := []string{}
+			for _, op := range ops {
+				opNames = append(opNames, op["op"].(string))
+			}
+			assert.Equal(t, tc.expOps, opNames)
+			if tc.origin != "" {
+				assert.Equal(t, map[string]interface{}{"op": libovsdb.OperationComment, "comment": tc.origin}, ops[len(ops)-1])
+			}
+		})
+	}
+}
+
+func TestMonitorScope(t *testing.T) {
+	const (
+		tables = `{
+			"Logical_Switch": {"columns": {
+				"name": {"type": "string"},
+				"ports": {"type": {"key": {"type": "uuid", "refTable": "Logical_Switch_Port"}, "min": 0, "max": "unlimited"}},
+				"external_ids": {"type": {"key": "string", "value": "string", "min": 0, "max": "unlimited"}}}},
+			"Logical_Switch_Port": {"columns": {
+				"name": {"type": "string"},
+				"external_ids": {"type": {"key": "string", "value": "string", "min": 0, "max": "unlimited"}}}},
+			"Load_Balancer": {"columns": {"name": {"type": "string"}}},
+			"ACL": {"columns": {"match": {"type": "string"}}}
+		}`
+		lsUUID       = "3f1c6a2e-5b7d-4e8f-9a0b-1c2d3e4f5a6b"
+		lspUUID      = "7a8b9c0d-1e2f-4a3b-8c4d-5e6f7a8b9c0d"
+		otherLSPUUID = "0f1e2d3c-4b5a-4968-8776-655443322110"
+	)
+	scope := &MonitorScope{
+		Tables:      []string{TableLogicalSwitch, TableLogicalSwitchPort},
+		Names:       []string{"node1"},
+		ExternalIDs: map[string]string{"node": "node1"},
+	}
+
+	t.Run("narrows the scoped tables and the cache to the rows in scope", func(t *testing.T) {
+		var requests map[string]interface{}
+		server := newFakeServer(t, DBNB, tables, map[string]func([]interface{}) (interface{}, error){
+			"monitor_cond_since": func(params []interface{}) (interface{}, error) {
+				requests = params[2].(map[string]interface{})
+				// the server only sends the rows in scope: the switch of
+				// node1 and its pod port, not the port of another node it
+				// still references
+				return []interface{}{false, "txn1", map[string]interface{}{
+					TableLogicalSwitch: map[string]interface{}{
+						lsUUID: map[string]interface{}{"initial": map[string]interface{}{
+							"name":  "node1",
+							"ports": []interface{}{"set", []interface{}{[]interface{}{"uuid", lspUUID}, []interface{}{"uuid", otherLSPUUID}}},
+						}},
+					},
+					TableLogicalSwitchPort: map[string]interface{}{
+						lspUUID: map[string]interface{}{"initial": map[string]interface{}{
+							"name":         "ns1_pod1",
+							"external_ids": []interface{}{"map", []interface{}{[]interface{}{"node", "node1"}}},
+						}},
+					},
+				}}, nil
+			},
+		})
+		defer server.close()
+		odbi := server.connect(t, &Config{MonitorScope: scope}, DBNB)
+		defer odbi.close()
+		odbi.cache = make(map[string]map[string]libovsdb.Row)
+
+		updates, _, err := odbi.monitorTables(DBNB, DBNB)
+		assert.Nil(t, err)
+		where := []interface{}{
+			[]interface{}{"name", "==", "node1"},
+			[]interface{}{"external_ids", "includes", []interface{}{"map", []interface{}{[]interface{}{"node", "node1"}}}},
+		}
+		for _, table := range []string{TableLogicalSwitch, TableLogicalSwitchPort} {
+			assert.Equal(t, where, requests[table].(map[string]interface{})["where"], table)
+		}
+		for _, table := range []string{TableLoadBalancer, TableACL} {
+			assert.NotContains(t, requests[table].(map[string]interface{}), "where", table)
+		}
+
+		odbi.populateCache2(DBNB, *updates, false)
+		switches, err := odbi.LSGet("node1")
+		assert.Nil(t, err)
+		assert.Len(t, switches, 1)
+		_, err = odbi.LSGet("node2")
+		assert.Equal(t, ErrorNotFound, err)
+		_, err = odbi.LSPGet("ns1_pod2")
+		assert.Equal(t, ErrorNotFound, err)
+		// the port out of scope the switch references is skipped
+		ports, err := odbi.LSPList("node1")
+		assert.Nil(t, err)
+		assert.Len(t, ports, 1)
+		assert.Equal(t, "ns1_pod1", ports[0].Name)
+	})
+
+	errTests := []struct {
+		desc     string
+		scope    *MonitorScope
+		errMatch string
+	}{
+		{
+			desc:     "a scope matching no column of a table",
+			scope:    &MonitorScope{Tables: []string{TableACL}, Names: []string{"node1"}},
+			errMatch: "selects no column of table \"ACL\"",
+		},
+		{
+			desc:     "a scope on external IDs only of a table without external_ids",
+			scope:    &MonitorScope{Tables: []string{TableLoadBalancer}, ExternalIDs: map[string]string{"node": "node1"}},
+			errMatch: "selects no column of table \"Load_Balancer\"",
+		},
+		{
+			desc:     "a scoped table that is not monitored",
+			scope:    &MonitorScope{Tables: []string{TableNAT}, Names: []string{"node1"}},
+			errMatch: "scoped table \"NAT\"",
+		},
+	}
+	for i, tc := range errTests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			server := newFakeServer(t, DBNB, tables, nil)
+			defer server.close()
+			odbi := server.connect(t, &Config{MonitorScope: tc.scope}, DBNB)
+			defer odbi.close()
+
+			_, _, err := odbi.monitorTables(DBNB, DBNB)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errMatch)
+			assert.Equal(t, 0, server.callCount("monitor_cond_since"))
+		})
+	}
+}
+
+// lspTestSchema is the schema of the Logical_Switch_Port table
+const lspTestSchema = `{
+	"Logical_Switch_Port": {"columns": {
+		"name": {"type": "string"},
+		"type": {"type": "string"},
+		"options": {"type": {"key": "string", "value": "string", "min": 0, "max": "unlimited"}},
+		"parent_name": {"type": {"key": "string", "min": 0, "max": 1}},
+		"tag_request": {"type": {"key": {"type": "integer", "minInteger": 0, "maxInteger": 4095}, "min": 0, "max": 1}},
+		"tag": {"type": {"key": {"type": "integer", "minInteger": 1, "maxInteger": 4095}, "min": 0, "max": 1}},
+		"addresses": {"type": {"key": "string", "min": 0, "max": "unlimited"}},
+		"dynamic_addresses": {"type": {"key": "string", "min": 0, "max": 1}},
+		"port_security": {"type": {"key": "string", "min": 0, "max": "unlimited"}},
+		"up": {"type": {"key": "boolean", "min": 0, "max": 1}},
+		"enabled": {"type": {"key": "boolean", "min": 0, "max": 1}},
+		"dhcpv4_options": {"type": {"key": {"type": "uuid", "refTable": "DHCP_Options", "refType": "weak"}, "min": 0, "max": 1}},
+		"dhcpv6_options": {"type": {"key": {"type": "uuid", "refTable": "DHCP_Options", "refType": "weak"}, "min": 0, "max": 1}},
+		"ha_chassis_group": {"type": {"key": {"type": "uuid", "refTable": "HA_Chassis_Group", "refType": "strong"}, "min": 0, "max": 1}},
+		"external_ids": {"type": {"key": "string", "value": "string", "min": 0, "max": "unlimited"}}}}
+}`
+
+// podLSPRow returns the columns the server sends for a new pod port, the ones
+// without their default value
+func podLSPRow(i int) libovsdb.Row {
+	address := fmt.Sprintf("0a:58:0a:f4:%02x:%02x 10.244.%d.%d", i/256%256, i%256, i/256%256, i%256)
+	return libovsdb.Row{Fields: map[string]interface{}{
+		"name":          fmt.Sprintf("ns1_pod%d", i),
+		"addresses":     address,
+		"port_security": address,
+		"options":       libovsdb.OvsMap{GoMap: map[interface{}]interface{}{"requested-chassis": "node1"}},
+		"external_ids":  libovsdb.OvsMap{GoMap: map[interface{}]interface{}{"namespace": "ns1", "pod": "true"}},
+	}}
+}
+
+// newLSPTestDB returns a client whose cache holds the given pod ports, added
+// the way the monitor updates add them
+func newLSPTestDB(t testing.TB, skipColumnDefaults bool, ports int) *ovndb {
+	odbi := newOvndb(&Config{SkipColumnDefaults: skipColumnDefaults}, DBNB)
+	odbi.client = newTestSchemaClient(t, DBNB, lspTestSchema)
+	odbi.tableCols = map[string][]string{TableLogicalSwitchPort: {}}
+	odbi.cache = make(map[string]map[string]libovsdb.Row)
+	rows := make(map[string]libovsdb.RowUpdate2, ports)
+	for i := 0; i < ports; i++ {
+		rows[fmt.Sprintf("lsp%d", i)] = libovsdb.RowUpdate2{Insert: podLSPRow(i)}
+	}
+	odbi.populateCache2(DBNB, libovsdb.TableUpdates2{Updates: map[string]libovsdb.TableUpdate2{
+		TableLogicalSwitchPort: {Rows: rows},
+	}}, false)
+	return odbi
+}
+
+func TestSkipColumnDefaults(t *testing.T) {
+	withDefaults := newLSPTestDB(t, false, 1)
+	assert.Len(t, withDefaults.cache[TableLogicalSwitchPort]["lsp0"].Fields, 15)
+	withoutDefaults := newLSPTestDB(t, true, 1)
+	assert.Equal(t, podLSPRow(0), withoutDefaults.cache[TableLogicalSwitchPort]["lsp0"])
+
+	for _, odbi := range []*ovndb{withDefaults, withoutDefaults} {
+		lsp, err := odbi.LSPGet("ns1_pod0")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"0a:58:0a:f4:00:00 10.244.0.0"}, lsp.Addresses)
+		assert.Equal(t, []string{"0a:58:0a:f4:00:00 10.244.0.0"}, lsp.PortSecurity)
+		assert.Equal(t, map[interface{}]interface{}{"requested-chassis": "node1"}, lsp.Options)
+		assert.Equal(t, map[interface{}]interface{}{"namespace": "ns1", "pod": "true"}, lsp.ExternalID)
+		// the columns with their default value read as zero values either way
+		assert.Equal(t, "", lsp.Type)
+		assert.Equal(t, "", lsp.DynamicAddresses)
+		assert.Equal(t, "", lsp.DHCPv4Options)
+		assert.Equal(t, "", lsp.DHCPv6Options)
+		assert.Equal(t, "", lsp.HAChassisGroup)
+
+		status, err := odbi.LSPStatus("ns1_pod0")
+		assert.Nil(t, err)
+		assert.Nil(t, status.Up)
+		up, err := odbi.lspIsUp("ns1_pod0")
+		assert.Nil(t, err)
+		assert.False(t, up)
+	}
+}
+
+// BenchmarkCacheColumnDefaults reports the memory held by the cache per pod
+// port, with and without the column defaults, in cache-B/row
+func BenchmarkCacheColumnDefaults(b *testing.B) {
+	const ports = 10000
+	for _, skip := range []bool{false, true} {
+		b.Run(fmt.Sprintf("SkipColumnDefaults=%v", skip), func(b *testing.B) {
+			var held uint64
+			var before, after runtime.MemStats
+			for i := 0; i < b.N; i++ {
+				runtime.GC()
+				runtime.ReadMemStats(&before)
+				odbi := newLSPTestDB(b, skip, ports)
+				runtime.GC()
+				runtime.ReadMemStats(&after)
+				held += after.HeapAlloc - before.HeapAlloc
+				runtime.KeepAlive(odbi)
+			}
+			b.ReportMetric(float64(held)/float64(b.N)/ports, "cache-B/row")
+		})
+	}
+}
@@ -0,0 +1,44 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+// portBindingChassisImp returns the name of the chassis the Port_Binding of
+// logicalPort is bound to, or an empty string while it is unbound
+func (odbi *ovndb) portBindingChassisImp(logicalPort string) (string, error) {
+	if !odbi.tableSupported(TablePortBinding) {
+		return "", ErrorSchema
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	for _, drows := range odbi.cache[TablePortBinding] {
+		if rowString(drows, "logical_port") != logicalPort {
+			continue
+		}
+		chassisUUID := rowUUID(drows, "chassis")
+		if chassisUUID == "" {
+			return "", nil
+		}
+		chassis, ok := odbi.cache[TableChassis][chassisUUID]
+		if !ok {
+			// the chassis is gone, the reference is weak and about to be cleared
+			return "", nil
+		}
+		return rowString(chassis, "name"), nil
+	}
+	return "", ErrorNotFound
+}
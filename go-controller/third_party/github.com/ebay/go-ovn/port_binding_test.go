@@ -0,0 +1,190 @@
+package goovn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPortBindingTestDB() *ovndb {
+	return &ovndb{
+		db: DBSB,
+		client: newTestClient(DBSB, map[string][]string{
+			TableChassis:     {"name"},
+			TablePortBinding: {"logical_port", "chassis"},
+		}),
+		cache: map[string]map[string]libovsdb.Row{
+			TableChassis: {
+				"ch1": {Fields: map[string]interface{}{"name": "chassis-node1"}},
+			},
+			TablePortBinding: {
+				"pb1": {Fields: map[string]interface{}{"logical_port": "ns_pod1", "chassis": libovsdb.UUID{GoUUID: "ch1"}}},
+				"pb2": {Fields: map[string]interface{}{"logical_port": "ns_pod2", "chassis": libovsdb.OvsSet{GoSet: []interface{}{libovsdb.UUID{GoUUID: "ch1"}}}}},
+				"pb3": {Fields: map[string]interface{}{"logical_port": "ns_pod3", "chassis": libovsdb.OvsSet{}}},
+				"pb4": {Fields: map[string]interface{}{"logical_port": "ns_pod4", "chassis": libovsdb.UUID{GoUUID: "ch2"}}},
+			},
+		},
+	}
+}
+
+func TestPortBindingChassis(t *testing.T) {
+	odbi := newPortBindingTestDB()
+	tests := []struct {
+		desc   string
+		port   string
+		expCh  string
+		expErr error
+	}{
+		{desc: "bound port", port: "ns_pod1", expCh: "chassis-node1"},
+		{desc: "bound port with the chassis as a set", port: "ns_pod2", expCh: "chassis-node1"},
+		{desc: "unbound port", port: "ns_pod3"},
+		{desc: "port bound to a deleted chassis", port: "ns_pod4"},
+		{desc: "port without a binding", port: "ns_pod5", expErr: ErrorNotFound},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			chassis, err := odbi.PortBindingChassis(tc.port)
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expCh, chassis)
+		})
+	}
+
+	t.Run("table not in the schema", func(t *testing.T) {
+		odbi := newPortBindingTestDB()
+		delete(odbi.client.Schema[DBSB].Tables, TablePortBinding)
+		_, err := odbi.PortBindingChassis("ns_pod1")
+		assert.Equal(t, ErrorSchema, err)
+	})
+}
+
+func TestLSPStatus(t *testing.T) {
+	newNBTestDB := func(sbClient Client) *ovndb {
+		return &ovndb{
+			db:       DBNB,
+			sbClient: sbClient,
+			cache: map[string]map[string]libovsdb.Row{
+				TableLogicalSwitchPort: {
+					"lsp1": {Fields: map[string]interface{}{"name": "ns_pod1", "up": true}},
+					"lsp3": {Fields: map[string]interface{}{"name": "ns_pod3", "up": libovsdb.OvsSet{}}},
+					"lsp5": {Fields: map[string]interface{}{"name": "ns_pod5", "up": false}},
+				},
+			},
+		}
+	}
+	up, down := true, false
+
+	tests := []struct {
+		desc      string
+		sbClient  Client
+		port      string
+		expStatus *LSPStatus
+		expErr    error
+	}{
+		{
+			desc:      "resolves the chassis from the southbound client",
+			sbClient:  newPortBindingTestDB(),
+			port:      "ns_pod1",
+			expStatus: &LSPStatus{Name: "ns_pod1", Up: &up, Chassis: "chassis-node1"},
+		},
+		{
+			desc:      "unbound port with an unset up column",
+			sbClient:  newPortBindingTestDB(),
+			port:      "ns_pod3",
+			expStatus: &LSPStatus{Name: "ns_pod3"},
+		},
+		{
+			desc:      "port without a binding yet",
+			sbClient:  newPortBindingTestDB(),
+			port:      "ns_pod5",
+			expStatus: &LSPStatus{Name: "ns_pod5", Up: &down},
+		},
+		{
+			desc:      "no southbound client",
+			port:      "ns_pod1",
+			expStatus: &LSPStatus{Name: "ns_pod1", Up: &up},
+		},
+		{
+			desc:     "missing port",
+			sbClient: newPortBindingTestDB(),
+			port:     "ns_pod2",
+			expErr:   ErrorNotFound,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			status, err := newNBTestDB(tc.sbClient).LSPStatus(tc.port)
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expStatus, status)
+		})
+	}
+
+	t.Run("southbound client without Port_Binding", func(t *testing.T) {
+		sb := newPortBindingTestDB()
+		delete(sb.client.Schema[DBSB].Tables, TablePortBinding)
+		status, err := newNBTestDB(sb).LSPStatus("ns_pod1")
+		assert.Nil(t, err)
+		assert.Equal(t, &LSPStatus{Name: "ns_pod1", Up: &up}, status)
+	})
+}
+
+func TestLSPStatusThroughConfig(t *testing.T) {
+	// the northbound client built from a configuration with the southbound
+	// client resolves the chassis from it
+	nb := newOvndb(&Config{SBClient: newPortBindingTestDB()}, DBNB)
+	nb.cache = map[string]map[string]libovsdb.Row{
+		TableLogicalSwitchPort: {
+			"lsp1": {Fields: map[string]interface{}{"name": "ns_pod1", "up": true}},
+		},
+	}
+	up := true
+	status, err := nb.LSPStatus("ns_pod1")
+	assert.Nil(t, err)
+	assert.Equal(t, &LSPStatus{Name: "ns_pod1", Up: &up, Chassis: "chassis-node1"}, status)
+}
+
+func TestNewClientSBClient(t *testing.T) {
+	scoped := newPortBindingTestDB()
+	scoped.cfgTableCols = map[string][]string{TableChassis: {}, TableEncap: {}}
+
+	tests := []struct {
+		desc     string
+		db       string
+		sbClient Client
+		errMatch string
+	}{
+		{
+			desc:     "southbound client of a southbound client",
+			db:       DBSB,
+			sbClient: newPortBindingTestDB(),
+			errMatch: "a southbound client is only used by a northbound client, not by a OVN_Southbound one",
+		},
+		{
+			desc:     "northbound client as the southbound client",
+			db:       DBNB,
+			sbClient: &ovndb{db: DBNB},
+			errMatch: "the southbound client is connected to OVN_Northbound",
+		},
+		{
+			desc:     "southbound client not monitoring Port_Binding",
+			db:       DBNB,
+			sbClient: scoped,
+			errMatch: "the southbound client doesn't monitor Port_Binding",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			client, err := NewClient(&Config{Db: tc.db, SBClient: tc.sbClient})
+			assert.EqualError(t, err, tc.errMatch)
+			assert.Nil(t, client)
+		})
+	}
+
+	t.Run("southbound client monitoring the bindings", func(t *testing.T) {
+		sb := newPortBindingTestDB()
+		assert.Nil(t, checkSBClient(sb))
+		sb.cfgTableCols = map[string][]string{TableChassis: {}, TablePortBinding: {}}
+		assert.Nil(t, checkSBClient(sb))
+	})
+}
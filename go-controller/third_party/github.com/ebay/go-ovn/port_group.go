@@ -0,0 +1,443 @@
+/**
+ * Copyright (c) 2017 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"fmt"
+
+	"github.com/ebay/libovsdb"
+)
+
+// PortGroup ovnnb item
+type PortGroup struct {
+	UUID       string
+	Name       string
+	Ports      []string
+	ACLs       []string
+	ExternalID map[interface{}]interface{}
+}
+
+func (odbi *ovndb) pgAddImp(group string, ports []string, external_ids map[string]string) (*OvnCommand, error) {
+	namedUUID, err := newRowUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	row := make(OVNRow)
+	row["name"] = group
+
+	if uuid := odbi.getRowUUID(TablePortGroup, row); len(uuid) > 0 {
+		return nil, ErrorExist
+	}
+
+	if ports != nil {
+		portUUIDs := make([]libovsdb.UUID, 0, len(ports))
+		for _, u := range ports {
+			portUUIDs = append(portUUIDs, stringToGoUUID(u))
+		}
+		pgports, err := libovsdb.NewOvsSet(portUUIDs)
+		if err != nil {
+			return nil, err
+		}
+		row["ports"] = pgports
+	}
+
+	if external_ids != nil {
+		oMap, err := libovsdb.NewOvsMap(external_ids)
+		if err != nil {
+			return nil, err
+		}
+		row["external_ids"] = oMap
+	}
+
+	insertOp := libovsdb.Operation{
+		Op:       opInsert,
+		Table:    TablePortGroup,
+		Row:      row,
+		UUIDName: namedUUID,
+	}
+	operations := []libovsdb.Operation{insertOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) pgAddWithACLsImp(group string, ports []string, acls []ACLSpec, external_ids map[string]string) (*OvnCommand, error) {
+	cmd, err := odbi.pgAddImp(group, ports, external_ids)
+	if err != nil {
+		return nil, err
+	}
+
+	type aclKey struct {
+		direction ACLDirection
+		match     string
+		priority  int
+	}
+	seen := make(map[aclKey]bool, len(acls))
+	aclUUIDs := make([]libovsdb.UUID, 0, len(acls))
+	operations := make([]libovsdb.Operation, 0, len(acls)+1)
+	for _, acl := range acls {
+		key := aclKey{acl.Direction, acl.Match, acl.Priority}
+		if seen[key] {
+			return nil, ErrorExist
+		}
+		seen[key] = true
+
+		row, err := odbi.newACLRow(acl)
+		if err != nil {
+			return nil, err
+		}
+		namedUUID, err := newRowUUID()
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, libovsdb.Operation{
+			Op:       opInsert,
+			Table:    TableACL,
+			Row:      row,
+			UUIDName: namedUUID,
+		})
+		aclUUIDs = append(aclUUIDs, stringToGoUUID(namedUUID))
+	}
+
+	// the group references its ACLs from the start, so it never exists
+	// without them. Its insert comes last, after the ACLs it refers to.
+	if len(aclUUIDs) > 0 {
+		aclSet, err := libovsdb.NewOvsSet(aclUUIDs)
+		if err != nil {
+			return nil, err
+		}
+		cmd.Operations[0].Row["acls"] = aclSet
+	}
+	operations = append(operations, cmd.Operations[0])
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+// PGAddressSetKey is the external_ids key of the address set created by
+// PortGroupAddWithAddressSet, naming the port group whose IPs it holds
+const PGAddressSetKey = "port_group"
+
+// pgAddWithAddressSetImp creates a port group together with an address set
+// holding the IPs of its ports. OVN port groups cannot contain other groups:
+// the ACLs of a group compose it with others by matching on the address sets
+// of its member groups, which then exist as soon as the groups do.
+func (odbi *ovndb) pgAddWithAddressSetImp(group string, ports []string, asName string) (*OvnCommand, error) {
+	if len(asName) == 0 {
+		return nil, ErrorOption
+	}
+
+	var addrs []string
+	odbi.cachemutex.RLock()
+	for _, port := range ports {
+		if !odbi.rowCached(TableLogicalSwitchPort, port) {
+			odbi.cachemutex.RUnlock()
+			return nil, fmt.Errorf("logical switch port %s of port group %s not found", port, group)
+		}
+		lp, err := odbi.uuidToLogicalPort(port)
+		if err != nil {
+			odbi.cachemutex.RUnlock()
+			return nil, err
+		}
+		for _, ip := range lp.IPs {
+			addrs = append(addrs, ip.String())
+		}
+	}
+	odbi.cachemutex.RUnlock()
+
+	asCmd, err := odbi.asAddImp(asName, addrs, map[string]string{PGAddressSetKey: group})
+	if err != nil {
+		return nil, err
+	}
+	pgCmd, err := odbi.pgAddImp(group, ports, nil)
+	if err != nil {
+		return nil, err
+	}
+	operations := append(asCmd.Operations, pgCmd.Operations...)
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) pgUpdateImp(group string, ports []string, external_ids map[string]string) (*OvnCommand, error) {
+	row := make(OVNRow)
+	row["name"] = group
+
+	if uuid := odbi.getRowUUID(TablePortGroup, row); len(uuid) == 0 {
+		return nil, ErrorNotFound
+	}
+
+	if len(ports) == 0 && external_ids == nil {
+		return nil, ErrorNoChanges
+	}
+
+	if len(ports) > 0 {
+		portUUIDs := make([]libovsdb.UUID, 0, len(ports))
+		for _, u := range ports {
+			portUUIDs = append(portUUIDs, stringToGoUUID(u))
+		}
+		pgports, err := libovsdb.NewOvsSet(portUUIDs)
+		if err != nil {
+			return nil, err
+		}
+		row["ports"] = pgports
+	}
+
+	if external_ids != nil {
+		oMap, err := libovsdb.NewOvsMap(external_ids)
+		if err != nil {
+			return nil, err
+		}
+		row["external_ids"] = oMap
+	}
+
+	condition := libovsdb.NewCondition("name", "==", group)
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TablePortGroup,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) pgAddPortImp(group, port string) (*OvnCommand, error) {
+	if _, err := odbi.pgGetImp(group); err != nil {
+		return nil, err
+	}
+
+	mutateUUID := []libovsdb.UUID{stringToGoUUID(port)}
+	mutateSet, err := libovsdb.NewOvsSet(mutateUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	mutation := libovsdb.NewMutation("ports", opInsert, mutateSet)
+	condition := libovsdb.NewCondition("name", "==", group)
+
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     TablePortGroup,
+		Mutations: []interface{}{mutation},
+		Where:     []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+// pgReconcilePortsImp returns a command inserting the desired ports missing
+// from the cached port group and deleting the ones that are not desired, in a
+// single mutate. It returns ErrorNoChanges when the group has the desired
+// ports already.
+func (odbi *ovndb) pgReconcilePortsImp(group string, desired []string) (*OvnCommand, error) {
+	pg, err := odbi.pgGetImp(group)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]bool, len(pg.Ports))
+	for _, port := range pg.Ports {
+		current[port] = true
+	}
+	wanted := make(map[string]bool, len(desired))
+	toAdd := []libovsdb.UUID{}
+	for _, port := range desired {
+		if !wanted[port] && !current[port] {
+			toAdd = append(toAdd, stringToGoUUID(port))
+		}
+		wanted[port] = true
+	}
+	toDel := []libovsdb.UUID{}
+	for _, port := range pg.Ports {
+		if !wanted[port] {
+			toDel = append(toDel, stringToGoUUID(port))
+		}
+	}
+	if len(toAdd) == 0 && len(toDel) == 0 {
+		return nil, ErrorNoChanges
+	}
+
+	var mutations []interface{}
+	if len(toDel) > 0 {
+		delSet, err := libovsdb.NewOvsSet(toDel)
+		if err != nil {
+			return nil, err
+		}
+		mutations = append(mutations, libovsdb.NewMutation("ports", opDelete, delSet))
+	}
+	if len(toAdd) > 0 {
+		addSet, err := libovsdb.NewOvsSet(toAdd)
+		if err != nil {
+			return nil, err
+		}
+		mutations = append(mutations, libovsdb.NewMutation("ports", opInsert, addSet))
+	}
+
+	condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(pg.UUID))
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     TablePortGroup,
+		Mutations: mutations,
+		Where:     []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) pgRemovePortImp(group string, port string) (*OvnCommand, error) {
+	if _, err := odbi.pgGetImp(group); err != nil {
+		return nil, err
+	}
+
+	mutateUUID := []libovsdb.UUID{stringToGoUUID(port)}
+	mutateSet, err := libovsdb.NewOvsSet(mutateUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	mutation := libovsdb.NewMutation("ports", opDelete, mutateSet)
+	condition := libovsdb.NewCondition("name", "==", group)
+
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     TablePortGroup,
+		Mutations: []interface{}{mutation},
+		Where:     []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) pgDelImp(group string) (*OvnCommand, error) {
+	if _, err := odbi.pgGetImp(group); err != nil {
+		return nil, err
+	}
+
+	condition := libovsdb.NewCondition("name", "==", group)
+	deleteOp := libovsdb.Operation{
+		Op:    opDelete,
+		Table: TablePortGroup,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{deleteOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) pgGetImp(pg string) (*PortGroup, error) {
+	var pgList []*PortGroup
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cachePortGroup, ok := odbi.cache[TablePortGroup]
+	if !ok {
+		return nil, ErrorNotFound
+	}
+
+	for uuid, drows := range cachePortGroup {
+		if rlsw, ok := drows.Fields["name"].(string); ok && rlsw == pg {
+			pgList = append(pgList, odbi.RowToPortGroup(uuid))
+		}
+	}
+
+	if len(pgList) == 0 {
+		return nil, ErrorNotFound
+	} else if len(pgList) != 1 {
+		return nil, ErrorDuplicateName
+	} else {
+		return pgList[0], nil
+	}
+}
+
+func (odbi *ovndb) pgIsReferencedImp(group string) (bool, []string, error) {
+	return odbi.matchReferencesImp("@" + group)
+}
+
+func (odbi *ovndb) RowToPortGroup(uuid string) *PortGroup {
+	cachePortGroup, ok := odbi.cache[TablePortGroup][uuid]
+	if !ok {
+		return nil
+	}
+	pg := &PortGroup{
+		UUID:       uuid,
+		Name:       rowString(cachePortGroup, "name"),
+		ExternalID: rowMap(cachePortGroup, "external_ids"),
+	}
+	ports := cachePortGroup.Fields["ports"]
+	switch ports.(type) {
+	case libovsdb.UUID:
+		pg.Ports = []string{ports.(libovsdb.UUID).GoUUID}
+	case libovsdb.OvsSet:
+		pg.Ports = odbi.ConvertGoSetToStringArray(ports.(libovsdb.OvsSet))
+	}
+	acls := cachePortGroup.Fields["acls"]
+	switch acls.(type) {
+	case libovsdb.UUID:
+		pg.ACLs = []string{acls.(libovsdb.UUID).GoUUID}
+	case libovsdb.OvsSet:
+		pg.ACLs = odbi.ConvertGoSetToStringArray(acls.(libovsdb.OvsSet))
+	}
+	return pg
+}
+
+func (odbi *ovndb) GetLogicalPortsByPortGroup(group string) ([]*LogicalSwitchPort, error) {
+	var listLSP []*LogicalSwitchPort
+
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cachePortGroup, ok := odbi.cache[TablePortGroup]
+	if !ok {
+		return nil, ErrorSchema
+	}
+
+	for _, drows := range cachePortGroup {
+		if pgname, ok := drows.Fields["name"].(string); ok && pgname == group {
+			ports := drows.Fields["ports"]
+			if ports != nil {
+				switch ports.(type) {
+				case libovsdb.OvsSet:
+					if ps, ok := ports.(libovsdb.OvsSet); ok {
+						for _, p := range ps.GoSet {
+							if vp, ok := p.(libovsdb.UUID); ok {
+								if !odbi.rowCached(TableLogicalSwitchPort, vp.GoUUID) {
+									continue
+								}
+								tp, err := odbi.uuidToLogicalPort(vp.GoUUID)
+								if err != nil {
+									return nil, fmt.Errorf("Couldn't get logical port: %s", err)
+								}
+								listLSP = append(listLSP, tp)
+							}
+						}
+					} else {
+						return nil, fmt.Errorf("type libovsdb.OvsSet casting failed")
+					}
+				case libovsdb.UUID:
+					if vp, ok := ports.(libovsdb.UUID); ok && odbi.rowCached(TableLogicalSwitchPort, vp.GoUUID) {
+						tp, err := odbi.uuidToLogicalPort(vp.GoUUID)
+						if err != nil {
+							return nil, fmt.Errorf("Couldn't get logical port: %s", err)
+						}
+						listLSP = append(listLSP, tp)
+					} else {
+						return nil, fmt.Errorf("type libovsdb.UUID casting failed")
+					}
+				default:
+					return nil, fmt.Errorf("Unsupport type found in ovsdb rows")
+				}
+			}
+			break
+		}
+	}
+	return listLSP, nil
+}
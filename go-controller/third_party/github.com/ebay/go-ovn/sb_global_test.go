@@ -0,0 +1,33 @@
+package goovn
+
+import (
+	"testing"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSBGlobalGetNbCfg(t *testing.T) {
+	newDB := func(rows map[string]libovsdb.Row) *ovndb {
+		return newTestDB(DBSB, map[string][]string{TableSBGlobal: {"options", "nb_cfg"}},
+			map[string]map[string]libovsdb.Row{TableSBGlobal: rows})
+	}
+
+	t.Run("reads nb_cfg", func(t *testing.T) {
+		odbi := newDB(map[string]libovsdb.Row{"sbg1": {Fields: map[string]interface{}{"nb_cfg": 5}}})
+		nbCfg, err := odbi.SBGlobalGetNbCfg()
+		assert.Nil(t, err)
+		assert.Equal(t, 5, nbCfg)
+	})
+
+	t.Run("SB_Global not created yet by ovn-northd", func(t *testing.T) {
+		_, err := newDB(nil).SBGlobalGetNbCfg()
+		assert.Equal(t, ErrorNotFound, err)
+	})
+
+	t.Run("SB_Global not in the schema", func(t *testing.T) {
+		odbi := &ovndb{db: DBSB, cache: map[string]map[string]libovsdb.Row{}}
+		_, err := odbi.SBGlobalGetNbCfg()
+		assert.Equal(t, ErrorSchema, err)
+	})
+}
@@ -0,0 +1,90 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"github.com/ebay/libovsdb"
+)
+
+// CacheSnapshot is a point-in-time copy of the cache of a client. It serves
+// the getters of Client from that copy, unaffected by the updates received
+// afterwards, so that a long series of reads such as an audit sees a
+// consistent state. A snapshot is not connected: the commands built from it
+// are executed with the client it was taken from, executing them on the
+// snapshot fails with ErrorSnapshot, and so do Lock, Steal and Unlock.
+//
+// The rows are copied along with their maps and sets, only the strings are
+// shared, so a snapshot holds about as much memory as the cache it was taken
+// from. Call the release function returned by Snapshot as soon as the
+// snapshot is no longer needed; the getters of a released snapshot find
+// nothing.
+type CacheSnapshot struct {
+	Client
+}
+
+func (odbi *ovndb) snapshotImp() (*CacheSnapshot, func()) {
+	odbi.cachemutex.RLock()
+	cache := make(map[string]map[string]libovsdb.Row, len(odbi.cache))
+	for table, rows := range odbi.cache {
+		tableCopy := make(map[string]libovsdb.Row, len(rows))
+		for uuid, row := range rows {
+			tableCopy[uuid] = copyRow(row)
+		}
+		cache[table] = tableCopy
+	}
+	snapshot := &ovndb{
+		db:                 odbi.db,
+		cache:              cache,
+		tableCols:          odbi.tableCols,
+		currentTxn:         odbi.currentTxn,
+		lastUpdate:         odbi.lastUpdate,
+		timeout:            odbi.timeout,
+		locks:              make(map[string]bool),
+		cacheLimitExceeded: make(map[string]bool),
+		sortListResults:    odbi.sortListResults,
+		skipColumnDefaults: odbi.skipColumnDefaults,
+		snapshot:           true,
+	}
+	odbi.cachemutex.RUnlock()
+
+	release := func() {
+		snapshot.cachemutex.Lock()
+		defer snapshot.cachemutex.Unlock()
+		snapshot.cache = make(map[string]map[string]libovsdb.Row)
+	}
+	return &CacheSnapshot{snapshot}, release
+}
+
+// copyRow copies a cached row so that the updates applied in place to the
+// original, to its maps and to its sets, don't show through the copy
+func copyRow(row libovsdb.Row) libovsdb.Row {
+	fields := make(map[string]interface{}, len(row.Fields))
+	for column, value := range row.Fields {
+		switch v := value.(type) {
+		case libovsdb.OvsMap:
+			goMap := make(map[interface{}]interface{}, len(v.GoMap))
+			for key, elem := range v.GoMap {
+				goMap[key] = elem
+			}
+			value = libovsdb.OvsMap{GoMap: goMap}
+		case libovsdb.OvsSet:
+			value = libovsdb.OvsSet{GoSet: append([]interface{}(nil), v.GoSet...)}
+		}
+		fields[column] = value
+	}
+	return libovsdb.Row{Fields: fields}
+}
@@ -0,0 +1,48 @@
+package goovn
+
+import (
+	"testing"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+	odbi := &ovndb{
+		db:     DBNB,
+		client: newTestClient(DBNB, map[string][]string{TableLogicalSwitch: {"name", "external_ids"}}),
+		cache: map[string]map[string]libovsdb.Row{
+			TableLogicalSwitch: {
+				"ls1": {Fields: map[string]interface{}{
+					"name":         "node1",
+					"external_ids": libovsdb.OvsMap{GoMap: map[interface{}]interface{}{"owner": "node1"}},
+				}},
+				"ls2": {Fields: map[string]interface{}{"name": "node2"}},
+			},
+		},
+		txnCounts: make(map[string]*TxnCounts),
+	}
+
+	snapshot, release := odbi.Snapshot()
+
+	// updates are applied to the cached rows in place
+	odbi.cache[TableLogicalSwitch]["ls1"].Fields["external_ids"].(libovsdb.OvsMap).GoMap["owner"] = "node2"
+	delete(odbi.cache[TableLogicalSwitch], "ls2")
+
+	switches, err := snapshot.LSGet("node1")
+	assert.NoError(t, err)
+	assert.Len(t, switches, 1)
+	assert.Equal(t, map[interface{}]interface{}{"owner": "node1"}, switches[0].ExternalID)
+	_, err = snapshot.LSGet("node2")
+	assert.NoError(t, err)
+
+	cmd, err := snapshot.LSAdd("node3")
+	assert.NoError(t, err)
+	assert.Equal(t, ErrorSnapshot, snapshot.Execute(cmd))
+	assert.Equal(t, ErrorSnapshot, snapshot.Lock("ovn_northd"))
+	assert.Equal(t, ErrorSnapshot, snapshot.Unlock("ovn_northd"))
+
+	release()
+	_, err = snapshot.LSGet("node1")
+	assert.Equal(t, ErrorNotFound, err)
+}
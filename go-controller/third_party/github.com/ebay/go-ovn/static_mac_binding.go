@@ -0,0 +1,143 @@
+/**
+ * Copyright (c) 2017 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/ebay/libovsdb"
+)
+
+// StaticMACBinding ovnnb item, only present in newer OVN schemas. It pins the
+// MAC of an IP reachable through a logical router port, so that the router
+// doesn't have to resolve it.
+type StaticMACBinding struct {
+	UUID        string
+	LogicalPort string
+	IP          string
+	MAC         string
+	// OverrideDynamicMAC lets the binding win over a MAC learned dynamically
+	OverrideDynamicMAC bool
+}
+
+func (odbi *ovndb) rowToStaticMACBinding(uuid string) *StaticMACBinding {
+	cacheBinding, ok := odbi.cache[TableStaticMACBinding][uuid]
+	if !ok {
+		return nil
+	}
+
+	binding := &StaticMACBinding{
+		UUID:        uuid,
+		LogicalPort: rowString(cacheBinding, "logical_port"),
+		IP:          rowString(cacheBinding, "ip"),
+		MAC:         rowString(cacheBinding, "mac"),
+	}
+	if override, ok := cacheBinding.Fields["override_dynamic_mac"].(bool); ok {
+		binding.OverrideDynamicMAC = override
+	}
+	return binding
+}
+
+// staticMACBindingSupported tells whether the server schema has the
+// Static_MAC_Binding table
+func (odbi *ovndb) staticMACBindingSupported() bool {
+	return odbi.tableSupported(TableStaticMACBinding)
+}
+
+func (odbi *ovndb) staticMACBindingAddImp(logicalPort, ip, mac string, overrideDynamic bool) (*OvnCommand, error) {
+	if len(logicalPort) == 0 {
+		return nil, fmt.Errorf("logical port cannot be empty while adding a static MAC binding")
+	}
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return nil, fmt.Errorf("invalid IP %q for the static MAC binding of %s", ip, logicalPort)
+	}
+	parsedMAC, err := net.ParseMAC(mac)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAC %q for the static MAC binding of %s: %v", mac, logicalPort, err)
+	}
+	if !odbi.staticMACBindingSupported() {
+		return nil, ErrorSchema
+	}
+	if uuid := odbi.getRowUUID(TableLogicalRouterPort, OVNRow{"name": logicalPort}); len(uuid) == 0 {
+		return nil, ErrorNotFound
+	}
+	// logical_port and ip are the index of the table
+	if uuid := odbi.getRowUUID(TableStaticMACBinding, OVNRow{"logical_port": logicalPort, "ip": parsedIP.String()}); len(uuid) > 0 {
+		return nil, ErrorExist
+	}
+
+	namedUUID, err := newRowUUID()
+	if err != nil {
+		return nil, err
+	}
+	row := make(OVNRow)
+	row["logical_port"] = logicalPort
+	row["ip"] = parsedIP.String()
+	row["mac"] = parsedMAC.String()
+	row["override_dynamic_mac"] = overrideDynamic
+
+	insertOp := libovsdb.Operation{
+		Op:       opInsert,
+		Table:    TableStaticMACBinding,
+		Row:      row,
+		UUIDName: namedUUID,
+	}
+	operations := []libovsdb.Operation{insertOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) staticMACBindingDelImp(logicalPort, ip string) (*OvnCommand, error) {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return nil, fmt.Errorf("invalid IP %q for the static MAC binding of %s", ip, logicalPort)
+	}
+	if !odbi.staticMACBindingSupported() {
+		return nil, ErrorSchema
+	}
+	if uuid := odbi.getRowUUID(TableStaticMACBinding, OVNRow{"logical_port": logicalPort, "ip": parsedIP.String()}); len(uuid) == 0 {
+		return nil, ErrorNotFound
+	}
+
+	deleteOp := libovsdb.Operation{
+		Op:    opDelete,
+		Table: TableStaticMACBinding,
+		Where: []interface{}{
+			libovsdb.NewCondition("logical_port", "==", logicalPort),
+			libovsdb.NewCondition("ip", "==", parsedIP.String()),
+		},
+	}
+	operations := []libovsdb.Operation{deleteOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) staticMACBindingListImp() ([]*StaticMACBinding, error) {
+	if !odbi.staticMACBindingSupported() {
+		return nil, ErrorSchema
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheBinding := odbi.cache[TableStaticMACBinding]
+
+	listBinding := make([]*StaticMACBinding, 0, len(cacheBinding))
+	for uuid := range cacheBinding {
+		listBinding = append(listBinding, odbi.rowToStaticMACBinding(uuid))
+	}
+	return listBinding, nil
+}
@@ -0,0 +1,153 @@
+package goovn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func newStaticMACBindingTestDB(bindings map[string]libovsdb.Row) *ovndb {
+	return newTestDB(DBNB, map[string][]string{
+		TableStaticMACBinding:  {"logical_port", "ip", "mac", "override_dynamic_mac"},
+		TableLogicalRouterPort: {"name"},
+	}, map[string]map[string]libovsdb.Row{
+		TableStaticMACBinding: bindings,
+		TableLogicalRouterPort: {
+			"lrp1": {Fields: map[string]interface{}{"name": "rtoe-GR_node1"}},
+		},
+	})
+}
+
+func TestStaticMACBindingAdd(t *testing.T) {
+	bindings := map[string]libovsdb.Row{
+		"smb1": {Fields: map[string]interface{}{"logical_port": "rtoe-GR_node1", "ip": "fd00::1", "mac": "0a:58:0a:00:00:01"}},
+	}
+	tests := []struct {
+		desc     string
+		bindings map[string]libovsdb.Row
+		port     string
+		ip       string
+		mac      string
+		override bool
+		noSchema bool
+		expOp    string
+		expErr   error
+	}{
+		{
+			desc:     "creates the first binding of an empty table",
+			port:     "rtoe-GR_node1",
+			ip:       "172.18.0.5",
+			mac:      "0A:58:0A:00:00:05",
+			override: true,
+			expOp:    "insert Static_MAC_Binding ip=172.18.0.5 logical_port=rtoe-GR_node1 mac=0a:58:0a:00:00:05 override_dynamic_mac=true",
+		},
+		{
+			desc:     "canonicalizes the IP",
+			bindings: bindings,
+			port:     "rtoe-GR_node1",
+			ip:       "fd00:0::5",
+			mac:      "0a:58:0a:00:00:05",
+			expOp:    "insert Static_MAC_Binding ip=fd00::5 logical_port=rtoe-GR_node1 mac=0a:58:0a:00:00:05 override_dynamic_mac=false",
+		},
+		{
+			desc:     "rejects a binding of the same port and IP",
+			bindings: bindings,
+			port:     "rtoe-GR_node1",
+			ip:       "fd00:0::1",
+			mac:      "0a:58:0a:00:00:05",
+			expErr:   ErrorExist,
+		},
+		{
+			desc:   "fails for a missing router port",
+			port:   "rtoe-GR_node2",
+			ip:     "172.18.0.5",
+			mac:    "0a:58:0a:00:00:05",
+			expErr: ErrorNotFound,
+		},
+		{
+			desc:   "rejects an invalid IP",
+			port:   "rtoe-GR_node1",
+			ip:     "172.18.0",
+			mac:    "0a:58:0a:00:00:05",
+			expErr: fmt.Errorf("invalid IP %q for the static MAC binding of %s", "172.18.0", "rtoe-GR_node1"),
+		},
+		{
+			desc:   "rejects an empty port",
+			ip:     "172.18.0.5",
+			mac:    "0a:58:0a:00:00:05",
+			expErr: fmt.Errorf("logical port cannot be empty while adding a static MAC binding"),
+		},
+		{
+			desc:     "fails without the table in the schema",
+			port:     "rtoe-GR_node1",
+			ip:       "172.18.0.5",
+			mac:      "0a:58:0a:00:00:05",
+			noSchema: true,
+			expErr:   ErrorSchema,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			odbi := newStaticMACBindingTestDB(tc.bindings)
+			if tc.noSchema {
+				delete(odbi.client.Schema[DBNB].Tables, TableStaticMACBinding)
+			}
+			cmd, err := odbi.StaticMACBindingAdd(tc.port, tc.ip, tc.mac, tc.override)
+			if tc.expErr != nil {
+				assert.Equal(t, tc.expErr, err)
+				assert.Nil(t, cmd)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, fmt.Sprint([]string{tc.expOp}), fmt.Sprint(cmd.Describe()))
+		})
+	}
+
+	t.Run("rejects an invalid MAC", func(t *testing.T) {
+		_, err := newStaticMACBindingTestDB(nil).StaticMACBindingAdd("rtoe-GR_node1", "172.18.0.5", "0a:58", false)
+		assert.Error(t, err)
+	})
+}
+
+func TestStaticMACBindingDel(t *testing.T) {
+	odbi := newStaticMACBindingTestDB(map[string]libovsdb.Row{
+		"smb1": {Fields: map[string]interface{}{"logical_port": "rtoe-GR_node1", "ip": "fd00::1", "mac": "0a:58:0a:00:00:01"}},
+	})
+	cmd, err := odbi.StaticMACBindingDel("rtoe-GR_node1", "fd00::0001")
+	assert.Nil(t, err)
+	assert.Equal(t, fmt.Sprint([]string{"delete Static_MAC_Binding where logical_port == rtoe-GR_node1 and ip == fd00::1"}),
+		fmt.Sprint(cmd.Describe()))
+
+	_, err = odbi.StaticMACBindingDel("rtoe-GR_node2", "fd00::1")
+	assert.Equal(t, ErrorNotFound, err)
+	_, err = newStaticMACBindingTestDB(nil).StaticMACBindingDel("rtoe-GR_node1", "fd00::1")
+	assert.Equal(t, ErrorNotFound, err)
+	_, err = odbi.StaticMACBindingDel("rtoe-GR_node1", "fd00:::1")
+	assert.Error(t, err)
+}
+
+func TestStaticMACBindingList(t *testing.T) {
+	odbi := newStaticMACBindingTestDB(map[string]libovsdb.Row{
+		"smb1": {Fields: map[string]interface{}{
+			"logical_port":         "rtoe-GR_node1",
+			"ip":                   "fd00::1",
+			"mac":                  "0a:58:0a:00:00:01",
+			"override_dynamic_mac": true,
+		}},
+	})
+	list, err := odbi.StaticMACBindingList()
+	assert.Nil(t, err)
+	assert.Equal(t, []*StaticMACBinding{
+		{UUID: "smb1", LogicalPort: "rtoe-GR_node1", IP: "fd00::1", MAC: "0a:58:0a:00:00:01", OverrideDynamicMAC: true},
+	}, list)
+
+	list, err = newStaticMACBindingTestDB(nil).StaticMACBindingList()
+	assert.Nil(t, err)
+	assert.Empty(t, list)
+
+	delete(odbi.client.Schema[DBNB].Tables, TableStaticMACBinding)
+	_, err = odbi.StaticMACBindingList()
+	assert.Equal(t, ErrorSchema, err)
+}
@@ -0,0 +1,8 @@
+module github.com/ebay/libovsdb
+
+go 1.13
+
+require (
+	github.com/cenkalti/hub v1.0.1 // indirect
+	github.com/cenkalti/rpc2 v0.0.0-20210604223624-c1acbc6ec984
+)
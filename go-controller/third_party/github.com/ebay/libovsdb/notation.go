@@ -0,0 +1,218 @@
+package libovsdb
+
+import "encoding/json"
+
+// Operation represents an operation according to RFC7047 section 5.2
+type Operation struct {
+	Op        string                   `json:"op"`
+	Table     string                   `json:"table"`
+	Row       map[string]interface{}   `json:"row,omitempty"`
+	Rows      []map[string]interface{} `json:"rows,omitempty"`
+	Columns   []string                 `json:"columns,omitempty"`
+	Mutations []interface{}            `json:"mutations,omitempty"`
+	Timeout   int                      `json:"timeout,omitempty"`
+	Where     []interface{}            `json:"where,omitempty"`
+	Until     string                   `json:"until,omitempty"`
+	UUIDName  string                   `json:"uuid-name,omitempty"`
+	Comment   string                   `json:"comment,omitempty"`
+}
+
+// MarshalJSON marshalls 'Operation' to a byte array
+// For 'select' operations, we dont omit the 'Where' field
+// to allow selecting all rows of a table
+// For 'wait' operations, we dont omit the 'Timeout' field
+// as a missing timeout means waiting forever, not failing at once
+// For 'comment' operations, only the comment is sent as the
+// server rejects any other member
+func (o Operation) MarshalJSON() ([]byte, error) {
+	type OpAlias Operation
+	switch o.Op {
+	case "select":
+		where := o.Where
+		if where == nil {
+			where = make([]interface{}, 0, 0)
+		}
+		return json.Marshal(&struct {
+			Where []interface{} `json:"where"`
+			OpAlias
+		}{
+			Where:   where,
+			OpAlias: (OpAlias)(o),
+		})
+	case "wait":
+		return json.Marshal(&struct {
+			Timeout int `json:"timeout"`
+			OpAlias
+		}{
+			Timeout: o.Timeout,
+			OpAlias: (OpAlias)(o),
+		})
+	case OperationComment:
+		return json.Marshal(&struct {
+			Op      string `json:"op"`
+			Comment string `json:"comment"`
+		}{
+			Op:      o.Op,
+			Comment: o.Comment,
+		})
+	default:
+		return json.Marshal(&struct {
+			OpAlias
+		}{
+			OpAlias: (OpAlias)(o),
+		})
+	}
+}
+
+// MonitorRequests represents a group of monitor requests according to RFC7047
+// We cannot use MonitorRequests by inlining the MonitorRequest Map structure till GoLang issue #6213 makes it.
+// The only option is to go with raw map[string]interface{} option :-( that sucks !
+// Refer to client.go : MonitorAll() function for more details
+type MonitorRequests struct {
+	Requests map[string]MonitorRequest `json:"requests,overflow"`
+}
+
+// MonitorRequest represents a monitor request according to RFC7047
+type MonitorRequest struct {
+	Columns []string      `json:"columns,omitempty"`
+	Select  MonitorSelect `json:"select,omitempty"`
+	// Where restricts the rows monitored to those matching any of the
+	// conditions, only monitor_cond and monitor_cond_since support it
+	Where []interface{} `json:"where,omitempty"`
+}
+
+// MonitorSelect represents a monitor select according to RFC7047
+type MonitorSelect struct {
+	Initial bool `json:"initial,omitempty"`
+	Insert  bool `json:"insert,omitempty"`
+	Delete  bool `json:"delete,omitempty"`
+	Modify  bool `json:"modify,omitempty"`
+}
+
+// TableUpdates is a collection of TableUpdate entries
+// We cannot use TableUpdates directly by json encoding by inlining the TableUpdate Map
+// structure till GoLang issue #6213 makes it.
+// The only option is to go with raw map[string]map[string]interface{} option :-( that sucks !
+// Refer to client.go : MonitorAll() function for more details
+type TableUpdates struct {
+	Updates map[string]TableUpdate `json:"updates,overflow"`
+}
+
+// TableUpdate represents a table update according to RFC7047
+type TableUpdate struct {
+	Rows map[string]RowUpdate `json:"rows,overflow"`
+}
+
+// RowUpdate represents a row update according to RFC7047
+type RowUpdate struct {
+	UUID UUID `json:"-,omitempty"`
+	New  Row  `json:"new,omitempty"`
+	Old  Row  `json:"old,omitempty"`
+}
+
+// TableUpdates is a collection of TableUpdate entries
+// We cannot use TableUpdates directly by json encoding by inlining the TableUpdate Map
+// structure till GoLang issue #6213 makes it.
+// The only option is to go with raw map[string]map[string]interface{} option :-( that sucks !
+// Refer to client.go : MonitorAll() function for more details
+type TableUpdates2 struct {
+	Updates map[string]TableUpdate2 `json:"updates,overflow"`
+}
+
+// TableUpdate represents a table update according to RFC7047
+type TableUpdate2 struct {
+	Rows map[string]RowUpdate2 `json:"rows,overflow"`
+}
+
+// RowUpdate represents a row update according to RFC7047
+type RowUpdate2 struct {
+	UUID    UUID `json:"-,omitempty"`
+	Initial Row  `json:"initial,omitempty"`
+	Insert  Row  `json:"insert,omitempty"`
+	Modify  Row  `json:"modify,omitempty"`
+	Delete  Row  `json:"delete,omitempty"`
+}
+
+// OvsdbError is an OVS Error Condition
+type OvsdbError struct {
+	Error   string `json:"error"`
+	Details string `json:"details,omitempty"`
+}
+
+// NewCondition creates a new condition as specified in RFC7047
+func NewCondition(column string, function string, value interface{}) []interface{} {
+	return []interface{}{column, function, value}
+}
+
+// NewMutation creates a new mutation as specified in RFC7047
+func NewMutation(column string, mutator string, value interface{}) []interface{} {
+	return []interface{}{column, mutator, value}
+}
+
+// TransactResponse represents the response to a Transact Operation
+type TransactResponse struct {
+	Result []OperationResult `json:"result"`
+	Error  string            `json:"error"`
+}
+
+// OperationResult is the result of an Operation
+type OperationResult struct {
+	Count   int         `json:"count,omitempty"`
+	Error   string      `json:"error,omitempty"`
+	Details string      `json:"details,omitempty"`
+	UUID    UUID        `json:"uuid,omitempty"`
+	Rows    []ResultRow `json:"rows,omitempty"`
+}
+
+func ovsSliceToGoNotation(val interface{}) (interface{}, error) {
+	switch val.(type) {
+	case []interface{}:
+		sl := val.([]interface{})
+		bsliced, err := json.Marshal(sl)
+		if err != nil {
+			return nil, err
+		}
+
+		switch sl[0] {
+		case "uuid":
+			var uuid UUID
+			err = json.Unmarshal(bsliced, &uuid)
+			return uuid, err
+		case "set":
+			var oSet OvsSet
+			err = json.Unmarshal(bsliced, &oSet)
+			return oSet, err
+		case "map":
+			var oMap OvsMap
+			err = json.Unmarshal(bsliced, &oMap)
+			return oMap, err
+		}
+		return val, nil
+	}
+	return val, nil
+}
+
+// TODO : add Condition, Function, Mutation and Mutator notations
+
+const (
+	// OperationInsert is an insert operation
+	OperationInsert = "insert"
+	// OperationSelect is a select operation
+	OperationSelect = "select"
+	// OperationUpdate is an update operation
+	OperationUpdate = "update"
+	// OperationMutate is a mutate operation
+	OperationMutate = "mutate"
+	// OperationDelete is a delete operation
+	OperationDelete = "delete"
+	// OperationWait is a wait operation
+	OperationWait = "wait"
+	// OperationCommit is a commit operation
+	OperationCommit = "commit"
+	// OperationAbort is an abort operation
+	OperationAbort = "abort"
+	// OperationComment is a comment operation
+	OperationComment = "comment"
+	// OperationAssert is an assert operation
+	OperationAssert = "assert"
+)